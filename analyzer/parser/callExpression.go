@@ -52,16 +52,16 @@ func AnalyzeCallExpression(node *ast.CallExpression, sourceCode string, processe
 		return nil, nil
 	}
 
+	// 检查是否是未赋值给变量的 CommonJS `require('...')`
+	if ast.IsRequireCall(node.AsNode(), true) {
+		return nil, analyzeRequireCall(node, sourceCode)
+	}
+
 	// 检查是否是独立的动态导入 `import(...)`
 	if node.Expression.Kind == ast.KindImportKeyword {
 		if len(node.Arguments.Nodes) > 0 {
-			arg := node.Arguments.Nodes[0]
-			var importPath string
-			if arg.Kind == ast.KindStringLiteral {
-				importPath = arg.AsStringLiteral().Text
-			} else if arg.Kind == ast.KindIdentifier {
-				importPath = arg.AsIdentifier().Text
-			} else {
+			importPath, pattern, ok := dynamicImportSpecifier(node.Arguments.Nodes[0])
+			if !ok {
 				return nil, nil // 不支持的动态导入参数类型
 			}
 
@@ -74,7 +74,10 @@ func AnalyzeCallExpression(node *ast.CallExpression, sourceCode string, processe
 						Type:         "dynamic",
 					},
 				},
-				Raw: utils.GetNodeText(node.AsNode(), sourceCode),
+				IsDynamic:      true,
+				Pattern:        pattern,
+				Raw:            utils.GetNodeText(node.AsNode(), sourceCode),
+				SourceLocation: NewSourceLocation(node.AsNode(), sourceCode),
 			}
 			return nil, importResult // 返回一个导入声明结果，而不是调用表达式
 		}
@@ -109,6 +112,12 @@ func AnalyzeCallExpression(node *ast.CallExpression, sourceCode string, processe
 
 // VisitCallExpression 从给定的 ast.CallExpression 节点中提取详细信息。
 func (p *Parser) VisitCallExpression(node *ast.CallExpression) {
+	// import.meta.glob() 与 require.context() 同样视为动态导入，不再记录为调用表达式
+	if importDecls := AnalyzeImportPattern(node, p.SourceCode); importDecls != nil {
+		p.Result.ImportDeclarations = append(p.Result.ImportDeclarations, importDecls...)
+		return
+	}
+
 	callExpr, importDecl := AnalyzeCallExpression(node, p.SourceCode, p.ProcessedDynamicImports)
 
 	if importDecl != nil {
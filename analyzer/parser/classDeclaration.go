@@ -0,0 +1,236 @@
+// package parser 提供了对单个 TypeScript/TSX 文件进行 AST（抽象语法树）解析的功能。
+// 本文件（classDeclaration.go）专门负责处理和解析类（Class）声明。
+package parser
+
+import (
+	"strings"
+
+	"github.com/Flying-Bird1999/analyzer-ts/analyzer/utils"
+
+	"github.com/Zzzen/typescript-go/use-at-your-own-risk/ast"
+)
+
+// 类成员的种类
+const (
+	ClassMemberKindConstructor = "constructor" // 构造函数
+	ClassMemberKindMethod      = "method"      // 方法
+	ClassMemberKindProperty    = "property"    // 属性（包括构造函数的参数属性）
+	ClassMemberKindGetter      = "getter"      // get 访问器
+	ClassMemberKindSetter      = "setter"      // set 访问器
+	ClassMemberKindIndex       = "index"       // 索引签名，例如 [key: string]: any
+	ClassMemberKindStaticBlock = "staticBlock" // 静态初始化块 static { ... }
+)
+
+// ClassMemberResult 存储一个类成员的解析结果。
+type ClassMemberResult struct {
+	Name                string            `json:"name"`                          // 成员名称。构造函数为 "constructor"，索引签名与静态块为空。
+	Kind                string            `json:"kind"`                          // 成员种类，取值见 ClassMemberKind* 常量。
+	Accessibility       string            `json:"accessibility,omitempty"`       // 显式声明的访问修饰符：public / private / protected。
+	IsStatic            bool              `json:"isStatic,omitempty"`            // 是否为静态成员。
+	IsReadonly          bool              `json:"isReadonly,omitempty"`          // 是否为只读成员。
+	IsAbstract          bool              `json:"isAbstract,omitempty"`          // 是否为抽象成员。
+	IsOptional          bool              `json:"isOptional,omitempty"`          // 是否为可选成员 (e.g., name?: string)。
+	IsAsync             bool              `json:"isAsync,omitempty"`             // 是否为异步方法。
+	IsOverride          bool              `json:"isOverride,omitempty"`          // 是否带有 override 修饰符。
+	IsParameterProperty bool              `json:"isParameterProperty,omitempty"` // 是否为构造函数的参数属性 (e.g., constructor(private api: Api))。
+	Type                string            `json:"type,omitempty"`                // 属性、访问器或索引签名的类型文本。
+	Parameters          []ParameterResult `json:"parameters,omitempty"`          // 方法、构造函数与访问器的参数列表。
+	ReturnType          string            `json:"returnType,omitempty"`          // 方法的返回类型文本。
+	Decorators          []DecoratorResult `json:"decorators,omitempty"`          // 成员上的装饰器 (e.g., @Input())。
+	Raw                 string            `json:"raw,omitempty"`                 // 节点在源码中的原始文本。
+	SourceLocation      *SourceLocation   `json:"sourceLocation,omitempty"`      // 节点在源码中的位置信息。
+}
+
+// ClassDeclarationResult 存储一个完整的类声明的解析结果。
+type ClassDeclarationResult struct {
+	Identifier      string              `json:"identifier"`               // 类的名称。`export default class {}` 这类匿名类为空。
+	Exported        bool                `json:"exported"`                 // 标记此类是否被导出。
+	IsDefaultExport bool                `json:"isDefaultExport"`          // 标记此类是否为 default export (export default class Foo {})。
+	IsAbstract      bool                `json:"isAbstract"`               // 标记此类是否为抽象类。
+	Namespace       string              `json:"namespace,omitempty"`      // 所属的命名空间或环境模块，顶层声明为空。规则见 EnclosingNamespace。
+	Generics        []string            `json:"generics,omitempty"`       // 泛型参数列表 (e.g., ["T", "K extends keyof T"])。
+	Extends         string              `json:"extends,omitempty"`        // extends 子句中的父类表达式文本 (e.g., "Base<T>")。
+	Implements      []string            `json:"implements,omitempty"`     // implements 子句中的接口列表。
+	Decorators      []DecoratorResult   `json:"decorators,omitempty"`     // 类上的装饰器 (e.g., @Injectable())。
+	Members         []ClassMemberResult `json:"members"`                  // 类的成员列表，按源码顺序排列，构造函数的参数属性紧跟在构造函数之后。
+	Raw             string              `json:"raw,omitempty"`            // 节点在源码中的原始文本。
+	SourceLocation  *SourceLocation     `json:"sourceLocation,omitempty"` // 节点在源码中的位置信息。
+	Node            *ast.Node           `json:"-"`                        // 对应的 AST 节点，不在 JSON 中序列化。
+}
+
+// AnalyzeClassDeclaration 是一个公共的、可复用的函数，用于从 AST 节点中解析类声明的详细信息。
+func AnalyzeClassDeclaration(node *ast.ClassDeclaration, sourceCode string) *ClassDeclarationResult {
+	classNode := node.AsNode()
+	result := &ClassDeclarationResult{
+		Generics:       []string{},
+		Implements:     []string{},
+		Decorators:     []DecoratorResult{},
+		Members:        []ClassMemberResult{},
+		Raw:            utils.GetNodeText(classNode, sourceCode),
+		SourceLocation: NewSourceLocation(classNode, sourceCode),
+		Node:           classNode,
+	}
+
+	if node.Name() != nil {
+		result.Identifier = node.Name().Text()
+	}
+
+	// 1. 解析修饰符与装饰器 (export, default, abstract, @Decorator)
+	flags := classNode.ModifierFlags()
+	result.Exported = flags&ast.ModifierFlagsExport != 0
+	result.IsDefaultExport = result.Exported && flags&ast.ModifierFlagsDefault != 0
+	result.IsAbstract = flags&ast.ModifierFlagsAbstract != 0
+	result.Decorators = collectDecorators(classNode, sourceCode)
+
+	// 2. 解析泛型参数
+	if node.TypeParameters != nil {
+		for _, param := range node.TypeParameters.Nodes {
+			result.Generics = append(result.Generics, strings.TrimSpace(utils.GetNodeText(param, sourceCode)))
+		}
+	}
+
+	// 3. 解析继承子句 (extends / implements)
+	if node.HeritageClauses != nil {
+		for _, clauseNode := range node.HeritageClauses.Nodes {
+			clause := clauseNode.AsHeritageClause()
+			if clause.Types == nil {
+				continue
+			}
+			for _, typeNode := range clause.Types.Nodes {
+				text := strings.TrimSpace(utils.GetNodeText(typeNode, sourceCode))
+				if clause.Token == ast.KindExtendsKeyword {
+					result.Extends = text
+				} else {
+					result.Implements = append(result.Implements, text)
+				}
+			}
+		}
+	}
+
+	// 4. 解析类成员
+	if node.Members != nil {
+		for _, member := range node.Members.Nodes {
+			result.Members = append(result.Members, analyzeClassMember(member, sourceCode)...)
+		}
+	}
+
+	return result
+}
+
+// analyzeClassMember 解析单个类成员。
+// 构造函数会额外返回其参数属性，因此返回值是一个列表；分号等无意义的成员返回空列表。
+func analyzeClassMember(node *ast.Node, sourceCode string) []ClassMemberResult {
+	member := ClassMemberResult{
+		Name:           classMemberName(node, sourceCode),
+		Decorators:     collectDecorators(node, sourceCode),
+		Raw:            utils.GetNodeText(node, sourceCode),
+		SourceLocation: NewSourceLocation(node, sourceCode),
+	}
+	applyMemberModifiers(&member, node.ModifierFlags())
+
+	switch node.Kind {
+	case ast.KindConstructor:
+		member.Name = "constructor"
+		member.Kind = ClassMemberKindConstructor
+		member.Parameters = analyzeParameterList(node.FunctionLikeData().Parameters, sourceCode)
+		// 构造函数中带有 public/private/protected/readonly/override 修饰符的参数同时也是类的属性
+		members := []ClassMemberResult{member}
+		for _, param := range node.Parameters() {
+			if !ast.HasSyntacticModifier(param, ast.ModifierFlagsParameterPropertyModifier) {
+				continue
+			}
+			members = append(members, analyzeParameterProperty(param, sourceCode))
+		}
+		return members
+	case ast.KindMethodDeclaration:
+		member.Kind = ClassMemberKindMethod
+		member.IsOptional = node.PostfixToken() != nil && node.PostfixToken().Kind == ast.KindQuestionToken
+		member.Parameters = analyzeParameterList(node.FunctionLikeData().Parameters, sourceCode)
+		member.ReturnType = nodeTypeText(node, sourceCode)
+	case ast.KindPropertyDeclaration:
+		member.Kind = ClassMemberKindProperty
+		member.IsOptional = node.PostfixToken() != nil && node.PostfixToken().Kind == ast.KindQuestionToken
+		member.Type = nodeTypeText(node, sourceCode)
+	case ast.KindGetAccessor:
+		member.Kind = ClassMemberKindGetter
+		member.Type = nodeTypeText(node, sourceCode)
+	case ast.KindSetAccessor:
+		member.Kind = ClassMemberKindSetter
+		member.Parameters = analyzeParameterList(node.FunctionLikeData().Parameters, sourceCode)
+		if len(member.Parameters) > 0 {
+			member.Type = member.Parameters[0].Type
+		}
+	case ast.KindIndexSignature:
+		member.Kind = ClassMemberKindIndex
+		member.Parameters = analyzeParameterList(node.FunctionLikeData().Parameters, sourceCode)
+		member.Type = nodeTypeText(node, sourceCode)
+	case ast.KindClassStaticBlockDeclaration:
+		member.Kind = ClassMemberKindStaticBlock
+		member.IsStatic = true
+	default:
+		return nil
+	}
+	return []ClassMemberResult{member}
+}
+
+// analyzeParameterProperty 将构造函数的参数属性转换为类成员。
+func analyzeParameterProperty(param *ast.Node, sourceCode string) ClassMemberResult {
+	member := ClassMemberResult{
+		Name:                classMemberName(param, sourceCode),
+		Kind:                ClassMemberKindProperty,
+		IsParameterProperty: true,
+		IsOptional:          param.QuestionToken() != nil,
+		Type:                nodeTypeText(param, sourceCode),
+		Decorators:          collectDecorators(param, sourceCode),
+		Raw:                 utils.GetNodeText(param, sourceCode),
+		SourceLocation:      NewSourceLocation(param, sourceCode),
+	}
+	applyMemberModifiers(&member, param.ModifierFlags())
+	return member
+}
+
+// applyMemberModifiers 根据修饰符标记填充成员的访问修饰符与布尔标记。
+func applyMemberModifiers(member *ClassMemberResult, flags ast.ModifierFlags) {
+	switch {
+	case flags&ast.ModifierFlagsPublic != 0:
+		member.Accessibility = "public"
+	case flags&ast.ModifierFlagsPrivate != 0:
+		member.Accessibility = "private"
+	case flags&ast.ModifierFlagsProtected != 0:
+		member.Accessibility = "protected"
+	}
+	member.IsStatic = flags&ast.ModifierFlagsStatic != 0
+	member.IsReadonly = flags&ast.ModifierFlagsReadonly != 0
+	member.IsAbstract = flags&ast.ModifierFlagsAbstract != 0
+	member.IsAsync = flags&ast.ModifierFlagsAsync != 0
+	member.IsOverride = flags&ast.ModifierFlagsOverride != 0
+}
+
+// classMemberName 返回类成员的名称。
+// 标识符、私有名称 (#foo) 与字面量名称返回其文本，计算属性名返回源码文本 (e.g., "[Symbol.iterator]")。
+func classMemberName(node *ast.Node, sourceCode string) string {
+	name := node.Name()
+	if name == nil {
+		return ""
+	}
+	switch name.Kind {
+	case ast.KindIdentifier, ast.KindPrivateIdentifier, ast.KindStringLiteral, ast.KindNumericLiteral:
+		return name.Text()
+	}
+	return strings.TrimSpace(utils.GetNodeText(name, sourceCode))
+}
+
+// nodeTypeText 返回节点上类型注解的文本，没有类型注解时返回空字符串。
+func nodeTypeText(node *ast.Node, sourceCode string) string {
+	if typeNode := node.Type(); typeNode != nil {
+		return strings.TrimSpace(utils.GetNodeText(typeNode, sourceCode))
+	}
+	return ""
+}
+
+// VisitClassDeclaration 解析类声明。
+func (p *Parser) VisitClassDeclaration(node *ast.ClassDeclaration) {
+	result := AnalyzeClassDeclaration(node, p.SourceCode)
+	result.Namespace = EnclosingNamespace(node.AsNode())
+	p.Result.ClassDeclarations = append(p.Result.ClassDeclarations, *result)
+}
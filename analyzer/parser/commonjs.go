@@ -0,0 +1,275 @@
+// package parser 提供了对单个 TypeScript/TSX 文件进行 AST（抽象语法树）解析的功能。
+// 本文件（commonjs.go）专门负责将 CommonJS 的 `require()`、`module.exports` 和 `exports.xxx`
+// 转换为与 ESM 相同的导入/导出记录，并通过 ModuleSystem 字段标记为 "cjs"。
+package parser
+
+import (
+	"strings"
+
+	"github.com/Flying-Bird1999/analyzer-ts/analyzer/utils"
+	"github.com/Zzzen/typescript-go/use-at-your-own-risk/ast"
+)
+
+// ModuleSystemCommonJS 标记导入/导出记录来自 CommonJS 语法。ESM 语法的记录 ModuleSystem 为空。
+const ModuleSystemCommonJS = "cjs"
+
+// requireTarget 描述一个 `require('mod')` 表达式，以及可选的对其结果的成员访问。
+type requireTarget struct {
+	call   *ast.Node // `require('mod')` 调用节点
+	source string    // 模块路径，例如 `./a`
+	member string    // 成员名，例如 `require('./a').foo` 中的 `foo`；直接使用 require 的结果时为空
+}
+
+// findRequireTarget 判断表达式是否为 `require('mod')`、`require('mod').member` 或 `require('mod')['member']`。
+func findRequireTarget(node *ast.Node) (requireTarget, bool) {
+	if node == nil {
+		return requireTarget{}, false
+	}
+	node = ast.SkipParentheses(node)
+	if ast.IsRequireCall(node, true) {
+		arg := node.AsCallExpression().Arguments.Nodes[0]
+		return requireTarget{call: node, source: arg.Text()}, true
+	}
+	if ast.IsPropertyAccessExpression(node) || ast.IsElementAccessExpression(node) {
+		inner := ast.SkipParentheses(node.Expression())
+		if !ast.IsRequireCall(inner, true) {
+			return requireTarget{}, false
+		}
+		name := ast.GetElementOrPropertyAccessName(node)
+		if name == nil {
+			return requireTarget{}, false
+		}
+		arg := inner.AsCallExpression().Arguments.Nodes[0]
+		return requireTarget{call: inner, source: arg.Text(), member: name.Text()}, true
+	}
+	return requireTarget{}, false
+}
+
+// analyzeRequireAssignment 专门处理初始值为 `require()` 的变量声明，如果成功解析则返回 true。
+//   - `const a = require('./a')`          → 命名空间导入 a
+//   - `const a = require('./a').default`  → 默认导入 a
+//   - `const b = require('./a').foo`      → 命名导入 foo as b
+//   - `const { foo, bar: baz } = require('./a')` → 命名导入 foo、bar as baz
+func (p *Parser) analyzeRequireAssignment(variableDecl *ast.VariableDeclaration) bool {
+	target, ok := findRequireTarget(variableDecl.Initializer)
+	if !ok {
+		return false
+	}
+
+	idr := NewImportDeclarationResult()
+	idr.Source = target.source
+	idr.ModuleSystem = ModuleSystemCommonJS
+	idr.Raw = utils.GetNodeText(variableDecl.AsNode(), p.SourceCode)
+	idr.SourceLocation = NewSourceLocation(variableDecl.AsNode(), p.SourceCode)
+	idr.Node = variableDecl.AsNode()
+
+	nameNode := variableDecl.Name()
+	switch {
+	case ast.IsIdentifier(nameNode):
+		identifier := nameNode.AsIdentifier().Text
+		switch target.member {
+		case "":
+			idr.addModule("namespace", identifier, identifier)
+		case "default":
+			idr.addModule("default", "default", identifier)
+		default:
+			idr.addModule("named", target.member, identifier)
+		}
+	case ast.IsObjectBindingPattern(nameNode) && target.member == "":
+		for _, element := range nameNode.AsBindingPattern().Elements.Nodes {
+			bindingElement := element.AsBindingElement()
+			if bindingElement == nil || bindingElement.Name() == nil {
+				continue
+			}
+			identifier := ""
+			if ast.IsIdentifier(bindingElement.Name()) {
+				identifier = bindingElement.Name().AsIdentifier().Text
+			}
+			// `const { ...rest } = require('./a')` 使用了整个模块
+			if bindingElement.DotDotDotToken != nil {
+				idr.addModule("namespace", identifier, identifier)
+				continue
+			}
+			propName := identifier
+			if propertyName := bindingElement.PropertyName; propertyName != nil {
+				if ast.IsComputedPropertyName(propertyName) {
+					continue
+				}
+				propName = propertyName.Text()
+			}
+			if identifier == "" {
+				// 嵌套解构 `const { a: { b } } = require('./a')`，只记录被使用的属性 a
+				identifier = propName
+			}
+			if propName == "" {
+				continue
+			}
+			idr.addModule("named", propName, identifier)
+		}
+	default:
+		// 数组解构等无法确定使用了哪些导出，交给 VisitCallExpression 作为整体导入处理
+		return false
+	}
+
+	p.Result.ImportDeclarations = append(p.Result.ImportDeclarations, *idr)
+	p.ProcessedDynamicImports[target.call] = true
+	return true
+}
+
+// analyzeRequireCall 处理没有赋值给变量的 `require('mod')` 调用。
+// 独立的 `require('./setup');` 语句视为副作用导入，其他位置（例如作为函数参数）视为对整个模块的命名空间导入。
+func analyzeRequireCall(node *ast.CallExpression, sourceCode string) *ImportDeclarationResult {
+	idr := NewImportDeclarationResult()
+	idr.Source = node.Arguments.Nodes[0].Text()
+	idr.ModuleSystem = ModuleSystemCommonJS
+	idr.Raw = utils.GetNodeText(node.AsNode(), sourceCode)
+	idr.SourceLocation = NewSourceLocation(node.AsNode(), sourceCode)
+	idr.Node = node.AsNode()
+	if parent := node.AsNode().Parent; parent == nil || parent.Kind != ast.KindExpressionStatement {
+		idr.addModule("namespace", "*", "*")
+	}
+	return idr
+}
+
+// VisitImportEqualsDeclaration 解析 TypeScript 的 `import a = require('./a')` 语法，结果为 CommonJS 命名空间导入。
+// `import a = Foo.Bar` 这类内部模块别名不涉及其他文件，会被忽略。
+func (p *Parser) VisitImportEqualsDeclaration(node *ast.ImportEqualsDeclaration) {
+	if node.ModuleReference == nil || !ast.IsExternalModuleReference(node.ModuleReference) {
+		return
+	}
+	expr := node.ModuleReference.AsExternalModuleReference().Expression
+	if expr == nil || !ast.IsStringLiteralLike(expr) {
+		return
+	}
+	idr := NewImportDeclarationResult()
+	idr.Source = expr.Text()
+	idr.ModuleSystem = ModuleSystemCommonJS
+	idr.Raw = utils.GetNodeText(node.AsNode(), p.SourceCode)
+	idr.SourceLocation = NewSourceLocation(node.AsNode(), p.SourceCode)
+	idr.Node = node.AsNode()
+	idr.IsTypeOnly = node.IsTypeOnly
+	name := node.Name().Text()
+	idr.addModule("namespace", name, name)
+	p.Result.ImportDeclarations = append(p.Result.ImportDeclarations, *idr)
+}
+
+// VisitBinaryExpression 解析 CommonJS 的导出赋值：
+//   - `module.exports = foo`            → 默认导出（ExportAssignments）
+//   - `module.exports = { a, b: c }`    → 命名导出 a、b（ExportDeclarations）
+//   - `exports.a = ...` / `module.exports.a = ...` → 命名导出 a
+//   - 右侧为 `require('./mod')` 时       → 从 ./mod 重导出
+func (p *Parser) VisitBinaryExpression(node *ast.BinaryExpression) {
+	if node.OperatorToken == nil || node.OperatorToken.Kind != ast.KindEqualsToken {
+		return
+	}
+	left := node.Left
+	right := ast.SkipParentheses(node.Right)
+
+	switch {
+	case ast.IsModuleExportsAccessExpression(left):
+		if target, ok := findRequireTarget(right); ok {
+			identifier := "*"
+			if target.member != "" {
+				identifier = "default"
+			}
+			p.addCommonJSReExport(node, target, identifier)
+			return
+		}
+		if ast.IsObjectLiteralExpression(right) {
+			p.addCommonJSObjectExports(node, right)
+			return
+		}
+		expr := strings.TrimSpace(utils.GetNodeText(right, p.SourceCode))
+		p.Result.ExportAssignments = append(p.Result.ExportAssignments, ExportAssignmentResult{
+			Expression:     expr,
+			Name:           extractNameFromExpression(expr),
+			ModuleSystem:   ModuleSystemCommonJS,
+			Raw:            utils.GetNodeText(node.AsNode(), p.SourceCode),
+			SourceLocation: NewSourceLocation(node.AsNode(), p.SourceCode),
+			Node:           node.AsNode(),
+		})
+	case isCommonJSExportsMember(left):
+		name := ast.GetElementOrPropertyAccessName(left).Text()
+		if target, ok := findRequireTarget(right); ok {
+			p.addCommonJSReExport(node, target, name)
+			return
+		}
+		moduleName := name
+		if ast.IsIdentifier(right) {
+			moduleName = right.AsIdentifier().Text
+		}
+		p.Result.ExportDeclarations = append(p.Result.ExportDeclarations, ExportDeclarationResult{
+			ExportModules:  []ExportModule{{ModuleName: moduleName, Type: "named", Identifier: name}},
+			Raw:            utils.GetNodeText(node.AsNode(), p.SourceCode),
+			Type:           "named-export",
+			ModuleSystem:   ModuleSystemCommonJS,
+			SourceLocation: NewSourceLocation(node.AsNode(), p.SourceCode),
+			Node:           node.AsNode(),
+		})
+	}
+}
+
+// isCommonJSExportsMember 判断赋值左侧是否为 `exports.a`、`exports['a']` 或 `module.exports.a`。
+func isCommonJSExportsMember(node *ast.Node) bool {
+	if !ast.IsPropertyAccessExpression(node) && !ast.IsElementAccessExpression(node) {
+		return false
+	}
+	if ast.GetElementOrPropertyAccessName(node) == nil {
+		return false
+	}
+	object := node.Expression()
+	return ast.IsExportsIdentifier(object) || ast.IsModuleExportsAccessExpression(object)
+}
+
+// addCommonJSReExport 记录 `module.exports = require('./a')` 或 `exports.a = require('./a').b` 形式的重导出。
+// identifier 是对外导出的名称，整体导出时为 "*"。
+func (p *Parser) addCommonJSReExport(node *ast.BinaryExpression, target requireTarget, identifier string) {
+	module := ExportModule{ModuleName: "*", Type: "namespace", Identifier: identifier}
+	if target.member != "" {
+		module = ExportModule{ModuleName: target.member, Type: "named", Identifier: identifier}
+	}
+	p.Result.ExportDeclarations = append(p.Result.ExportDeclarations, ExportDeclarationResult{
+		ExportModules:  []ExportModule{module},
+		Raw:            utils.GetNodeText(node.AsNode(), p.SourceCode),
+		Source:         target.source,
+		Type:           "re-export",
+		ModuleSystem:   ModuleSystemCommonJS,
+		SourceLocation: NewSourceLocation(node.AsNode(), p.SourceCode),
+		Node:           node.AsNode(),
+	})
+	p.ProcessedDynamicImports[target.call] = true
+}
+
+// addCommonJSObjectExports 将 `module.exports = { a, b: c, d() {} }` 中的每个属性记录为命名导出。
+// 属性值为 `require()` 时（例如 `{ a: require('./a') }`），会作为普通的导入被 VisitCallExpression 记录。
+func (p *Parser) addCommonJSObjectExports(node *ast.BinaryExpression, object *ast.Node) {
+	edr := ExportDeclarationResult{
+		ExportModules:  make([]ExportModule, 0),
+		Raw:            utils.GetNodeText(node.AsNode(), p.SourceCode),
+		Type:           "named-export",
+		ModuleSystem:   ModuleSystemCommonJS,
+		SourceLocation: NewSourceLocation(node.AsNode(), p.SourceCode),
+		Node:           node.AsNode(),
+	}
+	for _, property := range object.AsObjectLiteralExpression().Properties.Nodes {
+		switch property.Kind {
+		case ast.KindShorthandPropertyAssignment:
+			name := property.Name().Text()
+			edr.ExportModules = append(edr.ExportModules, ExportModule{ModuleName: name, Type: "named", Identifier: name})
+		case ast.KindPropertyAssignment, ast.KindMethodDeclaration, ast.KindGetAccessor, ast.KindSetAccessor:
+			nameNode := property.Name()
+			if nameNode == nil || ast.IsComputedPropertyName(nameNode) {
+				continue
+			}
+			name := nameNode.Text()
+			moduleName := name
+			if property.Kind == ast.KindPropertyAssignment {
+				if initializer := property.AsPropertyAssignment().Initializer; initializer != nil && ast.IsIdentifier(initializer) {
+					moduleName = initializer.AsIdentifier().Text
+				}
+			}
+			edr.ExportModules = append(edr.ExportModules, ExportModule{ModuleName: moduleName, Type: "named", Identifier: name})
+		}
+	}
+	p.Result.ExportDeclarations = append(p.Result.ExportDeclarations, edr)
+}
@@ -0,0 +1,321 @@
+// package parser 提供了对单个 TypeScript/TSX 文件进行 AST（抽象语法树）解析的功能。
+// 本文件（componentFile.go）专门负责处理 Vue 单文件组件（.vue）与 Svelte 组件（.svelte）：
+// 提取其中的 <script> 块交给 TS 解析器，并将模板中的组件标签记录为类 JSX 的元素。
+package parser
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/Flying-Bird1999/analyzer-ts/analyzer/utils"
+	"github.com/samber/lo"
+
+	"github.com/Zzzen/typescript-go/use-at-your-own-risk/ast"
+)
+
+// ComponentFileExtensions 是脚本写在 <script> 块中的组件文件扩展名。
+var ComponentFileExtensions = []string{".vue", ".svelte"}
+
+var (
+	// scriptBlockRegex 匹配 <script> 块，第 1 组为标签属性，第 2 组为脚本内容
+	scriptBlockRegex = regexp.MustCompile(`(?is)<script\b([^>]*)>(.*?)</script\s*>`)
+	// styleBlockRegex 匹配 <style> 块
+	styleBlockRegex = regexp.MustCompile(`(?is)<style\b[^>]*>.*?</style\s*>`)
+	// htmlCommentRegex 匹配 HTML 注释
+	htmlCommentRegex = regexp.MustCompile(`(?s)<!--.*?-->`)
+	// langAttrRegex 匹配 <script> 标签上的 lang 属性
+	langAttrRegex = regexp.MustCompile(`(?i)\blang\s*=\s*["']?(\w+)`)
+	// templateTagRegex 匹配模板中的开始标签，第 1 组为标签名
+	templateTagRegex = regexp.MustCompile(`<([A-Za-z][\w.:-]*)`)
+	// identifierRegex 匹配一个合法的 JS 标识符
+	identifierRegex = regexp.MustCompile(`^[A-Za-z_$][\w$]*$`)
+)
+
+// IsComponentFile 判断文件是否为 Vue 或 Svelte 组件文件。
+func IsComponentFile(filePath string) bool {
+	return lo.Contains(ComponentFileExtensions, strings.ToLower(filepath.Ext(filePath)))
+}
+
+// ComponentScript 是从组件文件中提取出的脚本。
+type ComponentScript struct {
+	// Source 与原文件等长，<script> 块之外的内容都被替换为空格（换行保留），
+	// 因此解析出的 AST 节点偏移与行号可以直接对应回原文件。
+	Source string
+	// Ext 是脚本语言对应的扩展名 (e.g., ".ts")，由 <script lang="..."> 决定，未声明时为 ".js"。
+	Ext string
+}
+
+// ExtractComponentScript 提取组件文件中的所有 <script> 块。
+// Vue 的 <script> 与 <script setup>、Svelte 的 <script context="module"> 与 <script> 会合并在同一份源码中解析。
+func ExtractComponentScript(sourceCode string) ComponentScript {
+	masked := blankOut(sourceCode, 0, len(sourceCode))
+	langs := map[string]bool{}
+	for _, match := range scriptBlockRegex.FindAllStringSubmatchIndex(sourceCode, -1) {
+		copy(masked[match[4]:match[5]], sourceCode[match[4]:match[5]])
+		if lang := langAttrRegex.FindStringSubmatch(sourceCode[match[2]:match[3]]); lang != nil {
+			langs[strings.ToLower(lang[1])] = true
+		}
+	}
+
+	ext := ".js"
+	switch {
+	case langs["tsx"]:
+		ext = ".tsx"
+	case langs["ts"]:
+		ext = ".ts"
+	case langs["jsx"]:
+		ext = ".jsx"
+	}
+	return ComponentScript{Source: string(masked), Ext: ext}
+}
+
+// ExtractTemplateElements 提取组件模板中使用的组件标签，以 JSXElement 的形式返回。
+// 原生 HTML 标签与 <svelte:head> 这类特殊元素会被忽略；Vue 中的短横线标签 (e.g., <my-button>) 会转换为 PascalCase。
+// 返回的元素没有对应的 AST 节点，Node 为 nil。
+func ExtractTemplateElements(filePath string, sourceCode string) []JSXElement {
+	isVue := strings.ToLower(filepath.Ext(filePath)) == ".vue"
+
+	// 只在模板区域中查找标签：先屏蔽脚本、样式与注释，Vue 再限定在最外层的 <template> 中
+	region := []byte(sourceCode)
+	for _, re := range []*regexp.Regexp{scriptBlockRegex, styleBlockRegex, htmlCommentRegex} {
+		for _, loc := range re.FindAllStringIndex(sourceCode, -1) {
+			copy(region[loc[0]:loc[1]], blankOut(sourceCode, loc[0], loc[1]))
+		}
+	}
+	if isVue {
+		start := strings.Index(sourceCode, "<template")
+		end := strings.LastIndex(sourceCode, "</template")
+		if start < 0 || end < start {
+			return []JSXElement{}
+		}
+		copy(region[:start], blankOut(sourceCode, 0, start))
+		copy(region[end:], blankOut(sourceCode, end, len(sourceCode)))
+	}
+	return templateElements(sourceCode, region, isVue)
+}
+
+// templateElements 在 region（与 sourceCode 等长，非模板区域已被替换为空白）中查找组件标签。
+func templateElements(sourceCode string, region []byte, isVue bool) []JSXElement {
+	elements := []JSXElement{}
+	for _, match := range templateTagRegex.FindAllSubmatchIndex(region, -1) {
+		chain := templateComponentChain(sourceCode[match[2]:match[3]], isVue)
+		if chain == nil {
+			continue
+		}
+		end := templateTagEnd(sourceCode, match[1])
+		elements = append(elements, JSXElement{
+			ComponentChain: chain,
+			Attrs:          parseTemplateAttrs(strings.TrimSuffix(sourceCode[match[1]:end], ">"), isVue),
+			Raw:            sourceCode[match[0]:end],
+			SourceLocation: offsetSourceLocation(sourceCode, match[0], end),
+		})
+	}
+	return elements
+}
+
+// newComponentParser 为组件文件创建解析器：脚本部分按 ExtractComponentScript 的结果解析，
+// 模板中的组件标签预先写入 JsxElements，脚本中的 JSX 元素会在遍历时继续追加。
+func newComponentParser(filePath string, sourceCode string) *Parser {
+	script := ExtractComponentScript(sourceCode)
+	sourceFile := utils.ParseTypeScriptFileAs(filePath, script.Source, script.Ext)
+	p := &Parser{
+		SourceCode:              script.Source,
+		Ast:                     sourceFile.AsNode(),
+		SourceFile:              sourceFile,
+		Result:                  NewParserResult(filePath),
+		ProcessedDynamicImports: make(map[*ast.Node]bool),
+	}
+	p.Result.JsxElements = append(p.Result.JsxElements, ExtractTemplateElements(filePath, sourceCode)...)
+	return p
+}
+
+// blankOut 返回 sourceCode[start:end] 的副本，其中除换行外的字符都被替换为空格，长度保持不变。
+func blankOut(sourceCode string, start int, end int) []byte {
+	blank := []byte(sourceCode[start:end])
+	for i, c := range blank {
+		if c != '\n' && c != '\r' {
+			blank[i] = ' '
+		}
+	}
+	return blank
+}
+
+// templateComponentChain 根据标签名判断其是否为组件，是组件时返回组件调用链，否则返回 nil。
+func templateComponentChain(tagName string, isVue bool) []string {
+	if strings.Contains(tagName, ":") {
+		return nil
+	}
+	chain := strings.Split(tagName, ".")
+	first := chain[0]
+	if first != "" && first[0] >= 'A' && first[0] <= 'Z' {
+		return chain
+	}
+	// Vue 模板中所有非原生标签都是组件，短横线形式与 PascalCase 形式等价
+	if isVue && len(chain) == 1 && strings.Contains(tagName, "-") {
+		return []string{kebabToPascal(tagName)}
+	}
+	return nil
+}
+
+// kebabToPascal 将短横线命名转换为 PascalCase，例如 "my-button" -> "MyButton"。
+func kebabToPascal(name string) string {
+	var builder strings.Builder
+	for _, part := range strings.Split(name, "-") {
+		if part == "" {
+			continue
+		}
+		builder.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return builder.String()
+}
+
+// templateTagEnd 返回从 pos 开始的开始标签结束位置（">" 之后的下标），引号与花括号中的 ">" 会被跳过。
+func templateTagEnd(sourceCode string, pos int) int {
+	var quote byte
+	depth := 0
+	for i := pos; i < len(sourceCode); i++ {
+		c := sourceCode[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '{':
+			depth++
+		case c == '}':
+			if depth > 0 {
+				depth--
+			}
+		case c == '>' && depth == 0:
+			return i + 1
+		}
+	}
+	return len(sourceCode)
+}
+
+// parseTemplateAttrs 解析开始标签中的属性文本。
+// 静态属性的值记为 stringLiteral；Vue 的 `:prop`、`@event`、`v-*` 与 Svelte 的 `{expr}` 记为表达式，
+// Svelte 的 `{...props}` 记为展开属性，`{name}` 简写记为同名属性。
+func parseTemplateAttrs(text string, isVue bool) []JSXAttribute {
+	attrs := []JSXAttribute{}
+	i := 0
+	for i < len(text) {
+		c := text[i]
+		if c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '/' {
+			i++
+			continue
+		}
+
+		// Svelte 的 {...props} 与 {name}
+		if c == '{' {
+			end := matchingBrace(text, i)
+			expr := strings.TrimSpace(text[i+1 : end])
+			i = end + 1
+			if strings.HasPrefix(expr, "...") {
+				attrs = append(attrs, JSXAttribute{Name: expr, IsSpread: true})
+			} else if expr != "" {
+				attrs = append(attrs, JSXAttribute{Name: expr, Value: templateExpressionValue(expr)})
+			}
+			continue
+		}
+
+		start := i
+		for i < len(text) && !strings.ContainsRune(" \t\n\r=/>", rune(text[i])) {
+			i++
+		}
+		if start == i {
+			// 无法识别的字符，跳过以避免死循环
+			i++
+			continue
+		}
+		attr := JSXAttribute{Name: text[start:i]}
+		for i < len(text) && strings.ContainsRune(" \t\n\r", rune(text[i])) {
+			i++
+		}
+		if i >= len(text) || text[i] != '=' {
+			attrs = append(attrs, attr)
+			continue
+		}
+		i++
+		for i < len(text) && strings.ContainsRune(" \t\n\r", rune(text[i])) {
+			i++
+		}
+
+		// 读取属性值：引号字符串、花括号表达式或无引号的值
+		valueStart := i
+		switch {
+		case i < len(text) && (text[i] == '"' || text[i] == '\''):
+			end := strings.IndexByte(text[i+1:], text[i])
+			if end < 0 {
+				i = len(text)
+			} else {
+				i += end + 2
+			}
+		case i < len(text) && text[i] == '{':
+			i = matchingBrace(text, i) + 1
+		default:
+			for i < len(text) && !strings.ContainsRune(" \t\n\r>", rune(text[i])) {
+				i++
+			}
+		}
+		attr.Value = templateAttrValue(attr.Name, text[valueStart:min(i, len(text))], isVue)
+		attrs = append(attrs, attr)
+	}
+	return attrs
+}
+
+// templateAttrValue 根据属性名与原始属性值构建结构化的属性值。
+func templateAttrValue(name string, raw string, isVue bool) *JSXAttributeValue {
+	inner := strings.Trim(raw, `"'`)
+	isExpression := false
+	if isVue {
+		isExpression = strings.HasPrefix(name, ":") || strings.HasPrefix(name, "@") ||
+			strings.HasPrefix(name, "#") || strings.HasPrefix(name, "v-")
+	} else if trimmed := strings.TrimSpace(inner); strings.HasPrefix(trimmed, "{") && strings.HasSuffix(trimmed, "}") {
+		isExpression = true
+		inner = trimmed[1 : len(trimmed)-1]
+	}
+
+	if isExpression {
+		return templateExpressionValue(strings.TrimSpace(inner))
+	}
+	return &JSXAttributeValue{Type: "stringLiteral", Expression: raw, Data: inner}
+}
+
+// templateExpressionValue 构建模板表达式的属性值，简单标识符记为 identifier，其余记为 other。
+func templateExpressionValue(expr string) *JSXAttributeValue {
+	if identifierRegex.MatchString(expr) {
+		return &JSXAttributeValue{Type: "identifier", Expression: expr, Data: expr}
+	}
+	return &JSXAttributeValue{Type: "other", Expression: expr}
+}
+
+// matchingBrace 返回与 text[start] 处的 "{" 匹配的 "}" 的下标，未闭合时返回 len(text)。
+func matchingBrace(text string, start int) int {
+	depth := 0
+	for i := start; i < len(text); i++ {
+		switch text[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return len(text)
+}
+
+// offsetSourceLocation 根据字符偏移创建 SourceLocation，用于没有 AST 节点的模板元素。
+func offsetSourceLocation(sourceCode string, start int, end int) *SourceLocation {
+	startLine, startChar := utils.GetLineAndCharacterOfPosition(sourceCode, start)
+	endLine, endChar := utils.GetLineAndCharacterOfPosition(sourceCode, end)
+	return &SourceLocation{
+		Start: NodePosition{Line: startLine + 1, Column: startChar + 1},
+		End:   NodePosition{Line: endLine + 1, Column: endChar + 1},
+	}
+}
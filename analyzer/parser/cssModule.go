@@ -0,0 +1,119 @@
+// package parser 提供了对单个 TypeScript/TSX 文件进行 AST（抽象语法树）解析的功能。
+// 本文件（cssModule.go）专门负责收集 CSS Modules 的类名引用，
+// 例如 `import styles from './a.module.less'` 之后的 `styles.foo`、`styles['foo']` 与 `const { foo } = styles`。
+package parser
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/Flying-Bird1999/analyzer-ts/analyzer/utils"
+
+	"github.com/Zzzen/typescript-go/use-at-your-own-risk/ast"
+)
+
+// cssModuleSourceRegex 匹配 CSS Modules 文件的导入路径（允许带有 Vite 的查询参数）
+var cssModuleSourceRegex = regexp.MustCompile(`(?i)\.module\.(css|less|scss|sass|styl)(\?.*)?$`)
+
+// IsCssModuleSource 判断导入路径是否指向 CSS Modules 文件 (e.g., "./index.module.less")。
+func IsCssModuleSource(source string) bool {
+	return cssModuleSourceRegex.MatchString(source)
+}
+
+// CssModuleReference 是对 CSS Modules 中一个类名的引用。
+type CssModuleReference struct {
+	Source         string          `json:"source"`                   // CSS Modules 文件的导入路径，例如 "./index.module.less"。
+	Identifier     string          `json:"identifier"`               // 引用所使用的本地标识符，例如 `styles.foo` 中的 "styles"。命名导入为类名本身。
+	ClassName      string          `json:"className"`                // 引用的类名。无法静态确定时（如 `styles[name]`）为空。
+	IsDynamic      bool            `json:"isDynamic,omitempty"`      // 是否为无法静态确定类名的引用。
+	Raw            string          `json:"raw,omitempty"`            // 节点在源码中的原始文本。
+	SourceLocation *SourceLocation `json:"sourceLocation,omitempty"` // 节点在源码中的位置信息。
+}
+
+// collectCssModuleReferences 在遍历结束后收集 CSS Modules 的类名引用。
+// 命名导入 (`import { foo } from './a.module.css'`) 直接记为引用；
+// 默认导入与命名空间导入的标识符上的属性访问、元素访问与对象解构记为引用。不考虑变量遮蔽。
+func (p *Parser) collectCssModuleReferences() {
+	bindings := map[string]string{}
+	for _, decl := range p.Result.ImportDeclarations {
+		if !IsCssModuleSource(decl.Source) {
+			continue
+		}
+		for _, module := range decl.ImportModules {
+			if module.Type == "named" {
+				p.addCssModuleReference(decl.Source, module.Identifier, module.ImportModule, decl.Node)
+				continue
+			}
+			bindings[module.Identifier] = decl.Source
+		}
+	}
+	if len(bindings) == 0 {
+		return
+	}
+
+	var walk func(node *ast.Node) bool
+	walk = func(node *ast.Node) bool {
+		switch node.Kind {
+		case ast.KindPropertyAccessExpression:
+			access := node.AsPropertyAccessExpression()
+			if source, ok := cssModuleBinding(access.Expression, bindings); ok {
+				p.addCssModuleReference(source, access.Expression.Text(), access.Name().Text(), node)
+			}
+		case ast.KindElementAccessExpression:
+			access := node.AsElementAccessExpression()
+			if source, ok := cssModuleBinding(access.Expression, bindings); ok {
+				className := ""
+				if ast.IsStringLiteralLike(access.ArgumentExpression) {
+					className = access.ArgumentExpression.Text()
+				}
+				p.addCssModuleReference(source, access.Expression.Text(), className, node)
+			}
+		case ast.KindVariableDeclaration:
+			decl := node.AsVariableDeclaration()
+			if decl.Initializer != nil && ast.IsObjectBindingPattern(decl.Name()) {
+				if source, ok := cssModuleBinding(decl.Initializer, bindings); ok {
+					for _, element := range decl.Name().AsBindingPattern().Elements.Nodes {
+						bindingElement := element.AsBindingElement()
+						if bindingElement == nil || bindingElement.DotDotDotToken != nil {
+							continue
+						}
+						className := ""
+						if propertyName := bindingElement.PropertyName; propertyName != nil && !ast.IsComputedPropertyName(propertyName) {
+							className = propertyName.Text()
+						} else if ast.IsIdentifier(bindingElement.Name()) {
+							className = bindingElement.Name().Text()
+						}
+						p.addCssModuleReference(source, decl.Initializer.Text(), className, element)
+					}
+				}
+			}
+		}
+		node.ForEachChild(walk)
+		return false
+	}
+	walk(p.Ast)
+}
+
+// cssModuleBinding 判断表达式是否为 CSS Modules 导入的标识符，是则返回其导入路径。
+func cssModuleBinding(expression *ast.Node, bindings map[string]string) (string, bool) {
+	if expression == nil || !ast.IsIdentifier(expression) {
+		return "", false
+	}
+	source, ok := bindings[expression.Text()]
+	return source, ok
+}
+
+// addCssModuleReference 记录一条 CSS Modules 的类名引用。
+func (p *Parser) addCssModuleReference(source string, identifier string, className string, node *ast.Node) {
+	reference := CssModuleReference{
+		Source:     source,
+		Identifier: identifier,
+		ClassName:  className,
+		IsDynamic:  className == "",
+	}
+	if node != nil {
+		reference.Raw = strings.TrimSpace(utils.GetNodeText(node, p.SourceCode))
+		reference.SourceLocation = NewSourceLocation(node, p.SourceCode)
+	}
+	p.Result.CssModuleReferences = append(p.Result.CssModuleReferences, reference)
+}
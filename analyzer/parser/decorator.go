@@ -0,0 +1,59 @@
+// package parser 提供了对单个 TypeScript/TSX 文件进行 AST（抽象语法树）解析的功能。
+// 本文件（decorator.go）专门负责处理和解析装饰器（Decorator），例如 NestJS 的 `@Controller('/users')`、MobX 的 `@observable`。
+package parser
+
+import (
+	"github.com/Flying-Bird1999/analyzer-ts/analyzer/utils"
+	"github.com/samber/lo"
+
+	"github.com/Zzzen/typescript-go/use-at-your-own-risk/ast"
+)
+
+// DecoratorResult 存储一个装饰器的解析结果。
+type DecoratorResult struct {
+	Name           string           `json:"name"`                     // 装饰器名称，即调用链的最后一段。`@Get(':id')` 为 "Get"，`@nest.Inject()` 为 "Inject"。
+	CallChain      []string         `json:"callChain"`                // 装饰器表达式的调用链，例如 `@nest.Inject()` 为 ["nest", "Inject"]。
+	IsCall         bool             `json:"isCall"`                   // 是否以调用形式使用：`@Injectable()` 为 true，`@observable` 为 false。
+	Arguments      []*VariableValue `json:"arguments"`                // 调用时传递的参数列表，非调用形式为空。
+	Raw            string           `json:"raw,omitempty"`            // 节点在源码中的原始文本。
+	SourceLocation *SourceLocation  `json:"sourceLocation,omitempty"` // 节点在源码中的位置信息。
+}
+
+// AnalyzeDecorator 是一个公共的、可复用的函数，用于从 AST 节点中解析装饰器。
+func AnalyzeDecorator(node *ast.Decorator, sourceCode string) *DecoratorResult {
+	decoratorNode := node.AsNode()
+	result := &DecoratorResult{
+		Arguments:      []*VariableValue{},
+		Raw:            utils.GetNodeText(decoratorNode, sourceCode),
+		SourceLocation: NewSourceLocation(decoratorNode, sourceCode),
+	}
+
+	expression := ast.SkipParentheses(node.Expression)
+	if expression.Kind == ast.KindCallExpression {
+		call := expression.AsCallExpression()
+		result.IsCall = true
+		expression = call.Expression
+		if call.Arguments != nil {
+			result.Arguments = lo.Map(call.Arguments.Nodes, func(arg *ast.Node, _ int) *VariableValue {
+				return AnalyzeVariableValueNode(arg, sourceCode)
+			})
+		}
+	}
+
+	result.CallChain = ReconstructCallChain(expression, sourceCode)
+	if len(result.CallChain) > 0 {
+		result.Name = result.CallChain[len(result.CallChain)-1]
+	}
+	return result
+}
+
+// collectDecorators 解析节点上的所有装饰器，按源码顺序返回。
+func collectDecorators(node *ast.Node, sourceCode string) []DecoratorResult {
+	decorators := []DecoratorResult{}
+	for _, modifier := range node.ModifierNodes() {
+		if modifier.Kind == ast.KindDecorator {
+			decorators = append(decorators, *AnalyzeDecorator(modifier.AsDecorator(), sourceCode))
+		}
+	}
+	return decorators
+}
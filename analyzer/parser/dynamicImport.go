@@ -0,0 +1,155 @@
+// package parser 提供了对单个 TypeScript/TSX 文件进行 AST（抽象语法树）解析的功能。
+// 本文件（dynamicImport.go）专门负责处理路径不确定的动态导入：
+// 带插值的 `import(`./locales/${lang}.ts`)`、Vite 的 `import.meta.glob()` 与 webpack 的 `require.context()`。
+// 这些导入只记录 glob 模式，由 projectParser 根据扫描到的文件列表展开为具体的导入。
+package parser
+
+import (
+	"strings"
+
+	"github.com/Flying-Bird1999/analyzer-ts/analyzer/utils"
+
+	"github.com/Zzzen/typescript-go/use-at-your-own-risk/ast"
+)
+
+// dynamicImportSpecifier 解析 `import()` 的参数，返回导入路径与 glob 模式。
+//   - 字符串字面量与无插值的模板字符串：返回路径本身，模式为空；
+//   - 标识符：返回标识符文本，模式为空（与之前的行为保持一致）；
+//   - 带插值的模板字符串与字符串拼接：插值部分替换为 `*`，路径与模式均为替换后的结果，
+//     例如 `./locales/${lang}.ts` 与 './locales/' + lang + '.ts' 都得到 "./locales/*.ts"。
+//
+// 无法解析，或模式以 `*` 开头（没有确定的目录前缀）时 ok 为 false。
+func dynamicImportSpecifier(arg *ast.Node) (source string, pattern string, ok bool) {
+	switch arg.Kind {
+	case ast.KindStringLiteral:
+		return arg.AsStringLiteral().Text, "", true
+	case ast.KindNoSubstitutionTemplateLiteral:
+		return arg.AsNoSubstitutionTemplateLiteral().Text, "", true
+	case ast.KindIdentifier:
+		return arg.AsIdentifier().Text, "", true
+	}
+
+	parts, ok := patternParts(arg)
+	if !ok {
+		return "", "", false
+	}
+	pattern = strings.Join(parts, "")
+	for strings.Contains(pattern, "**") {
+		pattern = strings.ReplaceAll(pattern, "**", "*")
+	}
+	if pattern == "" || strings.HasPrefix(pattern, "*") {
+		return "", "", false
+	}
+	return pattern, pattern, true
+}
+
+// patternParts 将模板字符串或字符串拼接表达式拆分为静态文本与 `*` 片段，表达式中至少要有一段静态文本。
+func patternParts(node *ast.Node) ([]string, bool) {
+	node = ast.SkipParentheses(node)
+	switch node.Kind {
+	case ast.KindStringLiteral:
+		return []string{node.AsStringLiteral().Text}, true
+	case ast.KindNoSubstitutionTemplateLiteral:
+		return []string{node.AsNoSubstitutionTemplateLiteral().Text}, true
+	case ast.KindTemplateExpression:
+		template := node.AsTemplateExpression()
+		parts := []string{template.Head.Text()}
+		for _, span := range template.TemplateSpans.Nodes {
+			parts = append(parts, "*", span.AsTemplateSpan().Literal.Text())
+		}
+		return parts, true
+	case ast.KindBinaryExpression:
+		binary := node.AsBinaryExpression()
+		if binary.OperatorToken.Kind != ast.KindPlusToken {
+			return nil, false
+		}
+		left, leftOk := patternParts(binary.Left)
+		right, rightOk := patternParts(binary.Right)
+		if !leftOk && !rightOk {
+			return nil, false
+		}
+		if !leftOk {
+			left = []string{"*"}
+		}
+		if !rightOk {
+			right = []string{"*"}
+		}
+		return append(left, right...), true
+	}
+	return nil, false
+}
+
+// AnalyzeImportPattern 解析 `import.meta.glob()`（及已废弃的 `import.meta.globEager()`）与 `require.context()` 调用。
+// 调用不属于这两种形式时返回 nil。
+//
+//   - `import.meta.glob('./pages/**/*.tsx')` 记录一条模式为 "./pages/**/*.tsx" 的导入，
+//     参数为数组时每个模式各记录一条，以 `!` 开头的排除模式会被忽略；
+//   - `require.context('./icons', true, /\.svg$/)` 记录一条模式为 "./icons/**/*" 的导入
+//     （第二个参数为 false 时为 "./icons/*"），正则表达式记录在 PatternFilter 中。
+func AnalyzeImportPattern(node *ast.CallExpression, sourceCode string) []ImportDeclarationResult {
+	callee := ast.SkipParentheses(node.Expression)
+	if callee.Kind != ast.KindPropertyAccessExpression {
+		return nil
+	}
+	access := callee.AsPropertyAccessExpression()
+	name := access.Name().Text()
+	args := node.Arguments.Nodes
+
+	newResult := func(pattern string) ImportDeclarationResult {
+		return ImportDeclarationResult{
+			Source: pattern,
+			ImportModules: []ImportModule{
+				{
+					Identifier:   "default",
+					ImportModule: "default",
+					Type:         "dynamic",
+				},
+			},
+			IsDynamic:      true,
+			Pattern:        pattern,
+			Raw:            utils.GetNodeText(node.AsNode(), sourceCode),
+			SourceLocation: NewSourceLocation(node.AsNode(), sourceCode),
+		}
+	}
+
+	switch {
+	case ast.IsImportMeta(access.Expression) && (name == "glob" || name == "globEager"):
+		if len(args) == 0 {
+			return nil
+		}
+		var patterns []string
+		switch args[0].Kind {
+		case ast.KindStringLiteral, ast.KindNoSubstitutionTemplateLiteral:
+			patterns = append(patterns, args[0].Text())
+		case ast.KindArrayLiteralExpression:
+			for _, element := range args[0].AsArrayLiteralExpression().Elements.Nodes {
+				if ast.IsStringLiteralLike(element) {
+					patterns = append(patterns, element.Text())
+				}
+			}
+		}
+		results := []ImportDeclarationResult{}
+		for _, pattern := range patterns {
+			if pattern != "" && !strings.HasPrefix(pattern, "!") {
+				results = append(results, newResult(pattern))
+			}
+		}
+		return results
+
+	case ast.IsIdentifier(access.Expression) && access.Expression.Text() == "require" && name == "context":
+		if len(args) == 0 || !ast.IsStringLiteralLike(args[0]) {
+			return nil
+		}
+		pattern := strings.TrimSuffix(args[0].Text(), "/") + "/**/*"
+		if len(args) > 1 && args[1].Kind == ast.KindFalseKeyword {
+			pattern = strings.TrimSuffix(args[0].Text(), "/") + "/*"
+		}
+		result := newResult(pattern)
+		if len(args) > 2 && args[2].Kind == ast.KindRegularExpressionLiteral {
+			result.PatternFilter = args[2].Text()
+		}
+		return []ImportDeclarationResult{result}
+	}
+
+	return nil
+}
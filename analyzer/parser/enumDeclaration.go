@@ -3,18 +3,34 @@
 package parser
 
 import (
+	"math"
+	"strconv"
+	"strings"
+
 	"github.com/Flying-Bird1999/analyzer-ts/analyzer/utils"
 
 	"github.com/Zzzen/typescript-go/use-at-your-own-risk/ast"
 )
 
+// EnumMemberResult 存储一个枚举成员的解析结果。
+type EnumMemberResult struct {
+	Name           string          `json:"name"`                     // 成员名称。
+	Initializer    string          `json:"initializer,omitempty"`    // 初始化表达式的源码文本，没有显式初始化时为空。
+	Value          interface{}     `json:"value,omitempty"`          // 计算出的常量值（float64 或 string），无法在编译期计算时为 nil。
+	SourceLocation *SourceLocation `json:"sourceLocation,omitempty"` // 成员在源码中的位置信息。
+}
+
 // EnumDeclarationResult 存储一个解析后的枚举声明信息。
 type EnumDeclarationResult struct {
-	Identifier     string         `json:"identifier"`     // 枚举的名称。
-	Exported       bool           `json:"exported"`       // 新增：标记此枚举是否被导出。
-	Raw            string         `json:"raw,omitempty"`            // 节点在源码中的原始文本
-	SourceLocation *SourceLocation `json:"sourceLocation,omitempty"` // 节点在源码中的位置信息
-	Node           *ast.Node      `json:"-"`                     // 对应的 AST 节点，不在 JSON 中序列化。
+	Identifier     string             `json:"identifier"`               // 枚举的名称。
+	Exported       bool               `json:"exported"`                 // 新增：标记此枚举是否被导出。
+	IsConst        bool               `json:"isConst"`                  // 标记此枚举是否为 const enum。
+	IsDeclare      bool               `json:"isDeclare"`                // 标记此枚举是否为 declare enum。
+	Namespace      string             `json:"namespace,omitempty"`      // 所属的命名空间或环境模块，顶层声明为空。规则见 EnclosingNamespace。
+	Members        []EnumMemberResult `json:"members"`                  // 枚举成员列表，按源码顺序排列。
+	Raw            string             `json:"raw,omitempty"`            // 节点在源码中的原始文本
+	SourceLocation *SourceLocation    `json:"sourceLocation,omitempty"` // 节点在源码中的位置信息
+	Node           *ast.Node          `json:"-"`                        // 对应的 AST 节点，不在 JSON 中序列化。
 }
 
 // AnalyzeEnumDeclaration 是一个公共的、可复用的函数，用于从 AST 节点中解析枚举声明。
@@ -23,6 +39,7 @@ func AnalyzeEnumDeclaration(node *ast.EnumDeclaration, sourceCode string) *EnumD
 
 	result := &EnumDeclarationResult{
 		Exported:       false, // 默认为 false
+		Members:        []EnumMemberResult{},
 		Raw:            raw,
 		SourceLocation: NewSourceLocation(node.AsNode(), sourceCode),
 		Node:           node.AsNode(),
@@ -37,21 +54,200 @@ func AnalyzeEnumDeclaration(node *ast.EnumDeclaration, sourceCode string) *EnumD
 		result.Identifier = ""
 	}
 
-	// 检查导出关键字
+	// 检查导出、const 与 declare 关键字
 	if modifiers := node.Modifiers(); modifiers != nil {
 		for _, modifier := range modifiers.Nodes {
-			if modifier != nil && modifier.Kind == ast.KindExportKeyword {
+			if modifier == nil {
+				continue
+			}
+			switch modifier.Kind {
+			case ast.KindExportKeyword:
 				result.Exported = true
-				break
+			case ast.KindConstKeyword:
+				result.IsConst = true
+			case ast.KindDeclareKeyword:
+				result.IsDeclare = true
 			}
 		}
 	}
 
+	// 解析枚举成员，并按照 TypeScript 的规则计算常量值
+	if node.Members != nil {
+		evaluator := &enumEvaluator{enumName: result.Identifier, values: make(map[string]interface{})}
+		var previous interface{} = float64(-1)
+		for _, memberNode := range node.Members.Nodes {
+			member := memberNode.AsEnumMember()
+			memberResult := EnumMemberResult{
+				Name:           enumMemberName(memberNode.Name()),
+				SourceLocation: NewSourceLocation(memberNode, sourceCode),
+			}
+			if member.Initializer != nil {
+				memberResult.Initializer = strings.TrimSpace(utils.GetNodeText(member.Initializer, sourceCode))
+				memberResult.Value = evaluator.evaluate(member.Initializer)
+			} else if prev, ok := previous.(float64); ok {
+				// 没有初始化表达式时，在上一个数值成员的基础上自增
+				memberResult.Value = prev + 1
+			}
+			evaluator.values[memberResult.Name] = memberResult.Value
+			previous = memberResult.Value
+			result.Members = append(result.Members, memberResult)
+		}
+	}
+
 	return result
 }
 
+// enumMemberName 返回枚举成员的名称，字符串字面量名称 ('a-b') 返回去掉引号后的文本。
+func enumMemberName(name *ast.Node) string {
+	if name == nil {
+		return ""
+	}
+	switch name.Kind {
+	case ast.KindIdentifier, ast.KindStringLiteral, ast.KindNumericLiteral, ast.KindNoSubstitutionTemplateLiteral:
+		return name.Text()
+	case ast.KindComputedPropertyName:
+		return enumMemberName(name.AsComputedPropertyName().Expression)
+	}
+	return ""
+}
+
+// enumEvaluator 用于计算枚举成员的常量值。
+// 支持数字/字符串字面量、一元与二元运算、括号，以及对同一枚举中已声明成员的引用 (B = A + 1, C = Color.A)。
+type enumEvaluator struct {
+	enumName string                 // 当前枚举的名称，用于识别 Color.A 形式的引用
+	values   map[string]interface{} // 已计算出的成员值
+}
+
+// evaluate 计算表达式的常量值，返回 float64、string，或在无法计算时返回 nil。
+func (e *enumEvaluator) evaluate(expr *ast.Node) interface{} {
+	switch expr.Kind {
+	case ast.KindNumericLiteral:
+		return parseNumericLiteral(expr.Text())
+	case ast.KindStringLiteral, ast.KindNoSubstitutionTemplateLiteral:
+		return expr.Text()
+	case ast.KindParenthesizedExpression:
+		return e.evaluate(expr.AsParenthesizedExpression().Expression)
+	case ast.KindIdentifier:
+		return e.values[expr.Text()]
+	case ast.KindPropertyAccessExpression:
+		access := expr.AsPropertyAccessExpression()
+		if ast.IsIdentifier(access.Expression) && access.Expression.Text() == e.enumName {
+			return e.values[access.Name().Text()]
+		}
+	case ast.KindElementAccessExpression:
+		access := expr.AsElementAccessExpression()
+		if ast.IsIdentifier(access.Expression) && access.Expression.Text() == e.enumName && ast.IsStringLiteralLike(access.ArgumentExpression) {
+			return e.values[access.ArgumentExpression.Text()]
+		}
+	case ast.KindPrefixUnaryExpression:
+		unary := expr.AsPrefixUnaryExpression()
+		operand, ok := e.evaluate(unary.Operand).(float64)
+		if !ok {
+			return nil
+		}
+		switch unary.Operator {
+		case ast.KindPlusToken:
+			return operand
+		case ast.KindMinusToken:
+			return -operand
+		case ast.KindTildeToken:
+			return float64(^toInt32(operand))
+		}
+	case ast.KindBinaryExpression:
+		binary := expr.AsBinaryExpression()
+		left := e.evaluate(binary.Left)
+		right := e.evaluate(binary.Right)
+		if left == nil || right == nil {
+			return nil
+		}
+		return evaluateBinary(binary.OperatorToken.Kind, left, right)
+	}
+	return nil
+}
+
+// evaluateBinary 按照 JavaScript 的语义计算二元运算，字符串只支持 + 拼接。
+// 结果为 Infinity 或 NaN（例如 1 / 0、0 % 0）时返回 nil：TypeScript 不把它们视为常量成员，JSON 也无法序列化。
+func evaluateBinary(operator ast.Kind, left interface{}, right interface{}) interface{} {
+	l, lok := left.(float64)
+	r, rok := right.(float64)
+	if !lok || !rok {
+		if operator == ast.KindPlusToken {
+			return formatEnumValue(left) + formatEnumValue(right)
+		}
+		return nil
+	}
+	value, ok := evaluateNumericBinary(operator, l, r)
+	if !ok || math.IsInf(value, 0) || math.IsNaN(value) {
+		return nil
+	}
+	return value
+}
+
+// evaluateNumericBinary 计算两个数字的二元运算，不支持的运算符返回 false。
+func evaluateNumericBinary(operator ast.Kind, l float64, r float64) (float64, bool) {
+	switch operator {
+	case ast.KindPlusToken:
+		return l + r, true
+	case ast.KindMinusToken:
+		return l - r, true
+	case ast.KindAsteriskToken:
+		return l * r, true
+	case ast.KindSlashToken:
+		return l / r, true
+	case ast.KindPercentToken:
+		return math.Mod(l, r), true
+	case ast.KindAsteriskAsteriskToken:
+		return math.Pow(l, r), true
+	case ast.KindLessThanLessThanToken:
+		return float64(toInt32(l) << (uint32(toInt32(r)) & 31)), true
+	case ast.KindGreaterThanGreaterThanToken:
+		return float64(toInt32(l) >> (uint32(toInt32(r)) & 31)), true
+	case ast.KindGreaterThanGreaterThanGreaterThanToken:
+		return float64(uint32(toInt32(l)) >> (uint32(toInt32(r)) & 31)), true
+	case ast.KindAmpersandToken:
+		return float64(toInt32(l) & toInt32(r)), true
+	case ast.KindBarToken:
+		return float64(toInt32(l) | toInt32(r)), true
+	case ast.KindCaretToken:
+		return float64(toInt32(l) ^ toInt32(r)), true
+	}
+	return 0, false
+}
+
+// toInt32 按照 JavaScript 位运算的规则将数字转换为 32 位整数。
+func toInt32(value float64) int32 {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0
+	}
+	return int32(uint32(int64(math.Trunc(value))))
+}
+
+// parseNumericLiteral 解析数字字面量，支持十六进制、八进制、二进制与数字分隔符。
+func parseNumericLiteral(text string) interface{} {
+	text = strings.ReplaceAll(text, "_", "")
+	if value, err := strconv.ParseFloat(text, 64); err == nil {
+		return value
+	}
+	if value, err := strconv.ParseInt(text, 0, 64); err == nil {
+		return float64(value)
+	}
+	return nil
+}
+
+// formatEnumValue 将枚举成员的值转换为字符串，数字按照 JavaScript 的格式输出 (1 而不是 1.0)。
+func formatEnumValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return ""
+}
+
 // VisitEnumDeclaration 解析枚举声明。
 func (p *Parser) VisitEnumDeclaration(node *ast.EnumDeclaration) {
 	result := AnalyzeEnumDeclaration(node, p.SourceCode)
-	p.Result.EnumDeclarations[result.Identifier] = *result
-}
\ No newline at end of file
+	result.Namespace = EnclosingNamespace(node.AsNode())
+	p.Result.EnumDeclarations[QualifiedName(result.Namespace, result.Identifier)] = *result
+}
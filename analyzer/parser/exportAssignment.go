@@ -13,6 +13,7 @@ import (
 type ExportAssignmentResult struct {
 	Expression     string         `json:"expression,omitempty"`     // 导出的表达式的文本。
 	Name           string         `json:"name,omitempty"`           // 导出的符号名（如 export default foo → "foo"）
+	ModuleSystem   string         `json:"moduleSystem,omitempty"`   // 模块系统。`export default` 为空，`module.exports = ...` 为 "cjs"。
 	Raw            string         `json:"raw,omitempty"`            // 节点在源码中的原始文本。
 	SourceLocation *SourceLocation `json:"sourceLocation,omitempty"` // 节点在源码中的位置信息。
 	Node           *ast.Node      `json:"-"`                     // 对应的 AST 节点，不在 JSON 中序列化。
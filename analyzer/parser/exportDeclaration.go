@@ -15,9 +15,10 @@ import (
 // - `export { School2 as NewSchool2 };` (带别名的命名导出)
 // - `export const name = "bird";` (导出变量)
 type ExportModule struct {
-	ModuleName string `json:"moduleName"` // 模块名, 对应实际导出的内容模块。例如 `export { a as b }` 中的 `a`。
-	Type       string `json:"type"`       // 导出类型: `named` (命名导出), `namespace` (命名空间导出)。
-	Identifier string `json:"identifier"` // 导出的标识符。例如 `export { a as b }` 中的 `b`。
+	ModuleName string `json:"moduleName"`           // 模块名, 对应实际导出的内容模块。例如 `export { a as b }` 中的 `a`。
+	Type       string `json:"type"`                 // 导出类型: `named` (命名导出), `namespace` (命名空间导出)。
+	Identifier string `json:"identifier"`           // 导出的标识符。例如 `export { a as b }` 中的 `b`。
+	IsTypeOnly bool   `json:"isTypeOnly,omitempty"` // 是否仅导出类型。`export type { a }` 中的所有模块以及 `export { type a }` 中的 `a` 为 true。
 }
 
 // ExportDeclarationResult 存储一个完整的导出声明的解析结果。
@@ -27,6 +28,8 @@ type ExportDeclarationResult struct {
 	Raw            string         `json:"raw,omitempty"`            // 节点在源码中的原始文本。
 	Source         string         `json:"source,omitempty"`         // 导出来源的模块路径。例如 `export { a } from "../index.ts"` 中的 `"../index.ts"`。
 	Type           string         `json:"type"`                      // 导出类型: `re-export` (重导出) 或 `named-export` (命名导出)。
+	ModuleSystem   string         `json:"moduleSystem,omitempty"`   // 模块系统。ESM 语法为空，`exports.xxx = ...` 等 CommonJS 导出为 "cjs"。
+	IsTypeOnly     bool           `json:"isTypeOnly,omitempty"`     // 是否为整体的类型导出 (`export type { a }` 或 `export type * from`)。
 	SourceLocation *SourceLocation `json:"sourceLocation,omitempty"` // 节点在源码中的位置信息。
 	Node           *ast.Node      `json:"-"`                     // 对应的 AST 节点，不在 JSON 中序列化。
 }
@@ -37,6 +40,7 @@ func AnalyzeExportDeclaration(node *ast.ExportDeclaration, sourceCode string) *E
 		ExportModules:  make([]ExportModule, 0),
 		Raw:            utils.GetNodeText(node.AsNode(), sourceCode),
 		SourceLocation: NewSourceLocation(node.AsNode(), sourceCode),
+		IsTypeOnly:     node.IsTypeOnly,
 		Node:           node.AsNode(),
 	}
 
@@ -65,6 +69,7 @@ func AnalyzeExportDeclaration(node *ast.ExportDeclaration, sourceCode string) *E
 					ModuleName: moduleName,
 					Type:       "named",
 					Identifier: identifier,
+					IsTypeOnly: node.IsTypeOnly || specifier.IsTypeOnly,
 				})
 			}
 			// 处理命名空间导出 `export * as ns from './module'`
@@ -75,6 +80,7 @@ func AnalyzeExportDeclaration(node *ast.ExportDeclaration, sourceCode string) *E
 				ModuleName: "*",
 				Type:       "namespace",
 				Identifier: identifier,
+				IsTypeOnly: node.IsTypeOnly,
 				})
 		}
 	} else {
@@ -84,6 +90,7 @@ func AnalyzeExportDeclaration(node *ast.ExportDeclaration, sourceCode string) *E
 				ModuleName: "*",
 				Type:       "namespace",
 				Identifier: "*",
+				IsTypeOnly: node.IsTypeOnly,
 			})
 		}
 	}
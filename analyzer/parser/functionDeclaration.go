@@ -13,28 +13,30 @@ import (
 // ParameterResult 存储一个解析后的函数参数信息。
 // 结构进行了扩展，以捕获更丰富的参数属性。
 type ParameterResult struct {
-	Name         string `json:"name"`                   // 参数名称
-	Type         string `json:"type"`                   // 参数的类型文本
-	Raw          string `json:"raw,omitempty"`                    // 参数在源码中的原始文本
-	Optional     bool   `json:"optional"`               // 新增：标记此参数是否可选 (e.g., name?: string)
-	DefaultValue string `json:"defaultValue,omitempty"` // 新增：存储参数的默认值 (e.g., port = 3000)
-	IsRest       bool   `json:"isRest"`                 // 新增：标记此参数是否为 rest 参数 (e.g., ...args)
+	Name         string            `json:"name"`                   // 参数名称
+	Type         string            `json:"type"`                   // 参数的类型文本
+	Raw          string            `json:"raw,omitempty"`          // 参数在源码中的原始文本
+	Optional     bool              `json:"optional"`               // 新增：标记此参数是否可选 (e.g., name?: string)
+	DefaultValue string            `json:"defaultValue,omitempty"` // 新增：存储参数的默认值 (e.g., port = 3000)
+	IsRest       bool              `json:"isRest"`                 // 新增：标记此参数是否为 rest 参数 (e.g., ...args)
+	Decorators   []DecoratorResult `json:"decorators,omitempty"`   // 参数上的装饰器 (e.g., @Inject(TOKEN))
 }
 
 // FunctionDeclarationResult 存储一个完整的函数声明的解析结果。
 // 结构进行了扩展，以支持泛型和更广泛的函数类型。
 type FunctionDeclarationResult struct {
-	Identifier     string            `json:"identifier"`     // 函数的名称。对于匿名函数或表达式，这通常是变量名。
-	Exported       bool              `json:"exported"`       // 标记此函数是否被导出。
-	IsDefaultExport bool              `json:"isDefaultExport"` // 标记此函数是否为 default export (export default function foo()).
-	IsAsync        bool              `json:"isAsync"`        // 标记此函数是否为异步函数 (async)。
-	IsGenerator    bool              `json:"isGenerator"`    // 新增：标记此函数是否为生成器函数 (function*)。
-	Generics       []string          `json:"generics,omitempty"`       // 新增：存储泛型参数列表 (e.g., ["T", "K"])。
-	Parameters     []ParameterResult `json:"parameters"`     // 函数的参数列表。
-	ReturnType     string            `json:"returnType,omitempty"`     // 函数的返回类型文本。
-	Raw            string            `json:"raw,omitempty"`            // 节点在源码中的原始文本。
-	SourceLocation *SourceLocation    `json:"sourceLocation,omitempty"` // 节点在源码中的位置信息。
-	Node           *ast.Node         `json:"-"`                     // 对应的 AST 节点，不在 JSON 中序列化。
+	Identifier      string            `json:"identifier"`               // 函数的名称。对于匿名函数或表达式，这通常是变量名。
+	Exported        bool              `json:"exported"`                 // 标记此函数是否被导出。
+	IsDefaultExport bool              `json:"isDefaultExport"`          // 标记此函数是否为 default export (export default function foo()).
+	Namespace       string            `json:"namespace,omitempty"`      // 所属的命名空间或环境模块，顶层声明为空。规则见 EnclosingNamespace。
+	IsAsync         bool              `json:"isAsync"`                  // 标记此函数是否为异步函数 (async)。
+	IsGenerator     bool              `json:"isGenerator"`              // 新增：标记此函数是否为生成器函数 (function*)。
+	Generics        []string          `json:"generics,omitempty"`       // 新增：存储泛型参数列表 (e.g., ["T", "K"])。
+	Parameters      []ParameterResult `json:"parameters"`               // 函数的参数列表。
+	ReturnType      string            `json:"returnType,omitempty"`     // 函数的返回类型文本。
+	Raw             string            `json:"raw,omitempty"`            // 节点在源码中的原始文本。
+	SourceLocation  *SourceLocation   `json:"sourceLocation,omitempty"` // 节点在源码中的位置信息。
+	Node            *ast.Node         `json:"-"`                        // 对应的 AST 节点，不在 JSON 中序列化。
 }
 
 // NewFunctionDeclarationResult 是基于 ast.FunctionDeclaration 节点创建函数解析结果的构造函数。
@@ -176,8 +178,14 @@ func extractFunctionDetails(result *FunctionDeclarationResult, node *ast.Node, s
 // parseParameters 是一个辅助函数，用于从参数列表中提取详细信息。
 // 这个函数被 `extractFunctionDetails` 调用，以减少代码重复。
 func parseParameters(result *FunctionDeclarationResult, params *ast.NodeList, sourceCode string) {
+	result.Parameters = append(result.Parameters, analyzeParameterList(params, sourceCode)...)
+}
+
+// analyzeParameterList 从参数列表中提取每个参数的详细信息，函数与类的方法共用此逻辑。
+func analyzeParameterList(params *ast.NodeList, sourceCode string) []ParameterResult {
+	parameters := []ParameterResult{}
 	if params == nil {
-		return
+		return parameters
 	}
 	for _, paramNode := range params.Nodes {
 		param := paramNode.AsParameterDeclaration()
@@ -205,15 +213,17 @@ func parseParameters(result *FunctionDeclarationResult, params *ast.NodeList, so
 			defaultValue = strings.TrimSpace(utils.GetNodeText(param.Initializer, sourceCode))
 		}
 
-		result.Parameters = append(result.Parameters, ParameterResult{
+		parameters = append(parameters, ParameterResult{
 			Name:         paramName,
 			Type:         paramType,
 			Raw:          utils.GetNodeText(param.AsNode(), sourceCode),
 			Optional:     param.QuestionToken != nil,
 			IsRest:       param.DotDotDotToken != nil,
 			DefaultValue: defaultValue,
+			Decorators:   collectDecorators(paramNode, sourceCode),
 		})
 	}
+	return parameters
 }
 
 // VisitFunctionDeclaration 解析函数声明。
@@ -232,6 +242,7 @@ func (p *Parser) VisitFunctionDeclaration(node *ast.FunctionDeclaration) {
 		}
 	}
 
-	// 3. 将解析结果存入
+	// 3. 记录所属的命名空间，并将解析结果存入
+	fr.Namespace = EnclosingNamespace(node.AsNode())
 	p.Result.FunctionDeclarations = append(p.Result.FunctionDeclarations, *fr)
-}
\ No newline at end of file
+}
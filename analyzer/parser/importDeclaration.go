@@ -10,9 +10,10 @@ import (
 // ImportModule 代表一个被导入的独立实体。
 // 它用于表示默认导入、命名导入或命名空间导入中的具体项。
 type ImportModule struct {
-	ImportModule string `json:"importModule"` // 原始模块名。对于 `import { a as b }` 是 `a`；对于默认导入是 `default`；对于命名空间导入是命名空间名称。
-	Type         string `json:"type"`         // 导入类型: `default`, `namespace`, `named`。
-	Identifier   string `json:"identifier"`   // 在当前文件中使用的标识符。对于 `import { a as b }` 是 `b`；对于 `import a` 是 `a`。
+	ImportModule string `json:"importModule"`         // 原始模块名。对于 `import { a as b }` 是 `a`；对于默认导入是 `default`；对于命名空间导入是命名空间名称。
+	Type         string `json:"type"`                 // 导入类型: `default`, `namespace`, `named`。
+	Identifier   string `json:"identifier"`           // 在当前文件中使用的标识符。对于 `import { a as b }` 是 `b`；对于 `import a` 是 `a`。
+	IsTypeOnly   bool   `json:"isTypeOnly,omitempty"` // 是否仅导入类型。`import type { a }` 中的所有模块以及 `import { type a }` 中的 `a` 为 true。
 }
 
 // ImportDeclarationResult 存储一个完整的导入声明的解析结果。
@@ -21,6 +22,11 @@ type ImportDeclarationResult struct {
 	ImportModules  []ImportModule `json:"importModules"`            // 该导入声明中包含的所有导入模块的列表。
 	Raw            string         `json:"raw,omitempty"`            // 节点在源码中的原始文本。
 	Source         string         `json:"source"`                   // 导入来源的模块路径，例如 `'./school'`。
+	ModuleSystem   string         `json:"moduleSystem,omitempty"`   // 模块系统。ESM 语法为空，CommonJS 的 `require()` 为 "cjs"。
+	IsTypeOnly     bool           `json:"isTypeOnly,omitempty"`     // 是否为整体的类型导入 (`import type ... from` 或 `import type a = require()`)。
+	IsDynamic      bool           `json:"dynamic,omitempty"`        // 是否为动态导入：`import()`、`import.meta.glob()` 与 `require.context()`。
+	Pattern        string         `json:"pattern,omitempty"`        // 路径不确定的动态导入的 glob 模式 (e.g., "./pages/**/*.tsx")，此时 Source 与其相同。规则见 dynamicImport.go。
+	PatternFilter  string         `json:"patternFilter,omitempty"`  // require.context() 的正则过滤条件（JS 正则字面量，e.g., `/\.vue$/`），匹配相对于目录、以 "./" 开头的路径。
 	SourceLocation *SourceLocation `json:"sourceLocation,omitempty"` // 节点在源码中的位置信息。
 	Node           *ast.Node      `json:"-"`                     // 对应的 AST 节点，不在 JSON 中序列化。
 }
@@ -33,11 +39,18 @@ func NewImportDeclarationResult() *ImportDeclarationResult {
 }
 
 // addModule 是一个辅助函数，用于向 ImportDeclarationResult 添加一个新的导入模块。
+// 整体的类型导入中的模块同样被标记为仅导入类型。
 func (idr *ImportDeclarationResult) addModule(moduleType, importModule, identifier string) {
+	idr.addTypedModule(moduleType, importModule, identifier, false)
+}
+
+// addTypedModule 与 addModule 相同，但可以通过 isTypeOnly 标记 `import { type a }` 这类行内的类型导入。
+func (idr *ImportDeclarationResult) addTypedModule(moduleType, importModule, identifier string, isTypeOnly bool) {
 	idr.ImportModules = append(idr.ImportModules, ImportModule{
 		Type:         moduleType,
 		ImportModule: importModule,
 		Identifier:   identifier,
+		IsTypeOnly:   isTypeOnly || idr.IsTypeOnly,
 	})
 }
 
@@ -59,6 +72,7 @@ func AnalyzeImportDeclaration(node *ast.ImportDeclaration, sourceCode string) *I
 	}
 
 	importClause := node.ImportClause.AsImportClause()
+	idr.IsTypeOnly = node.ImportClause.IsTypeOnly()
 
 	// 处理默认导入: `import MyDefault from '...'`
 	if ast.IsDefaultImport(node.AsNode()) {
@@ -82,7 +96,7 @@ func AnalyzeImportDeclaration(node *ast.ImportDeclaration, sourceCode string) *I
 			if importSpecifier.PropertyName != nil {
 				importModule = importSpecifier.PropertyName.Text()
 			}
-			idr.addModule("named", importModule, identifier)
+			idr.addTypedModule("named", importModule, identifier, element.IsTypeOnly())
 		}
 	}
 
@@ -20,6 +20,7 @@ type TypeReference struct {
 type InterfaceDeclarationResult struct {
 	Identifier     string                   `json:"identifier"`               // 接口的名称。
 	Exported       bool                     `json:"exported"`                 // 新增：标记此接口是否被导出。
+	Namespace      string                   `json:"namespace,omitempty"`      // 所属的命名空间或环境模块，顶层声明为空。规则见 EnclosingNamespace。
 	Raw            string                   `json:"raw,omitempty"`            // 节点在源码中的原始文本。
 	Reference      map[string]TypeReference `json:"reference,omitempty"`      // 接口所依赖的其他类型的映射，以类型名作为 key。
 	SourceLocation *SourceLocation           `json:"sourceLocation,omitempty"` // 节点在源码中的位置信息。
@@ -127,5 +128,6 @@ func (p *Parser) VisitInterfaceDeclaration(node *ast.InterfaceDeclaration) {
 	}
 
 	result := AnalyzeInterfaceDeclaration(node, p.SourceCode)
-	p.Result.InterfaceDeclarations[result.Identifier] = *result
+	result.Namespace = EnclosingNamespace(node.AsNode())
+	p.Result.InterfaceDeclarations[QualifiedName(result.Namespace, result.Identifier)] = *result
 }
\ No newline at end of file
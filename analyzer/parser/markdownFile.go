@@ -0,0 +1,224 @@
+// package parser 提供了对单个 TypeScript/TSX 文件进行 AST（抽象语法树）解析的功能。
+// 本文件（markdownFile.go）专门负责处理 Markdown（.md）与 MDX（.mdx）文档：
+// 将 MDX 的 ESM `import`/`export` 语句交给 TS 解析器，并将 MDX 正文中的组件标签记录为类 JSX 的元素，
+// 使文档站点中的组件使用也能被依赖分析统计到。ts/tsx/js/jsx 围栏代码块只是文档示例，
+// 只提取其内容与位置，不作为真实的导入与使用参与解析。
+package parser
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/Flying-Bird1999/analyzer-ts/analyzer/utils"
+	"github.com/samber/lo"
+
+	"github.com/Zzzen/typescript-go/use-at-your-own-risk/ast"
+)
+
+// MarkdownFileExtensions 是 Markdown 文档的扩展名。
+var MarkdownFileExtensions = []string{".md", ".mdx"}
+
+// markdownScriptLangs 是会被当作脚本解析的代码块语言。
+var markdownScriptLangs = []string{"ts", "tsx", "typescript", "js", "jsx", "javascript"}
+
+var (
+	// esmLineRegex 匹配 MDX 中 ESM 块的第一行
+	esmLineRegex = regexp.MustCompile(`^(import|export)\b`)
+	// inlineCodeRegex 匹配行内代码
+	inlineCodeRegex = regexp.MustCompile("`[^`\n]+`")
+	// mdxCommentRegex 匹配 MDX 中的表达式注释 `{/* ... */}`
+	mdxCommentRegex = regexp.MustCompile(`(?s)\{\s*/\*.*?\*/\s*\}`)
+)
+
+// IsMarkdownFile 判断文件是否为 Markdown 或 MDX 文档。
+func IsMarkdownFile(filePath string) bool {
+	return lo.Contains(MarkdownFileExtensions, strings.ToLower(filepath.Ext(filePath)))
+}
+
+// IsMdxFile 判断文件是否为 MDX 文档。
+func IsMdxFile(filePath string) bool {
+	return strings.ToLower(filepath.Ext(filePath)) == ".mdx"
+}
+
+// MarkdownCodeBlock 是文档中的一个 ts/tsx/js/jsx 围栏代码块。
+type MarkdownCodeBlock struct {
+	Lang           string          `json:"lang"`           // 代码块声明的语言，例如 "tsx"。
+	Code           string          `json:"code"`           // 代码块的内容，不含围栏行与末尾的换行。
+	SourceLocation *SourceLocation `json:"sourceLocation"` // 代码块内容在文档中的位置。
+}
+
+// markdownFence 是一个围栏代码块在源码中的范围。
+type markdownFence struct {
+	start, end         int // 整个代码块（含围栏行）的范围
+	codeStart, codeEnd int // 代码内容的范围
+	lang               string
+}
+
+// isScript 判断代码块是否需要作为脚本解析。
+func (f markdownFence) isScript() bool {
+	return lo.Contains(markdownScriptLangs, f.lang)
+}
+
+// markdownLayout 是按行扫描文档得到的结构：frontmatter、围栏代码块与 MDX 的 ESM 块。
+type markdownLayout struct {
+	frontmatterEnd int
+	fences         []markdownFence
+	esm            [][2]int
+}
+
+// scanMarkdown 按行扫描文档。ESM 块从行首的 `import`/`export` 开始，到下一个空行结束；
+// 围栏代码块以三个及以上的 ``` 或 ~~~ 开始，到同一字符、不短于开始围栏的行结束，未闭合时持续到文件末尾。
+func scanMarkdown(sourceCode string) markdownLayout {
+	layout := markdownLayout{}
+	pos := 0
+	if strings.HasPrefix(sourceCode, "---\n") || strings.HasPrefix(sourceCode, "---\r\n") {
+		if end := strings.Index(sourceCode[3:], "\n---"); end >= 0 {
+			pos = min(lineEnd(sourceCode, 3+end+1)+1, len(sourceCode))
+			layout.frontmatterEnd = pos
+		}
+	}
+
+	var fence *markdownFence
+	var fenceChar byte
+	fenceLen := 0
+	esmStart := -1
+	for pos < len(sourceCode) {
+		end := lineEnd(sourceCode, pos)
+		next := min(end+1, len(sourceCode))
+		line := strings.TrimRight(sourceCode[pos:end], "\r")
+		trimmed := strings.TrimLeft(line, " ")
+		indent := len(line) - len(trimmed)
+
+		switch {
+		case fence != nil:
+			if indent <= 3 && isClosingFence(trimmed, fenceChar, fenceLen) {
+				fence.codeEnd, fence.end = pos, end
+				layout.fences = append(layout.fences, *fence)
+				fence = nil
+			}
+		case esmStart >= 0:
+			if strings.TrimSpace(line) == "" {
+				layout.esm = append(layout.esm, [2]int{esmStart, pos})
+				esmStart = -1
+			}
+		case indent <= 3 && openingFenceLen(trimmed) > 0:
+			fenceChar, fenceLen = trimmed[0], openingFenceLen(trimmed)
+			lang := ""
+			if fields := strings.Fields(trimmed[fenceLen:]); len(fields) > 0 {
+				lang = strings.ToLower(strings.SplitN(fields[0], "{", 2)[0])
+			}
+			fence = &markdownFence{start: pos, codeStart: next, lang: lang}
+		case indent == 0 && esmLineRegex.MatchString(line):
+			esmStart = pos
+		}
+		pos = next
+	}
+	if fence != nil {
+		fence.codeEnd, fence.end = len(sourceCode), len(sourceCode)
+		layout.fences = append(layout.fences, *fence)
+	}
+	if esmStart >= 0 {
+		layout.esm = append(layout.esm, [2]int{esmStart, len(sourceCode)})
+	}
+	return layout
+}
+
+// lineEnd 返回从 pos 开始的行的结束位置（"\n" 的下标，最后一行为 len(sourceCode)）。
+func lineEnd(sourceCode string, pos int) int {
+	if idx := strings.IndexByte(sourceCode[pos:], '\n'); idx >= 0 {
+		return pos + idx
+	}
+	return len(sourceCode)
+}
+
+// openingFenceLen 返回开始围栏的长度，不是开始围栏时返回 0。反引号围栏的信息字符串中不能再出现反引号。
+func openingFenceLen(line string) int {
+	if line == "" || (line[0] != '`' && line[0] != '~') {
+		return 0
+	}
+	n := len(line) - len(strings.TrimLeft(line, line[:1]))
+	if n < 3 || (line[0] == '`' && strings.Contains(line[n:], "`")) {
+		return 0
+	}
+	return n
+}
+
+// isClosingFence 判断一行是否为结束围栏：只由不少于 n 个 c 组成（允许尾随空白）。
+func isClosingFence(line string, c byte, n int) bool {
+	line = strings.TrimRight(line, " \t")
+	return len(line) >= n && strings.Trim(line, string(c)) == ""
+}
+
+// ExtractMarkdownCodeBlocks 提取文档中所有 ts/tsx/js/jsx 围栏代码块。
+func ExtractMarkdownCodeBlocks(sourceCode string) []MarkdownCodeBlock {
+	blocks := []MarkdownCodeBlock{}
+	for _, fence := range scanMarkdown(sourceCode).fences {
+		if !fence.isScript() {
+			continue
+		}
+		// 结束位置不包含最后一行的换行符，使其落在代码的最后一行而不是结束围栏所在行
+		code := strings.TrimRight(sourceCode[fence.codeStart:fence.codeEnd], "\r\n")
+		blocks = append(blocks, MarkdownCodeBlock{
+			Lang:           fence.lang,
+			Code:           code,
+			SourceLocation: offsetSourceLocation(sourceCode, fence.codeStart, fence.codeStart+len(code)),
+		})
+	}
+	return blocks
+}
+
+// ExtractMarkdownScript 返回与原文档等长的脚本源码：只保留 MDX 文档的 ESM 块，
+// 其余内容（包括围栏代码块）都被替换为空格（换行保留），因此解析出的节点行号可以直接对应回原文档。
+// 普通的 Markdown 文档没有 ESM 语句，返回的源码只包含空白。
+func ExtractMarkdownScript(filePath string, sourceCode string) string {
+	layout := scanMarkdown(sourceCode)
+	masked := blankOut(sourceCode, 0, len(sourceCode))
+	if IsMdxFile(filePath) {
+		for _, esm := range layout.esm {
+			copy(masked[esm[0]:esm[1]], sourceCode[esm[0]:esm[1]])
+		}
+	}
+	return string(masked)
+}
+
+// ExtractMdxElements 提取 MDX 正文中使用的组件标签（PascalCase 或 `Foo.Bar`），以 JSXElement 的形式返回。
+// frontmatter、ESM 块、代码块、行内代码与注释中的标签会被忽略。返回的元素没有对应的 AST 节点，Node 为 nil。
+func ExtractMdxElements(sourceCode string) []JSXElement {
+	layout := scanMarkdown(sourceCode)
+	region := []byte(sourceCode)
+	mask := func(start int, end int) {
+		copy(region[start:end], blankOut(sourceCode, start, end))
+	}
+	mask(0, layout.frontmatterEnd)
+	for _, esm := range layout.esm {
+		mask(esm[0], esm[1])
+	}
+	for _, fence := range layout.fences {
+		mask(fence.start, fence.end)
+	}
+	for _, re := range []*regexp.Regexp{inlineCodeRegex, mdxCommentRegex, htmlCommentRegex} {
+		for _, loc := range re.FindAllIndex(region, -1) {
+			mask(loc[0], loc[1])
+		}
+	}
+	return templateElements(sourceCode, region, false)
+}
+
+// newMarkdownParser 为 Markdown/MDX 文档创建解析器：脚本部分按 ExtractMarkdownScript 的结果解析，
+// MDX 正文中的组件标签预先写入 JsxElements。
+func newMarkdownParser(filePath string, sourceCode string) *Parser {
+	script := ExtractMarkdownScript(filePath, sourceCode)
+	sourceFile := utils.ParseTypeScriptFileAs(filePath, script, ".tsx")
+	p := &Parser{
+		SourceCode:              script,
+		Ast:                     sourceFile.AsNode(),
+		SourceFile:              sourceFile,
+		Result:                  NewParserResult(filePath),
+		ProcessedDynamicImports: make(map[*ast.Node]bool),
+	}
+	if IsMdxFile(filePath) {
+		p.Result.JsxElements = append(p.Result.JsxElements, ExtractMdxElements(sourceCode)...)
+	}
+	return p
+}
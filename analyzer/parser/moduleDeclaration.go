@@ -0,0 +1,176 @@
+// package parser 提供了对单个 TypeScript/TSX 文件进行 AST（抽象语法树）解析的功能。
+// 本文件（moduleDeclaration.go）专门负责处理命名空间（namespace）、环境模块声明（declare module 'x'）与全局扩展（declare global）。
+package parser
+
+import (
+	"strings"
+
+	"github.com/Flying-Bird1999/analyzer-ts/analyzer/utils"
+
+	"github.com/Zzzen/typescript-go/use-at-your-own-risk/ast"
+)
+
+// 模块声明的种类
+const (
+	ModuleKindNamespace = "namespace" // namespace Foo {} 或 module Foo {}
+	ModuleKindModule    = "module"    // declare module 'x' {}，名称为字符串字面量
+	ModuleKindGlobal    = "global"    // declare global {}
+)
+
+// ModuleMemberResult 是模块声明体中直接包含的一个声明。
+type ModuleMemberResult struct {
+	Identifier string `json:"identifier"` // 声明的名称
+	Kind       string `json:"kind"`       // 声明的种类：interface / type / enum / function / class / variable / namespace / module
+	Exported   bool   `json:"exported"`   // 是否带有 export 关键字
+}
+
+// ModuleDeclarationResult 存储一个命名空间、环境模块声明或全局扩展的解析结果。
+// 嵌套的命名空间（包括 `namespace A.B {}`）各自产生一条结果，并通过 Namespace 字段记录其外层路径。
+type ModuleDeclarationResult struct {
+	Name           string               `json:"name"`                     // 名称。namespace 为标识符，declare module 为模块路径（不含引号），declare global 为 "global"。
+	Kind           string               `json:"kind"`                     // 种类，取值见 ModuleKind* 常量。
+	Namespace      string               `json:"namespace,omitempty"`      // 外层命名空间路径，规则与其他声明的 Namespace 字段一致。
+	Exported       bool                 `json:"exported"`                 // 是否带有 export 关键字 (export namespace Foo {})。
+	IsDeclare      bool                 `json:"isDeclare"`                // 是否带有 declare 关键字。
+	IsAugmentation bool                 `json:"isAugmentation"`           // 是否为模块扩展：模块文件中的 declare module 'x' 与 declare global。
+	Declarations   []ModuleMemberResult `json:"declarations"`             // 声明体中直接包含的声明，详细信息仍记录在 InterfaceDeclarations 等集合中。
+	Raw            string               `json:"raw,omitempty"`            // 节点在源码中的原始文本。
+	SourceLocation *SourceLocation      `json:"sourceLocation,omitempty"` // 节点在源码中的位置信息。
+	Node           *ast.Node            `json:"-"`                        // 对应的 AST 节点，不在 JSON 中序列化。
+}
+
+// QualifiedName 返回声明在文件内的限定名称，用作 InterfaceDeclarations 等集合的 key。
+// 顶层声明直接返回名称，命名空间内的声明返回 "Namespace.Name" (e.g., "API.User")。
+func QualifiedName(namespace string, identifier string) string {
+	if namespace == "" {
+		return identifier
+	}
+	return namespace + "." + identifier
+}
+
+// EnclosingNamespace 返回包含该节点的命名空间路径，不在任何模块声明中时返回空字符串。
+// 路径由外到内以 "." 连接：namespace 使用其名称，declare module 使用带双引号的模块路径 (e.g., `"antd"`)，
+// declare global 使用 "global"。例如 `declare module 'vue' { namespace JSX {} }` 中的声明返回 `"vue".JSX`。
+func EnclosingNamespace(node *ast.Node) string {
+	var segments []string
+	for parent := node.Parent; parent != nil; parent = parent.Parent {
+		if parent.Kind == ast.KindModuleDeclaration {
+			segments = append(segments, moduleSegment(parent.AsModuleDeclaration()))
+		}
+	}
+	// 由内到外收集，需要反转
+	for i, j := 0, len(segments)-1; i < j; i, j = i+1, j-1 {
+		segments[i], segments[j] = segments[j], segments[i]
+	}
+	return strings.Join(segments, ".")
+}
+
+// moduleSegment 返回模块声明在命名空间路径中的片段。
+func moduleSegment(node *ast.ModuleDeclaration) string {
+	switch moduleKind(node) {
+	case ModuleKindGlobal:
+		return "global"
+	case ModuleKindModule:
+		return `"` + node.Name().Text() + `"`
+	}
+	return node.Name().Text()
+}
+
+// moduleKind 判断模块声明的种类。
+func moduleKind(node *ast.ModuleDeclaration) string {
+	if node.Keyword == ast.KindGlobalKeyword {
+		return ModuleKindGlobal
+	}
+	if node.Name() != nil && ast.IsStringLiteral(node.Name()) {
+		return ModuleKindModule
+	}
+	return ModuleKindNamespace
+}
+
+// AnalyzeModuleDeclaration 是一个公共的、可复用的函数，用于从 AST 节点中解析模块声明。
+// isExternalModule 表示当前文件是否为模块文件（包含顶层的 import/export），用于判断 declare module 是否为模块扩展。
+func AnalyzeModuleDeclaration(node *ast.ModuleDeclaration, sourceCode string, isExternalModule bool) *ModuleDeclarationResult {
+	moduleNode := node.AsNode()
+	result := &ModuleDeclarationResult{
+		Kind:           moduleKind(node),
+		Namespace:      EnclosingNamespace(moduleNode),
+		Declarations:   []ModuleMemberResult{},
+		Raw:            utils.GetNodeText(moduleNode, sourceCode),
+		SourceLocation: NewSourceLocation(moduleNode, sourceCode),
+		Node:           moduleNode,
+	}
+	result.Name = node.Name().Text()
+
+	flags := moduleNode.ModifierFlags()
+	result.Exported = flags&ast.ModifierFlagsExport != 0
+	result.IsDeclare = flags&ast.ModifierFlagsAmbient != 0
+	switch result.Kind {
+	case ModuleKindGlobal:
+		result.IsAugmentation = true
+	case ModuleKindModule:
+		result.IsAugmentation = isExternalModule && result.Namespace == ""
+	}
+
+	// 解析声明体中直接包含的声明。`namespace A.B {}` 中 A 的声明体就是 B 本身。
+	body := node.Body
+	if body == nil {
+		return result
+	}
+	if body.Kind == ast.KindModuleDeclaration {
+		inner := body.AsModuleDeclaration()
+		result.Declarations = append(result.Declarations, ModuleMemberResult{
+			Identifier: inner.Name().Text(),
+			Kind:       moduleKind(inner),
+			Exported:   true,
+		})
+		return result
+	}
+	if body.Kind != ast.KindModuleBlock {
+		return result
+	}
+	for _, statement := range body.AsModuleBlock().Statements.Nodes {
+		exported := statement.ModifierFlags()&ast.ModifierFlagsExport != 0
+		switch statement.Kind {
+		case ast.KindInterfaceDeclaration:
+			result.addDeclaration(statement.Name(), "interface", exported)
+		case ast.KindTypeAliasDeclaration:
+			result.addDeclaration(statement.Name(), "type", exported)
+		case ast.KindEnumDeclaration:
+			result.addDeclaration(statement.Name(), "enum", exported)
+		case ast.KindFunctionDeclaration:
+			result.addDeclaration(statement.Name(), "function", exported)
+		case ast.KindClassDeclaration:
+			result.addDeclaration(statement.Name(), "class", exported)
+		case ast.KindModuleDeclaration:
+			result.addDeclaration(statement.Name(), moduleKind(statement.AsModuleDeclaration()), exported)
+		case ast.KindVariableStatement:
+			declarationList := statement.AsVariableStatement().DeclarationList.AsVariableDeclarationList()
+			for _, decl := range declarationList.Declarations.Nodes {
+				if ast.IsIdentifier(decl.Name()) {
+					result.addDeclaration(decl.Name(), "variable", exported)
+				}
+			}
+		}
+	}
+
+	return result
+}
+
+// addDeclaration 向模块声明中追加一个直接包含的声明，忽略没有名称的声明。
+func (m *ModuleDeclarationResult) addDeclaration(name *ast.Node, kind string, exported bool) {
+	if name == nil {
+		return
+	}
+	m.Declarations = append(m.Declarations, ModuleMemberResult{
+		Identifier: name.Text(),
+		Kind:       kind,
+		Exported:   exported,
+	})
+}
+
+// VisitModuleDeclaration 解析 namespace、declare module 与 declare global 声明。
+// 声明体中的接口、类型、函数等仍由各自的 Visit 方法处理，并通过 EnclosingNamespace 记录所属的命名空间。
+func (p *Parser) VisitModuleDeclaration(node *ast.ModuleDeclaration) {
+	result := AnalyzeModuleDeclaration(node, p.SourceCode, ast.IsExternalModule(p.SourceFile))
+	p.Result.ModuleDeclarations = append(p.Result.ModuleDeclarations, *result)
+}
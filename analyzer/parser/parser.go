@@ -9,6 +9,7 @@ import (
 	"github.com/Flying-Bird1999/analyzer-ts/analyzer/utils"
 
 	"github.com/Zzzen/typescript-go/use-at-your-own-risk/ast"
+	"github.com/Zzzen/typescript-go/use-at-your-own-risk/scanner"
 )
 
 // Parser 定义了解析器的主要结构，包含了源码、AST 和最终的解析结果。
@@ -21,8 +22,8 @@ type Parser struct {
 	SourceFile *ast.SourceFile
 	// Result 用于存储和累积解析过程中提取出的所有信息。
 	Result *ParserResult
-	// processedDynamicImports 用于标记在变量声明中找到的动态导入节点。
-	// 这样做是为了防止在后续的 `analyzeCallExpression` 中对同一个 `import()` 调用进行重复处理。
+	// processedDynamicImports 用于标记在变量声明中找到的动态导入节点，以及已经转换为导入/导出记录的 `require()` 调用。
+	// 这样做是为了防止在后续的 `analyzeCallExpression` 中对同一个 `import()` / `require()` 调用进行重复处理。
 	ProcessedDynamicImports map[*ast.Node]bool
 }
 
@@ -38,7 +39,15 @@ func NewParser(filePath string) (*Parser, error) {
 
 // NewParserFromSource 使用源码字符串创建并返回一个新的 Parser 实例。
 // 这个构造函数对于测试非常有用，可以避免文件系统的 I/O 操作。
+// .vue 与 .svelte 文件只解析其中的 <script> 块，详见 componentFile.go；
+// .mdx 文档只解析其中的 ESM 语句与组件标签，.md 文档中没有需要解析的内容，详见 markdownFile.go。
 func NewParserFromSource(filePath string, sourceCode string) (*Parser, error) {
+	if IsComponentFile(filePath) {
+		return newComponentParser(filePath, sourceCode), nil
+	}
+	if IsMarkdownFile(filePath) {
+		return newMarkdownParser(filePath, sourceCode), nil
+	}
 	sourceFile := utils.ParseTypeScriptFile(filePath, sourceCode)
 	return &Parser{
 		SourceCode:              sourceCode,
@@ -64,9 +73,13 @@ type Visitor interface {
 	VisitCallExpression(*ast.CallExpression)
 	VisitJsxElement(*ast.Node) // JsxElement 和 JsxSelfClosingElement 没有独立的类型，使用 Node
 	VisitFunctionDeclaration(*ast.FunctionDeclaration)
+	VisitClassDeclaration(*ast.ClassDeclaration)
+	VisitModuleDeclaration(*ast.ModuleDeclaration) // namespace、declare module 与 declare global
 	VisitAnyKeyword(*ast.Node)
 	VisitAsExpression(*ast.AsExpression)
 	VisitReturnStatement(*ast.ReturnStatement)
+	VisitImportEqualsDeclaration(*ast.ImportEqualsDeclaration)
+	VisitBinaryExpression(*ast.BinaryExpression) // 用于识别 CommonJS 的 `module.exports` / `exports.xxx` 赋值
 }
 
 // Traverse 是解析器的核心驱动函数。
@@ -102,6 +115,9 @@ func (p *Parser) Traverse() {
 
 	// 从 AST 的根节点开始遍历。
 	walk(p.Ast)
+
+	// CSS Modules 的类名引用依赖完整的导入列表，因此在遍历结束后收集。
+	p.collectCssModuleReferences()
 }
 
 // dispatch 是节点分发器，它取代了旧的 switch 语句。
@@ -135,12 +151,21 @@ func (p *Parser) dispatch(node *ast.Node) (continueWalk bool) {
 		p.VisitJsxElement(node)
 	case ast.KindFunctionDeclaration:
 		p.VisitFunctionDeclaration(node.AsFunctionDeclaration())
+	case ast.KindClassDeclaration:
+		p.VisitClassDeclaration(node.AsClassDeclaration())
+	case ast.KindModuleDeclaration:
+		p.VisitModuleDeclaration(node.AsModuleDeclaration())
 	case ast.KindAnyKeyword:
 		p.VisitAnyKeyword(node)
 	case ast.KindAsExpression:
 		p.VisitAsExpression(node.AsAsExpression())
 	case ast.KindReturnStatement:
 		p.VisitReturnStatement(node.AsReturnStatement())
+	case ast.KindImportEqualsDeclaration:
+		p.VisitImportEqualsDeclaration(node.AsImportEqualsDeclaration())
+		continueWalk = false // `import a = require('./a')` 不需深入遍历。
+	case ast.KindBinaryExpression:
+		p.VisitBinaryExpression(node.AsBinaryExpression())
 	}
 
 	return continueWalk
@@ -163,9 +188,13 @@ func (p *Parser) addError(node *ast.Node, format string, args ...interface{}) {
 }
 
 // NewSourceLocation 是一个辅助函数，用于从 AST 节点中创建并返回一个准确的 SourceLocation。
-// 它将节点的字符偏移位置转换为行列号。
+// 它将节点的字符偏移位置转换为行列号。起始位置包含节点前导的空白与注释，
+// 只有 HasMaskedRegions 的文件会跳过这些前导内容，见该函数的说明。
 func NewSourceLocation(node *ast.Node, sourceCode string) *SourceLocation {
 	startPos, endPos := node.Pos(), node.End()
+	if sourceFile := ast.GetSourceFileOfNode(node); sourceFile != nil && HasMaskedRegions(sourceFile.FileName()) {
+		startPos = scanner.SkipTrivia(sourceCode, startPos)
+	}
 	startLine, startChar := utils.GetLineAndCharacterOfPosition(sourceCode, startPos)
 	endLine, endChar := utils.GetLineAndCharacterOfPosition(sourceCode, endPos)
 
@@ -175,6 +204,13 @@ func NewSourceLocation(node *ast.Node, sourceCode string) *SourceLocation {
 	}
 }
 
+// HasMaskedRegions 判断文件在解析前是否有区域被替换为空白：组件文件的模板与样式、Markdown/MDX 文档的正文。
+// 这些空白区域会成为其后第一条语句的前导空白，使起始位置落在模板或正文中，
+// 因此这类文件中节点的起始位置跳过前导的空白与注释，指向节点的第一个 token。
+func HasMaskedRegions(filePath string) bool {
+	return IsComponentFile(filePath) || IsMarkdownFile(filePath)
+}
+
 // ParserResult 是单文件解析的最终结果容器。
 // 它存储了从文件中提取出的所有顶层声明和表达式。
 type ParserResult struct {
@@ -189,7 +225,10 @@ type ParserResult struct {
 	CallExpressions       []CallExpression
 	JsxElements           []JSXElement
 	FunctionDeclarations  []FunctionDeclarationResult
+	ClassDeclarations     []ClassDeclarationResult
+	ModuleDeclarations    []ModuleDeclarationResult
 	ReturnStatements      []ReturnStatementResult // 新增：用于存储 return 语句
+	CssModuleReferences   []CssModuleReference    // CSS Modules 的类名引用，例如 `styles.foo`
 	ExtractedNodes        ExtractedNodes
 	Errors                []error
 }
@@ -220,7 +259,10 @@ func NewParserResult(filePath string) *ParserResult {
 		CallExpressions:       []CallExpression{},
 		JsxElements:           []JSXElement{},
 		FunctionDeclarations:  []FunctionDeclarationResult{},
+		ClassDeclarations:     []ClassDeclarationResult{},
+		ModuleDeclarations:    []ModuleDeclarationResult{},
 		ReturnStatements:      []ReturnStatementResult{},
+		CssModuleReferences:   []CssModuleReference{},
 		ExtractedNodes: ExtractedNodes{
 			AnyDeclarations: []AnyInfo{},
 			AsExpressions:   []AsExpression{},
@@ -242,7 +284,10 @@ func (pr *ParserResult) GetResult() ParserResult {
 		CallExpressions:       pr.CallExpressions,
 		JsxElements:           pr.JsxElements,
 		FunctionDeclarations:  pr.FunctionDeclarations,
+		ClassDeclarations:     pr.ClassDeclarations,
+		ModuleDeclarations:    pr.ModuleDeclarations,
 		ReturnStatements:      pr.ReturnStatements,
+		CssModuleReferences:   pr.CssModuleReferences,
 		ExtractedNodes: ExtractedNodes{
 			AnyDeclarations: pr.ExtractedNodes.AnyDeclarations,
 			AsExpressions:   pr.ExtractedNodes.AsExpressions,
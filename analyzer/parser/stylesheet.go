@@ -0,0 +1,308 @@
+// package parser 提供了对单个 TypeScript/TSX 文件进行 AST（抽象语法树）解析的功能。
+// 本文件（stylesheet.go）专门负责解析样式文件（.css/.less/.scss/.sass）：
+// 提取 `@import`/`@use`/`@forward` 依赖、声明的类名以及 CSS 自定义属性（`--name: value`）。
+// 样式文件没有 AST，解析基于一个识别注释、字符串、插值与花括号嵌套的轻量扫描器。
+package parser
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// StyleClassName 是样式文件中声明的一个类名。
+type StyleClassName struct {
+	Name           string          `json:"name"`                     // 类名，不含前导的 "."。SCSS/LESS 中的 `&-suffix` 会与父选择器拼接。
+	SourceLocation *SourceLocation `json:"sourceLocation,omitempty"` // 类名第一次出现的位置。
+}
+
+// CustomProperty 是样式文件中声明的一个 CSS 自定义属性。
+type CustomProperty struct {
+	Name           string          `json:"name"`                     // 属性名，包含前导的 "--" (e.g., "--primary-color")。
+	Value          string          `json:"value"`                    // 属性值的原始文本。
+	SourceLocation *SourceLocation `json:"sourceLocation,omitempty"` // 声明的位置。
+}
+
+// StylesheetResult 是单个样式文件的解析结果。
+type StylesheetResult struct {
+	ImportDeclarations []ImportDeclarationResult `json:"importDeclarations"` // `@import`/`@use`/`@forward` 依赖，记为没有导入模块的副作用导入。
+	ClassNames         []StyleClassName          `json:"classNames"`         // 声明的类名，按第一次出现的顺序去重。
+	CustomProperties   []CustomProperty          `json:"customProperties"`   // 声明的 CSS 自定义属性，按源码顺序排列。
+}
+
+var (
+	// styleImportRegex 匹配导入类的 at-rule，第 1 组为规则名
+	styleImportRegex = regexp.MustCompile(`^@(import|use|forward|require)\b`)
+	// styleImportPathRegex 匹配 at-rule 参数中的 url(...) 或字符串路径
+	styleImportPathRegex = regexp.MustCompile(`url\(\s*['"]?([^'")\s]+)['"]?\s*\)|'([^']+)'|"([^"]+)"`)
+	// customPropertyRegex 匹配自定义属性声明，第 1 组为属性名，第 2 组为属性值
+	customPropertyRegex = regexp.MustCompile(`^(--[\w-]+)\s*:\s*([\s\S]*?)\s*$`)
+	// classSelectorRegex 匹配选择器中的类名，第 1 组为类名
+	classSelectorRegex = regexp.MustCompile(`\.(-?[_a-zA-Z][\w-]*)`)
+	// parentSuffixRegex 匹配 `&-primary`、`&__item` 这类父选择器后缀，第 1 组为后缀
+	parentSuffixRegex = regexp.MustCompile(`&([\w-]+)`)
+	// globalSelectorRegex 匹配 CSS Modules 的 :global(...)，其中的类名不属于当前模块
+	globalSelectorRegex = regexp.MustCompile(`:global\([^)]*\)`)
+)
+
+// ParseStylesheet 解析样式文件。LESS/SCSS/SASS 额外识别 `//` 行注释；
+// SASS 的缩进语法没有花括号，只能提取其中的导入与自定义属性。
+func ParseStylesheet(filePath string, sourceCode string) *StylesheetResult {
+	ext := strings.ToLower(filepath.Ext(filePath))
+	s := &stylesheetScanner{
+		source:   sourceCode,
+		code:     maskStyleComments(sourceCode, ext != ".css"),
+		isSass:   ext == ".sass",
+		seen:     map[string]bool{},
+		result:   &StylesheetResult{ImportDeclarations: []ImportDeclarationResult{}, ClassNames: []StyleClassName{}, CustomProperties: []CustomProperty{}},
+		selector: [][]string{nil},
+	}
+	s.scan()
+	return s.result
+}
+
+// stylesheetScanner 按花括号把样式文件切分为选择器（`{` 之前的部分）与语句（`;`、`}` 之前的部分）。
+type stylesheetScanner struct {
+	source string // 原始源码
+	code   string // 注释被替换为空白后的源码，与 source 等长
+	isSass bool   // 是否为 SASS 缩进语法，换行同样结束一条语句
+	seen   map[string]bool
+	result *StylesheetResult
+	// selector 是当前嵌套层级的选择器栈，每一层记录该层每个选择器的最后一个类名，用于拼接 `&-suffix`
+	selector [][]string
+}
+
+// scan 扫描整个文件。
+func (s *stylesheetScanner) scan() {
+	code := s.code
+	start := 0
+	for i := 0; i < len(code); i++ {
+		switch c := code[i]; {
+		case c == '"' || c == '\'':
+			i = skipStyleString(code, i)
+		case c == 'u' && strings.HasPrefix(code[i:], "url("):
+			// url(data:image/png;base64,...) 中的 ";" 不结束语句
+			i = skipStyleURL(code, i)
+		case (c == '#' || c == '@') && i+1 < len(code) && code[i+1] == '{':
+			// SCSS 的 #{...} 与 LESS 的 @{...} 插值不是代码块
+			i = matchingBrace(code, i+1)
+		case c == '{':
+			s.block(start, i)
+			start = i + 1
+		case c == ';' || (c == '\n' && s.isSass):
+			s.statement(start, i)
+			start = i + 1
+		case c == '}':
+			s.statement(start, i)
+			if len(s.selector) > 1 {
+				s.selector = s.selector[:len(s.selector)-1]
+			}
+			start = i + 1
+		}
+	}
+	s.statement(start, len(code))
+}
+
+// block 处理 code[start:end] 处的代码块头部：at-rule 沿用父级选择器，普通选择器提取其中的类名。
+func (s *stylesheetScanner) block(start int, end int) {
+	start, end = trimStyleRange(s.code, start, end)
+	parents := s.selector[len(s.selector)-1]
+	prelude := s.code[start:end]
+	if strings.HasPrefix(prelude, "@") {
+		s.selector = append(s.selector, parents)
+		return
+	}
+
+	var lastClasses []string
+	offset := start
+	for _, part := range splitStyleSelectors(prelude) {
+		last := ""
+		masked := globalSelectorRegex.ReplaceAllStringFunc(part, func(m string) string { return strings.Repeat(" ", len(m)) })
+		masked = maskStyleBrackets(masked)
+		for _, match := range classSelectorRegex.FindAllStringSubmatchIndex(masked, -1) {
+			next := ""
+			if match[1] < len(masked) {
+				next = masked[match[1]:]
+			}
+			// LESS 的 mixin 定义 `.mixin() {` 与带插值的类名不是普通类名
+			if strings.HasPrefix(next, "(") || strings.HasPrefix(next, "#{") || strings.HasPrefix(next, "@{") {
+				continue
+			}
+			last = masked[match[2]:match[3]]
+			s.addClass(last, offset+match[0], offset+match[1])
+		}
+		for _, match := range parentSuffixRegex.FindAllStringSubmatchIndex(masked, -1) {
+			for _, parent := range parents {
+				if parent != "" {
+					last = parent + masked[match[2]:match[3]]
+					s.addClass(last, offset+match[0], offset+match[1])
+				}
+			}
+		}
+		// `&:hover` 这类没有新类名的选择器沿用父选择器的类名，使其子规则中的 `&-suffix` 仍能拼接
+		if last == "" && strings.Contains(masked, "&") && len(parents) > 0 {
+			last = parents[0]
+		}
+		lastClasses = append(lastClasses, last)
+		offset += len(part) + 1
+	}
+	s.selector = append(s.selector, lastClasses)
+}
+
+// statement 处理 code[start:end] 处的一条语句：导入类的 at-rule 或自定义属性声明。
+func (s *stylesheetScanner) statement(start int, end int) {
+	start, end = trimStyleRange(s.code, start, end)
+	if start >= end {
+		return
+	}
+	text := s.code[start:end]
+
+	if match := styleImportRegex.FindStringSubmatch(text); match != nil {
+		paths := styleImportPathRegex.FindAllStringSubmatch(text, -1)
+		// @use 与 @forward 只有一个路径，其后的 `with (...)` 中可能出现其他字符串
+		if match[1] != "import" && len(paths) > 1 {
+			paths = paths[:1]
+		}
+		for _, path := range paths {
+			source := path[1] + path[2] + path[3]
+			if isExternalStylePath(source) {
+				continue
+			}
+			s.result.ImportDeclarations = append(s.result.ImportDeclarations, ImportDeclarationResult{
+				ImportModules:  []ImportModule{},
+				Source:         source,
+				Raw:            s.source[start:end],
+				SourceLocation: offsetSourceLocation(s.source, start, end),
+			})
+		}
+		return
+	}
+
+	if match := customPropertyRegex.FindStringSubmatch(text); match != nil {
+		s.result.CustomProperties = append(s.result.CustomProperties, CustomProperty{
+			Name:           match[1],
+			Value:          match[2],
+			SourceLocation: offsetSourceLocation(s.source, start, end),
+		})
+	}
+}
+
+// addClass 记录一个类名，同名类名只记录第一次出现的位置。
+func (s *stylesheetScanner) addClass(name string, start int, end int) {
+	if s.seen[name] {
+		return
+	}
+	s.seen[name] = true
+	s.result.ClassNames = append(s.result.ClassNames, StyleClassName{Name: name, SourceLocation: offsetSourceLocation(s.source, start, end)})
+}
+
+// isExternalStylePath 判断导入路径是否为远程地址或 Sass 内置模块 (e.g., "sass:math")，这类导入不构成项目内的依赖。
+func isExternalStylePath(path string) bool {
+	return strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") ||
+		strings.HasPrefix(path, "//") || strings.HasPrefix(path, "sass:")
+}
+
+// maskStyleComments 将源码中的 `/* */` 注释（以及 lineComments 为 true 时的 `//` 行注释）替换为等长的空白。
+// 字符串中的内容保持不变；紧跟在 ":" 之后的 `//`（例如 url(http://...)）不视为注释。
+func maskStyleComments(sourceCode string, lineComments bool) string {
+	code := []byte(sourceCode)
+	for i := 0; i < len(code); i++ {
+		switch {
+		case code[i] == '"' || code[i] == '\'':
+			i = skipStyleString(sourceCode, i)
+		case code[i] == 'u' && strings.HasPrefix(sourceCode[i:], "url("):
+			i = skipStyleURL(sourceCode, i)
+		case code[i] == '/' && i+1 < len(code) && code[i+1] == '*':
+			end := strings.Index(sourceCode[i+2:], "*/")
+			if end < 0 {
+				end = len(code)
+			} else {
+				end += i + 4
+			}
+			copy(code[i:end], blankOut(sourceCode, i, end))
+			i = end - 1
+		case lineComments && code[i] == '/' && i+1 < len(code) && code[i+1] == '/' && (i == 0 || code[i-1] != ':'):
+			end := strings.IndexByte(sourceCode[i:], '\n')
+			if end < 0 {
+				end = len(code)
+			} else {
+				end += i
+			}
+			copy(code[i:end], blankOut(sourceCode, i, end))
+			i = end - 1
+		}
+	}
+	return string(code)
+}
+
+// skipStyleString 返回从 code[start] 处开始的字符串的结束引号下标，未闭合时返回 len(code)-1。
+func skipStyleString(code string, start int) int {
+	quote := code[start]
+	for i := start + 1; i < len(code); i++ {
+		switch code[i] {
+		case '\\':
+			i++
+		case quote, '\n':
+			return i
+		}
+	}
+	return len(code) - 1
+}
+
+// skipStyleURL 返回从 code[start] 处开始的 `url(...)` 的右括号下标，未闭合时返回 len(code)-1。
+// 无引号的 url 中可能出现 "//"、";" 等字符 (e.g., `url(//cdn.com/a.png)`)，需要整体跳过。
+func skipStyleURL(code string, start int) int {
+	end := strings.IndexByte(code[start:], ')')
+	if end < 0 {
+		return len(code) - 1
+	}
+	return start + end
+}
+
+// trimStyleRange 去掉 code[start:end] 两端的空白，返回新的范围。
+func trimStyleRange(code string, start int, end int) (int, int) {
+	for start < end && strings.ContainsRune(" \t\r\n", rune(code[start])) {
+		start++
+	}
+	for end > start && strings.ContainsRune(" \t\r\n", rune(code[end-1])) {
+		end--
+	}
+	return start, end
+}
+
+// splitStyleSelectors 按顶层的逗号拆分选择器列表，括号中的逗号 (e.g., `:is(.a, .b)`) 不拆分。
+func splitStyleSelectors(prelude string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i := 0; i < len(prelude); i++ {
+		switch prelude[i] {
+		case '(', '[':
+			depth++
+		case ')', ']':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, prelude[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, prelude[start:])
+}
+
+// maskStyleBrackets 将属性选择器 `[...]` 与其中的字符串替换为等长的空白，避免 `[href$=".pdf"]` 被识别为类名。
+func maskStyleBrackets(selector string) string {
+	masked := []byte(selector)
+	depth := 0
+	for i, c := range masked {
+		switch {
+		case c == '[':
+			depth++
+		case c == ']' && depth > 0:
+			depth--
+		case depth > 0:
+			masked[i] = ' '
+		}
+	}
+	return string(masked)
+}
@@ -0,0 +1,113 @@
+package parser_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Flying-Bird1999/analyzer-ts/analyzer/parser"
+)
+
+// TestAnalyzeClassDeclaration 测试分析类声明的功能
+func TestAnalyzeClassDeclaration(t *testing.T) {
+	// testCases 定义了一系列的测试用例
+	testCases := []struct {
+		name         string // 测试用例名称
+		code         string // 需要被解析的代码
+		expectedJSON string // 期望的解析结果
+	}{
+		{
+			name: "继承、泛型与导出",
+			code: `export abstract class Repository<T extends { id: string }> extends Base<T> implements Store, Disposable {}`,
+			expectedJSON: `[{
+				"identifier": "Repository",
+				"exported": true,
+				"isDefaultExport": false,
+				"isAbstract": true,
+				"generics": ["T extends { id: string }"],
+				"extends": "Base<T>",
+				"implements": ["Store", "Disposable"],
+				"members": []
+			}]`,
+		},
+		{
+			name: "默认导出的匿名类与装饰器",
+			code: "@Injectable()\nexport default class {}",
+			expectedJSON: `[{
+				"identifier": "",
+				"exported": true,
+				"isDefaultExport": true,
+				"isAbstract": false,
+				"decorators": [{"name": "Injectable", "callChain": ["Injectable"], "isCall": true, "arguments": []}],
+				"members": []
+			}]`,
+		},
+		{
+			name: "成员与构造函数参数属性",
+			code: `class UserService {
+	static instance?: UserService;
+	private readonly cache: Map<string, User> = new Map();
+	#secret = 1;
+	[key: string]: any;
+	constructor(private api: Api, public readonly name = 'users', plain: number) {}
+	@Get(':id')
+	async find(id: string): Promise<User> { return this.api.get(id); }
+	protected abstract reset(): void;
+	get size(): number { return this.cache.size; }
+	set size(value: number) {}
+}`,
+			expectedJSON: `[{
+				"identifier": "UserService",
+				"exported": false,
+				"isDefaultExport": false,
+				"isAbstract": false,
+				"members": [
+					{"name": "instance", "kind": "property", "isStatic": true, "isOptional": true, "type": "UserService"},
+					{"name": "cache", "kind": "property", "accessibility": "private", "isReadonly": true, "type": "Map<string, User>"},
+					{"name": "#secret", "kind": "property"},
+					{"name": "", "kind": "index", "type": "any", "parameters": [{"name": "key", "type": "string", "optional": false, "isRest": false}]},
+					{"name": "constructor", "kind": "constructor", "parameters": [
+						{"name": "api", "type": "Api", "optional": false, "isRest": false},
+						{"name": "name", "type": "", "optional": false, "defaultValue": "'users'", "isRest": false},
+						{"name": "plain", "type": "number", "optional": false, "isRest": false}
+					]},
+					{"name": "api", "kind": "property", "accessibility": "private", "isParameterProperty": true, "type": "Api"},
+					{"name": "name", "kind": "property", "accessibility": "public", "isReadonly": true, "isParameterProperty": true},
+					{"name": "find", "kind": "method", "isAsync": true, "decorators": [{"name": "Get", "callChain": ["Get"], "isCall": true, "arguments": [{"type": "stringLiteral", "expression": "':id'", "data": ":id"}]}], "returnType": "Promise<User>", "parameters": [{"name": "id", "type": "string", "optional": false, "isRest": false}]},
+					{"name": "reset", "kind": "method", "accessibility": "protected", "isAbstract": true, "returnType": "void"},
+					{"name": "size", "kind": "getter", "type": "number"},
+					{"name": "size", "kind": "setter", "type": "number", "parameters": [{"name": "value", "type": "number", "optional": false, "isRest": false}]}
+				]
+			}]`,
+		},
+	}
+
+	// extractFn 定义了如何从完整的解析结果中提取我们关心的部分
+	extractFn := func(result *parser.ParserResult) []parser.ClassDeclarationResult {
+		return result.ClassDeclarations
+	}
+
+	// marshalFn 定义了如何将提取出的结果序列化为 JSON，忽略原始文本与位置信息
+	marshalFn := func(result []parser.ClassDeclarationResult) ([]byte, error) {
+		for i := range result {
+			result[i].Raw = ""
+			result[i].SourceLocation = nil
+			clearDecoratorLocations(result[i].Decorators)
+			for j := range result[i].Members {
+				result[i].Members[j].Raw = ""
+				result[i].Members[j].SourceLocation = nil
+				clearDecoratorLocations(result[i].Members[j].Decorators)
+				for k := range result[i].Members[j].Parameters {
+					result[i].Members[j].Parameters[k].Raw = ""
+				}
+			}
+		}
+		return json.MarshalIndent(result, "", "\t")
+	}
+
+	// 遍历所有测试用例并执行测试
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			RunTest(t, tc.code, tc.expectedJSON, extractFn, marshalFn)
+		})
+	}
+}
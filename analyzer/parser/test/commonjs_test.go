@@ -0,0 +1,204 @@
+package parser_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Flying-Bird1999/analyzer-ts/analyzer/parser"
+)
+
+// TestCommonJSRequire 测试将 CommonJS 的 require() 转换为导入声明
+func TestCommonJSRequire(t *testing.T) {
+	// expectedImport 定义了测试期望的单个导入声明
+	type expectedImport struct {
+		ImportModules []parser.ImportModule `json:"importModules"` // 导入的模块列表
+		Source        string                `json:"source"`        // 导入来源
+		ModuleSystem  string                `json:"moduleSystem"`  // 模块系统
+	}
+
+	// testCases 定义了一系列的测试用例
+	testCases := []struct {
+		name     string           // 测试用例名称
+		code     string           // 需要被解析的代码
+		expected []expectedImport // 期望的解析结果
+	}{
+		{
+			name: "整体引入",
+			code: "const utils = require('./utils');",
+			expected: []expectedImport{
+				{ImportModules: []parser.ImportModule{{ImportModule: "utils", Type: "namespace", Identifier: "utils"}}, Source: "./utils", ModuleSystem: "cjs"},
+			},
+		},
+		{
+			name: "解构引入",
+			code: "const { format, parse: parseDate } = require('./date');",
+			expected: []expectedImport{
+				{ImportModules: []parser.ImportModule{
+					{ImportModule: "format", Type: "named", Identifier: "format"},
+					{ImportModule: "parse", Type: "named", Identifier: "parseDate"},
+				}, Source: "./date", ModuleSystem: "cjs"},
+			},
+		},
+		{
+			name: "成员访问与 default",
+			code: "const a = require('./a').foo;\nconst B = require('./b').default;",
+			expected: []expectedImport{
+				{ImportModules: []parser.ImportModule{{ImportModule: "foo", Type: "named", Identifier: "a"}}, Source: "./a", ModuleSystem: "cjs"},
+				{ImportModules: []parser.ImportModule{{ImportModule: "default", Type: "default", Identifier: "B"}}, Source: "./b", ModuleSystem: "cjs"},
+			},
+		},
+		{
+			name: "副作用引入与作为参数引入",
+			code: "require('./setup');\nuse(require('express'));",
+			expected: []expectedImport{
+				{ImportModules: []parser.ImportModule{}, Source: "./setup", ModuleSystem: "cjs"},
+				{ImportModules: []parser.ImportModule{{ImportModule: "*", Type: "namespace", Identifier: "*"}}, Source: "express", ModuleSystem: "cjs"},
+			},
+		},
+		{
+			name: "import = require",
+			code: "import fs = require('fs');",
+			expected: []expectedImport{
+				{ImportModules: []parser.ImportModule{{ImportModule: "fs", Type: "namespace", Identifier: "fs"}}, Source: "fs", ModuleSystem: "cjs"},
+			},
+		},
+		{
+			name:     "非字面量参数的 require 不作为导入",
+			code:     "const mod = require(name);",
+			expected: []expectedImport{},
+		},
+	}
+
+	// extractFn 定义了如何从完整的解析结果中提取我们关心的部分
+	extractFn := func(result *parser.ParserResult) []expectedImport {
+		imports := []expectedImport{}
+		for _, decl := range result.ImportDeclarations {
+			imports = append(imports, expectedImport{ImportModules: decl.ImportModules, Source: decl.Source, ModuleSystem: decl.ModuleSystem})
+		}
+		return imports
+	}
+
+	// marshalFn 定义了如何将提取出的结果序列化为 JSON
+	marshalFn := func(result []expectedImport) ([]byte, error) {
+		return json.MarshalIndent(result, "", "\t")
+	}
+
+	// 遍历所有测试用例并执行测试
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			expectedJSON, err := json.MarshalIndent(tc.expected, "", "\t")
+			if err != nil {
+				t.Fatalf("无法将期望结果序列化为 JSON: %v", err)
+			}
+			RunTest(t, tc.code, string(expectedJSON), extractFn, marshalFn)
+		})
+	}
+}
+
+// TestCommonJSExports 测试将 module.exports 与 exports.xxx 转换为导出声明
+func TestCommonJSExports(t *testing.T) {
+	// expectedResult 定义了测试期望的导出结果
+	type expectedResult struct {
+		ExportDeclarations []struct {
+			ExportModules []parser.ExportModule `json:"exportModules"`
+			Source        string                `json:"source"`
+			Type          string                `json:"type"`
+			ModuleSystem  string                `json:"moduleSystem"`
+		} `json:"exportDeclarations"`
+		ExportAssignments []struct {
+			Expression   string `json:"expression"`
+			Name         string `json:"name"`
+			ModuleSystem string `json:"moduleSystem"`
+		} `json:"exportAssignments"`
+		ImportCount int `json:"importCount"`
+	}
+
+	// testCases 定义了一系列的测试用例
+	testCases := []struct {
+		name         string // 测试用例名称
+		code         string // 需要被解析的代码
+		expectedJSON string // 期望的解析结果
+	}{
+		{
+			name: "module.exports 赋值为标识符",
+			code: "function createApp() {}\nmodule.exports = createApp;",
+			expectedJSON: `{
+				"exportDeclarations": [],
+				"exportAssignments": [{"expression": "createApp", "name": "createApp", "moduleSystem": "cjs"}],
+				"importCount": 0
+			}`,
+		},
+		{
+			name: "module.exports 赋值为对象字面量",
+			code: "module.exports = { a, b: helper, c() {}, d: 1 };",
+			expectedJSON: `{
+				"exportDeclarations": [{
+					"exportModules": [
+						{"moduleName": "a", "type": "named", "identifier": "a"},
+						{"moduleName": "helper", "type": "named", "identifier": "b"},
+						{"moduleName": "c", "type": "named", "identifier": "c"},
+						{"moduleName": "d", "type": "named", "identifier": "d"}
+					],
+					"source": "", "type": "named-export", "moduleSystem": "cjs"
+				}],
+				"exportAssignments": [],
+				"importCount": 0
+			}`,
+		},
+		{
+			name: "exports.xxx 与 module.exports.xxx",
+			code: "exports.foo = foo;\nmodule.exports.bar = () => 1;\nexports['baz'] = 2;",
+			expectedJSON: `{
+				"exportDeclarations": [
+					{"exportModules": [{"moduleName": "foo", "type": "named", "identifier": "foo"}], "source": "", "type": "named-export", "moduleSystem": "cjs"},
+					{"exportModules": [{"moduleName": "bar", "type": "named", "identifier": "bar"}], "source": "", "type": "named-export", "moduleSystem": "cjs"},
+					{"exportModules": [{"moduleName": "baz", "type": "named", "identifier": "baz"}], "source": "", "type": "named-export", "moduleSystem": "cjs"}
+				],
+				"exportAssignments": [],
+				"importCount": 0
+			}`,
+		},
+		{
+			name: "通过 require 重导出",
+			code: "module.exports = require('./impl');\nexports.util = require('./util');\nexports.fmt = require('./fmt').format;",
+			expectedJSON: `{
+				"exportDeclarations": [
+					{"exportModules": [{"moduleName": "*", "type": "namespace", "identifier": "*"}], "source": "./impl", "type": "re-export", "moduleSystem": "cjs"},
+					{"exportModules": [{"moduleName": "*", "type": "namespace", "identifier": "util"}], "source": "./util", "type": "re-export", "moduleSystem": "cjs"},
+					{"exportModules": [{"moduleName": "format", "type": "named", "identifier": "fmt"}], "source": "./fmt", "type": "re-export", "moduleSystem": "cjs"}
+				],
+				"exportAssignments": [],
+				"importCount": 0
+			}`,
+		},
+	}
+
+	// extractFn 定义了如何从完整的解析结果中提取我们关心的部分
+	extractFn := func(result *parser.ParserResult) *parser.ParserResult {
+		return result
+	}
+
+	// marshalFn 定义了如何将提取出的结果序列化为 JSON
+	marshalFn := func(result *parser.ParserResult) ([]byte, error) {
+		var out expectedResult
+		data, err := json.Marshal(struct {
+			ExportDeclarations []parser.ExportDeclarationResult `json:"exportDeclarations"`
+			ExportAssignments  []parser.ExportAssignmentResult  `json:"exportAssignments"`
+		}{result.ExportDeclarations, result.ExportAssignments})
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, &out); err != nil {
+			return nil, err
+		}
+		out.ImportCount = len(result.ImportDeclarations)
+		return json.Marshal(out)
+	}
+
+	// 遍历所有测试用例并执行测试
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			RunTest(t, tc.code, tc.expectedJSON, extractFn, marshalFn)
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package parser_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Flying-Bird1999/analyzer-ts/analyzer/parser"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestComponentFile 测试 .vue 与 .svelte 文件中 <script> 块与模板组件标签的解析
+func TestComponentFile(t *testing.T) {
+	// testCases 定义了一系列的测试用例
+	testCases := []struct {
+		name         string // 测试用例名称
+		filePath     string // 虚拟的文件路径，决定按 Vue 还是 Svelte 处理
+		code         string // 需要被解析的代码
+		expectedJSON string // 期望的解析结果
+	}{
+		{
+			name:     "Vue 单文件组件",
+			filePath: "/src/App.vue",
+			code: `<template>
+  <div class="app">
+    <MyButton :label="title" @click="onClick" disabled type="primary" />
+    <user-card v-bind:user="user"></user-card>
+    <!-- <Ignored /> -->
+  </div>
+</template>
+
+<script lang="ts">
+export default { name: 'App' }
+</script>
+<script setup lang="ts">
+import MyButton from './MyButton.vue'
+import UserCard from '@/components/UserCard.vue'
+const title: string = 'hi'
+</script>
+
+<style scoped>
+.a > .b { color: red }
+</style>`,
+			expectedJSON: `{
+				"imports": [
+					{"source": "./MyButton.vue", "line": 13},
+					{"source": "@/components/UserCard.vue", "line": 14}
+				],
+				"variables": [{"name": "title", "line": 15}],
+				"elements": [
+					{"componentChain": ["MyButton"], "line": 3, "raw": "<MyButton :label=\"title\" @click=\"onClick\" disabled type=\"primary\" />", "attrs": [
+						{"name": ":label", "value": {"type": "identifier", "expression": "title", "data": "title"}, "isSpread": false},
+						{"name": "@click", "value": {"type": "identifier", "expression": "onClick", "data": "onClick"}, "isSpread": false},
+						{"name": "disabled", "value": null, "isSpread": false},
+						{"name": "type", "value": {"type": "stringLiteral", "expression": "\"primary\"", "data": "primary"}, "isSpread": false}
+					]},
+					{"componentChain": ["UserCard"], "line": 4, "raw": "<user-card v-bind:user=\"user\">", "attrs": [
+						{"name": "v-bind:user", "value": {"type": "identifier", "expression": "user", "data": "user"}, "isSpread": false}
+					]}
+				]
+			}`,
+		},
+		{
+			name:     "Svelte 组件",
+			filePath: "/src/Counter.svelte",
+			code: `<script context="module">
+  export const prerender = true
+</script>
+
+<script>
+  import Button from './Button.svelte'
+  import * as Icons from 'lucide-svelte'
+  let count = 0
+</script>
+
+<svelte:head><title>Counter</title></svelte:head>
+<Button {count} {...$$restProps} on:click={() => count++} label="Add {count}">
+  <Icons.Plus size={16} />
+</Button>`,
+			expectedJSON: `{
+				"imports": [
+					{"source": "./Button.svelte", "line": 6},
+					{"source": "lucide-svelte", "line": 7}
+				],
+				"variables": [{"name": "prerender", "line": 2}, {"name": "count", "line": 8}],
+				"elements": [
+					{"componentChain": ["Button"], "line": 12, "raw": "<Button {count} {...$$restProps} on:click={() => count++} label=\"Add {count}\">", "attrs": [
+						{"name": "count", "value": {"type": "identifier", "expression": "count", "data": "count"}, "isSpread": false},
+						{"name": "...$$restProps", "value": null, "isSpread": true},
+						{"name": "on:click", "value": {"type": "other", "expression": "() => count++"}, "isSpread": false},
+						{"name": "label", "value": {"type": "stringLiteral", "expression": "\"Add {count}\"", "data": "Add {count}"}, "isSpread": false}
+					]},
+					{"componentChain": ["Icons", "Plus"], "line": 13, "raw": "<Icons.Plus size={16} />", "attrs": [
+						{"name": "size", "value": {"type": "other", "expression": "16"}, "isSpread": false}
+					]}
+				]
+			}`,
+		},
+	}
+
+	type location struct {
+		Source string `json:"source,omitempty"`
+		Name   string `json:"name,omitempty"`
+		Line   int    `json:"line"`
+	}
+	type element struct {
+		ComponentChain []string              `json:"componentChain"`
+		Attrs          []parser.JSXAttribute `json:"attrs"`
+		Raw            string                `json:"raw"`
+		Line           int                   `json:"line"`
+	}
+	type componentResult struct {
+		Imports   []location `json:"imports"`
+		Variables []location `json:"variables"`
+		Elements  []element  `json:"elements"`
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := parser.NewParserFromSource(tc.filePath, tc.code)
+			assert.NoError(t, err, "创建解析器失败")
+			p.Traverse()
+			assert.Empty(t, p.Result.Errors)
+
+			result := componentResult{Imports: []location{}, Variables: []location{}, Elements: []element{}}
+			for _, decl := range p.Result.ImportDeclarations {
+				result.Imports = append(result.Imports, location{Source: decl.Source, Line: decl.SourceLocation.Start.Line})
+			}
+			for _, decl := range p.Result.VariableDeclarations {
+				for _, declarator := range decl.Declarators {
+					result.Variables = append(result.Variables, location{Name: declarator.Identifier, Line: decl.SourceLocation.Start.Line})
+				}
+			}
+			for _, el := range p.Result.JsxElements {
+				result.Elements = append(result.Elements, element{ComponentChain: el.ComponentChain, Attrs: el.Attrs, Raw: el.Raw, Line: el.SourceLocation.Start.Line})
+			}
+
+			resultJSON, err := json.MarshalIndent(result, "", "\t")
+			assert.NoError(t, err, "将结果序列化为 JSON 失败")
+			assert.JSONEq(t, tc.expectedJSON, string(resultJSON), "生成的 JSON 应与预期的 JSON 匹配")
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package parser_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Flying-Bird1999/analyzer-ts/analyzer/parser"
+)
+
+// clearDecoratorLocations 清除装饰器的原始文本与位置信息，便于比较解析结果
+func clearDecoratorLocations(decorators []parser.DecoratorResult) {
+	for i := range decorators {
+		decorators[i].Raw = ""
+		decorators[i].SourceLocation = nil
+	}
+}
+
+// TestAnalyzeDecorators 测试类、方法、属性与参数上的装饰器解析
+func TestAnalyzeDecorators(t *testing.T) {
+	code := `@Controller('/users')
+export class UserController {
+	@observable count = 0;
+	@Get(':id')
+	@UseGuards(AuthGuard, RolesGuard)
+	find(@Param('id') id: string, @nest.Inject(TOKEN) @Optional() cache: Cache) {}
+}`
+
+	expectedJSON := `{
+		"class": [
+			{"name": "Controller", "callChain": ["Controller"], "isCall": true, "arguments": [
+				{"type": "stringLiteral", "expression": "'/users'", "data": "/users"}
+			]}
+		],
+		"members": {
+			"count": [
+				{"name": "observable", "callChain": ["observable"], "isCall": false, "arguments": []}
+			],
+			"find": [
+				{"name": "Get", "callChain": ["Get"], "isCall": true, "arguments": [
+					{"type": "stringLiteral", "expression": "':id'", "data": ":id"}
+				]},
+				{"name": "UseGuards", "callChain": ["UseGuards"], "isCall": true, "arguments": [
+					{"type": "identifier", "expression": "AuthGuard", "data": "AuthGuard"},
+					{"type": "identifier", "expression": "RolesGuard", "data": "RolesGuard"}
+				]}
+			]
+		},
+		"parameters": {
+			"id": [
+				{"name": "Param", "callChain": ["Param"], "isCall": true, "arguments": [
+					{"type": "stringLiteral", "expression": "'id'", "data": "id"}
+				]}
+			],
+			"cache": [
+				{"name": "Inject", "callChain": ["nest", "Inject"], "isCall": true, "arguments": [
+					{"type": "identifier", "expression": "TOKEN", "data": "TOKEN"}
+				]},
+				{"name": "Optional", "callChain": ["Optional"], "isCall": true, "arguments": []}
+			]
+		}
+	}`
+
+	type decoratorsResult struct {
+		Class      []parser.DecoratorResult            `json:"class"`
+		Members    map[string][]parser.DecoratorResult `json:"members"`
+		Parameters map[string][]parser.DecoratorResult `json:"parameters"`
+	}
+
+	// extractFn 按类、成员与参数收集装饰器
+	extractFn := func(result *parser.ParserResult) decoratorsResult {
+		out := decoratorsResult{Members: map[string][]parser.DecoratorResult{}, Parameters: map[string][]parser.DecoratorResult{}}
+		if len(result.ClassDeclarations) == 0 {
+			return out
+		}
+		class := result.ClassDeclarations[0]
+		out.Class = class.Decorators
+		for _, member := range class.Members {
+			out.Members[member.Name] = member.Decorators
+			for _, param := range member.Parameters {
+				out.Parameters[param.Name] = param.Decorators
+			}
+		}
+		return out
+	}
+
+	// marshalFn 定义了如何将提取出的结果序列化为 JSON，忽略原始文本与位置信息
+	marshalFn := func(result decoratorsResult) ([]byte, error) {
+		clearDecoratorLocations(result.Class)
+		for _, decorators := range result.Members {
+			clearDecoratorLocations(decorators)
+		}
+		for _, decorators := range result.Parameters {
+			clearDecoratorLocations(decorators)
+		}
+		return json.MarshalIndent(result, "", "\t")
+	}
+
+	RunTest(t, code, expectedJSON, extractFn, marshalFn)
+}
@@ -71,3 +71,98 @@ func TestNewEnumDeclarationResult(t *testing.T) {
 		})
 	}
 }
+
+// TestEnumDeclarationMembers 测试枚举成员、常量值计算以及 const / declare 标记
+func TestEnumDeclarationMembers(t *testing.T) {
+	// testCases 定义了一系列的测试用例
+	testCases := []struct {
+		name         string // 测试用例名称
+		code         string // 需要被解析的代码
+		expectedJSON string // 期望的解析结果
+	}{
+		{
+			name: "数字自增与成员引用",
+			code: `export enum Flags { None, A = 1 << 1, B, C = A | B, D = Flags.C * 2, E = -D, F = ~0, G = 0x10 }`,
+			expectedJSON: `{
+				"identifier": "Flags", "isConst": false, "isDeclare": false,
+				"members": [
+					{"name": "None", "value": 0},
+					{"name": "A", "initializer": "1 << 1", "value": 2},
+					{"name": "B", "value": 3},
+					{"name": "C", "initializer": "A | B", "value": 3},
+					{"name": "D", "initializer": "Flags.C * 2", "value": 6},
+					{"name": "E", "initializer": "-D", "value": -6},
+					{"name": "F", "initializer": "~0", "value": -1},
+					{"name": "G", "initializer": "0x10", "value": 16}
+				]
+			}`,
+		},
+		{
+			name: "const enum 与字符串成员",
+			code: "const enum Status { Active = 'active', Prefixed = `status-` + Active, 'with-dash' = 'x' }",
+			expectedJSON: `{
+				"identifier": "Status", "isConst": true, "isDeclare": false,
+				"members": [
+					{"name": "Active", "initializer": "'active'", "value": "active"},
+					{"name": "Prefixed", "initializer": "` + "`status-` + Active" + `", "value": "status-active"},
+					{"name": "with-dash", "initializer": "'x'", "value": "x"}
+				]
+			}`,
+		},
+		{
+			name: "declare enum 与无法计算的成员",
+			code: `declare enum Size { Small = getSize(), Medium, Large = 'large'.length }`,
+			expectedJSON: `{
+				"identifier": "Size", "isConst": false, "isDeclare": true,
+				"members": [
+					{"name": "Small", "initializer": "getSize()"},
+					{"name": "Medium"},
+					{"name": "Large", "initializer": "'large'.length"}
+				]
+			}`,
+		},
+		{
+			name: "结果为 Infinity 或 NaN 的成员视为无法计算",
+			code: `export enum Broken { A = 1 / 0, B, C = 0 / 0, D = 5 % 0, E = 10 ** 400, F = 7 }`,
+			expectedJSON: `{
+				"identifier": "Broken", "isConst": false, "isDeclare": false,
+				"members": [
+					{"name": "A", "initializer": "1 / 0"},
+					{"name": "B"},
+					{"name": "C", "initializer": "0 / 0"},
+					{"name": "D", "initializer": "5 % 0"},
+					{"name": "E", "initializer": "10 ** 400"},
+					{"name": "F", "initializer": "7", "value": 7}
+				]
+			}`,
+		},
+	}
+
+	// extractFn 定义了如何从完整的解析结果中提取我们关心的部分
+	extractFn := func(result *parser.ParserResult) parser.EnumDeclarationResult {
+		for _, enum := range result.EnumDeclarations {
+			return enum
+		}
+		return parser.EnumDeclarationResult{}
+	}
+
+	// marshalFn 定义了如何将提取出的结果序列化为 JSON，忽略原始文本与位置信息
+	marshalFn := func(result parser.EnumDeclarationResult) ([]byte, error) {
+		for i := range result.Members {
+			result.Members[i].SourceLocation = nil
+		}
+		return json.MarshalIndent(struct {
+			Identifier string                    `json:"identifier"`
+			IsConst    bool                      `json:"isConst"`
+			IsDeclare  bool                      `json:"isDeclare"`
+			Members    []parser.EnumMemberResult `json:"members"`
+		}{result.Identifier, result.IsConst, result.IsDeclare, result.Members}, "", "\t")
+	}
+
+	// 遍历所有测试用例并执行测试
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			RunTest(t, tc.code, tc.expectedJSON, extractFn, marshalFn)
+		})
+	}
+}
@@ -139,3 +139,119 @@ func TestAnalyzeImportDeclaration(t *testing.T) {
 		})
 	}
 }
+
+// TestTypeOnlyImportExport 测试整体的与行内的类型导入/导出标记
+func TestTypeOnlyImportExport(t *testing.T) {
+	code := `import type { Props, State as S } from './types';
+import { type Config, createConfig } from './config';
+import type Theme from './theme';
+import type fs = require('fs');
+export type { Props } from './types';
+export { type Config, createConfig };
+export type * as models from './models';`
+
+	expectedJSON := `{
+		"imports": [
+			{"isTypeOnly": true, "importModules": [
+				{"importModule": "Props", "type": "named", "identifier": "Props", "isTypeOnly": true},
+				{"importModule": "State", "type": "named", "identifier": "S", "isTypeOnly": true}
+			]},
+			{"isTypeOnly": false, "importModules": [
+				{"importModule": "Config", "type": "named", "identifier": "Config", "isTypeOnly": true},
+				{"importModule": "createConfig", "type": "named", "identifier": "createConfig"}
+			]},
+			{"isTypeOnly": true, "importModules": [
+				{"importModule": "default", "type": "default", "identifier": "Theme", "isTypeOnly": true}
+			]},
+			{"isTypeOnly": true, "importModules": [
+				{"importModule": "fs", "type": "namespace", "identifier": "fs", "isTypeOnly": true}
+			]}
+		],
+		"exports": [
+			{"isTypeOnly": true, "exportModules": [
+				{"moduleName": "Props", "type": "named", "identifier": "Props", "isTypeOnly": true}
+			]},
+			{"isTypeOnly": false, "exportModules": [
+				{"moduleName": "Config", "type": "named", "identifier": "Config", "isTypeOnly": true},
+				{"moduleName": "createConfig", "type": "named", "identifier": "createConfig"}
+			]},
+			{"isTypeOnly": true, "exportModules": [
+				{"moduleName": "*", "type": "namespace", "identifier": "models", "isTypeOnly": true}
+			]}
+		]
+	}`
+
+	type importResult struct {
+		IsTypeOnly    bool                  `json:"isTypeOnly"`
+		ImportModules []parser.ImportModule `json:"importModules"`
+	}
+	type exportResult struct {
+		IsTypeOnly    bool                  `json:"isTypeOnly"`
+		ExportModules []parser.ExportModule `json:"exportModules"`
+	}
+	type typeOnlyResult struct {
+		Imports []importResult `json:"imports"`
+		Exports []exportResult `json:"exports"`
+	}
+
+	// extractFn 只保留类型导入/导出相关的字段
+	extractFn := func(result *parser.ParserResult) typeOnlyResult {
+		out := typeOnlyResult{Imports: []importResult{}, Exports: []exportResult{}}
+		for _, decl := range result.ImportDeclarations {
+			out.Imports = append(out.Imports, importResult{IsTypeOnly: decl.IsTypeOnly, ImportModules: decl.ImportModules})
+		}
+		for _, decl := range result.ExportDeclarations {
+			out.Exports = append(out.Exports, exportResult{IsTypeOnly: decl.IsTypeOnly, ExportModules: decl.ExportModules})
+		}
+		return out
+	}
+
+	// marshalFn 定义了如何将提取出的结果序列化为 JSON
+	marshalFn := func(result typeOnlyResult) ([]byte, error) {
+		return json.MarshalIndent(result, "", "\t")
+	}
+
+	RunTest(t, code, expectedJSON, extractFn, marshalFn)
+}
+
+// TestDynamicImportPatterns 测试路径不确定的动态导入被记录为 glob 模式
+func TestDynamicImportPatterns(t *testing.T) {
+	code := `const pages = import.meta.glob('./pages/**/*.tsx');
+const icons = import.meta.glob(['./icons/*.svg', '!./icons/legacy-*.svg']);
+const views = require.context('./views', false, /\.vue$/);
+import('./routes');
+const locale = import(` + "`./locales/${lang}.ts`" + `);
+const theme = import('./themes/' + name + '.css');`
+
+	expectedJSON := `[
+		{"source": "./pages/**/*.tsx", "pattern": "./pages/**/*.tsx", "dynamic": true},
+		{"source": "./icons/*.svg", "pattern": "./icons/*.svg", "dynamic": true},
+		{"source": "./views/*", "pattern": "./views/*", "patternFilter": "/\\.vue$/", "dynamic": true},
+		{"source": "./routes", "dynamic": true},
+		{"source": "./locales/*.ts", "pattern": "./locales/*.ts", "dynamic": true},
+		{"source": "./themes/*.css", "pattern": "./themes/*.css", "dynamic": true}
+	]`
+
+	type patternResult struct {
+		Source        string `json:"source"`
+		Pattern       string `json:"pattern,omitempty"`
+		PatternFilter string `json:"patternFilter,omitempty"`
+		IsDynamic     bool   `json:"dynamic"`
+	}
+
+	// extractFn 只保留动态导入相关的字段
+	extractFn := func(result *parser.ParserResult) []patternResult {
+		out := []patternResult{}
+		for _, decl := range result.ImportDeclarations {
+			out = append(out, patternResult{Source: decl.Source, Pattern: decl.Pattern, PatternFilter: decl.PatternFilter, IsDynamic: decl.IsDynamic})
+		}
+		return out
+	}
+
+	// marshalFn 定义了如何将提取出的结果序列化为 JSON
+	marshalFn := func(result []patternResult) ([]byte, error) {
+		return json.MarshalIndent(result, "", "\t")
+	}
+
+	RunTest(t, code, expectedJSON, extractFn, marshalFn)
+}
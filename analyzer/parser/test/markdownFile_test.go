@@ -0,0 +1,136 @@
+package parser_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Flying-Bird1999/analyzer-ts/analyzer/parser"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestMarkdownFile 测试 .mdx 文件中 ESM 语句与组件标签的解析，以及代码块的提取。
+// 代码块只是文档示例，其中的导入与组件标签不会出现在解析结果中。
+func TestMarkdownFile(t *testing.T) {
+	testCases := []struct {
+		name         string // 测试用例名称
+		filePath     string // 虚拟的文件路径，决定按 Markdown 还是 MDX 处理
+		code         string // 需要被解析的文档
+		expectedJSON string // 期望的解析结果
+	}{
+		{
+			name:     "MDX 文档",
+			filePath: "/docs/button.mdx",
+			code: `---
+title: Button
+---
+import { Button } from '@/components/Button'
+import * as Icons from '@/icons'
+
+export const meta = { title: 'Button' }
+
+# Button <Badge />
+
+Use ` + "`<Inline />`" + ` for inline code.
+
+<Button type="primary" onClick={() => alert('hi')}>
+  <Icons.Plus size={16} />
+</Button>
+
+{/* <Commented /> */}
+<!-- <Ignored /> -->
+
+` + "```tsx" + `
+import { Tooltip } from '@/components/Tooltip'
+export const Demo = () => <Tooltip title="tip" />
+` + "```",
+			expectedJSON: `{
+				"imports": [
+					{"source": "@/components/Button", "line": 4},
+					{"source": "@/icons", "line": 5}
+				],
+				"variables": [{"name": "meta", "line": 7}],
+				"elements": [
+					{"componentChain": ["Badge"], "line": 9},
+					{"componentChain": ["Button"], "line": 13},
+					{"componentChain": ["Icons", "Plus"], "line": 14}
+				],
+				"codeBlocks": [{"lang": "tsx", "start": 21, "end": 22}]
+			}`,
+		},
+		{
+			name:     "Markdown 文档",
+			filePath: "/README.md",
+			code: `# Usage
+
+import Foo from 'prose-not-esm'
+
+<Button />
+
+` + "```bash" + `
+npm install button
+` + "```" + `
+
+~~~ts title="example.ts"
+import { createButton } from '@/lib'
+const button = createButton()
+~~~`,
+			expectedJSON: `{
+				"imports": [],
+				"variables": [],
+				"elements": [],
+				"codeBlocks": [{"lang": "ts", "start": 12, "end": 13}]
+			}`,
+		},
+	}
+
+	type location struct {
+		Source string `json:"source,omitempty"`
+		Name   string `json:"name,omitempty"`
+		Line   int    `json:"line"`
+	}
+	type element struct {
+		ComponentChain []string `json:"componentChain"`
+		Line           int      `json:"line"`
+	}
+	type codeBlock struct {
+		Lang  string `json:"lang"`
+		Start int    `json:"start"`
+		End   int    `json:"end"`
+	}
+	type markdownResult struct {
+		Imports    []location  `json:"imports"`
+		Variables  []location  `json:"variables"`
+		Elements   []element   `json:"elements"`
+		CodeBlocks []codeBlock `json:"codeBlocks"`
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := parser.NewParserFromSource(tc.filePath, tc.code)
+			assert.NoError(t, err, "创建解析器失败")
+			p.Traverse()
+			assert.Empty(t, p.Result.Errors)
+
+			result := markdownResult{Imports: []location{}, Variables: []location{}, Elements: []element{}, CodeBlocks: []codeBlock{}}
+			for _, decl := range p.Result.ImportDeclarations {
+				result.Imports = append(result.Imports, location{Source: decl.Source, Line: decl.SourceLocation.Start.Line})
+			}
+			for _, decl := range p.Result.VariableDeclarations {
+				for _, declarator := range decl.Declarators {
+					result.Variables = append(result.Variables, location{Name: declarator.Identifier, Line: decl.SourceLocation.Start.Line})
+				}
+			}
+			for _, el := range p.Result.JsxElements {
+				result.Elements = append(result.Elements, element{ComponentChain: el.ComponentChain, Line: el.SourceLocation.Start.Line})
+			}
+			for _, block := range parser.ExtractMarkdownCodeBlocks(tc.code) {
+				result.CodeBlocks = append(result.CodeBlocks, codeBlock{Lang: block.Lang, Start: block.SourceLocation.Start.Line, End: block.SourceLocation.End.Line})
+			}
+
+			resultJSON, err := json.MarshalIndent(result, "", "\t")
+			assert.NoError(t, err, "将结果序列化为 JSON 失败")
+			assert.JSONEq(t, tc.expectedJSON, string(resultJSON), "生成的 JSON 应与预期的 JSON 匹配")
+		})
+	}
+}
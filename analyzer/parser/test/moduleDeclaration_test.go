@@ -0,0 +1,153 @@
+package parser_test
+
+import (
+	"encoding/json"
+	"sort"
+	"testing"
+
+	"github.com/Flying-Bird1999/analyzer-ts/analyzer/parser"
+)
+
+// TestAnalyzeModuleDeclaration 测试 namespace、declare module 与 declare global 的解析
+func TestAnalyzeModuleDeclaration(t *testing.T) {
+	// testCases 定义了一系列的测试用例
+	testCases := []struct {
+		name         string // 测试用例名称
+		code         string // 需要被解析的代码
+		expectedJSON string // 期望的解析结果
+	}{
+		{
+			name: "命名空间与嵌套命名空间",
+			code: `export namespace API {
+	export interface User { id: string }
+	const internal = 1;
+	export namespace Admin.Roles {}
+}`,
+			expectedJSON: `[
+				{"name": "API", "kind": "namespace", "exported": true, "isDeclare": false, "isAugmentation": false, "declarations": [
+					{"identifier": "User", "kind": "interface", "exported": true},
+					{"identifier": "internal", "kind": "variable", "exported": false},
+					{"identifier": "Admin", "kind": "namespace", "exported": true}
+				]},
+				{"name": "Admin", "kind": "namespace", "namespace": "API", "exported": true, "isDeclare": false, "isAugmentation": false, "declarations": [
+					{"identifier": "Roles", "kind": "namespace", "exported": true}
+				]},
+				{"name": "Roles", "kind": "namespace", "namespace": "API.Admin", "exported": true, "isDeclare": false, "isAugmentation": false, "declarations": []}
+			]`,
+		},
+		{
+			name: "模块文件中的模块扩展与全局扩展",
+			code: `import 'antd';
+declare module 'antd' {
+	interface ButtonProps { track?: string }
+}
+declare global {
+	interface Window { __APP__: string }
+}`,
+			expectedJSON: `[
+				{"name": "antd", "kind": "module", "exported": false, "isDeclare": true, "isAugmentation": true, "declarations": [
+					{"identifier": "ButtonProps", "kind": "interface", "exported": false}
+				]},
+				{"name": "global", "kind": "global", "exported": false, "isDeclare": true, "isAugmentation": true, "declarations": [
+					{"identifier": "Window", "kind": "interface", "exported": false}
+				]}
+			]`,
+		},
+		{
+			name: "脚本文件中的环境模块声明",
+			code: `declare module '*.svg' {
+	const content: string;
+	export default content;
+}`,
+			expectedJSON: `[
+				{"name": "*.svg", "kind": "module", "exported": false, "isDeclare": true, "isAugmentation": false, "declarations": [
+					{"identifier": "content", "kind": "variable", "exported": false}
+				]}
+			]`,
+		},
+	}
+
+	// extractFn 定义了如何从完整的解析结果中提取我们关心的部分
+	extractFn := func(result *parser.ParserResult) []parser.ModuleDeclarationResult {
+		return result.ModuleDeclarations
+	}
+
+	// marshalFn 定义了如何将提取出的结果序列化为 JSON，忽略原始文本与位置信息
+	marshalFn := func(result []parser.ModuleDeclarationResult) ([]byte, error) {
+		for i := range result {
+			result[i].Raw = ""
+			result[i].SourceLocation = nil
+		}
+		return json.MarshalIndent(result, "", "\t")
+	}
+
+	// 遍历所有测试用例并执行测试
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			RunTest(t, tc.code, tc.expectedJSON, extractFn, marshalFn)
+		})
+	}
+}
+
+// TestDeclarationNamespace 测试命名空间内的声明记录所属命名空间，并以限定名称作为 key
+func TestDeclarationNamespace(t *testing.T) {
+	code := `interface Props {}
+declare namespace API {
+	interface Props { id: string }
+	type Id = string;
+	function get(): void;
+	class Client {}
+	enum Status { Ok }
+}
+declare module 'vue' {
+	interface ComponentCustomProperties {}
+}
+declare global {
+	interface Window {}
+}`
+	expectedJSON := `{
+		"interfaces": {"Props": "", "API.Props": "API", "\"vue\".ComponentCustomProperties": "\"vue\"", "global.Window": "global"},
+		"types": {"API.Id": "API"},
+		"enums": {"API.Status": "API"},
+		"functions": ["API.get"],
+		"classes": ["API.Client"]
+	}`
+
+	type namespaces struct {
+		Interfaces map[string]string `json:"interfaces"`
+		Types      map[string]string `json:"types"`
+		Enums      map[string]string `json:"enums"`
+		Functions  []string          `json:"functions"`
+		Classes    []string          `json:"classes"`
+	}
+
+	// extractFn 收集每个声明的 key 与其记录的命名空间
+	extractFn := func(result *parser.ParserResult) namespaces {
+		out := namespaces{Interfaces: map[string]string{}, Types: map[string]string{}, Enums: map[string]string{}, Functions: []string{}, Classes: []string{}}
+		for key, decl := range result.InterfaceDeclarations {
+			out.Interfaces[key] = decl.Namespace
+		}
+		for key, decl := range result.TypeDeclarations {
+			out.Types[key] = decl.Namespace
+		}
+		for key, decl := range result.EnumDeclarations {
+			out.Enums[key] = decl.Namespace
+		}
+		for _, decl := range result.FunctionDeclarations {
+			out.Functions = append(out.Functions, parser.QualifiedName(decl.Namespace, decl.Identifier))
+		}
+		for _, decl := range result.ClassDeclarations {
+			out.Classes = append(out.Classes, parser.QualifiedName(decl.Namespace, decl.Identifier))
+		}
+		sort.Strings(out.Functions)
+		sort.Strings(out.Classes)
+		return out
+	}
+
+	// marshalFn 定义了如何将提取出的结果序列化为 JSON
+	marshalFn := func(result namespaces) ([]byte, error) {
+		return json.MarshalIndent(result, "", "\t")
+	}
+
+	RunTest(t, code, expectedJSON, extractFn, marshalFn)
+}
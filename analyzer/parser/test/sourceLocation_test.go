@@ -0,0 +1,42 @@
+package parser_test
+
+import (
+	"testing"
+
+	"github.com/Flying-Bird1999/analyzer-ts/analyzer/parser"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSourceLocationStart 固定 SourceLocation 的起始位置语义：普通文件中起始位置包含节点前导的空白与注释，
+// 组件文件与文档中被替换为空白的区域会成为前导空白，因此这类文件的起始位置跳过前导内容，指向节点的第一个 token。
+func TestSourceLocationStart(t *testing.T) {
+	script := `import { a } from './a';
+
+// 导出说明
+export { a };
+`
+	type location struct {
+		Line   int
+		Column int
+	}
+	starts := func(filePath string, code string) []location {
+		p, err := parser.NewParserFromSource(filePath, code)
+		assert.NoError(t, err)
+		p.Traverse()
+		result := []location{}
+		for _, decl := range p.Result.ImportDeclarations {
+			result = append(result, location{decl.SourceLocation.Start.Line, decl.SourceLocation.Start.Column})
+		}
+		for _, decl := range p.Result.ExportDeclarations {
+			result = append(result, location{decl.SourceLocation.Start.Line, decl.SourceLocation.Start.Column})
+		}
+		return result
+	}
+
+	// 普通文件：导出声明从上一条语句的行尾开始
+	assert.Equal(t, []location{{1, 1}, {1, 25}}, starts("/src/test.ts", script))
+
+	// 组件文件：模板之后的第一条语句与其后的语句都从第一个 token 开始
+	assert.Equal(t, []location{{5, 1}, {8, 1}}, starts("/src/Test.vue", "<template>\n  <div />\n</template>\n<script lang=\"ts\">\n"+script+"</script>\n"))
+}
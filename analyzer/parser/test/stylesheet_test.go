@@ -0,0 +1,137 @@
+package parser_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Flying-Bird1999/analyzer-ts/analyzer/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+// styleSummary 只保留样式文件解析结果中便于断言的字段
+type styleSummary struct {
+	Imports          []string          `json:"imports"`
+	ClassNames       []string          `json:"classNames"`
+	CustomProperties map[string]string `json:"customProperties"`
+}
+
+func summarizeStylesheet(result *parser.StylesheetResult) styleSummary {
+	summary := styleSummary{Imports: []string{}, ClassNames: []string{}, CustomProperties: map[string]string{}}
+	for _, decl := range result.ImportDeclarations {
+		summary.Imports = append(summary.Imports, decl.Source)
+	}
+	for _, class := range result.ClassNames {
+		summary.ClassNames = append(summary.ClassNames, class.Name)
+	}
+	for _, property := range result.CustomProperties {
+		summary.CustomProperties[property.Name] = property.Value
+	}
+	return summary
+}
+
+func TestParseStylesheet(t *testing.T) {
+	testCases := []struct {
+		name         string
+		filePath     string
+		code         string
+		expectedJSON string
+	}{
+		{
+			name:     "CSS 导入、类名与自定义属性",
+			filePath: "/src/index.css",
+			code: `@import url("./reset.css");
+@import 'https://fonts.googleapis.com/css?family=Roboto';
+/* .commented { color: red; } */
+:root { --primary-color: #1677ff; --gap: 8px; }
+.btn, .btn.active > .icon { content: ".not-a-class"; background: url(./a.png); }
+[data-type=".attr"] .title:hover {}
+:global(.ant-btn) .local {}
+@media (max-width: 600px) { .mobile { color: red; } }`,
+			expectedJSON: `{
+				"imports": ["./reset.css"],
+				"classNames": ["btn", "active", "icon", "title", "local", "mobile"],
+				"customProperties": {"--primary-color": "#1677ff", "--gap": "8px"}
+			}`,
+		},
+		{
+			name:     "SCSS 嵌套、父选择器后缀与 partial 导入",
+			filePath: "/src/card.module.scss",
+			code: `@use 'vars' as v;
+@forward "mixins";
+// .line-comment {}
+.card {
+  color: v.$text; // 行尾注释
+  &-header { .title {} }
+  &__body { &--large {} }
+  &:hover {}
+  .#{$prefix}-skip {}
+}`,
+			expectedJSON: `{
+				"imports": ["vars", "mixins"],
+				"classNames": ["card", "card-header", "title", "card__body", "card__body--large"],
+				"customProperties": {}
+			}`,
+		},
+		{
+			name:     "LESS mixin 调用不是类名声明",
+			filePath: "/src/index.module.less",
+			code: `@import (reference) '~antd/lib/style/themes/default.less';
+.mixin() { color: red; }
+.wrapper { .mixin(); &-inner { --inner-gap: @gap; } }`,
+			expectedJSON: `{
+				"imports": ["~antd/lib/style/themes/default.less"],
+				"classNames": ["wrapper", "wrapper-inner"],
+				"customProperties": {"--inner-gap": "@gap"}
+			}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := parser.ParseStylesheet(tc.filePath, tc.code)
+			resultJSON, err := json.Marshal(summarizeStylesheet(result))
+			assert.NoError(t, err)
+			assert.JSONEq(t, tc.expectedJSON, string(resultJSON))
+		})
+	}
+}
+
+func TestCssModuleReferences(t *testing.T) {
+	code := `import styles from './index.module.less';
+import * as card from './card.module.scss';
+import { active } from './button.module.css';
+import other from './other.less';
+const { header, 'footer-bar': footer } = styles;
+export const classNames = (name: string) => [
+	styles.wrapper,
+	card['card-title'],
+	styles[name],
+	other.ignored,
+];`
+
+	expectedJSON := `[
+		{"source": "./button.module.css", "identifier": "active", "className": "active"},
+		{"source": "./index.module.less", "identifier": "styles", "className": "header"},
+		{"source": "./index.module.less", "identifier": "styles", "className": "footer-bar"},
+		{"source": "./index.module.less", "identifier": "styles", "className": "wrapper"},
+		{"source": "./card.module.scss", "identifier": "card", "className": "card-title"},
+		{"source": "./index.module.less", "identifier": "styles", "className": "", "isDynamic": true}
+	]`
+
+	// extractFn 去掉位置信息与原始文本，只比较引用本身
+	extractFn := func(result *parser.ParserResult) []parser.CssModuleReference {
+		out := []parser.CssModuleReference{}
+		for _, ref := range result.CssModuleReferences {
+			ref.Raw = ""
+			ref.SourceLocation = nil
+			out = append(out, ref)
+		}
+		return out
+	}
+
+	marshalFn := func(result []parser.CssModuleReference) ([]byte, error) {
+		return json.MarshalIndent(result, "", "\t")
+	}
+
+	RunTest(t, code, expectedJSON, extractFn, marshalFn)
+}
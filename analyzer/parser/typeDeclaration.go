@@ -12,6 +12,7 @@ import (
 type TypeDeclarationResult struct {
 	Identifier     string                   `json:"identifier"`               // 类型别名的名称。
 	Exported       bool                     `json:"exported"`                 // 新增：标记此类型别名是否被导出。
+	Namespace      string                   `json:"namespace,omitempty"`      // 所属的命名空间或环境模块，顶层声明为空。规则见 EnclosingNamespace。
 	Raw            string                   `json:"raw,omitempty"`            // 节点在源码中的原始文本。
 	Reference      map[string]TypeReference `json:"reference,omitempty"`      // 该类型别名所依赖的其他类型的映射。
 	SourceLocation *SourceLocation           `json:"sourceLocation,omitempty"` // 节点在源码中的位置信息。
@@ -81,5 +82,6 @@ func AnalyzeTypeAliasDeclaration(node *ast.TypeAliasDeclaration, sourceCode stri
 // VisitTypeAliasDeclaration 解析 `type` 别名声明。
 func (p *Parser) VisitTypeAliasDeclaration(node *ast.TypeAliasDeclaration) {
 	result := AnalyzeTypeAliasDeclaration(node, p.SourceCode)
-	p.Result.TypeDeclarations[result.Identifier] = *result
+	result.Namespace = EnclosingNamespace(node.AsNode())
+	p.Result.TypeDeclarations[QualifiedName(result.Namespace, result.Identifier)] = *result
 }
\ No newline at end of file
@@ -62,6 +62,9 @@ type VariableDeclaration struct {
 	// 例如 `const { name } = user` 中，Source 代表 `user`。
 	Source *VariableValue `json:"source,omitempty"`
 
+	// Namespace 是该声明所属的命名空间或环境模块，顶层声明为空。规则见 EnclosingNamespace。
+	Namespace string `json:"namespace,omitempty"`
+
 	// Declarators 包含此语句中所有的变量声明器。
 	Declarators []*VariableDeclarator `json:"declarators"`
 
@@ -199,7 +202,7 @@ func (p *Parser) VisitVariableStatement(node *ast.VariableStatement) {
 		}
 	}
 
-	// 遍历所有声明，检查是否是函数赋值或动态导入
+	// 遍历所有声明，检查是否是函数赋值、动态导入或 CommonJS 的 require()
 	for _, decl := range node.DeclarationList.AsVariableDeclarationList().Declarations.Nodes {
 		variableDecl := decl.AsVariableDeclaration()
 		if p.analyzeFunctionAssignment(variableDecl, isExported) {
@@ -208,10 +211,17 @@ func (p *Parser) VisitVariableStatement(node *ast.VariableStatement) {
 		if p.analyzeDynamicImportAssignment(variableDecl) {
 			continue
 		}
+		if p.analyzeRequireAssignment(variableDecl) {
+			continue
+		}
 	}
 
 	// 将常规变量声明添加到结果中
 	decls := ExtractVariableDeclarations(node, p.SourceCode)
+	namespace := EnclosingNamespace(node.AsNode())
+	for i := range decls {
+		decls[i].Namespace = namespace
+	}
 	p.Result.VariableDeclarations = append(p.Result.VariableDeclarations, decls...)
 }
 
@@ -227,6 +237,7 @@ func (p *Parser) analyzeFunctionAssignment(variableDecl *ast.VariableDeclaration
 
 		if initKind == ast.KindArrowFunction || initKind == ast.KindFunctionExpression {
 			fr := NewFunctionDeclarationResultFromExpression(identifier, isExported, initializerNode, p.SourceCode)
+			fr.Namespace = EnclosingNamespace(variableDecl.AsNode())
 			p.Result.FunctionDeclarations = append(p.Result.FunctionDeclarations, *fr)
 			return true
 		}
@@ -242,7 +253,7 @@ func (p *Parser) analyzeDynamicImportAssignment(variableDecl *ast.VariableDeclar
 
 	if ast.IsIdentifier(nameNode) && initializerNode != nil {
 		identifier := nameNode.AsIdentifier().Text
-		importCallNode, importPath := p.findDynamicImport(initializerNode)
+		importCallNode, importPath, pattern := p.findDynamicImport(initializerNode)
 
 		if importCallNode != nil && importPath != "" {
 			importResult := &ImportDeclarationResult{
@@ -254,7 +265,10 @@ func (p *Parser) analyzeDynamicImportAssignment(variableDecl *ast.VariableDeclar
 						Type:         "dynamic_variable",
 					},
 				},
-				Raw: utils.GetNodeText(importCallNode, p.SourceCode),
+				IsDynamic:      true,
+				Pattern:        pattern,
+				Raw:            utils.GetNodeText(importCallNode, p.SourceCode),
+				SourceLocation: NewSourceLocation(importCallNode, p.SourceCode),
 			}
 			p.Result.ImportDeclarations = append(p.Result.ImportDeclarations, *importResult)
 			p.ProcessedDynamicImports[importCallNode] = true
@@ -266,10 +280,10 @@ func (p *Parser) analyzeDynamicImportAssignment(variableDecl *ast.VariableDeclar
 
 // findDynamicImport 递归地在给定的 AST 节点中查找第一个 `import()` 调用。
 // 它会深入常见的包装函数（如 `lazy`, `() => ...`）内部进行查找。
-// 返回找到的 `import()` 对应的 ast.Node 和导入的路径字符串。
-func (p *Parser) findDynamicImport(node *ast.Node) (*ast.Node, string) {
+// 返回找到的 `import()` 对应的 ast.Node、导入的路径字符串，以及路径带插值时的 glob 模式（见 dynamicImportSpecifier）。
+func (p *Parser) findDynamicImport(node *ast.Node) (*ast.Node, string, string) {
 	if node == nil {
-		return nil, ""
+		return nil, "", ""
 	}
 	// 基本情况：当前节点就是 `import()` 调用
 
@@ -277,11 +291,8 @@ func (p *Parser) findDynamicImport(node *ast.Node) (*ast.Node, string) {
 		callExpr := node.AsCallExpression()
 		if callExpr.Expression.Kind == ast.KindImportKeyword {
 			if len(callExpr.Arguments.Nodes) > 0 {
-				arg := callExpr.Arguments.Nodes[0]
-				if arg.Kind == ast.KindStringLiteral {
-					return node, arg.AsStringLiteral().Text
-				} else if arg.Kind == ast.KindIdentifier {
-					return node, arg.AsIdentifier().Text
+				if importPath, pattern, ok := dynamicImportSpecifier(callExpr.Arguments.Nodes[0]); ok {
+					return node, importPath, pattern
 				}
 			}
 		}
@@ -289,17 +300,17 @@ func (p *Parser) findDynamicImport(node *ast.Node) (*ast.Node, string) {
 
 	// 递归情况：遍历子节点查找。
 	var foundNode *ast.Node
-	var foundPath string
+	var foundPath, foundPattern string
 	node.ForEachChild(func(child *ast.Node) bool {
 		// 如果已经找到了，就停止遍历，防止找到更深层的无关 `import`。
 		if foundNode != nil {
 			return true // stop traversal
 		}
-		foundNode, foundPath = p.findDynamicImport(child)
+		foundNode, foundPath, foundPattern = p.findDynamicImport(child)
 		return foundNode != nil // 如果在子节点中找到了，返回 true 停止遍历
 	})
 
-	return foundNode, foundPath
+	return foundNode, foundPath, foundPattern
 }
 
 // analyzeBindingPattern 解析解构模式。
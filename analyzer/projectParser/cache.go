@@ -0,0 +1,345 @@
+package projectParser
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+
+	"github.com/Flying-Bird1999/analyzer-ts/analyzer/parser"
+	"github.com/Flying-Bird1999/analyzer-ts/analyzer/scanProject"
+	"github.com/samber/lo"
+)
+
+// ToolVersion 是当前工具的版本号，参与解析缓存指纹的计算。
+// 由命令行入口在初始化时注入，版本变化后旧缓存会整体失效。
+var ToolVersion = "dev"
+
+// parseCacheFormatVersion 是缓存文件格式的版本号，缓存结构发生不兼容变更时需要递增。
+const parseCacheFormatVersion = 16
+
+// ParseCache 是基于文件内容哈希的持久化解析缓存。
+//
+// 每个 JS/TS 文件的 JsFileParserResult 会连同其内容哈希、路径解析输入（别名、baseUrl）以及解析时发起的
+// 模块路径解析请求一起序列化到缓存目录中。再次解析时，内容与解析输入都未变化、且重新解析这些请求得到相同结果的文件
+// 会直接从缓存恢复，跳过 AST 解析。因此新增、删除文件或重新安装 npm 包只会使解析结果受影响的条目失效。
+// 注意：AST 与各结果中的 Node 指针不会被缓存，从缓存恢复的结果中它们为 nil。
+//
+// 目录结构：
+//
+//	<dir>/meta.json          缓存指纹，指纹不一致时整个缓存失效
+//	<dir>/entries/<hash>.json 单个文件的缓存条目
+type ParseCache struct {
+	dir    string
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+// parseCacheMeta 记录了缓存目录对应的全局指纹。
+type parseCacheMeta struct {
+	Fingerprint string `json:"fingerprint"`
+}
+
+// cacheResolverInputs 是影响导入路径解析结果的输入，任一项变化都会导致单个缓存条目失效。
+type cacheResolverInputs struct {
+	Alias       map[string]string   `json:"alias"`
+	Paths       map[string][]string `json:"paths"`
+	TsConfigDir string              `json:"tsconfigDir"`
+	BaseUrl     string              `json:"baseUrl"`
+	// Resolution 影响 npm 包与 `#` 导入的解析结果
+	Resolution ModuleResolutionOptions `json:"resolution"`
+}
+
+// cacheModuleRequest 是解析文件时发起的一次模块路径解析（导入、导出、`declare module` 与 JSX 组件的来源）。
+// Pattern 不为空时表示按 ExpandImportPattern 展开的动态导入 glob 模式。
+type cacheModuleRequest struct {
+	Specifier    string `json:"specifier,omitempty"`
+	ModuleSystem string `json:"moduleSystem,omitempty"`
+	Pattern      string `json:"pattern,omitempty"`
+	Filter       string `json:"filter,omitempty"`
+}
+
+// parseCacheEntry 是单个文件的缓存条目。
+type parseCacheEntry struct {
+	Path        string              `json:"path"`
+	ContentHash string              `json:"contentHash"`
+	Resolver    cacheResolverInputs `json:"resolver"`
+	// Modules 与 ModulesHash 记录解析请求及其解析结果的摘要，命中时重新解析并比较摘要，
+	// 以发现导入目标被删除、新增文件改变了解析结果、node_modules 中 package.json 的 exports/main 变化等情况。
+	Modules     []cacheModuleRequest `json:"modules,omitempty"`
+	ModulesHash string               `json:"modulesHash"`
+	Result      JsFileParserResult   `json:"result"`
+	// Raw 是与文件内容不同的原始源码（如 .vue、.svelte、.md 文件中提取出的脚本），为 nil 时原始源码即文件内容。
+	// JsFileParserResult.Raw 不参与序列化，因此单独保存。
+	Raw *string `json:"raw,omitempty"`
+	// Errors 单独以字符串形式保存，因为 error 接口无法直接反序列化。
+	Errors []string `json:"errors,omitempty"`
+}
+
+// NewParseCache 打开（必要时创建）位于 dir 的解析缓存。
+// 如果缓存目录中记录的指纹与 fingerprint 不一致，已有的缓存条目会被全部清除。
+func NewParseCache(dir string, fingerprint string) (*ParseCache, error) {
+	entriesDir := filepath.Join(dir, "entries")
+	metaPath := filepath.Join(dir, "meta.json")
+
+	var meta parseCacheMeta
+	if data, err := os.ReadFile(metaPath); err == nil {
+		_ = json.Unmarshal(data, &meta)
+	}
+
+	if meta.Fingerprint != fingerprint {
+		if err := os.RemoveAll(entriesDir); err != nil {
+			return nil, fmt.Errorf("清理过期的解析缓存失败: %w", err)
+		}
+	}
+	if err := os.MkdirAll(entriesDir, 0755); err != nil {
+		return nil, fmt.Errorf("创建解析缓存目录失败: %w", err)
+	}
+	if meta.Fingerprint != fingerprint {
+		data, _ := json.Marshal(parseCacheMeta{Fingerprint: fingerprint})
+		if err := writeFileAtomic(metaPath, data); err != nil {
+			return nil, fmt.Errorf("写入解析缓存元数据失败: %w", err)
+		}
+	}
+
+	return &ParseCache{dir: dir}, nil
+}
+
+// Stats 返回本次运行中缓存的命中与未命中次数。
+func (c *ParseCache) Stats() (hits int64, misses int64) {
+	return c.hits.Load(), c.misses.Load()
+}
+
+// Load 尝试从缓存中恢复指定文件的解析结果。只有内容哈希与解析输入都一致，
+// 且 resolveModules 重新解析条目中记录的请求得到相同的摘要时才算命中。可以被并发调用。
+func (c *ParseCache) Load(targetPath string, content string, resolver cacheResolverInputs, resolveModules func([]cacheModuleRequest) string) (*JsFileParserResult, bool) {
+	data, err := os.ReadFile(c.entryPath(targetPath))
+	if err != nil {
+		c.misses.Add(1)
+		return nil, false
+	}
+
+	var entry parseCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil ||
+		entry.Path != targetPath ||
+		entry.ContentHash != hashString(content) ||
+		!sameResolverInputs(entry.Resolver, resolver) ||
+		resolveModules(entry.Modules) != entry.ModulesHash {
+		c.misses.Add(1)
+		return nil, false
+	}
+
+	result := entry.Result
+	result.Raw = content
+	if entry.Raw != nil {
+		result.Raw = *entry.Raw
+	}
+	for _, msg := range entry.Errors {
+		result.Errors = append(result.Errors, errors.New(msg))
+	}
+	c.hits.Add(1)
+	return &result, true
+}
+
+// Store 将指定文件的解析结果写入缓存，modulesHash 是 modules 当前解析结果的摘要。
+// 写入失败不会影响解析流程，只返回错误供调用方记录，可以被并发调用。
+func (c *ParseCache) Store(targetPath string, content string, resolver cacheResolverInputs, modules []cacheModuleRequest, modulesHash string, result *JsFileParserResult) error {
+	entry := parseCacheEntry{
+		Path:        targetPath,
+		ContentHash: hashString(content),
+		Resolver:    resolver,
+		Modules:     modules,
+		ModulesHash: modulesHash,
+		Result:      *result,
+	}
+	if result.Raw != content {
+		raw := result.Raw
+		entry.Raw = &raw
+	}
+	entry.Result.Errors = nil
+	for _, err := range result.Errors {
+		entry.Errors = append(entry.Errors, err.Error())
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("序列化解析缓存失败: %w", err)
+	}
+	if err := writeFileAtomic(c.entryPath(targetPath), data); err != nil {
+		return fmt.Errorf("写入解析缓存失败: %w", err)
+	}
+	return nil
+}
+
+// entryPath 返回指定源文件对应的缓存条目路径。
+func (c *ParseCache) entryPath(targetPath string) string {
+	return filepath.Join(c.dir, "entries", hashString(targetPath)+".json")
+}
+
+// sameResolverInputs 判断两组解析输入是否一致。
+// 通过比较 JSON 序列化结果，避免 nil map 与空 map 等表示差异带来的误判。
+func sameResolverInputs(a, b cacheResolverInputs) bool {
+	aData, _ := json.Marshal(a)
+	bData, _ := json.Marshal(b)
+	return string(aData) == string(bData)
+}
+
+// writeFileAtomic 先写入临时文件再重命名，避免并发读取到写了一半的文件。
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// hashString 返回字符串的 sha256 十六进制摘要。
+func hashString(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+// cacheDir 返回解析缓存目录的绝对路径，相对路径以项目根目录为基准。
+func (ppr *ProjectParserResult) cacheDir() string {
+	if filepath.IsAbs(ppr.Config.CacheDir) {
+		return ppr.Config.CacheDir
+	}
+	return filepath.Join(ppr.Config.RootPath, ppr.Config.CacheDir)
+}
+
+// cacheFingerprint 计算整个项目的缓存指纹。
+// 指纹只覆盖影响所有文件的输入：工具版本、缓存格式、所有 tsconfig.json / package.json 的内容、解析后的别名配置、
+// workspace 包与扩展名配置。文件增删与 node_modules 的变化只影响部分文件的解析结果，由 Load 按条目校验；
+// 解析选项在恢复结果之后才应用，不影响缓存内容。
+func (ppr *ProjectParserResult) cacheFingerprint(paths []string, fileList map[string]scanProject.FileItem) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "format:%d\nversion:%s\n", parseCacheFormatVersion, ToolVersion)
+
+	rootTsConfig, _ := json.Marshal(ppr.Config.RootTsConfig)
+	packageTsConfigs, _ := json.Marshal(ppr.Config.PackageTsConfigMaps)
+	workspaces, _ := json.Marshal(ppr.Config.WorkspacePackages)
+	fmt.Fprintf(h, "tsconfig:%s\npackages:%s\nworkspaces:%s\n", rootTsConfig, packageTsConfigs, workspaces)
+	fmt.Fprintf(h, "extensions:%s\ntargets:%s\n", strings.Join(ppr.Config.Extensions, ","), strings.Join(ppr.Config.TargetExtensions, ","))
+
+	// paths 由调用方排序，保证指纹稳定
+	for _, targetPath := range paths {
+		fileName := fileList[targetPath].FileName
+		if fileName != "package.json" && !(strings.HasPrefix(fileName, "tsconfig") && strings.HasSuffix(fileName, ".json")) {
+			continue
+		}
+		content, err := os.ReadFile(targetPath)
+		if err != nil {
+			continue
+		}
+		fmt.Fprintf(h, "config:%s:%s\n", targetPath, hashString(string(content)))
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// loadOrBuildJsFileResult 优先从解析缓存中恢复文件的解析结果，未命中时重新解析并写回缓存。
+// 缓存中不保存 AST，因此 RetainAST 为 true 时不读取缓存，总是重新解析，但仍写回缓存供之后不需要 AST 的解析使用。
+// 解析与缓存过程中遇到的问题记录到 file 的诊断信息中。
+func (ppr *ProjectParserResult) loadOrBuildJsFileResult(file *parsedFile, content string) *JsFileParserResult {
+	targetPath := file.path
+	if ppr.cache == nil {
+		result, _ := ppr.buildJsFileResultWithDiagnostics(file, content)
+		return result
+	}
+
+	alias, tsconfigDir, baseUrl := ppr.getTsConfigForFile(targetPath)
+	resolver := cacheResolverInputs{
+		Alias:       alias,
+		Paths:       ppr.getTsConfigPaths(tsconfigDir),
+		TsConfigDir: tsconfigDir,
+		BaseUrl:     baseUrl,
+		Resolution:  ppr.getModuleResolutionOptions(tsconfigDir),
+	}
+	resolveModules := func(modules []cacheModuleRequest) string {
+		return ppr.resolveModuleRequests(targetPath, modules)
+	}
+
+	if !ppr.Config.ParseOptions.RetainAST {
+		if result, ok := ppr.cache.Load(targetPath, content, resolver, resolveModules); ok {
+			file.diagnostics = append(file.diagnostics, jsResultDiagnostics(targetPath, result)...)
+			return result
+		}
+	}
+
+	result, modules := ppr.buildJsFileResultWithDiagnostics(file, content)
+	if result != nil {
+		if err := ppr.cache.Store(targetPath, content, resolver, modules, resolveModules(modules), result); err != nil {
+			file.addDiagnostic(DiagnosticPhaseCache, DiagnosticSeverityWarning, err)
+		}
+	}
+	return result
+}
+
+// buildJsFileResultWithDiagnostics 解析单个 JS/TS 文件，并将解析失败或解析结果中的错误记录到 file 的诊断信息中。
+// 同时返回解析过程中发起的模块路径解析请求。
+func (ppr *ProjectParserResult) buildJsFileResultWithDiagnostics(file *parsedFile, content string) (*JsFileParserResult, []cacheModuleRequest) {
+	result, modules, err := ppr.buildJsFileResult(file.path, content)
+	if err != nil {
+		file.addDiagnostic(DiagnosticPhaseParse, DiagnosticSeverityError, err)
+		return nil, nil
+	}
+	file.diagnostics = append(file.diagnostics, jsResultDiagnostics(file.path, result)...)
+	return result, modules
+}
+
+// moduleRequests 列出 BuildJsFileResult 转换 result 时发起的所有模块路径解析请求（已去重）。
+func moduleRequests(result parser.ParserResult) []cacheModuleRequest {
+	var requests []cacheModuleRequest
+	for _, decl := range result.ImportDeclarations {
+		if decl.Pattern != "" {
+			requests = append(requests, cacheModuleRequest{Pattern: decl.Pattern, Filter: decl.PatternFilter})
+			continue
+		}
+		requests = append(requests, cacheModuleRequest{Specifier: decl.Source, ModuleSystem: decl.ModuleSystem})
+	}
+	for _, decl := range result.ExportDeclarations {
+		if decl.Source != "" {
+			requests = append(requests, cacheModuleRequest{Specifier: decl.Source, ModuleSystem: decl.ModuleSystem})
+		}
+	}
+	for _, decl := range result.ModuleDeclarations {
+		if decl.Kind == parser.ModuleKindModule && !strings.Contains(decl.Name, "*") {
+			requests = append(requests, cacheModuleRequest{Specifier: decl.Name})
+		}
+	}
+	for _, element := range result.JsxElements {
+		if componentName := customComponentName(element); componentName != "" {
+			requests = append(requests, cacheModuleRequest{Specifier: componentName})
+		}
+	}
+	return lo.Uniq(requests)
+}
+
+// resolveModuleRequests 按当前的文件系统与配置重新解析 importerPath 发起的模块路径解析请求，返回解析结果的摘要。
+func (ppr *ProjectParserResult) resolveModuleRequests(importerPath string, requests []cacheModuleRequest) string {
+	alias, tsconfigDir, baseUrl := ppr.getTsConfigForFile(importerPath)
+	sources := make([]SourceData, 0, len(requests))
+	for _, request := range requests {
+		if request.Pattern != "" {
+			sources = append(sources, ppr.ExpandImportPattern(importerPath, request.Pattern, request.Filter, alias, tsconfigDir, baseUrl)...)
+			continue
+		}
+		sources = append(sources, ppr.matchModuleSource(importerPath, request.Specifier, request.ModuleSystem, alias, tsconfigDir, baseUrl))
+	}
+	data, _ := json.Marshal(sources)
+	return hashString(string(data))
+}
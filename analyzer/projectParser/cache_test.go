@@ -0,0 +1,191 @@
+package projectParser
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// parseWithCache 使用指定的缓存目录解析测试项目，返回解析结果及本次的缓存命中/未命中次数。
+// 缓存只在不保留 AST 时读取，因此这里只保留原始源码。
+func parseWithCache(t *testing.T, rootPath string, cacheDir string) (*ProjectParserResult, int64, int64) {
+	t.Helper()
+	return parseWithCacheOptions(t, rootPath, cacheDir, ParseOptions{RetainRaw: true})
+}
+
+// parseWithCacheOptions 与 parseWithCache 相同，但使用指定的解析选项。
+func parseWithCacheOptions(t *testing.T, rootPath string, cacheDir string, options ParseOptions) (*ProjectParserResult, int64, int64) {
+	t.Helper()
+	config := NewProjectParserConfig(rootPath, nil, true, []string{})
+	config.CacheDir = cacheDir
+	config.ParseOptions = options
+	ppr := NewProjectParserResult(config)
+	ppr.ProjectParser()
+	if ppr.cache == nil {
+		t.Fatalf("解析缓存未启用")
+	}
+	hits, misses := ppr.cache.Stats()
+	return ppr, hits, misses
+}
+
+// TestParseCache 测试解析缓存的命中与失效逻辑。
+func TestParseCache(t *testing.T) {
+	rootPath, cleanup := setupTestProject(t)
+	defer cleanup()
+	cacheDir := t.TempDir()
+
+	// 第一次解析：全部未命中
+	first, hits, misses := parseWithCache(t, rootPath, cacheDir)
+	if hits != 0 || misses != 3 {
+		t.Fatalf("首次解析预期 0 次命中 3 次未命中, 得到 %d/%d", hits, misses)
+	}
+
+	// 第二次解析：全部命中，且结果与首次解析一致
+	second, hits, misses := parseWithCache(t, rootPath, cacheDir)
+	if hits != 3 || misses != 0 {
+		t.Fatalf("再次解析预期 3 次命中 0 次未命中, 得到 %d/%d", hits, misses)
+	}
+	firstJson, _ := json.Marshal(first.Js_Data)
+	secondJson, _ := json.Marshal(second.Js_Data)
+	if string(firstJson) != string(secondJson) {
+		t.Errorf("从缓存恢复的结果与首次解析不一致:\n%s\n%s", firstJson, secondJson)
+	}
+	mainPath := filepath.Join(rootPath, "src", "main.ts")
+	if second.Js_Data[mainPath].Raw == "" {
+		t.Errorf("从缓存恢复的结果应包含原始源码")
+	}
+
+	// 修改单个文件：只有该文件未命中
+	if err := os.WriteFile(mainPath, []byte(`import App from "@/App"; export const a = 1;`), 0644); err != nil {
+		t.Fatalf("修改 main.ts 失败: %v", err)
+	}
+	_, hits, misses = parseWithCache(t, rootPath, cacheDir)
+	if hits != 2 || misses != 1 {
+		t.Errorf("修改单个文件后预期 2 次命中 1 次未命中, 得到 %d/%d", hits, misses)
+	}
+
+	// 修改 tsconfig.json：整个缓存失效
+	tsconfig := `{"compilerOptions": {"baseUrl": ".", "paths": {"~/*": ["src/*"]}}}`
+	if err := os.WriteFile(filepath.Join(rootPath, "tsconfig.json"), []byte(tsconfig), 0644); err != nil {
+		t.Fatalf("修改 tsconfig.json 失败: %v", err)
+	}
+	_, hits, misses = parseWithCache(t, rootPath, cacheDir)
+	if hits != 0 || misses != 3 {
+		t.Errorf("修改 tsconfig.json 后预期 0 次命中 3 次未命中, 得到 %d/%d", hits, misses)
+	}
+
+	// 工具版本变化：整个缓存失效
+	originalVersion := ToolVersion
+	ToolVersion = originalVersion + "-next"
+	defer func() { ToolVersion = originalVersion }()
+	_, hits, misses = parseWithCache(t, rootPath, cacheDir)
+	if hits != 0 || misses != 3 {
+		t.Errorf("工具版本变化后预期 0 次命中 3 次未命中, 得到 %d/%d", hits, misses)
+	}
+}
+
+// TestParseCacheRetainAST 测试保留 AST 时不从缓存恢复结果：缓存中的结果没有 AST 与 Node 指针。
+func TestParseCacheRetainAST(t *testing.T) {
+	rootPath, cleanup := setupTestProject(t)
+	defer cleanup()
+	cacheDir := t.TempDir()
+
+	// 先写入缓存
+	if _, hits, misses := parseWithCache(t, rootPath, cacheDir); hits != 0 || misses != 3 {
+		t.Fatalf("首次解析预期 0 次命中 3 次未命中, 得到 %d/%d", hits, misses)
+	}
+
+	// 保留 AST 时不读取缓存，每个文件都重新解析
+	result, hits, _ := parseWithCacheOptions(t, rootPath, cacheDir, DefaultParseOptions())
+	if hits != 0 {
+		t.Errorf("保留 AST 时不应命中缓存, 得到 %d 次命中", hits)
+	}
+	mainPath := filepath.Join(rootPath, "src", "main.ts")
+	main := result.Js_Data[mainPath]
+	if main.Ast == nil {
+		t.Fatalf("保留 AST 时结果应包含 AST")
+	}
+	for _, decl := range main.ImportDeclarations {
+		if decl.Node == nil {
+			t.Errorf("保留 AST 时导入声明应包含 Node 指针: %s", decl.Raw)
+		}
+	}
+
+	// 保留 AST 的解析同样写入缓存，之后不需要 AST 的解析仍然命中
+	if _, hits, misses := parseWithCache(t, rootPath, cacheDir); hits != 3 || misses != 0 {
+		t.Errorf("再次解析预期 3 次命中 0 次未命中, 得到 %d/%d", hits, misses)
+	}
+}
+
+// TestParseCacheValidatesModules 测试缓存条目按各自的模块解析结果失效：
+// 新增无关文件不影响命中，删除导入目标或修改 node_modules 中的 package.json 只使受影响的文件失效。
+func TestParseCacheValidatesModules(t *testing.T) {
+	rootPath := t.TempDir()
+	cacheDir := t.TempDir()
+	files := map[string]string{
+		"src/a.ts":                      `import { b } from "./b"; import pkg from "pkg"; export const a = b + pkg;`,
+		"src/b.ts":                      `export const b = 1;`,
+		"src/c.ts":                      `export const c = 1;`,
+		"src/View.vue":                  "<template><div /></template>\n<script setup lang=\"ts\">\nimport { c } from './c'\n</script>\n",
+		"node_modules/pkg/package.json": `{"name": "pkg", "main": "index.js"}`,
+		"node_modules/pkg/index.js":     `module.exports = 1;`,
+		"node_modules/pkg/next.js":      `module.exports = 2;`,
+	}
+	for rel, content := range files {
+		writeCacheTestFile(t, filepath.Join(rootPath, rel), content)
+	}
+
+	first, hits, misses := parseWithCache(t, rootPath, cacheDir)
+	if hits != 0 || misses != 4 {
+		t.Fatalf("首次解析预期 0 次命中 4 次未命中, 得到 %d/%d", hits, misses)
+	}
+
+	// 从缓存恢复的组件文件与首次解析一样只包含提取出的脚本
+	second, hits, misses := parseWithCache(t, rootPath, cacheDir)
+	if hits != 4 || misses != 0 {
+		t.Fatalf("再次解析预期 4 次命中 0 次未命中, 得到 %d/%d", hits, misses)
+	}
+	viewPath := filepath.Join(rootPath, "src", "View.vue")
+	if first.Js_Data[viewPath].Raw != second.Js_Data[viewPath].Raw {
+		t.Errorf("从缓存恢复的 Raw 与首次解析不一致:\n%q\n%q", first.Js_Data[viewPath].Raw, second.Js_Data[viewPath].Raw)
+	}
+
+	// 新增无关文件：已有条目全部命中
+	writeCacheTestFile(t, filepath.Join(rootPath, "src", "d.ts"), `export const d = 1;`)
+	_, hits, misses = parseWithCache(t, rootPath, cacheDir)
+	if hits != 4 || misses != 1 {
+		t.Errorf("新增无关文件后预期 4 次命中 1 次未命中, 得到 %d/%d", hits, misses)
+	}
+
+	// 删除被导入的文件：只有导入它的 a.ts 未命中
+	if err := os.Remove(filepath.Join(rootPath, "src", "b.ts")); err != nil {
+		t.Fatal(err)
+	}
+	_, hits, misses = parseWithCache(t, rootPath, cacheDir)
+	if hits != 3 || misses != 1 {
+		t.Errorf("删除导入目标后预期 3 次命中 1 次未命中, 得到 %d/%d", hits, misses)
+	}
+
+	// 修改 node_modules 中的 package.json：只有导入该包的 a.ts 未命中，且解析到新的入口
+	writeCacheTestFile(t, filepath.Join(rootPath, "node_modules", "pkg", "package.json"), `{"name": "pkg", "main": "next.js"}`)
+	third, hits, misses := parseWithCache(t, rootPath, cacheDir)
+	if hits != 3 || misses != 1 {
+		t.Errorf("修改 node_modules 中的 package.json 后预期 3 次命中 1 次未命中, 得到 %d/%d", hits, misses)
+	}
+	for _, decl := range third.Js_Data[filepath.Join(rootPath, "src", "a.ts")].ImportDeclarations {
+		if decl.Source.NpmPkg == "pkg" && filepath.Base(decl.Source.ResolvedPath) != "next.js" {
+			t.Errorf("pkg 应解析到新的入口 next.js, 得到 %s", decl.Source.ResolvedPath)
+		}
+	}
+}
+
+func writeCacheTestFile(t *testing.T, path string, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,94 @@
+package projectParser
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/Flying-Bird1999/analyzer-ts/analyzer/scanProject"
+)
+
+// 诊断信息产生的阶段
+const (
+	DiagnosticPhaseScan        = "scan"         // 遍历项目目录
+	DiagnosticPhaseTsConfig    = "tsconfig"     // 读取 tsconfig.json
+	DiagnosticPhasePackageJson = "package-json" // 读取 package.json
+	DiagnosticPhaseParse       = "parse"        // 读取并解析 JS/TS 文件
+	DiagnosticPhaseCache       = "cache"        // 读写解析缓存
+)
+
+// 诊断信息的严重程度
+const (
+	// DiagnosticSeverityError 表示文件被跳过或配置没有生效，解析结果缺失了这部分内容。
+	DiagnosticSeverityError = "error"
+	// DiagnosticSeverityWarning 表示文件已解析，但结果可能不完整，或者问题不影响解析结果（例如缓存写入失败）。
+	DiagnosticSeverityWarning = "warning"
+)
+
+// Diagnostic 是解析过程中遇到的一个问题。
+type Diagnostic struct {
+	// File 是出现问题的文件或目录的绝对路径，与具体文件无关时为空。
+	File string `json:"file,omitempty"`
+	// Phase 是问题产生的阶段，取值见 DiagnosticPhase* 常量。
+	Phase string `json:"phase"`
+	// Severity 是问题的严重程度，取值见 DiagnosticSeverity* 常量。
+	Severity string `json:"severity"`
+	// Message 是错误信息。
+	Message string `json:"message"`
+}
+
+// newDiagnostic 根据 error 创建一条诊断信息。
+func newDiagnostic(file string, phase string, severity string, err error) Diagnostic {
+	return Diagnostic{File: file, Phase: phase, Severity: severity, Message: err.Error()}
+}
+
+// ScanDiagnostics 将 scanProject 遍历目录时遇到的错误转换为诊断信息，按路径排序。
+func ScanDiagnostics(scanErrors []scanProject.ScanError) []Diagnostic {
+	diagnostics := make([]Diagnostic, 0, len(scanErrors))
+	for _, scanErr := range scanErrors {
+		diagnostics = append(diagnostics, Diagnostic{
+			File:     scanErr.Path,
+			Phase:    DiagnosticPhaseScan,
+			Severity: DiagnosticSeverityError,
+			Message:  scanErr.Message,
+		})
+	}
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		return diagnostics[i].File < diagnostics[j].File
+	})
+	return diagnostics
+}
+
+// ErrorDiagnostics 返回所有 error 级别的诊断信息。
+func (ppr *ProjectParserResult) ErrorDiagnostics() []Diagnostic {
+	var errs []Diagnostic
+	for _, d := range ppr.Diagnostics {
+		if d.Severity == DiagnosticSeverityError {
+			errs = append(errs, d)
+		}
+	}
+	return errs
+}
+
+// CheckStrict 用于严格模式：存在 error 级别的诊断信息时返回错误，错误信息中包含第一个问题的详情。
+func (ppr *ProjectParserResult) CheckStrict() error {
+	return CheckStrictDiagnostics(ppr.Diagnostics)
+}
+
+// CheckStrictDiagnostics 是 CheckStrict 的通用版本，适用于只持有诊断列表的调用方。
+func CheckStrictDiagnostics(diagnostics []Diagnostic) error {
+	var first *Diagnostic
+	count := 0
+	for i := range diagnostics {
+		if diagnostics[i].Severity != DiagnosticSeverityError {
+			continue
+		}
+		if first == nil {
+			first = &diagnostics[i]
+		}
+		count++
+	}
+	if count == 0 {
+		return nil
+	}
+	return fmt.Errorf("严格模式: 解析过程中出现 %d 个错误, 首个错误 [%s] %s: %s", count, first.Phase, first.File, first.Message)
+}
@@ -0,0 +1,49 @@
+package projectParser
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// TestProjectParserDiagnostics 测试无效的 tsconfig.json 与 package.json 被记录为诊断信息，而不是打印后丢弃。
+func TestProjectParserDiagnostics(t *testing.T) {
+	root := t.TempDir()
+	writeTestFiles(t, root, map[string]string{
+		"tsconfig.json":           `{"compilerOptions": {"baseUrl": ".",`,
+		"package.json":            `{"name": "root", "dependencies": {}}`,
+		"packages/a/package.json": `{"name": "a", "dependencies": [`,
+		"src/index.ts":            `export const a = 1;`,
+	})
+
+	ppr := NewProjectParserResult(NewProjectParserConfig(root, nil, false, nil))
+	ppr.ProjectParser()
+
+	found := make(map[string]Diagnostic)
+	for _, d := range ppr.Diagnostics {
+		found[d.Phase] = d
+	}
+	if d, ok := found[DiagnosticPhaseTsConfig]; !ok || d.File != filepath.Join(root, "tsconfig.json") || d.Severity != DiagnosticSeverityError {
+		t.Errorf("预期记录 tsconfig.json 的诊断信息, 得到 %+v", ppr.Diagnostics)
+	}
+	if d, ok := found[DiagnosticPhasePackageJson]; !ok || d.File != filepath.Join(root, "packages", "a", "package.json") || d.Message == "" {
+		t.Errorf("预期记录 package.json 的诊断信息, 得到 %+v", ppr.Diagnostics)
+	}
+	if _, ok := ppr.Js_Data[filepath.Join(root, "src", "index.ts")]; !ok {
+		t.Errorf("其他文件应继续被解析")
+	}
+	if err := ppr.CheckStrict(); err == nil {
+		t.Errorf("存在 error 级别的诊断信息时严格模式应返回错误")
+	}
+}
+
+// TestCheckStrictIgnoresWarnings 测试严格模式只因 error 级别的诊断信息失败。
+func TestCheckStrictIgnoresWarnings(t *testing.T) {
+	warnings := []Diagnostic{{File: "a.ts", Phase: DiagnosticPhaseParse, Severity: DiagnosticSeverityWarning, Message: "w"}}
+	if err := CheckStrictDiagnostics(warnings); err != nil {
+		t.Errorf("只有 warning 时不应返回错误: %v", err)
+	}
+	diagnostics := append(warnings, Diagnostic{File: "b.ts", Phase: DiagnosticPhaseScan, Severity: DiagnosticSeverityError, Message: "e"})
+	if err := CheckStrictDiagnostics(diagnostics); err == nil {
+		t.Errorf("存在 error 时应返回错误")
+	}
+}
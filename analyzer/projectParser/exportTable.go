@@ -0,0 +1,138 @@
+package projectParser
+
+import "github.com/Flying-Bird1999/analyzer-ts/analyzer/parser"
+
+// ExportEntry 是文件对外导出的一个符号。
+type ExportEntry struct {
+	// Name 是导出的外部名称，默认导出为 "default"，`export * from` 为 "*"。
+	Name string `json:"name"`
+	// LocalName 是符号在声明文件中的名称：本地导出为本地标识符，再导出为来源模块中的导出名。
+	LocalName string `json:"localName,omitempty"`
+	// Kind 是导出的来源："declaration"（export 修饰的声明）、"named"（`export { a }`）、
+	// "re-export"（`export { a } from`、`export * from`）或 "default"（`export default` / `module.exports =`）。
+	Kind string `json:"kind"`
+	// From 是再导出的来源文件的绝对路径，本地导出或来源不是项目内文件时为空。
+	From string `json:"from,omitempty"`
+	// IsTypeOnly 表示只导出了类型。
+	IsTypeOnly bool `json:"isTypeOnly,omitempty"`
+	// Line 是导出所在的行号（从 1 开始），没有位置信息时为 0。
+	Line int `json:"line,omitempty"`
+}
+
+// 导出表中导出的来源。
+const (
+	ExportKindDeclaration = "declaration"
+	ExportKindNamed       = "named"
+	ExportKindReExport    = "re-export"
+	ExportKindDefault     = "default"
+)
+
+// ExportTable 记录了每个文件对外导出的符号，key 是文件的绝对路径。
+type ExportTable map[string][]ExportEntry
+
+// BuildExportTable 根据解析结果构建导出表。
+// 命名空间与环境模块内部的导出属于命名空间本身，不计入文件的导出。
+func BuildExportTable(ppr *ProjectParserResult) ExportTable {
+	table := make(ExportTable)
+	if ppr == nil {
+		return table
+	}
+
+	for _, path := range sortedKeys(ppr.Js_Data) {
+		fileData := ppr.Js_Data[path]
+		var entries []ExportEntry
+
+		for _, decl := range fileData.ExportDeclarations {
+			kind, from := ExportKindNamed, ""
+			if decl.Source != nil {
+				kind = ExportKindReExport
+				if isProjectSource(*decl.Source) {
+					from = decl.Source.FilePath
+				}
+			}
+			for _, module := range decl.ExportModules {
+				localName := module.ModuleName
+				if localName == "" {
+					localName = module.Identifier
+				}
+				entries = append(entries, ExportEntry{
+					Name:       module.Identifier,
+					LocalName:  localName,
+					Kind:       kind,
+					From:       from,
+					IsTypeOnly: decl.IsTypeOnly || module.IsTypeOnly,
+					Line:       startLine(decl.SourceLocation),
+				})
+			}
+		}
+		for _, assign := range fileData.ExportAssignments {
+			entries = append(entries, ExportEntry{Name: "default", LocalName: assign.Name, Kind: ExportKindDefault, Line: startLine(assign.SourceLocation)})
+		}
+
+		for _, decl := range fileData.VariableDeclarations {
+			if !decl.Exported || decl.Namespace != "" {
+				continue
+			}
+			for _, declarator := range decl.Declarators {
+				entries = append(entries, declarationExport(declarator.Identifier, false, false, startLine(decl.SourceLocation)))
+			}
+		}
+		for _, decl := range fileData.FunctionDeclarations {
+			if decl.Exported && decl.Namespace == "" {
+				entries = append(entries, declarationExport(decl.Identifier, decl.IsDefaultExport, false, startLine(decl.SourceLocation)))
+			}
+		}
+		for _, decl := range fileData.ClassDeclarations {
+			if decl.Exported && decl.Namespace == "" {
+				entries = append(entries, declarationExport(decl.Identifier, decl.IsDefaultExport, false, startLine(decl.SourceLocation)))
+			}
+		}
+		for _, name := range sortedKeys(fileData.InterfaceDeclarations) {
+			if decl := fileData.InterfaceDeclarations[name]; decl.Exported && decl.Namespace == "" {
+				entries = append(entries, declarationExport(name, false, true, startLine(decl.SourceLocation)))
+			}
+		}
+		for _, name := range sortedKeys(fileData.TypeDeclarations) {
+			if decl := fileData.TypeDeclarations[name]; decl.Exported && decl.Namespace == "" {
+				entries = append(entries, declarationExport(name, false, true, startLine(decl.SourceLocation)))
+			}
+		}
+		for _, name := range sortedKeys(fileData.EnumDeclarations) {
+			if decl := fileData.EnumDeclarations[name]; decl.Exported && decl.Namespace == "" {
+				entries = append(entries, declarationExport(name, false, false, startLine(decl.SourceLocation)))
+			}
+		}
+
+		if len(entries) > 0 {
+			table[path] = entries
+		}
+	}
+	return table
+}
+
+// Lookup 返回文件中外部名称为 name 的导出。
+func (t ExportTable) Lookup(path string, name string) (ExportEntry, bool) {
+	for _, entry := range t[path] {
+		if entry.Name == name {
+			return entry, true
+		}
+	}
+	return ExportEntry{}, false
+}
+
+// declarationExport 为 export 修饰的声明创建导出项，`export default function foo()` 的外部名称为 "default"。
+func declarationExport(identifier string, isDefault bool, isTypeOnly bool, line int) ExportEntry {
+	entry := ExportEntry{Name: identifier, LocalName: identifier, Kind: ExportKindDeclaration, IsTypeOnly: isTypeOnly, Line: line}
+	if isDefault {
+		entry.Name = "default"
+	}
+	return entry
+}
+
+// startLine 返回位置信息的起始行号，没有位置信息时为 0。
+func startLine(location *parser.SourceLocation) int {
+	if location == nil {
+		return 0
+	}
+	return location.Start.Line
+}
@@ -0,0 +1,64 @@
+package projectParser
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// TestExportTable 测试导出表收集声明导出、命名导出、再导出与默认导出，并忽略命名空间内部的导出。
+func TestExportTable(t *testing.T) {
+	root := t.TempDir()
+	writeTestFiles(t, root, map[string]string{
+		"src/index.ts": "export { format as fmt } from './format';\nexport * from './types';\nexport type { Props } from './types';\n",
+		"src/format.ts": "export const format = () => '';\nconst local = 1;\nexport { local as renamed };\nexport default function main() {}\n" +
+			"export interface Options {}\nexport type Id = string;\nexport enum Color { Red }\nexport class Store {}\n" +
+			"export namespace Inner { export const hidden = 1; }\n",
+		"src/types.ts": "export interface Props {}\n",
+	})
+
+	ppr := NewProjectParserResult(NewProjectParserConfig(root, nil, false, []string{}))
+	ppr.ProjectParser()
+	table := BuildExportTable(ppr)
+	indexPath := filepath.Join(root, "src", "index.ts")
+	formatPath := filepath.Join(root, "src", "format.ts")
+	typesPath := filepath.Join(root, "src", "types.ts")
+
+	entry, ok := table.Lookup(indexPath, "fmt")
+	if !ok || entry.Kind != ExportKindReExport || entry.LocalName != "format" || entry.From != formatPath || entry.Line != 1 {
+		t.Errorf("别名再导出不符合预期: %+v", entry)
+	}
+	if entry, ok := table.Lookup(indexPath, "*"); !ok || entry.From != typesPath {
+		t.Errorf("export * 不符合预期: %+v", entry)
+	}
+	if entry, ok := table.Lookup(indexPath, "Props"); !ok || !entry.IsTypeOnly {
+		t.Errorf("仅类型再导出不符合预期: %+v", entry)
+	}
+
+	expectedKinds := map[string]string{
+		"format":  ExportKindDeclaration,
+		"renamed": ExportKindNamed,
+		"default": ExportKindDeclaration,
+		"Options": ExportKindDeclaration,
+		"Id":      ExportKindDeclaration,
+		"Color":   ExportKindDeclaration,
+		"Store":   ExportKindDeclaration,
+	}
+	for name, kind := range expectedKinds {
+		entry, ok := table.Lookup(formatPath, name)
+		if !ok || entry.Kind != kind {
+			t.Errorf("format.ts 的导出 %s 不符合预期: %+v", name, entry)
+		}
+	}
+	if entry, _ := table.Lookup(formatPath, "renamed"); entry.LocalName != "local" {
+		t.Errorf("命名导出的本地名称应为 local, 得到 %+v", entry)
+	}
+	if entry, _ := table.Lookup(formatPath, "default"); entry.LocalName != "main" {
+		t.Errorf("默认导出的函数的本地名称应为 main, 得到 %+v", entry)
+	}
+	if entry, _ := table.Lookup(formatPath, "Options"); !entry.IsTypeOnly {
+		t.Errorf("接口导出应为仅类型导出")
+	}
+	if _, ok := table.Lookup(formatPath, "hidden"); ok {
+		t.Errorf("命名空间内部的导出不应计入文件的导出")
+	}
+}
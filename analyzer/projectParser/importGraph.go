@@ -0,0 +1,118 @@
+package projectParser
+
+import (
+	"sort"
+
+	"github.com/samber/lo"
+)
+
+// 导入关系图中边的类型。
+const (
+	ImportEdgeKindImport   = "import"    // `import` 语句、`require()` 与动态导入
+	ImportEdgeKindReExport = "re-export" // `export ... from` 再导出
+	ImportEdgeKindJsx      = "jsx"       // JSX 元素或 .vue / .svelte 模板中的组件
+	ImportEdgeKindStyle    = "style"     // 样式文件中的 `@import` / `@use`
+)
+
+// ImportEdge 是导入关系图中的一条边：From 文件依赖了 To 文件。
+type ImportEdge struct {
+	// From 是发起导入的文件的绝对路径。
+	From string `json:"from"`
+	// To 是被导入的项目内文件的绝对路径。
+	To string `json:"to"`
+	// Kind 是边的类型，取值见 ImportEdgeKind* 常量。
+	Kind string `json:"kind"`
+	// IsTypeOnly 表示该导入只导入了类型，编译后会被移除，不构成运行时依赖。
+	IsTypeOnly bool `json:"isTypeOnly,omitempty"`
+}
+
+// ImportGraph 是项目内文件之间的导入关系图。
+// key 是文件的绝对路径，值是以该文件为 From（正向图）或 To（反向图）的所有边。
+// 只包含解析到项目内文件的导入，npm 包与无法解析的路径不在图中。
+type ImportGraph map[string][]ImportEdge
+
+// BuildImportGraph 根据解析结果构建正向导入关系图，key 是发起导入的文件。
+// 边来自 JS 文件的导入声明、再导出声明、JSX 元素，以及样式文件的 `@import`。
+// 文件按路径排序后依次处理，每个文件的边保持其在源码中出现的顺序。
+func BuildImportGraph(ppr *ProjectParserResult) ImportGraph {
+	graph := make(ImportGraph)
+	if ppr == nil {
+		return graph
+	}
+
+	for _, path := range sortedKeys(ppr.Js_Data) {
+		fileData := ppr.Js_Data[path]
+		for _, decl := range fileData.ImportDeclarations {
+			if isProjectSource(decl.Source) {
+				graph.add(ImportEdge{From: path, To: decl.Source.FilePath, Kind: ImportEdgeKindImport, IsTypeOnly: decl.OnlyTypes()})
+			}
+		}
+		for _, decl := range fileData.ExportDeclarations {
+			if decl.Source != nil && isProjectSource(*decl.Source) {
+				graph.add(ImportEdge{From: path, To: decl.Source.FilePath, Kind: ImportEdgeKindReExport, IsTypeOnly: decl.IsTypeOnly})
+			}
+		}
+		for _, element := range fileData.JsxElements {
+			if isProjectSource(element.Source) {
+				graph.add(ImportEdge{From: path, To: element.Source.FilePath, Kind: ImportEdgeKindJsx})
+			}
+		}
+	}
+	for _, path := range sortedKeys(ppr.Css_Data) {
+		for _, decl := range ppr.Css_Data[path].ImportDeclarations {
+			if isProjectSource(decl.Source) {
+				graph.add(ImportEdge{From: path, To: decl.Source.FilePath, Kind: ImportEdgeKindStyle})
+			}
+		}
+	}
+	return graph
+}
+
+// isProjectSource 判断导入来源是否为项目内的文件。npm 包与未知来源的 FilePath 保留的是原始导入路径，不是项目内文件。
+func isProjectSource(source SourceData) bool {
+	return source.FilePath != "" && source.Type != "npm" && source.Type != "unknown"
+}
+
+// add 将边加入正向图。
+func (g ImportGraph) add(edge ImportEdge) {
+	g[edge.From] = append(g[edge.From], edge)
+}
+
+// Reverse 返回反向导入关系图，key 是被导入的文件，值是所有导入了它的边。
+func (g ImportGraph) Reverse() ImportGraph {
+	reverse := make(ImportGraph)
+	for _, from := range sortedKeys(g) {
+		for _, edge := range g[from] {
+			reverse[edge.To] = append(reverse[edge.To], edge)
+		}
+	}
+	return reverse
+}
+
+// Neighbors 返回与 path 相连的文件：正向图中为 path 导入的文件，反向图中为导入了 path 的文件。
+// kinds 不为空时只考虑这些类型的边。结果去重并保持边的顺序。
+func (g ImportGraph) Neighbors(path string, kinds ...string) []string {
+	var neighbors []string
+	seen := make(map[string]bool)
+	for _, edge := range g[path] {
+		if len(kinds) > 0 && !lo.Contains(kinds, edge.Kind) {
+			continue
+		}
+		neighbor := edge.To
+		if neighbor == path {
+			neighbor = edge.From
+		}
+		if !seen[neighbor] {
+			seen[neighbor] = true
+			neighbors = append(neighbors, neighbor)
+		}
+	}
+	return neighbors
+}
+
+// sortedKeys 返回 map 的 key 并按字典序排序，用于保证遍历顺序稳定。
+func sortedKeys[V any](m map[string]V) []string {
+	keys := lo.Keys(m)
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,65 @@
+package projectParser
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// TestImportGraph 测试导入关系图的各类边、反向图与按类型过滤的相邻文件，npm 包不计入图中。
+func TestImportGraph(t *testing.T) {
+	root := t.TempDir()
+	writeTestFiles(t, root, map[string]string{
+		"src/main.tsx":      "import { helper } from './utils';\nimport type { Props } from './types';\nimport Button from './Button';\nimport 'react';\nexport { helper as h } from './utils';\nexport const App = () => <Button />;\n",
+		"src/utils.ts":      "export * from './format';\nexport const helper = 1;\n",
+		"src/format.ts":     "export const format = () => '';\n",
+		"src/types.ts":      "export interface Props {}\n",
+		"src/Button.tsx":    "import './Button.scss';\nexport default function Button() { return null; }\n",
+		"src/Button.scss":   "@import './vars';\n",
+		"src/_vars.scss":    "$gap: 8px;\n",
+		"src/standalone.ts": "export const x = 1;\n",
+	})
+
+	ppr := NewProjectParserResult(NewProjectParserConfig(root, nil, false, []string{}))
+	ppr.ProjectParser()
+	path := func(name string) string { return filepath.Join(root, "src", name) }
+
+	graph := BuildImportGraph(ppr)
+	expected := []ImportEdge{
+		{From: path("main.tsx"), To: path("utils.ts"), Kind: ImportEdgeKindImport},
+		{From: path("main.tsx"), To: path("types.ts"), Kind: ImportEdgeKindImport, IsTypeOnly: true},
+		{From: path("main.tsx"), To: path("Button.tsx"), Kind: ImportEdgeKindImport},
+		{From: path("main.tsx"), To: path("utils.ts"), Kind: ImportEdgeKindReExport},
+	}
+	if !reflect.DeepEqual(graph[path("main.tsx")], expected) {
+		t.Errorf("main.tsx 的边不符合预期:\n得到 %+v\n预期 %+v", graph[path("main.tsx")], expected)
+	}
+	if edges := graph[path("utils.ts")]; len(edges) != 1 || edges[0].Kind != ImportEdgeKindReExport || edges[0].To != path("format.ts") {
+		t.Errorf("utils.ts 应有一条指向 format.ts 的再导出边, 得到 %+v", edges)
+	}
+	if edges := graph[path("Button.scss")]; len(edges) != 1 || edges[0].Kind != ImportEdgeKindStyle || edges[0].To != path("_vars.scss") {
+		t.Errorf("Button.scss 应有一条指向 _vars.scss 的样式边, 得到 %+v", edges)
+	}
+	if _, ok := graph[path("standalone.ts")]; ok {
+		t.Errorf("没有导入的文件不应出现在正向图中")
+	}
+
+	// 同一对文件之间的多条边在相邻文件中只出现一次
+	if got := graph.Neighbors(path("main.tsx")); !reflect.DeepEqual(got, []string{path("utils.ts"), path("types.ts"), path("Button.tsx")}) {
+		t.Errorf("main.tsx 的相邻文件不符合预期: %v", got)
+	}
+	if got := graph.Neighbors(path("main.tsx"), ImportEdgeKindReExport); !reflect.DeepEqual(got, []string{path("utils.ts")}) {
+		t.Errorf("按再导出类型过滤的相邻文件不符合预期: %v", got)
+	}
+
+	reverse := graph.Reverse()
+	if got := reverse.Neighbors(path("Button.tsx")); !reflect.DeepEqual(got, []string{path("main.tsx")}) {
+		t.Errorf("导入了 Button.tsx 的文件不符合预期: %v", got)
+	}
+	if got := reverse.Neighbors(path("Button.scss")); !reflect.DeepEqual(got, []string{path("Button.tsx")}) {
+		t.Errorf("导入了 Button.scss 的文件不符合预期: %v", got)
+	}
+	if len(reverse[path("main.tsx")]) != 0 {
+		t.Errorf("未被导入的文件不应出现在反向图中")
+	}
+}
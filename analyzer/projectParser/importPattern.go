@@ -0,0 +1,113 @@
+package projectParser
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/gobwas/glob"
+)
+
+// --- 动态导入 glob 模式的展开 ---
+
+// ExpandImportPattern 将动态导入的 glob 模式（`import.meta.glob('./pages/**/*.tsx')`、
+// `import(`./locales/${lang}.ts`)` 等）按本次扫描到的文件列表展开，返回每个匹配文件对应的 SourceData。
+//
+// 模式中的基准路径与普通导入的解析规则一致：相对路径基于导入方所在目录，以 `/` 开头的路径基于项目根目录，
+// 其余路径依次尝试 tsconfig 的 `paths` / 别名规则与 baseUrl。
+// filter 是 `require.context` 的正则表达式字面量 (e.g., `/\.vue$/`)，用于匹配相对于基准目录的 "./" 路径。
+// 导入方自身不会被匹配；没有任何文件匹配时返回一条 Type 为 "unknown"、FilePath 为原始模式的记录。
+func (ppr *ProjectParserResult) ExpandImportPattern(importerPath string, pattern string, filter string, alias map[string]string, tsconfigDir string, baseUrl string) []SourceData {
+	sources := []SourceData{}
+	for _, absPattern := range ppr.resolveImportPattern(importerPath, pattern, alias, tsconfigDir, baseUrl) {
+		for _, match := range matchScannedFiles(ppr.scannedFiles, absPattern, filter) {
+			if match == importerPath {
+				continue
+			}
+			sources = append(sources, SourceData{FilePath: match, Type: localSourceType(match)})
+		}
+		if len(sources) > 0 {
+			break
+		}
+	}
+	if len(sources) == 0 {
+		return []SourceData{{FilePath: pattern, Type: "unknown"}}
+	}
+	return sources
+}
+
+// resolveImportPattern 将模式转换为按顺序尝试的绝对路径模式列表。
+func (ppr *ProjectParserResult) resolveImportPattern(importerPath string, pattern string, alias map[string]string, tsconfigDir string, baseUrl string) []string {
+	if isRelativePath(pattern) {
+		return []string{filepath.Join(filepath.Dir(importerPath), pattern)}
+	}
+	if strings.HasPrefix(pattern, "/") {
+		return []string{filepath.Join(ppr.Config.RootPath, pattern)}
+	}
+
+	rules := aliasToPathRules(alias)
+	if paths := ppr.getTsConfigPaths(tsconfigDir); len(paths) > 0 {
+		rules = pathsToPathRules(paths)
+	}
+	var candidates []string
+	if rule, star, ok := matchPathRule(pattern, rules); ok {
+		for _, target := range rule.targets {
+			candidates = append(candidates, filepath.Join(tsconfigDir, baseUrl, strings.Replace(target, "*", star, 1)))
+		}
+	}
+	if baseUrl != "" {
+		candidates = append(candidates, filepath.Join(tsconfigDir, baseUrl, pattern))
+	}
+	return candidates
+}
+
+// matchScannedFiles 返回 files 中与绝对路径模式 absPattern 匹配的文件，保持 files 的顺序。
+// `**/` 可以匹配零层目录，即 "pages/**/*.tsx" 同时匹配 "pages/index.tsx" 与 "pages/user/list.tsx"。
+func matchScannedFiles(files []string, absPattern string, filter string) []string {
+	slashPattern := filepath.ToSlash(absPattern)
+	// 静态前缀（第一个通配符所在目录之前的部分）按字面量匹配，避免目录名中的 `{`、`[` 被当作通配符
+	baseDir := slashPattern
+	if idx := strings.IndexAny(slashPattern, "*?{["); idx >= 0 {
+		baseDir = slashPattern[:strings.LastIndex(slashPattern[:idx], "/")+1]
+	}
+	rest := strings.TrimPrefix(slashPattern, baseDir)
+
+	var globs []glob.Glob
+	for _, variant := range []string{rest, strings.ReplaceAll(rest, "**/", "")} {
+		if g, err := glob.Compile(glob.QuoteMeta(baseDir)+variant, '/'); err == nil {
+			globs = append(globs, g)
+		}
+	}
+	filterRegex := compileImportFilter(filter)
+
+	matches := []string{}
+	for _, file := range files {
+		slashFile := filepath.ToSlash(file)
+		if !matchAnyGlob(globs, slashFile) {
+			continue
+		}
+		if filterRegex != nil && !filterRegex.MatchString("./"+strings.TrimPrefix(slashFile, baseDir)) {
+			continue
+		}
+		matches = append(matches, file)
+	}
+	return matches
+}
+
+// compileImportFilter 将 JS 正则表达式字面量 (e.g., `/\.svg$/i`) 编译为 Go 正则表达式。
+// 过滤器为空或无法编译时返回 nil，表示不过滤。
+func compileImportFilter(filter string) *regexp.Regexp {
+	end := strings.LastIndex(filter, "/")
+	if !strings.HasPrefix(filter, "/") || end <= 0 {
+		return nil
+	}
+	body := filter[1:end]
+	if strings.Contains(filter[end+1:], "i") {
+		body = "(?i)" + body
+	}
+	re, err := regexp.Compile(body)
+	if err != nil {
+		return nil
+	}
+	return re
+}
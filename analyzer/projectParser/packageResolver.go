@@ -0,0 +1,585 @@
+package projectParser
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// --- npm 包与 package.json `imports` 解析 ---
+
+// 支持的 moduleResolution 模式，与 tsconfig.json 中的 `compilerOptions.moduleResolution` 取值一致。
+const (
+	ModuleResolutionBundler  = "bundler"
+	ModuleResolutionNode16   = "node16"
+	ModuleResolutionNodeNext = "nodenext"
+	ModuleResolutionNode10   = "node10"
+)
+
+// typesVersionsTsVersion 是匹配 package.json `typesVersions` 时假定的 TypeScript 版本。
+var typesVersionsTsVersion = [2]int{5, 9}
+
+// ModuleResolutionOptions 描述了 npm 包以及 `#` 开头的子路径导入的解析方式。
+type ModuleResolutionOptions struct {
+	// Mode 是 moduleResolution 模式，为空时按 "bundler" 处理。
+	// "node10"（以及旧的 "node"）模式不读取 `exports` / `imports` 字段。
+	Mode string `json:"mode,omitempty"`
+	// Conditions 是启用的导出条件。为空时根据 Mode 使用默认条件：
+	// bundler 为 types、import；node16/nodenext 为 types、node，以及根据导入方模块格式决定的 import 或 require。
+	// "default" 条件总是启用。
+	Conditions []string `json:"conditions,omitempty"`
+	// CustomConditions 是在默认条件之外额外启用的条件，对应 tsconfig 的 `customConditions`。
+	CustomConditions []string `json:"customConditions,omitempty"`
+	// Require 表示本次解析的是 CommonJS 的 require() 调用。未指定 Conditions 时使用 "require" 条件代替 "import"。
+	Require bool `json:"require,omitempty"`
+}
+
+// ResolvePackageSource 在 MatchImportSource 的结果基础上，尝试将 npm 包导入或 `#` 开头的子路径导入解析为具体文件。
+//
+// 解析顺序与 Node/TypeScript 保持一致：
+//  1. `#` 开头的导入读取离导入方最近的 package.json 的 `imports` 字段；
+//  2. 包名与最近的 package.json 的 name 相同时解析为自引用；
+//  3. 否则向上查找 node_modules 中的包，优先使用 `exports`，
+//     没有 `exports` 时依次尝试 `typesVersions`、`types`/`typings`、`module`、`main` 和 index 文件。
+//
+// npm 包解析成功时 Type 仍为 "npm"，具体文件记录在 ResolvedPath 中；
+// `imports` 映射到项目内文件时 Type 按 localSourceType 取 "file"、"style" 或 "asset"。无法解析时原样返回 source。
+func ResolvePackageSource(importerPath string, importPath string, source SourceData, extensions []string, options ModuleResolutionOptions) SourceData {
+	if source.Type != "npm" {
+		return source
+	}
+	conditions := conditionSet(activeConditions(importerPath, options))
+	subpath := "." + strings.TrimPrefix(importPath, source.NpmPkg)
+
+	if mode := strings.ToLower(options.Mode); mode == ModuleResolutionNode10 || mode == "node" {
+		// 旧版解析不识别 exports/imports，只按 types/main 等字段查找
+		if pkgDir, ok := findPackageDir(importerPath, source.NpmPkg); ok {
+			if resolved, ok := resolveLegacyPackage(pkgDir, subpath, extensions, conditions); ok {
+				source.ResolvedPath = resolved
+			}
+		}
+		return source
+	}
+
+	// 1. `#` 开头的子路径导入
+	if strings.HasPrefix(importPath, "#") {
+		manifest, pkgDir, ok := findNearestManifest(filepath.Dir(importerPath))
+		if !ok || manifest.Imports == nil {
+			return source
+		}
+		target, ok := resolveExportsMap(manifest.Imports, importPath, conditions)
+		if !ok {
+			return source
+		}
+		if strings.HasPrefix(target, "./") {
+			if resolved, ok := resolvePackageTarget(filepath.Join(pkgDir, target), extensions, conditions); ok {
+				return SourceData{FilePath: resolved, Type: localSourceType(resolved)}
+			}
+			return source
+		}
+		// Node 不允许 `imports` 的目标是另一个 `#` 说明符，这类目标视为无法解析，也避免 {"#a": "#b", "#b": "#a"} 无限递归
+		if strings.HasPrefix(target, "#") {
+			return source
+		}
+		// `imports` 也可以映射到另一个 npm 包
+		mapped := SourceData{FilePath: target, NpmPkg: extractNpmPackageName(target), Type: "npm"}
+		return ResolvePackageSource(importerPath, target, mapped, extensions, options)
+	}
+
+	// 2. 自引用：导入方所在包的 name 与导入的包名一致
+	if manifest, pkgDir, ok := findNearestManifest(filepath.Dir(importerPath)); ok && manifest.Name == source.NpmPkg && manifest.Exports != nil {
+		if resolved, ok := resolvePackageEntry(manifest, pkgDir, subpath, extensions, conditions); ok {
+			source.ResolvedPath = resolved
+		}
+		return source
+	}
+
+	// 3. node_modules 中的包
+	pkgDir, ok := findPackageDir(importerPath, source.NpmPkg)
+	if !ok {
+		return source
+	}
+	manifest, ok := readPackageManifest(filepath.Join(pkgDir, "package.json"))
+	if !ok {
+		return source
+	}
+	if resolved, ok := resolvePackageEntry(manifest, pkgDir, subpath, extensions, conditions); ok {
+		source.ResolvedPath = resolved
+	}
+	return source
+}
+
+// activeConditions 返回本次解析启用的导出条件。
+func activeConditions(importerPath string, options ModuleResolutionOptions) []string {
+	var conditions []string
+	switch {
+	case len(options.Conditions) > 0:
+		conditions = append(conditions, options.Conditions...)
+	case strings.ToLower(options.Mode) == ModuleResolutionNode16 || strings.ToLower(options.Mode) == ModuleResolutionNodeNext:
+		if isEsmImporter(importerPath) && !options.Require {
+			conditions = []string{"types", "node", "import"}
+		} else {
+			conditions = []string{"types", "node", "require"}
+		}
+	case options.Require:
+		conditions = []string{"types", "require"}
+	default:
+		conditions = []string{"types", "import"}
+	}
+	return append(conditions, options.CustomConditions...)
+}
+
+// conditionSet 将条件列表转换为集合，并始终加入 "default" 条件。
+func conditionSet(conditions []string) map[string]bool {
+	set := map[string]bool{"default": true}
+	for _, condition := range conditions {
+		set[condition] = true
+	}
+	return set
+}
+
+// isEsmImporter 判断导入方是否为 ES 模块：.mts/.mjs 总是 ESM，.cts/.cjs 总是 CommonJS，
+// 其他文件取决于最近的 package.json 的 "type" 字段。
+func isEsmImporter(importerPath string) bool {
+	switch filepath.Ext(importerPath) {
+	case ".mts", ".mjs":
+		return true
+	case ".cts", ".cjs":
+		return false
+	}
+	manifest, _, ok := findNearestManifest(filepath.Dir(importerPath))
+	return ok && manifest.Type == "module"
+}
+
+// resolvePackageEntry 解析包内的子路径（"." 或 "./sub"）。
+func resolvePackageEntry(manifest *packageManifest, pkgDir string, subpath string, extensions []string, conditions map[string]bool) (string, bool) {
+	if manifest.Exports != nil {
+		target, ok := resolveExportsMap(normalizeExports(manifest.Exports), subpath, conditions)
+		if !ok {
+			// 定义了 exports 时，未导出的子路径不可访问
+			return "", false
+		}
+		return resolvePackageTarget(filepath.Join(pkgDir, target), extensions, conditions)
+	}
+	return resolveLegacyPackage(pkgDir, subpath, extensions, conditions)
+}
+
+// resolveLegacyPackage 在没有 `exports` 字段时解析包内的子路径。
+func resolveLegacyPackage(pkgDir string, subpath string, extensions []string, conditions map[string]bool) (string, bool) {
+	manifest, _ := readPackageManifest(filepath.Join(pkgDir, "package.json"))
+
+	// typesVersions 只在启用 types 条件时生效，它会重定向所有子路径
+	if conditions["types"] && manifest != nil && manifest.TypesVersions != nil {
+		if paths := selectTypesVersionsPaths(manifest.TypesVersions); paths != nil {
+			lookup := strings.TrimPrefix(strings.TrimPrefix(subpath, "."), "/")
+			if lookup == "" && manifest.Types != "" {
+				lookup = strings.TrimPrefix(manifest.Types, "./")
+			}
+			if rule, star, ok := matchPathRule(lookup, pathsToPathRules(paths)); ok {
+				for _, target := range rule.targets {
+					if resolved, ok := resolvePackageTarget(filepath.Join(pkgDir, strings.Replace(target, "*", star, 1)), extensions, conditions); ok {
+						return resolved, true
+					}
+				}
+			}
+		}
+	}
+
+	if subpath != "." {
+		return resolvePackageTarget(filepath.Join(pkgDir, subpath), extensions, conditions)
+	}
+
+	if manifest != nil {
+		var fields []string
+		if conditions["types"] {
+			fields = append(fields, manifest.Types, manifest.Typings)
+		}
+		if conditions["import"] {
+			fields = append(fields, manifest.Module)
+		}
+		fields = append(fields, manifest.Main)
+		for _, field := range fields {
+			if field == "" {
+				continue
+			}
+			if resolved, ok := resolvePackageTarget(filepath.Join(pkgDir, field), extensions, conditions); ok {
+				return resolved, true
+			}
+		}
+	}
+	return resolveAsFile(filepath.Join(pkgDir, "index"), extensions)
+}
+
+// resolvePackageTarget 将包内的目标路径解析为具体文件。
+// 启用 types 条件时，对 .js/.mjs/.cjs 目标优先查找同名的 TypeScript 源文件或声明文件。
+func resolvePackageTarget(path string, extensions []string, conditions map[string]bool) (string, bool) {
+	if conditions["types"] {
+		for _, candidate := range typeScriptSiblings(path) {
+			if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+				return candidate, true
+			}
+		}
+	}
+	return resolveAsFile(path, extensions)
+}
+
+// typeScriptSiblings 返回 JS 文件对应的 TypeScript 源文件与声明文件候选路径。
+func typeScriptSiblings(path string) []string {
+	replacements := map[string][]string{
+		".js":  {".ts", ".tsx", ".d.ts"},
+		".jsx": {".tsx", ".d.ts"},
+		".mjs": {".mts", ".d.mts"},
+		".cjs": {".cts", ".d.cts"},
+	}
+	ext := filepath.Ext(path)
+	candidates := []string{}
+	for _, replacement := range replacements[ext] {
+		candidates = append(candidates, strings.TrimSuffix(path, ext)+replacement)
+	}
+	return candidates
+}
+
+// --- exports / imports 字段 ---
+
+// orderedObject 是保留键顺序的 JSON 对象。exports 中条件的先后顺序决定匹配优先级，因此不能使用 map。
+type orderedObject struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+// normalizeExports 将 exports 的简写形式（字符串、数组、纯条件对象）统一为以子路径为键的对象。
+func normalizeExports(exports interface{}) interface{} {
+	if obj, ok := exports.(*orderedObject); ok {
+		for _, key := range obj.keys {
+			if strings.HasPrefix(key, ".") {
+				return obj
+			}
+		}
+	}
+	return &orderedObject{keys: []string{"."}, values: map[string]interface{}{".": exports}}
+}
+
+// resolveExportsMap 在 exports/imports 映射中查找 request 对应的目标路径。
+// 先查找完全相同的键，再在含 `*` 的模式中选择前缀最长的一条，最后兼容以 "/" 结尾的旧式目录映射。
+func resolveExportsMap(mapping interface{}, request string, conditions map[string]bool) (string, bool) {
+	obj, ok := mapping.(*orderedObject)
+	if !ok {
+		return "", false
+	}
+	if value, ok := obj.values[request]; ok && !strings.Contains(request, "*") {
+		return resolveExportsTarget(value, "", conditions)
+	}
+
+	bestKey, bestStar, bestPrefixLen := "", "", -1
+	for _, key := range obj.keys {
+		idx := strings.Index(key, "*")
+		if idx < 0 {
+			if strings.HasSuffix(key, "/") && strings.HasPrefix(request, key) && len(key) > bestPrefixLen {
+				bestKey, bestStar, bestPrefixLen = key, request[len(key):], len(key)
+			}
+			continue
+		}
+		prefix, suffix := key[:idx], key[idx+1:]
+		if len(request) < len(prefix)+len(suffix) || !strings.HasPrefix(request, prefix) || !strings.HasSuffix(request, suffix) {
+			continue
+		}
+		if len(prefix) > bestPrefixLen {
+			bestKey, bestStar, bestPrefixLen = key, request[len(prefix):len(request)-len(suffix)], len(prefix)
+		}
+	}
+	if bestKey == "" {
+		return "", false
+	}
+	if strings.HasSuffix(bestKey, "/") {
+		target, ok := resolveExportsTarget(obj.values[bestKey], "", conditions)
+		return target + bestStar, ok
+	}
+	return resolveExportsTarget(obj.values[bestKey], bestStar, conditions)
+}
+
+// resolveExportsTarget 按条件解析 exports 的目标值，并将目标中的 `*` 替换为 star。
+// 目标可以是字符串、候选数组或条件对象；null 表示该子路径被显式屏蔽。
+func resolveExportsTarget(target interface{}, star string, conditions map[string]bool) (string, bool) {
+	switch value := target.(type) {
+	case string:
+		return strings.ReplaceAll(value, "*", star), true
+	case []interface{}:
+		for _, item := range value {
+			if resolved, ok := resolveExportsTarget(item, star, conditions); ok {
+				return resolved, true
+			}
+		}
+	case *orderedObject:
+		for _, key := range value.keys {
+			if !conditions[key] {
+				continue
+			}
+			if resolved, ok := resolveExportsTarget(value.values[key], star, conditions); ok {
+				return resolved, true
+			}
+		}
+	}
+	return "", false
+}
+
+// parseOrderedJSON 解析 JSON 值，对象会被解析为保留键顺序的 orderedObject。
+func parseOrderedJSON(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	return decodeOrderedValue(decoder)
+}
+
+func decodeOrderedValue(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch delim := token.(type) {
+	case json.Delim:
+		switch delim {
+		case '{':
+			obj := &orderedObject{values: make(map[string]interface{})}
+			for decoder.More() {
+				keyToken, err := decoder.Token()
+				if err != nil {
+					return nil, err
+				}
+				key, _ := keyToken.(string)
+				value, err := decodeOrderedValue(decoder)
+				if err != nil {
+					return nil, err
+				}
+				if _, exists := obj.values[key]; !exists {
+					obj.keys = append(obj.keys, key)
+				}
+				obj.values[key] = value
+			}
+			_, err := decoder.Token() // '}'
+			return obj, err
+		case '[':
+			arr := []interface{}{}
+			for decoder.More() {
+				value, err := decodeOrderedValue(decoder)
+				if err != nil {
+					return nil, err
+				}
+				arr = append(arr, value)
+			}
+			_, err := decoder.Token() // ']'
+			return arr, err
+		}
+	}
+	return token, nil
+}
+
+// --- typesVersions ---
+
+// selectTypesVersionsPaths 选择第一个版本范围与当前 TypeScript 版本匹配的 typesVersions 映射。
+func selectTypesVersionsPaths(typesVersions *orderedObject) map[string][]string {
+	for _, versionRange := range typesVersions.keys {
+		if !matchTypesVersionRange(versionRange) {
+			continue
+		}
+		obj, ok := typesVersions.values[versionRange].(*orderedObject)
+		if !ok {
+			return nil
+		}
+		paths := make(map[string][]string)
+		for _, key := range obj.keys {
+			if targets, ok := obj.values[key].([]interface{}); ok {
+				for _, target := range targets {
+					if s, ok := target.(string); ok {
+						paths[key] = append(paths[key], s)
+					}
+				}
+			}
+		}
+		return paths
+	}
+	return nil
+}
+
+// matchTypesVersionRange 判断 typesVersions 的版本范围（例如 "*"、">=4.1"、"<4.0"，多个条件以空格分隔）是否匹配。
+func matchTypesVersionRange(versionRange string) bool {
+	for _, part := range strings.Fields(versionRange) {
+		if part == "*" {
+			continue
+		}
+		op := part[:len(part)-len(strings.TrimLeft(part, "<>=~^"))]
+		version := parseMajorMinor(strings.TrimPrefix(part, op))
+		cmp := compareMajorMinor(typesVersionsTsVersion, version)
+		switch op {
+		case ">=":
+			if cmp < 0 {
+				return false
+			}
+		case ">":
+			if cmp <= 0 {
+				return false
+			}
+		case "<=":
+			if cmp > 0 {
+				return false
+			}
+		case "<":
+			if cmp >= 0 {
+				return false
+			}
+		default:
+			if cmp != 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func parseMajorMinor(version string) [2]int {
+	var result [2]int
+	for i, part := range strings.SplitN(version, ".", 3) {
+		if i > 1 {
+			break
+		}
+		result[i], _ = strconv.Atoi(part)
+	}
+	return result
+}
+
+func compareMajorMinor(a, b [2]int) int {
+	for i := 0; i < 2; i++ {
+		if a[i] != b[i] {
+			if a[i] < b[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// --- package.json 读取 ---
+
+// packageManifest 是解析模块时需要的 package.json 字段。
+type packageManifest struct {
+	Name          string
+	Type          string
+	Main          string
+	Module        string
+	Types         string
+	Typings       string
+	Source        string
+	Exports       interface{}
+	Imports       interface{}
+	TypesVersions *orderedObject
+}
+
+// manifestCacheEntry 缓存已读取的 package.json，文件修改时间或大小变化后重新读取。
+type manifestCacheEntry struct {
+	modTime  time.Time
+	size     int64
+	manifest *packageManifest
+}
+
+// manifestCache 在整个进程内缓存 package.json 的解析结果，可以被并发访问。
+var manifestCache sync.Map
+
+// readPackageManifest 读取并解析 package.json。
+func readPackageManifest(path string) (*packageManifest, bool) {
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return nil, false
+	}
+	if cached, ok := manifestCache.Load(path); ok {
+		entry := cached.(manifestCacheEntry)
+		if entry.modTime.Equal(info.ModTime()) && entry.size == info.Size() {
+			return entry.manifest, entry.manifest != nil
+		}
+	}
+
+	manifest := parsePackageManifest(path)
+	manifestCache.Store(path, manifestCacheEntry{modTime: info.ModTime(), size: info.Size(), manifest: manifest})
+	return manifest, manifest != nil
+}
+
+func parsePackageManifest(path string) *packageManifest {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var raw struct {
+		Name          string          `json:"name"`
+		Type          string          `json:"type"`
+		Main          string          `json:"main"`
+		Module        string          `json:"module"`
+		Types         string          `json:"types"`
+		Typings       string          `json:"typings"`
+		Source        string          `json:"source"`
+		Exports       json.RawMessage `json:"exports"`
+		Imports       json.RawMessage `json:"imports"`
+		TypesVersions json.RawMessage `json:"typesVersions"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil
+	}
+
+	manifest := &packageManifest{
+		Name:    raw.Name,
+		Type:    raw.Type,
+		Main:    raw.Main,
+		Module:  raw.Module,
+		Types:   raw.Types,
+		Typings: raw.Typings,
+		Source:  raw.Source,
+	}
+	if len(raw.Exports) > 0 {
+		manifest.Exports, _ = parseOrderedJSON(raw.Exports)
+	}
+	if len(raw.Imports) > 0 {
+		manifest.Imports, _ = parseOrderedJSON(raw.Imports)
+	}
+	if len(raw.TypesVersions) > 0 {
+		if value, err := parseOrderedJSON(raw.TypesVersions); err == nil {
+			manifest.TypesVersions, _ = value.(*orderedObject)
+		}
+	}
+	return manifest
+}
+
+// findNearestManifest 从 dir 开始向上查找最近的 package.json，跳过 node_modules 目录本身。
+func findNearestManifest(dir string) (*packageManifest, string, bool) {
+	for {
+		if filepath.Base(dir) != "node_modules" {
+			if manifest, ok := readPackageManifest(filepath.Join(dir, "package.json")); ok {
+				return manifest, dir, true
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, "", false
+		}
+		dir = parent
+	}
+}
+
+// findPackageDir 从导入方所在目录开始向上查找 node_modules/<pkgName>。
+func findPackageDir(importerPath string, pkgName string) (string, bool) {
+	if pkgName == "" {
+		return "", false
+	}
+	dir := filepath.Dir(importerPath)
+	for {
+		candidate := filepath.Join(dir, "node_modules", pkgName)
+		if info, err := os.Stat(candidate); err == nil && info.IsDir() {
+			return candidate, true
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
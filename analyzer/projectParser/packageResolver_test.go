@@ -0,0 +1,190 @@
+package projectParser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// setupPackageResolverProject 创建一个包含 node_modules 以及 package.json `imports` 的测试项目。
+func setupPackageResolverProject(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	files := map[string]string{
+		"package.json": `{
+			"name": "app",
+			"imports": {
+				"#utils/*": "./src/utils/*.ts",
+				"#dep": "cond-pkg",
+				"#cycle-a": "#cycle-b",
+				"#cycle-b": "#cycle-a"
+			}
+		}`,
+		"src/main.ts":         ``,
+		"src/utils/format.ts": `export const format = 1;`,
+
+		// 没有 exports 的包，子路径直接映射到包内文件
+		"node_modules/lodash-es/package.json": `{"name": "lodash-es", "main": "lodash.js", "module": "lodash.js", "type": "module"}`,
+		"node_modules/lodash-es/lodash.js":    ``,
+		"node_modules/lodash-es/debounce.js":  ``,
+
+		// 使用条件导出与子路径模式的包
+		"node_modules/cond-pkg/package.json": `{
+			"name": "cond-pkg",
+			"exports": {
+				".": {
+					"types": "./index.d.ts",
+					"import": "./esm/index.js",
+					"require": "./cjs/index.cjs",
+					"default": "./index.js"
+				},
+				"./feature/*": {
+					"import": "./esm/feature/*.js",
+					"require": "./cjs/feature/*.cjs"
+				},
+				"./internal/*": null
+			}
+		}`,
+		"node_modules/cond-pkg/index.d.ts":          ``,
+		"node_modules/cond-pkg/index.js":            ``,
+		"node_modules/cond-pkg/esm/index.js":        ``,
+		"node_modules/cond-pkg/cjs/index.cjs":       ``,
+		"node_modules/cond-pkg/esm/feature/a.js":    ``,
+		"node_modules/cond-pkg/cjs/feature/a.cjs":   ``,
+		"node_modules/cond-pkg/internal/secret.js":  ``,
+		"node_modules/cond-pkg/not-exported/foo.js": ``,
+
+		// 使用 typesVersions 的包
+		"node_modules/tv-pkg/package.json":     `{"name": "tv-pkg", "main": "index.js", "typesVersions": {"<4.0": {"*": ["ts3/*"]}, ">=4.1": {"*": ["ts4.1/*"]}}}`,
+		"node_modules/tv-pkg/index.js":         ``,
+		"node_modules/tv-pkg/sub.js":           ``,
+		"node_modules/tv-pkg/ts4.1/sub.d.ts":   ``,
+		"node_modules/tv-pkg/ts3/sub.d.ts":     ``,
+		"node_modules/tv-pkg/ts4.1/index.d.ts": ``,
+	}
+	for rel, content := range files {
+		full := filepath.Join(root, rel)
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+// TestResolvePackageSource 测试 npm 包与 `#` 导入按 package.json 字段解析到具体文件。
+func TestResolvePackageSource(t *testing.T) {
+	root := setupPackageResolverProject(t)
+	importerPath := filepath.Join(root, "src", "main.ts")
+	extensions := []string{".ts", ".tsx", ".d.ts", ".js", ".jsx"}
+	nodeModules := filepath.Join(root, "node_modules")
+
+	tests := []struct {
+		name         string
+		importPath   string
+		options      ModuleResolutionOptions
+		expectedType string
+		expectedPath string // Type 为 file 时对应 FilePath，为 npm 时对应 ResolvedPath
+	}{
+		{"无 exports 的子路径", "lodash-es/debounce", ModuleResolutionOptions{}, "npm", filepath.Join(nodeModules, "lodash-es", "debounce.js")},
+		{"无 exports 的包入口", "lodash-es", ModuleResolutionOptions{}, "npm", filepath.Join(nodeModules, "lodash-es", "lodash.js")},
+		{"bundler 默认优先 types 条件", "cond-pkg", ModuleResolutionOptions{Mode: "bundler"}, "npm", filepath.Join(nodeModules, "cond-pkg", "index.d.ts")},
+		{"import 条件", "cond-pkg", ModuleResolutionOptions{Conditions: []string{"import"}}, "npm", filepath.Join(nodeModules, "cond-pkg", "esm", "index.js")},
+		{"require 条件", "cond-pkg", ModuleResolutionOptions{Conditions: []string{"require"}}, "npm", filepath.Join(nodeModules, "cond-pkg", "cjs", "index.cjs")},
+		{"只剩 default 条件", "cond-pkg", ModuleResolutionOptions{Conditions: []string{"browser"}}, "npm", filepath.Join(nodeModules, "cond-pkg", "index.js")},
+		{"node16 下 CommonJS 导入方", "cond-pkg/feature/a", ModuleResolutionOptions{Mode: "node16"}, "npm", filepath.Join(nodeModules, "cond-pkg", "cjs", "feature", "a.cjs")},
+		{"子路径模式", "cond-pkg/feature/a", ModuleResolutionOptions{Conditions: []string{"import"}}, "npm", filepath.Join(nodeModules, "cond-pkg", "esm", "feature", "a.js")},
+		{"被 null 屏蔽的子路径", "cond-pkg/internal/secret", ModuleResolutionOptions{}, "npm", ""},
+		{"未导出的子路径", "cond-pkg/not-exported/foo", ModuleResolutionOptions{}, "npm", ""},
+		{"typesVersions", "tv-pkg/sub", ModuleResolutionOptions{}, "npm", filepath.Join(nodeModules, "tv-pkg", "ts4.1", "sub.d.ts")},
+		{"node10 忽略 exports", "cond-pkg/not-exported/foo", ModuleResolutionOptions{Mode: "node10", Conditions: []string{"import"}}, "npm", filepath.Join(nodeModules, "cond-pkg", "not-exported", "foo.js")},
+		{"imports 映射到项目文件", "#utils/format", ModuleResolutionOptions{}, "file", filepath.Join(root, "src", "utils", "format.ts")},
+		{"imports 映射到 npm 包", "#dep", ModuleResolutionOptions{Conditions: []string{"import"}}, "npm", filepath.Join(nodeModules, "cond-pkg", "esm", "index.js")},
+		{"不存在的包", "missing-pkg/sub", ModuleResolutionOptions{}, "npm", ""},
+		{"imports 的目标不能是另一个 # 说明符", "#cycle-a", ModuleResolutionOptions{}, "npm", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := MatchImportSource(importerPath, tt.importPath, root, nil, extensions, "")
+			source = ResolvePackageSource(importerPath, tt.importPath, source, extensions, tt.options)
+			if source.Type != tt.expectedType {
+				t.Fatalf("预期类型 %s, 得到 %s (%+v)", tt.expectedType, source.Type, source)
+			}
+			got := source.ResolvedPath
+			if source.Type == "file" {
+				got = source.FilePath
+			}
+			if got != tt.expectedPath {
+				t.Errorf("预期解析为 %q, 得到 %q", tt.expectedPath, got)
+			}
+		})
+	}
+}
+
+// TestProjectParserResolvesPackageImports 测试项目解析时按 tsconfig 的 moduleResolution 与配置的条件解析 npm 包。
+func TestProjectParserResolvesPackageImports(t *testing.T) {
+	root := setupPackageResolverProject(t)
+	tsconfig := `{"compilerOptions": {"moduleResolution": "Bundler", "customConditions": ["browser"]}}`
+	if err := os.WriteFile(filepath.Join(root, "tsconfig.json"), []byte(tsconfig), 0644); err != nil {
+		t.Fatal(err)
+	}
+	mainPath := filepath.Join(root, "src", "main.ts")
+	if err := os.WriteFile(mainPath, []byte(`import debounce from "lodash-es/debounce"; import { format } from "#utils/format";`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	config := NewProjectParserConfig(root, nil, false, nil)
+	if config.RootTsConfig.ModuleResolution != "bundler" || len(config.RootTsConfig.CustomConditions) != 1 {
+		t.Fatalf("预期读取到 moduleResolution 与 customConditions, 得到 %+v", config.RootTsConfig)
+	}
+	ppr := NewProjectParserResult(config)
+	ppr.ProjectParser()
+
+	imports := ppr.Js_Data[mainPath].ImportDeclarations
+	if len(imports) != 2 {
+		t.Fatalf("预期 2 个导入声明, 得到 %d", len(imports))
+	}
+	if imports[0].Source.NpmPkg != "lodash-es" || imports[0].Source.ResolvedPath != filepath.Join(root, "node_modules", "lodash-es", "debounce.js") {
+		t.Errorf("lodash-es/debounce 解析错误: %+v", imports[0].Source)
+	}
+	if imports[1].Source.Type != "file" || imports[1].Source.FilePath != filepath.Join(root, "src", "utils", "format.ts") {
+		t.Errorf("#utils/format 解析错误: %+v", imports[1].Source)
+	}
+}
+
+// TestProjectParserResolvesCommonJSRequire 测试 require() 以 "require" 条件解析 npm 包，并与 import 一样解析项目内文件。
+func TestProjectParserResolvesCommonJSRequire(t *testing.T) {
+	root := setupPackageResolverProject(t)
+	mainPath := filepath.Join(root, "src", "main.ts")
+	code := `import esm from "cond-pkg/feature/a";
+const cjs = require("cond-pkg/feature/a");
+const { format } = require("./utils/format");
+module.exports = { esm, cjs, format };`
+	if err := os.WriteFile(mainPath, []byte(code), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ppr := NewProjectParserResult(NewProjectParserConfig(root, nil, false, nil))
+	ppr.ProjectParser()
+
+	fileData := ppr.Js_Data[mainPath]
+	imports := fileData.ImportDeclarations
+	if len(imports) != 3 {
+		t.Fatalf("预期 3 个导入声明, 得到 %d", len(imports))
+	}
+	if imports[0].ModuleSystem != "" || imports[0].Source.ResolvedPath != filepath.Join(root, "node_modules", "cond-pkg", "esm", "feature", "a.js") {
+		t.Errorf("import 应使用 import 条件: %+v", imports[0])
+	}
+	if imports[1].ModuleSystem != "cjs" || imports[1].Source.ResolvedPath != filepath.Join(root, "node_modules", "cond-pkg", "cjs", "feature", "a.cjs") {
+		t.Errorf("require 应使用 require 条件: %+v", imports[1])
+	}
+	if imports[2].Source.Type != "file" || imports[2].Source.FilePath != filepath.Join(root, "src", "utils", "format.ts") {
+		t.Errorf("require 的相对路径解析错误: %+v", imports[2].Source)
+	}
+	if len(fileData.ExportDeclarations) != 1 || fileData.ExportDeclarations[0].ModuleSystem != "cjs" || len(fileData.ExportDeclarations[0].ExportModules) != 3 {
+		t.Errorf("module.exports 对象应转换为 3 个命名导出: %+v", fileData.ExportDeclarations)
+	}
+}
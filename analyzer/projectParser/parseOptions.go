@@ -0,0 +1,125 @@
+package projectParser
+
+import (
+	"fmt"
+	"os"
+)
+
+// ParseOptions 决定了解析结果中保留哪些占用大量内存的数据。
+//
+// 只需要提取出的声明记录（导入、导出、变量等）的场景可以使用 LowMemoryParseOptions，
+// 解析完成后立即丢弃每个文件的 AST 与原始源码，使内存占用只与记录的数量相关。
+// 声明级别的 Raw 文本与 SourceLocation 不受影响。
+type ParseOptions struct {
+	// RetainAST 为 true 时保留文件的 AST（JsFileParserResult.Ast）以及各声明结果中的 Node 指针。
+	RetainAST bool `json:"retainAST"`
+	// RetainRaw 为 true 时保留文件的原始源码（JsFileParserResult.Raw）。
+	RetainRaw bool `json:"retainRaw"`
+}
+
+// DefaultParseOptions 返回默认的解析选项：保留 AST 与原始源码。
+func DefaultParseOptions() ParseOptions {
+	return ParseOptions{RetainAST: true, RetainRaw: true}
+}
+
+// LowMemoryParseOptions 返回最省内存的解析选项：AST 与原始源码都不保留。
+func LowMemoryParseOptions() ParseOptions {
+	return ParseOptions{}
+}
+
+// Merge 返回同时满足 o 与 other 需求的解析选项。
+func (o ParseOptions) Merge(other ParseOptions) ParseOptions {
+	return ParseOptions{
+		RetainAST: o.RetainAST || other.RetainAST,
+		RetainRaw: o.RetainRaw || other.RetainRaw,
+	}
+}
+
+// Satisfies 判断按 o 解析的结果是否满足 required 的需求。
+func (o ParseOptions) Satisfies(required ParseOptions) bool {
+	return (o.RetainAST || !required.RetainAST) && (o.RetainRaw || !required.RetainRaw)
+}
+
+// apply 按解析选项丢弃结果中不需要保留的数据。
+func (o ParseOptions) apply(result *JsFileParserResult) {
+	if result == nil {
+		return
+	}
+	if !o.RetainAST {
+		result.DropAST()
+	}
+	if !o.RetainRaw {
+		result.Raw = ""
+	}
+}
+
+// DropAST 丢弃文件的 AST 以及各声明结果中的 Node 指针，使整棵 AST 可以被垃圾回收。
+// 结果中的其余字段保持不变，效果与从解析缓存恢复的结果相同。
+func (r *JsFileParserResult) DropAST() {
+	r.Ast = nil
+	for i := range r.ImportDeclarations {
+		r.ImportDeclarations[i].Node = nil
+	}
+	for i := range r.ExportDeclarations {
+		r.ExportDeclarations[i].Node = nil
+	}
+	for i := range r.ExportAssignments {
+		r.ExportAssignments[i].Node = nil
+	}
+	for name, decl := range r.InterfaceDeclarations {
+		decl.Node = nil
+		r.InterfaceDeclarations[name] = decl
+	}
+	for name, decl := range r.TypeDeclarations {
+		decl.Node = nil
+		r.TypeDeclarations[name] = decl
+	}
+	for name, decl := range r.EnumDeclarations {
+		decl.Node = nil
+		r.EnumDeclarations[name] = decl
+	}
+	for i := range r.VariableDeclarations {
+		r.VariableDeclarations[i].Node = nil
+	}
+	for i := range r.CallExpressions {
+		r.CallExpressions[i].Node = nil
+	}
+	for i := range r.JsxElements {
+		r.JsxElements[i].Node = nil
+	}
+	for i := range r.FunctionDeclarations {
+		r.FunctionDeclarations[i].Node = nil
+	}
+	for i := range r.ClassDeclarations {
+		r.ClassDeclarations[i].Node = nil
+	}
+	for i := range r.ModuleDeclarations {
+		r.ModuleDeclarations[i].Node = nil
+	}
+	for i := range r.ExtractedNodes.AnyDeclarations {
+		r.ExtractedNodes.AnyDeclarations[i].Node = nil
+	}
+	for i := range r.ExtractedNodes.AsExpressions {
+		r.ExtractedNodes.AsExpressions[i].Node = nil
+	}
+}
+
+// HasAST 判断结果是否持有 AST。按 LowMemoryParseOptions 解析或从解析缓存恢复的结果没有 AST。
+func (r JsFileParserResult) HasAST() bool {
+	return r.Ast != nil
+}
+
+// ReparseJsFile 重新解析单个文件，返回带有 AST、Node 指针与原始源码的完整结果，不修改结果容器。
+// 用于在低内存模式下按需恢复某个文件的 AST。源码优先从磁盘读取，
+// 因为 .vue / .svelte / .md 文件的 Raw 只包含提取出的脚本；读取失败时（例如内存中的项目）退回到 Js_Data 中保留的 Raw。
+func (ppr *ProjectParserResult) ReparseJsFile(targetPath string) (*JsFileParserResult, error) {
+	data, err := os.ReadFile(targetPath)
+	if err != nil {
+		existing, ok := ppr.Js_Data[targetPath]
+		if !ok || existing.Raw == "" {
+			return nil, fmt.Errorf("读取文件失败: %w", err)
+		}
+		return ppr.BuildJsFileResult(targetPath, existing.Raw)
+	}
+	return ppr.BuildJsFileResult(targetPath, string(data))
+}
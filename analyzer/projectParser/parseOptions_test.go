@@ -0,0 +1,67 @@
+package projectParser
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+)
+
+// TestLowMemoryParseOptions 测试低内存模式会丢弃 AST 与原始源码，而提取出的声明记录保持不变。
+func TestLowMemoryParseOptions(t *testing.T) {
+	rootPath, cleanup := setupTestProject(t)
+	defer cleanup()
+	mainPath := filepath.Join(rootPath, "src", "main.ts")
+
+	full := NewProjectParserResult(NewProjectParserConfig(rootPath, nil, false, []string{}))
+	full.ProjectParser()
+	if !full.Js_Data[mainPath].HasAST() || full.Js_Data[mainPath].Raw == "" {
+		t.Fatalf("默认解析选项应保留 AST 与原始源码")
+	}
+	if full.Js_Data[mainPath].ImportDeclarations[0].Node == nil {
+		t.Fatalf("默认解析选项应保留 Node 指针")
+	}
+
+	config := NewProjectParserConfig(rootPath, nil, false, []string{})
+	config.ParseOptions = LowMemoryParseOptions()
+	low := NewProjectParserResult(config)
+	low.ProjectParser()
+
+	result := low.Js_Data[mainPath]
+	if result.HasAST() || result.Raw != "" {
+		t.Errorf("低内存模式不应保留 AST 与原始源码")
+	}
+	if len(result.ImportDeclarations) != 1 || result.ImportDeclarations[0].Node != nil {
+		t.Errorf("低内存模式应保留导入记录并清除 Node 指针, 得到 %+v", result.ImportDeclarations)
+	}
+	fullJson, _ := json.Marshal(full.Js_Data)
+	lowJson, _ := json.Marshal(low.Js_Data)
+	if string(fullJson) != string(lowJson) {
+		t.Errorf("低内存模式的声明记录与默认模式不一致:\n%s\n%s", fullJson, lowJson)
+	}
+
+	// 按需重新解析可以恢复完整的结果
+	reparsed, err := low.ReparseJsFile(mainPath)
+	if err != nil {
+		t.Fatalf("重新解析失败: %v", err)
+	}
+	if !reparsed.HasAST() || reparsed.Raw == "" || reparsed.ImportDeclarations[0].Node == nil {
+		t.Errorf("重新解析的结果应包含 AST、原始源码与 Node 指针")
+	}
+}
+
+// TestParseOptionsMerge 测试解析选项的合并与满足关系。
+func TestParseOptionsMerge(t *testing.T) {
+	low := LowMemoryParseOptions()
+	astOnly := ParseOptions{RetainAST: true}
+	rawOnly := ParseOptions{RetainRaw: true}
+
+	if merged := astOnly.Merge(rawOnly); merged != DefaultParseOptions() {
+		t.Errorf("合并结果应为默认选项, 得到 %+v", merged)
+	}
+	if !low.Satisfies(low) || !DefaultParseOptions().Satisfies(astOnly) {
+		t.Errorf("保留更多数据的选项应满足保留更少数据的需求")
+	}
+	if low.Satisfies(astOnly) || rawOnly.Satisfies(astOnly) {
+		t.Errorf("未保留 AST 的选项不应满足需要 AST 的需求")
+	}
+}
@@ -7,7 +7,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
+	"sort"
 	"strings"
+	"sync"
 
 	"github.com/Flying-Bird1999/analyzer-ts/analyzer/parser"
 	"github.com/Flying-Bird1999/analyzer-ts/analyzer/scanProject"
@@ -33,6 +36,27 @@ type ProjectParserConfig struct {
 	Ignore []string
 	// IsMonorepo 是一个布尔值，指示当前分析的项目是否是一个 monorepo 仓库。
 	IsMonorepo bool
+	// Gitignore 为 true 时，扫描文件会额外遵循 .gitignore、.git/info/exclude 与 .analyzerignore 中的规则。
+	Gitignore bool
+	// Jobs 是并发解析文件时使用的 worker 数量。小于等于 0 时使用 runtime.NumCPU()。
+	Jobs int
+	// CacheDir 是持久化解析缓存的目录，为空时不启用缓存。相对路径以 RootPath 为基准。
+	// 缓存中不保存 AST，ParseOptions.RetainAST 为 true 时只写入缓存而不读取。
+	CacheDir string
+	// ModuleResolution 覆盖 tsconfig 中的 `moduleResolution`，决定 npm 包和 `#` 导入的解析方式（bundler、node16、nodenext、node10）。
+	ModuleResolution string
+	// Conditions 是解析 package.json `exports` / `imports` 时启用的条件（如 import、require、types、browser）。
+	// 为空时根据 moduleResolution 使用默认条件。
+	Conditions []string
+	// WorkspacePackages 是从 pnpm-workspace.yaml 或根 package.json 的 `workspaces` 字段中发现的 workspace 包，
+	// 键是包名。导入这些包时会解析到兄弟包的源码文件，而不是 node_modules 中的构建产物。
+	WorkspacePackages map[string]WorkspacePackage
+	// ParseOptions 决定 Js_Data 中是否保留每个文件的 AST 与原始源码，默认全部保留。
+	// 只需要声明记录的场景可以设置为 LowMemoryParseOptions()，以降低大型项目的内存占用。
+	ParseOptions ParseOptions
+
+	// diagnostics 是创建配置时（读取 tsconfig.json）遇到的问题，会被带入解析结果的 Diagnostics 中。
+	diagnostics []Diagnostic
 }
 
 // ProjectParserResult 结构体是整个项目解析过程的最终结果容器。
@@ -41,8 +65,17 @@ type ProjectParserResult struct {
 	Config       ProjectParserConfig                    `json:"config"`
 	Js_Data      map[string]JsFileParserResult          `json:"js_data"`
 	Package_Data map[string]PackageJsonFileParserResult `json:"package_data"`
-	Css_Data     map[string]CssFileInfo                 `json:"css_data"` // CSS 文件路径占位
-	Md_Data      map[string]MdFileInfo                  `json:"md_data"`  // Markdown 文件路径占位
+	Css_Data     map[string]CssFileInfo                 `json:"css_data"` // 样式文件的解析结果
+	Md_Data      map[string]MdFileInfo                  `json:"md_data"`  // Markdown 文档中的代码块
+	// WorkspaceGraph 是 workspace 包之间的依赖关系图，项目中没有 workspace 包时为 nil。
+	WorkspaceGraph *WorkspaceGraph `json:"workspace_graph,omitempty"`
+	// Diagnostics 是解析过程中遇到的所有问题（读取失败、解析失败的文件等），按产生顺序排列。
+	Diagnostics []Diagnostic `json:"diagnostics"`
+
+	// cache 是本次解析使用的持久化解析缓存，未启用时为 nil。
+	cache *ParseCache
+	// scannedFiles 是本次解析扫描到的所有文件的绝对路径（已排序），用于展开带 glob 模式的动态导入。
+	scannedFiles []string
 }
 
 // NewProjectParserConfig 创建并初始化一个项目解析器的配置对象。
@@ -50,7 +83,8 @@ type ProjectParserResult struct {
 func NewProjectParserConfig(rootPath string, ignore []string, isMonorepo bool, targetExtensions []string) ProjectParserConfig {
 	absRootPath, _ := filepath.Abs(rootPath)
 	extensions := []string{".ts", ".tsx", ".d.ts", ".js", ".jsx"}
-	rootTsConfig := ReadAliasFromTsConfig(absRootPath)
+	var diagnostics []Diagnostic
+	rootTsConfig := readAliasRecursive(filepath.Join(absRootPath, "tsconfig.json"), absRootPath, &diagnostics)
 
 	if ignore == nil || len(ignore) == 0 {
 		ignore = []string{"**/node_modules/**", "**/dist/**", "**/build/**", "**/test/**", "**/public/**", "**/static/**"}
@@ -59,7 +93,7 @@ func NewProjectParserConfig(rootPath string, ignore []string, isMonorepo bool, t
 	// 为 monorepo 项目查找所有子包的 tsconfig 别名。
 	packageTsConfigs := make(map[string]TsConfig)
 	if isMonorepo {
-		packageTsConfigs = FindAllTsConfigsAndAliases(absRootPath, ignore)
+		packageTsConfigs = findAllTsConfigsAndAliases(absRootPath, ignore, &diagnostics)
 	}
 
 	return ProjectParserConfig{
@@ -70,6 +104,9 @@ func NewProjectParserConfig(rootPath string, ignore []string, isMonorepo bool, t
 		TargetExtensions:    targetExtensions,
 		Ignore:              ignore,
 		IsMonorepo:          isMonorepo,
+		WorkspacePackages:   FindWorkspacePackages(absRootPath),
+		ParseOptions:        DefaultParseOptions(),
+		diagnostics:         diagnostics,
 	}
 }
 
@@ -81,66 +118,193 @@ func NewProjectParserResult(config ProjectParserConfig) *ProjectParserResult {
 		Package_Data: make(map[string]PackageJsonFileParserResult),
 		Css_Data:     make(map[string]CssFileInfo),
 		Md_Data:      make(map[string]MdFileInfo),
+		Diagnostics:  append([]Diagnostic{}, config.diagnostics...),
 	}
 }
 
 // ProjectParser 是项目解析的入口和总调度方法。
+// 文件的读取与解析会分发到一个有界的 worker 池中并发执行，
+// 解析结果按文件路径排序后再统一写回，保证输出与顺序执行完全一致。
 func (ppr *ProjectParserResult) ProjectParser() {
 	projectScanner := scanProject.NewProjectResult(ppr.Config.RootPath, ppr.Config.Ignore, ppr.Config.IsMonorepo)
+	projectScanner.Jobs = ppr.Config.Jobs
+	projectScanner.Gitignore = ppr.Config.Gitignore
 	projectScanner.ScanProject()
+	ppr.Diagnostics = append(ppr.Diagnostics, ScanDiagnostics(projectScanner.GetErrors())...)
 
-	extensionsToUse := ppr.Config.Extensions
-	if len(ppr.Config.TargetExtensions) > 0 {
-		extensionsToUse = ppr.Config.TargetExtensions
+	fileList := projectScanner.GetFileList()
+	paths := make([]string, 0, len(fileList))
+	for targetPath := range fileList {
+		paths = append(paths, targetPath)
+	}
+	sort.Strings(paths)
+	ppr.scannedFiles = paths
+
+	if ppr.Config.CacheDir != "" {
+		cache, err := NewParseCache(ppr.cacheDir(), ppr.cacheFingerprint(paths, fileList))
+		if err != nil {
+			ppr.Diagnostics = append(ppr.Diagnostics, newDiagnostic(ppr.cacheDir(), DiagnosticPhaseCache, DiagnosticSeverityWarning, fmt.Errorf("初始化解析缓存失败，本次将不使用缓存: %w", err)))
+		} else {
+			ppr.cache = cache
+		}
+	}
+
+	// 每个 worker 只写入自己负责的下标，无需加锁。
+	results := make([]parsedFile, len(paths))
+	tasks := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < ppr.jobs(); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range tasks {
+				results[idx] = ppr.parseFile(paths[idx], fileList[paths[idx]])
+			}
+		}()
+	}
+	for idx := range paths {
+		tasks <- idx
+	}
+	close(tasks)
+	wg.Wait()
+
+	// 按排序后的顺序合并结果，保证 Package_Data 等同名 key 的覆盖顺序是确定的。
+	for _, file := range results {
+		ppr.mergeParsedFile(file)
+	}
+
+	ppr.WorkspaceGraph = BuildWorkspaceGraph(ppr.Package_Data, ppr.Config.WorkspacePackages)
+}
+
+// parsedFile 是单个文件在 worker 中的解析产物，随后由 mergeParsedFile 统一写回结果容器。
+type parsedFile struct {
+	path string
+	js   *JsFileParserResult
+	pkg  *PackageJsonFileParserResult
+	css  *CssFileInfo
+	md   *MdFileInfo
+	// diagnostics 是解析该文件时遇到的问题
+	diagnostics []Diagnostic
+}
+
+// jobs 返回实际使用的 worker 数量。
+func (ppr *ProjectParserResult) jobs() int {
+	if ppr.Config.Jobs > 0 {
+		return ppr.Config.Jobs
 	}
+	return runtime.NumCPU()
+}
 
+// parseFile 根据文件类型完成单个文件的读取与解析，不会修改 ppr 的任何状态，因此可以被并发调用。
+func (ppr *ProjectParserResult) parseFile(targetPath string, fileDetail scanProject.FileItem) parsedFile {
 	// CSS 文件扩展名
 	cssExtensions := []string{".css", ".less", ".scss", ".sass"}
 	// MD 文件扩展名
 	mdExtensions := []string{".md", ".mdx"}
 
-	for targetPath, fileDetail := range projectScanner.GetFileList() {
-		included := false
-		for _, ext := range extensionsToUse {
-			if strings.HasSuffix(targetPath, ext) {
-				included = true
-				break
-			}
-		}
+	file := parsedFile{path: targetPath}
 
-		if included {
-			// 从磁盘读取文件内容
-			content, err := os.ReadFile(targetPath)
-			if err == nil {
-				ppr.parseJsFile(targetPath, string(content))
-			}
+	if ppr.isJsFile(targetPath) {
+		// 从磁盘读取文件内容
+		content, err := os.ReadFile(targetPath)
+		if err != nil {
+			file.addDiagnostic(DiagnosticPhaseParse, DiagnosticSeverityError, fmt.Errorf("读取文件失败: %w", err))
+		} else {
+			file.js = ppr.loadOrBuildJsFileResult(&file, string(content))
+			ppr.Config.ParseOptions.apply(file.js)
 		}
+	}
 
-		if fileDetail.FileName == "package.json" {
-			ppr.parsePackageJson(targetPath)
+	if fileDetail.FileName == "package.json" {
+		pkg, err := ppr.parsePackageJson(targetPath)
+		if err != nil {
+			file.addDiagnostic(DiagnosticPhasePackageJson, DiagnosticSeverityError, err)
 		}
+		file.pkg = pkg
+	}
 
-		// 收集 CSS 文件路径（仅占位）
-		for _, ext := range cssExtensions {
-			if strings.HasSuffix(targetPath, ext) {
-				ppr.Css_Data[targetPath] = CssFileInfo{}
+	// 解析样式文件
+	for _, ext := range cssExtensions {
+		if strings.HasSuffix(targetPath, ext) {
+			content, err := os.ReadFile(targetPath)
+			if err != nil {
+				file.addDiagnostic(DiagnosticPhaseParse, DiagnosticSeverityError, fmt.Errorf("读取文件失败: %w", err))
 				break
 			}
+			file.css = ppr.BuildCssFileResult(targetPath, string(content))
+			break
 		}
+	}
 
-		// 收集 MD 文件路径（仅占位）
-		for _, ext := range mdExtensions {
-			if strings.HasSuffix(targetPath, ext) {
-				ppr.Md_Data[targetPath] = MdFileInfo{}
+	// 提取 Markdown 文档中的代码块及其位置，代码块不参与 JS 解析；MDX 的 ESM 语句的解析结果已经随 JS 文件一起存入 Js_Data
+	for _, ext := range mdExtensions {
+		if strings.HasSuffix(targetPath, ext) {
+			content, err := os.ReadFile(targetPath)
+			if err != nil {
+				file.addDiagnostic(DiagnosticPhaseParse, DiagnosticSeverityError, fmt.Errorf("读取文件失败: %w", err))
 				break
 			}
+			file.md = &MdFileInfo{CodeBlocks: parser.ExtractMarkdownCodeBlocks(string(content))}
+			break
+		}
+	}
+
+	return file
+}
+
+// addDiagnostic 为该文件记录一条诊断信息。
+func (file *parsedFile) addDiagnostic(phase string, severity string, err error) {
+	file.diagnostics = append(file.diagnostics, newDiagnostic(file.path, phase, severity, err))
+}
+
+// isJsFile 判断文件是否需要作为 JS/TS 文件解析。设置了 TargetExtensions 时以其为准。
+// 未设置时，.vue 与 .svelte 组件文件也会被解析，其 <script> 块的结果同样存入 Js_Data；
+// .mdx 文档的 ESM 语句与组件标签同理。.md 文档中的代码块只是示例，只记录在 Md_Data 中。
+func (ppr *ProjectParserResult) isJsFile(targetPath string) bool {
+	extensionsToUse := append(append(append([]string{}, ppr.Config.Extensions...), parser.ComponentFileExtensions...), ".mdx")
+	if len(ppr.Config.TargetExtensions) > 0 {
+		extensionsToUse = ppr.Config.TargetExtensions
+	}
+	for _, ext := range extensionsToUse {
+		if strings.HasSuffix(targetPath, ext) {
+			return true
 		}
 	}
+	return false
+}
+
+// mergeParsedFile 将单个文件的解析产物写入结果容器。只能在单个 goroutine 中调用。
+func (ppr *ProjectParserResult) mergeParsedFile(file parsedFile) {
+	ppr.Diagnostics = append(ppr.Diagnostics, file.diagnostics...)
+	if file.js != nil {
+		ppr.Js_Data[file.path] = *file.js
+	}
+	if file.pkg != nil {
+		ppr.Package_Data[file.pkg.Workspace] = *file.pkg
+	}
+	if file.css != nil {
+		ppr.Css_Data[file.path] = *file.css
+	}
+	if file.md != nil {
+		ppr.Md_Data[file.path] = *file.md
+	}
 }
 
 // ProjectParserFromMemory 是一个用于内存解析的入口方法。
 func (ppr *ProjectParserResult) ProjectParserFromMemory(sources map[string]string) {
+	ppr.scannedFiles = lo.Keys(sources)
+	sort.Strings(ppr.scannedFiles)
 	for path, content := range sources {
+		if localSourceType(path) == "style" {
+			ppr.Css_Data[path] = *ppr.BuildCssFileResult(path, content)
+			continue
+		}
+		if parser.IsMarkdownFile(path) {
+			ppr.Md_Data[path] = MdFileInfo{CodeBlocks: parser.ExtractMarkdownCodeBlocks(content)}
+			if !parser.IsMdxFile(path) {
+				continue
+			}
+		}
 		ppr.parseJsFile(path, content)
 	}
 }
@@ -169,21 +333,103 @@ func (ppr *ProjectParserResult) getTsConfigForFile(targetPath string) (map[strin
 	return bestMatchAlias, bestMatchDir, bestMatchBaseUrl
 }
 
+// matchImportSource 使用 tsconfigDir 对应的配置解析导入路径。
+// 该 tsconfig 配置了 `paths` 时按完整的 `paths` 语义解析（多个候选路径、模式中间的通配符），否则退回到基于 alias 的前缀匹配。
+// 被判定为 npm 包的导入会继续按 package.json 的 exports/imports 等字段解析到具体文件。
+func (ppr *ProjectParserResult) matchImportSource(importerPath string, importPath string, alias map[string]string, tsconfigDir string, baseUrl string) SourceData {
+	return ppr.matchModuleSource(importerPath, importPath, "", alias, tsconfigDir, baseUrl)
+}
+
+// matchModuleSource 与 matchImportSource 相同，但会根据导入的模块系统选择 exports/imports 的条件：
+// CommonJS 的 require() 使用 "require" 条件，ESM 的 import 使用 "import" 条件。
+func (ppr *ProjectParserResult) matchModuleSource(importerPath string, importPath string, moduleSystem string, alias map[string]string, tsconfigDir string, baseUrl string) SourceData {
+	var sourceData SourceData
+	if paths := ppr.getTsConfigPaths(tsconfigDir); len(paths) > 0 {
+		sourceData = MatchImportSourceWithPaths(importerPath, importPath, tsconfigDir, paths, ppr.Config.Extensions, baseUrl)
+	} else {
+		sourceData = MatchImportSource(importerPath, importPath, tsconfigDir, alias, ppr.Config.Extensions, baseUrl)
+	}
+	options := ppr.getModuleResolutionOptions(tsconfigDir)
+	options.Require = moduleSystem == parser.ModuleSystemCommonJS
+	if resolved := ResolveWorkspaceSource(importPath, sourceData, ppr.Config.WorkspacePackages, ppr.Config.Extensions, options); resolved.Type == "workspace" {
+		return resolved
+	}
+	return ResolvePackageSource(importerPath, importPath, sourceData, ppr.Config.Extensions, options)
+}
+
+// getTsConfigByDir 返回位于 tsconfigDir 目录的 tsconfig 配置。
+func (ppr *ProjectParserResult) getTsConfigByDir(tsconfigDir string) (TsConfig, bool) {
+	if config, ok := ppr.Config.PackageTsConfigMaps[tsconfigDir]; ok {
+		return config, true
+	}
+	if tsconfigDir == ppr.Config.RootPath {
+		return ppr.Config.RootTsConfig, true
+	}
+	return TsConfig{}, false
+}
+
+// getTsConfigPaths 返回位于 tsconfigDir 目录的 tsconfig 中完整的 `paths` 配置。
+func (ppr *ProjectParserResult) getTsConfigPaths(tsconfigDir string) map[string][]string {
+	config, _ := ppr.getTsConfigByDir(tsconfigDir)
+	return config.Paths
+}
+
+// getModuleResolutionOptions 合并 tsconfig 与解析器配置，得到 npm 包的解析选项。解析器配置优先。
+func (ppr *ProjectParserResult) getModuleResolutionOptions(tsconfigDir string) ModuleResolutionOptions {
+	config, _ := ppr.getTsConfigByDir(tsconfigDir)
+	options := ModuleResolutionOptions{
+		Mode:             config.ModuleResolution,
+		Conditions:       ppr.Config.Conditions,
+		CustomConditions: config.CustomConditions,
+	}
+	if ppr.Config.ModuleResolution != "" {
+		options.Mode = ppr.Config.ModuleResolution
+	}
+	return options
+}
+
 // parseJsFile 负责处理单个 JS/TS 文件的解析流程。
 func (ppr *ProjectParserResult) parseJsFile(targetPath string, content string) {
-	fileParser, err := parser.NewParserFromSource(targetPath, content)
+	result, err := ppr.BuildJsFileResult(targetPath, content)
 	if err != nil {
-		// TODO: handle error
-		fmt.Printf("创建 parser 失败：%v\n", err)
+		ppr.Diagnostics = append(ppr.Diagnostics, newDiagnostic(targetPath, DiagnosticPhaseParse, DiagnosticSeverityError, err))
 		return
 	}
+	ppr.Diagnostics = append(ppr.Diagnostics, jsResultDiagnostics(targetPath, result)...)
+	ppr.Config.ParseOptions.apply(result)
+	ppr.Js_Data[targetPath] = *result
+}
+
+// jsResultDiagnostics 将单个文件解析结果中的错误转换为 warning 级别的诊断信息，
+// 这些错误发生在遍历 AST 时，文件的其余部分仍然被正常解析。
+func jsResultDiagnostics(targetPath string, result *JsFileParserResult) []Diagnostic {
+	var diagnostics []Diagnostic
+	for _, err := range result.Errors {
+		diagnostics = append(diagnostics, newDiagnostic(targetPath, DiagnosticPhaseParse, DiagnosticSeverityWarning, err))
+	}
+	return diagnostics
+}
+
+// BuildJsFileResult 解析单个 JS/TS 文件并返回其解析结果，不修改结果容器。
+// 无法创建解析器时返回错误；遍历 AST 时遇到的错误记录在结果的 Errors 中。
+func (ppr *ProjectParserResult) BuildJsFileResult(targetPath string, content string) (*JsFileParserResult, error) {
+	result, _, err := ppr.buildJsFileResult(targetPath, content)
+	return result, err
+}
+
+// buildJsFileResult 与 BuildJsFileResult 相同，同时返回解析过程中发起的模块路径解析请求，供解析缓存在命中时校验解析结果。
+func (ppr *ProjectParserResult) buildJsFileResult(targetPath string, content string) (*JsFileParserResult, []cacheModuleRequest, error) {
+	fileParser, err := parser.NewParserFromSource(targetPath, content)
+	if err != nil {
+		return nil, nil, fmt.Errorf("创建 parser 失败: %w", err)
+	}
 	fileParser.Traverse()
 	result := fileParser.Result.GetResult()
 
 	// 为当前文件获取最匹配的路径别名配置和其所在目录
 	aliasForFile, tsconfigDir, baseUrl := ppr.getTsConfigForFile(targetPath)
 
-	ppr.Js_Data[targetPath] = JsFileParserResult{
+	return &JsFileParserResult{
 		Ast:                   fileParser.Ast,
 		Raw:                   fileParser.SourceCode, // 传递原始源码
 		ImportDeclarations:    ppr.TransformImportDeclarations(targetPath, result.ImportDeclarations, aliasForFile, tsconfigDir, baseUrl),
@@ -196,72 +442,137 @@ func (ppr *ProjectParserResult) parseJsFile(targetPath string, content string) {
 		CallExpressions:       result.CallExpressions,
 		JsxElements:           ppr.TransformJsxElements(targetPath, result.JsxElements, aliasForFile, tsconfigDir, baseUrl),
 		FunctionDeclarations:  result.FunctionDeclarations,
+		ClassDeclarations:     result.ClassDeclarations,
+		ModuleDeclarations:    ppr.TransformModuleDeclarations(targetPath, result.ModuleDeclarations, aliasForFile, tsconfigDir, baseUrl),
+		CssModuleReferences:   result.CssModuleReferences,
 		ExtractedNodes:        result.ExtractedNodes,
 		Errors:                fileParser.Result.Errors, // 使用 fileParser.Result.Errors 替换 result.Errors
+	}, moduleRequests(result), nil
+}
+
+// BuildCssFileResult 解析单个样式文件并返回其解析结果，不修改结果容器。
+func (ppr *ProjectParserResult) BuildCssFileResult(targetPath string, content string) *CssFileInfo {
+	result := parser.ParseStylesheet(targetPath, content)
+	aliasForFile, tsconfigDir, baseUrl := ppr.getTsConfigForFile(targetPath)
+	return &CssFileInfo{
+		ImportDeclarations: ppr.TransformStyleImportDeclarations(targetPath, result.ImportDeclarations, aliasForFile, tsconfigDir, baseUrl),
+		ClassNames:         result.ClassNames,
+		CustomProperties:   result.CustomProperties,
 	}
 }
 
-// parsePackageJson 负责处理单个 `package.json` 文件的解析。
-func (ppr *ProjectParserResult) parsePackageJson(targetPath string) {
+// parsePackageJson 负责处理单个 `package.json` 文件的解析，返回解析结果而不修改结果容器。
+func (ppr *ProjectParserResult) parsePackageJson(targetPath string) (*PackageJsonFileParserResult, error) {
 	packageJsonInfo, err := GetPackageJson(targetPath)
 	if err != nil {
-		fmt.Printf("解析 package.json 失败: %v\n", err)
-		return
+		return nil, err
 	}
 
+	// 使用相对于项目根目录的路径作为 key，避免 apps/web 与 packages/web 这类同名目录互相覆盖。
 	workspaceKey := "root"
-	if filepath.Dir(targetPath) != ppr.Config.RootPath {
-		workspaceKey = filepath.Base(filepath.Dir(targetPath))
+	if rel, err := filepath.Rel(ppr.Config.RootPath, filepath.Dir(targetPath)); err == nil && rel != "." {
+		workspaceKey = filepath.ToSlash(rel)
 	}
 
-	ppr.Package_Data[workspaceKey] = PackageJsonFileParserResult{
+	return &PackageJsonFileParserResult{
 		Workspace: workspaceKey,
 		Path:      targetPath,
 		Namespace: packageJsonInfo.Name,
 		Version:   packageJsonInfo.Version,
 		NpmList:   packageJsonInfo.NpmList,
-	}
+	}, nil
 }
 
 // TransformImportDeclarations 将导入声明转换为高级格式，并使用给定的别名映射来解析模块源。
+// 带有 glob 模式的动态导入会按 ExpandImportPattern 展开为多条导入记录。
 func (ppr *ProjectParserResult) TransformImportDeclarations(importerPath string, decls []parser.ImportDeclarationResult, alias map[string]string, tsconfigDir string, baseUrl string) []ImportDeclarationResult {
+	results := make([]ImportDeclarationResult, 0, len(decls))
+	for _, decl := range decls {
+		var sources []SourceData
+		if decl.Pattern != "" {
+			sources = ppr.ExpandImportPattern(importerPath, decl.Pattern, decl.PatternFilter, alias, tsconfigDir, baseUrl)
+		} else {
+			sources = append(sources, ppr.matchModuleSource(importerPath, decl.Source, decl.ModuleSystem, alias, tsconfigDir, baseUrl))
+		}
+		for _, sourceData := range sources {
+			results = append(results, ImportDeclarationResult{
+				ImportModules: lo.Map(decl.ImportModules, func(module parser.ImportModule, _ int) ImportModule {
+					return ImportModule{
+						ImportModule: module.ImportModule,
+						Type:         module.Type,
+						Identifier:   module.Identifier,
+						IsTypeOnly:   module.IsTypeOnly,
+					}
+				}),
+				Raw:            decl.Raw,
+				Source:         sourceData,
+				ModuleSystem:   decl.ModuleSystem,
+				IsTypeOnly:     decl.IsTypeOnly,
+				IsDynamic:      decl.IsDynamic,
+				Pattern:        decl.Pattern,
+				SourceLocation: decl.SourceLocation,
+				Node:           decl.Node, // 传递 Node 指针
+			})
+		}
+	}
+	return results
+}
+
+// TransformStyleImportDeclarations 将样式文件中的 `@import`/`@use` 依赖转换为高级格式，来源的解析规则见 matchStyleSource。
+func (ppr *ProjectParserResult) TransformStyleImportDeclarations(importerPath string, decls []parser.ImportDeclarationResult, alias map[string]string, tsconfigDir string, baseUrl string) []ImportDeclarationResult {
 	return lo.Map(decls, func(decl parser.ImportDeclarationResult, _ int) ImportDeclarationResult {
-		sourceData := MatchImportSource(
-			importerPath,
-			decl.Source,
-			tsconfigDir, // 使用 tsconfig 所在的目录作为解析基准
-			alias,
-			ppr.Config.Extensions,
-			baseUrl,
-		)
 		return ImportDeclarationResult{
-			ImportModules: lo.Map(decl.ImportModules, func(module parser.ImportModule, _ int) ImportModule {
-				return ImportModule{
-					ImportModule: module.ImportModule,
-					Type:         module.Type,
-					Identifier:   module.Identifier,
-				}
-			}),
-			Raw:    decl.Raw,
-			Source: sourceData,
-			Node:   decl.Node, // 传递 Node 指针
+			ImportModules:  []ImportModule{},
+			Raw:            decl.Raw,
+			Source:         ppr.matchStyleSource(importerPath, decl.Source, alias, tsconfigDir, baseUrl),
+			SourceLocation: decl.SourceLocation,
 		}
 	})
 }
 
+// matchStyleSource 解析样式文件中的导入路径。与 JS 导入相同，依次尝试路径别名、相对路径、baseUrl 与 npm 包，
+// 但补全的扩展名为样式扩展名。此外：
+//   - webpack 的 `~` 前缀 (e.g., "~antd/lib/style") 表示 npm 包；
+//   - Sass 与 LESS 中不带 "./" 的路径首先相对于当前文件解析；
+//   - SCSS 的 partial 文件：`@use 'vars'` 可以指向 `_vars.scss`。
+func (ppr *ProjectParserResult) matchStyleSource(importerPath string, importPath string, alias map[string]string, tsconfigDir string, baseUrl string) SourceData {
+	extensions := lo.Uniq(append([]string{filepath.Ext(importerPath)}, StyleFileExtensions...))
+	resolve := func(path string) SourceData {
+		if paths := ppr.getTsConfigPaths(tsconfigDir); len(paths) > 0 {
+			return MatchImportSourceWithPaths(importerPath, path, tsconfigDir, paths, extensions, baseUrl)
+		}
+		return MatchImportSource(importerPath, path, tsconfigDir, alias, extensions, baseUrl)
+	}
+
+	candidates := []string{importPath}
+	if strings.HasPrefix(importPath, "~") {
+		importPath = strings.TrimPrefix(strings.TrimPrefix(importPath, "~"), "/")
+		candidates = []string{importPath}
+	} else if !isRelativePath(importPath) && !strings.HasPrefix(importPath, "/") {
+		candidates = []string{"./" + importPath, importPath}
+	}
+	for _, candidate := range candidates {
+		dir, base := filepath.Split(candidate)
+		for _, path := range []string{candidate, dir + "_" + base} {
+			if sourceData := resolve(path); sourceData.Type != "npm" && sourceData.Type != "unknown" {
+				return sourceData
+			}
+		}
+	}
+
+	sourceData := resolve(importPath)
+	if sourceData.Type != "npm" {
+		return sourceData
+	}
+	return ResolvePackageSource(importerPath, importPath, sourceData, extensions, ppr.getModuleResolutionOptions(tsconfigDir))
+}
+
 // TransformExportDeclarations 将导出声明转换为高级格式，并使用给定的别名映射来解析模块源。
 func (ppr *ProjectParserResult) TransformExportDeclarations(importerPath string, decls []parser.ExportDeclarationResult, alias map[string]string, tsconfigDir string, baseUrl string) []ExportDeclarationResult {
 	return lo.Map(decls, func(decl parser.ExportDeclarationResult, _ int) ExportDeclarationResult {
 		var sourceData *SourceData
 		if decl.Source != "" {
-			data := MatchImportSource(
-				importerPath,
-				decl.Source,
-				tsconfigDir, // 使用 tsconfig 所在的目录作为解析基准
-				alias,
-				ppr.Config.Extensions,
-				baseUrl,
-			)
+			data := ppr.matchModuleSource(importerPath, decl.Source, decl.ModuleSystem, alias, tsconfigDir, baseUrl)
 			sourceData = &data
 		}
 
@@ -271,11 +582,31 @@ func (ppr *ProjectParserResult) TransformExportDeclarations(importerPath string,
 					ModuleName: module.ModuleName,
 					Type:       module.Type,
 					Identifier: module.Identifier,
+					IsTypeOnly: module.IsTypeOnly,
 				}
 			}),
-			Raw:    decl.Raw,
-			Source: sourceData,
-			Node:   decl.Node, // 传递 Node 指针
+			Raw:            decl.Raw,
+			Source:         sourceData,
+			ModuleSystem:   decl.ModuleSystem,
+			IsTypeOnly:     decl.IsTypeOnly,
+			SourceLocation: decl.SourceLocation,
+			Node:           decl.Node, // 传递 Node 指针
+		}
+	})
+}
+
+// TransformModuleDeclarations 将模块声明转换为高级格式，并像导入一样解析 `declare module 'x'` 的目标模块。
+func (ppr *ProjectParserResult) TransformModuleDeclarations(importerPath string, decls []parser.ModuleDeclarationResult, alias map[string]string, tsconfigDir string, baseUrl string) []ModuleDeclarationResult {
+	return lo.Map(decls, func(decl parser.ModuleDeclarationResult, _ int) ModuleDeclarationResult {
+		var sourceData *SourceData
+		if decl.Kind == parser.ModuleKindModule && !strings.Contains(decl.Name, "*") {
+			data := ppr.matchModuleSource(importerPath, decl.Name, "", alias, tsconfigDir, baseUrl)
+			sourceData = &data
+		}
+
+		return ModuleDeclarationResult{
+			ModuleDeclarationResult: decl,
+			Source:                  sourceData,
 		}
 	})
 }
@@ -283,25 +614,11 @@ func (ppr *ProjectParserResult) TransformExportDeclarations(importerPath string,
 // TransformJsxElements 将JSX元素转换为高级格式，并解析其组件来源。
 func (ppr *ProjectParserResult) TransformJsxElements(importerPath string, elements []parser.JSXElement, alias map[string]string, tsconfigDir string, baseUrl string) []JSXElementResult {
 	return lo.Map(elements, func(element parser.JSXElement, _ int) JSXElementResult {
-		// 构建组件名称（取最后一个作为组件名）
-		componentName := ""
-		if len(element.ComponentChain) > 0 {
-			componentName = element.ComponentChain[len(element.ComponentChain)-1]
-		}
-
 		// 对于自定义组件（非HTML标签），尝试解析其来源
 		var sourceData SourceData
-		if componentName != "" && strings.ToLower(componentName[0:1]) != componentName[0:1] {
-			// 这是一个自定义组件（首字母大写），尝试解析其来源
+		if componentName := customComponentName(element); componentName != "" {
 			// 简化处理：假设组件名与导入的模块名相同
-			sourceData = MatchImportSource(
-				importerPath,
-				componentName,
-				tsconfigDir,
-				alias,
-				ppr.Config.Extensions,
-				baseUrl,
-			)
+			sourceData = ppr.matchImportSource(importerPath, componentName, alias, tsconfigDir, baseUrl)
 		}
 
 		return JSXElementResult{
@@ -313,3 +630,16 @@ func (ppr *ProjectParserResult) TransformJsxElements(importerPath string, elemen
 		}
 	})
 }
+
+// customComponentName 返回 JSX 元素的组件名（组件链中的最后一个名称）。
+// 只有首字母大写的自定义组件才返回组件名，HTML 标签返回空字符串。
+func customComponentName(element parser.JSXElement) string {
+	if len(element.ComponentChain) == 0 {
+		return ""
+	}
+	componentName := element.ComponentChain[len(element.ComponentChain)-1]
+	if componentName == "" || strings.ToLower(componentName[0:1]) == componentName[0:1] {
+		return ""
+	}
+	return componentName
+}
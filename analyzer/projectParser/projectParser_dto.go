@@ -5,14 +5,22 @@ import (
 	"github.com/Zzzen/typescript-go/use-at-your-own-risk/ast"
 )
 
-// CssFileInfo CSS 文件路径占位（仅用于标记文件存在，实际解析由 UM-Creator 完成）
+// CssFileInfo 存储了对单个样式文件（.css/.less/.scss/.sass）解析后得到的数据。
 type CssFileInfo struct {
-	// 保留空结构，仅用于占位
+	// ImportDeclarations 是 `@import`/`@use`/`@forward` 依赖，来源按 JS 导入的规则解析，
+	// 并额外尝试样式扩展名、SCSS 的 `_partial` 文件与 webpack 的 `~` 前缀。
+	ImportDeclarations []ImportDeclarationResult `json:"importDeclarations"`
+	// ClassNames 是文件中声明的类名，按第一次出现的顺序去重。
+	ClassNames []parser.StyleClassName `json:"classNames"`
+	// CustomProperties 是文件中声明的 CSS 自定义属性 (e.g., `--primary-color: #1677ff`)。
+	CustomProperties []parser.CustomProperty `json:"customProperties"`
 }
 
-// MdFileInfo Markdown 文件路径占位（仅用于标记文件存在，实际解析由 UM-Creator 完成）
+// MdFileInfo 存储了单个 Markdown/MDX 文档的解析结果。
+// 代码块与 MDX 的 ESM 语句、组件标签的解析结果与 JS 文件一样存入 Js_Data，这里只记录代码块本身。
 type MdFileInfo struct {
-	// 保留空结构，仅用于占位
+	// CodeBlocks 是文档中的 ts/tsx/js/jsx 围栏代码块及其位置。
+	CodeBlocks []parser.MarkdownCodeBlock `json:"codeBlocks"`
 }
 
 // JsFileParserResult 结构体用于存储对单个JS或TS文件进行解析后得到的核心数据。
@@ -32,6 +40,9 @@ type JsFileParserResult struct {
 	CallExpressions       []parser.CallExpression                      `json:"callExpressions,omitempty"`       // 文件中的函数调用表达式
 	JsxElements           []JSXElementResult                           `json:"jsxElements,omitempty"`           // 文件中的JSX元素
 	FunctionDeclarations  []parser.FunctionDeclarationResult           `json:"functionsDeclarations,omitempty"` // 文件中所有函数声明的信息
+	ClassDeclarations     []parser.ClassDeclarationResult              `json:"classDeclarations,omitempty"`     // 文件中所有类声明的信息
+	ModuleDeclarations    []ModuleDeclarationResult                    `json:"moduleDeclarations,omitempty"`    // 文件中的命名空间、环境模块声明与全局扩展
+	CssModuleReferences   []parser.CssModuleReference                  `json:"cssModuleReferences,omitempty"`   // CSS Modules 的类名引用，例如 `styles.foo`
 	ExtractedNodes        parser.ExtractedNodes                        `json:"extractedNodes,omitempty"`        // 用于存储提取的节点信息
 	Errors                []error                                      `json:"errors,omitempty"`                // 新增：用于存储解析过程中遇到的错误
 	Ast                   *ast.Node                                    `json:"-"`                               // Ast a a new field to store the ast of the file
@@ -42,7 +53,8 @@ type JsFileParserResult struct {
 // 这对于理解项目的基本配置、依赖关系和在 monorepo 中的角色至关重要。
 type PackageJsonFileParserResult struct {
 	// Workspace 表示该 `package.json` 所属的工作区。
-	// 在 monorepo 项目中，这通常是子包的目录名。对于根目录或非 monorepo 项目，其值为 "root"。
+	// 其值为 `package.json` 所在目录相对于项目根目录的路径（使用 `/` 分隔），例如 "packages/web"。
+	// 对于根目录的 `package.json`，其值为 "root"。它同时也是 Package_Data 中的 key。
 	Workspace string `json:"workspace"`
 	// Path 记录了 `package.json` 文件在文件系统中的绝对路径。
 	Path string `json:"path"`
@@ -77,6 +89,17 @@ type ImportDeclarationResult struct {
 	Raw string `json:"raw,omitempty"`
 	// Source 包含了对导入来源模块的解析结果，包括其绝对路径、类型（文件或NPM包）等。
 	Source SourceData `json:"source"`
+	// ModuleSystem 是导入所使用的模块系统。ESM 的 `import` 为空，CommonJS 的 `require()` 为 "cjs"。
+	ModuleSystem string `json:"moduleSystem,omitempty"`
+	// IsTypeOnly 表示这是一个整体的类型导入，例如 `import type { A } from './mod'`。
+	IsTypeOnly bool `json:"isTypeOnly,omitempty"`
+	// IsDynamic 表示这是一个动态导入：`import()`、`import.meta.glob()` 或 `require.context()`。
+	IsDynamic bool `json:"dynamic,omitempty"`
+	// Pattern 是路径不确定的动态导入的 glob 模式（例如 "./pages/**/*.tsx"）。
+	// 这类导入会按扫描到的文件列表展开，每个匹配的文件各产生一条导入记录，它们的 Pattern 相同。
+	Pattern string `json:"pattern,omitempty"`
+	// SourceLocation 记录了该导入声明在源文件中的位置。
+	SourceLocation *parser.SourceLocation `json:"sourceLocation,omitempty"`
 	// Node 存储了该声明对应的原始 AST 节点。
 	Node *ast.Node `json:"-"`
 }
@@ -94,6 +117,25 @@ type ImportModule struct {
 	// - 对于 `import { a as b }`，它是 `b`。
 	// - 对于 `import a from ...`，它是 `a`。
 	Identifier string `json:"identifier"`
+	// IsTypeOnly 表示该模块只导入了类型：整体的类型导入中的所有模块，或 `import { type A }` 中的 `A`。
+	IsTypeOnly bool `json:"isTypeOnly,omitempty"`
+}
+
+// OnlyTypes 判断该导入声明是否只导入了类型，即整体的类型导入，或所有导入的模块都是行内的类型导入。
+// 这类导入在编译后会被移除，不构成运行时依赖。副作用导入（没有导入任何模块）不属于此类。
+func (d ImportDeclarationResult) OnlyTypes() bool {
+	if d.IsTypeOnly {
+		return true
+	}
+	if len(d.ImportModules) == 0 {
+		return false
+	}
+	for _, module := range d.ImportModules {
+		if !module.IsTypeOnly {
+			return false
+		}
+	}
+	return true
 }
 
 // ExportDeclarationResult 存储了单个导出声明（`export ...`）的完整解析结果。
@@ -105,6 +147,12 @@ type ExportDeclarationResult struct {
 	// Source 在 "re-export"（再导出）场景下（例如 `export { a } from './mod'`）不为 nil。
 	// 它包含了对来源模块的解析结果。对于常规的命名导出，此字段为 nil。
 	Source *SourceData `json:"source,omitempty"`
+	// ModuleSystem 是导出所使用的模块系统。ESM 的 `export` 为空，`module.exports` / `exports.xxx` 为 "cjs"。
+	ModuleSystem string `json:"moduleSystem,omitempty"`
+	// IsTypeOnly 表示这是一个整体的类型导出，例如 `export type { A } from './mod'`。
+	IsTypeOnly bool `json:"isTypeOnly,omitempty"`
+	// SourceLocation 记录了该导出声明在源文件中的位置。
+	SourceLocation *parser.SourceLocation `json:"sourceLocation,omitempty"`
 	// Node 存储了该声明对应的原始 AST 节点。
 	Node *ast.Node `json:"-"`
 }
@@ -120,6 +168,16 @@ type ExportModule struct {
 	// Identifier 是导出的标识符（外部名称）。
 	// - 对于 `export { a as b }`，它是 `b`。
 	Identifier string `json:"identifier"`
+	// IsTypeOnly 表示该模块只导出了类型：整体的类型导出中的所有模块，或 `export { type A }` 中的 `A`。
+	IsTypeOnly bool `json:"isTypeOnly,omitempty"`
+}
+
+// ModuleDeclarationResult 存储了单个命名空间、环境模块声明（`declare module 'x'`）或全局扩展（`declare global`）的解析结果。
+type ModuleDeclarationResult struct {
+	parser.ModuleDeclarationResult
+	// Source 仅在 `declare module 'x'` 场景下不为 nil，包含了像导入一样对扩展目标模块的解析结果。
+	// 名称中带有通配符的环境模块声明（例如 `declare module '*.svg'`）不指向具体模块，此字段为 nil。
+	Source *SourceData `json:"source,omitempty"`
 }
 
 // JSXElementResult 存储了单个JSX元素的解析结果，包括其来源信息。
@@ -136,15 +194,34 @@ type JSXElementResult struct {
 type SourceData struct {
 	// FilePath 是解析后的模块的绝对文件路径。如果来源是NPM包，则此字段为空。
 	FilePath string `json:"filePath,omitempty"`
-	// NpmPkg 是NPM包的名称。如果来源是本地文件，则此字段为空。
+	// NpmPkg 是NPM包的名称。如果来源是本地文件，则此字段为空；来源是 workspace 包时为该包的包名。
 	NpmPkg string `json:"npmPkg,omitempty"`
-	// Type 表示来源的类型，可以是 "file"（本地文件）, "npm"（NPM包）, 或 "unknown"（未知）。
+	// Type 表示来源的类型，可以是 "file"（本地脚本或组件文件）, "style"（本地样式文件，如 .css/.less/.scss）,
+	// "asset"（本地的图片、字体、JSON 等其他文件）, "npm"（NPM包）,
+	// "workspace"（monorepo 中的兄弟包，FilePath 为其源码文件）, 或 "unknown"（未知，例如找不到文件的相对路径）。
 	Type string `json:"type"`
+	// ResolvedPath 是 npm 包导入解析到的具体文件（依据 package.json 的 exports、types、main 等字段）。
+	// 仅在 Type 为 "npm" 且包能在 node_modules 中找到时填充。
+	ResolvedPath string `json:"resolvedPath,omitempty"`
+	// AliasRule 是解析时命中的路径别名规则（tsconfig `paths` 中的 key，例如 "@/*"）。未通过别名解析时为空。
+	AliasRule string `json:"aliasRule,omitempty"`
+}
+
+// IsLocal 判断来源是否为项目内的源码文件，即本地文件或解析到源码的 workspace 包。
+func (s SourceData) IsLocal() bool {
+	return s.Type == "file" || s.Type == "workspace"
 }
 
 // TsConfig holds the parsed information from a tsconfig.json file,
 // including path aliases and the base URL for module resolution.
+// Alias keeps only the first target of each alias with the `*` stripped,
+// while Paths keeps the raw `paths` patterns with all of their fallbacks.
 type TsConfig struct {
-	Alias   map[string]string `json:"alias"`
-	BaseUrl string            `json:"baseUrl"`
+	Alias   map[string]string   `json:"alias"`
+	Paths   map[string][]string `json:"paths,omitempty"`
+	BaseUrl string              `json:"baseUrl"`
+	// ModuleResolution and CustomConditions mirror the compilerOptions of the same name
+	// and drive how npm packages and `#` imports are resolved.
+	ModuleResolution string   `json:"moduleResolution,omitempty"`
+	CustomConditions []string `json:"customConditions,omitempty"`
 }
@@ -1,6 +1,7 @@
 package projectParser
 
 import (
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -116,6 +117,10 @@ func TestNewProjectParserConfig(t *testing.T) {
 	if !reflect.DeepEqual(configMono.PackageTsConfigMaps[subProjectDir].Alias, expectedSubAlias) {
 		t.Errorf("预期的子项目别名是 %+v, 得到 %+v", expectedSubAlias, configMono.PackageTsConfigMaps[subProjectDir].Alias)
 	}
+	expectedSubPaths := map[string][]string{"@/*": {"src/*"}, "@sub/*": {"./lib/*"}}
+	if !reflect.DeepEqual(configMono.PackageTsConfigMaps[subProjectDir].Paths, expectedSubPaths) {
+		t.Errorf("预期的子项目 paths 是 %+v, 得到 %+v", expectedSubPaths, configMono.PackageTsConfigMaps[subProjectDir].Paths)
+	}
 }
 
 // TestGetTsConfigForFile 测试 getTsConfigForFile 方法的正确性。
@@ -168,6 +173,7 @@ func TestTransformImportDeclarations(t *testing.T) {
 	ppr := NewProjectParserResult(config)
 
 	importerPath := filepath.Join(rootPath, "src", "main.ts")
+	location := &parser.SourceLocation{Start: parser.NodePosition{Line: 3, Column: 1}, End: parser.NodePosition{Line: 3, Column: 30}}
 	decls := []parser.ImportDeclarationResult{
 		{
 			Source: "@/App",
@@ -175,12 +181,20 @@ func TestTransformImportDeclarations(t *testing.T) {
 				{ImportModule: "default", Type: "default", Identifier: "App"},
 			},
 		},
+		{
+			Source: "@/App",
+			ImportModules: []parser.ImportModule{
+				{ImportModule: "Props", Type: "named", Identifier: "Props", IsTypeOnly: true},
+			},
+			IsTypeOnly:     true,
+			SourceLocation: location,
+		},
 	}
 
 	transformed := ppr.TransformImportDeclarations(importerPath, decls, ppr.Config.RootTsConfig.Alias, ppr.Config.RootPath, ppr.Config.RootTsConfig.BaseUrl)
 
-	if len(transformed) != 1 {
-		t.Fatalf("预期转换后有 1 个声明, 得到 %d", len(transformed))
+	if len(transformed) != 2 {
+		t.Fatalf("预期转换后有 2 个声明, 得到 %d", len(transformed))
 	}
 
 	sourceData := transformed[0].Source
@@ -191,6 +205,57 @@ func TestTransformImportDeclarations(t *testing.T) {
 	if sourceData.FilePath != expectedFilePath {
 		t.Errorf("预期的解析文件路径是 %s, 得到 %s", expectedFilePath, sourceData.FilePath)
 	}
+	if sourceData.AliasRule != "@/*" {
+		t.Errorf("预期命中的别名规则是 '@/*', 得到 '%s'", sourceData.AliasRule)
+	}
+	if transformed[0].IsTypeOnly || transformed[0].ImportModules[0].IsTypeOnly {
+		t.Errorf("预期普通导入没有类型导入标记, 得到 %+v", transformed[0])
+	}
+
+	// 类型导入 (import type { Props } from '@/App') 保留类型导入标记与位置信息，来源同样被解析
+	typeOnly := transformed[1]
+	if typeOnly.Source.FilePath != expectedFilePath {
+		t.Errorf("预期类型导入的解析文件路径是 %s, 得到 %s", expectedFilePath, typeOnly.Source.FilePath)
+	}
+	if !typeOnly.IsTypeOnly || !typeOnly.ImportModules[0].IsTypeOnly {
+		t.Errorf("预期类型导入标记被保留, 得到 %+v", typeOnly)
+	}
+	if typeOnly.SourceLocation != location {
+		t.Errorf("预期位置信息被保留, 得到 %+v", typeOnly.SourceLocation)
+	}
+}
+
+// TestTransformModuleDeclarations 测试模块声明的扩展目标是否像导入一样被解析，
+// 命名空间与带通配符的环境模块声明不解析来源。
+func TestTransformModuleDeclarations(t *testing.T) {
+	rootPath, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	config := NewProjectParserConfig(rootPath, nil, false, []string{})
+	ppr := NewProjectParserResult(config)
+
+	importerPath := filepath.Join(rootPath, "src", "main.ts")
+	decls := []parser.ModuleDeclarationResult{
+		{Name: "@/App", Kind: parser.ModuleKindModule, IsDeclare: true, IsAugmentation: true},
+		{Name: "antd", Kind: parser.ModuleKindModule, IsDeclare: true, IsAugmentation: true},
+		{Name: "*.svg", Kind: parser.ModuleKindModule, IsDeclare: true},
+		{Name: "API", Kind: parser.ModuleKindNamespace},
+	}
+
+	transformed := ppr.TransformModuleDeclarations(importerPath, decls, ppr.Config.RootTsConfig.Alias, ppr.Config.RootPath, ppr.Config.RootTsConfig.BaseUrl)
+
+	if len(transformed) != 4 {
+		t.Fatalf("预期转换后有 4 个声明, 得到 %d", len(transformed))
+	}
+	if source := transformed[0].Source; source == nil || source.Type != "file" || source.FilePath != filepath.Join(rootPath, "src", "App.ts") {
+		t.Errorf("预期 '@/App' 解析为本地文件 src/App.ts, 得到 %+v", source)
+	}
+	if source := transformed[1].Source; source == nil || source.Type != "npm" || source.NpmPkg != "antd" {
+		t.Errorf("预期 'antd' 解析为 npm 包, 得到 %+v", source)
+	}
+	if transformed[2].Source != nil || transformed[3].Source != nil {
+		t.Errorf("预期通配符模块与命名空间不解析来源, 得到 %+v, %+v", transformed[2].Source, transformed[3].Source)
+	}
 }
 
 // TestProjectParser 测试 ProjectParser 的整体功能。
@@ -222,10 +287,240 @@ func TestProjectParser(t *testing.T) {
 	if ppr.Package_Data["root"].Namespace != "root-pkg" {
 		t.Errorf("预期根 package 的命名空间是 'root-pkg', 得到 '%s'", ppr.Package_Data["root"].Namespace)
 	}
-	if _, ok := ppr.Package_Data["sub"]; !ok {
+	if _, ok := ppr.Package_Data["packages/sub"]; !ok {
 		t.Errorf("预期找到子项目 package.json 的解析数据")
 	}
-	if ppr.Package_Data["sub"].Namespace != "sub-pkg" {
-		t.Errorf("预期子 package 的命名空间是 'sub-pkg', 得到 '%s'", ppr.Package_Data["sub"].Namespace)
+	if ppr.Package_Data["packages/sub"].Namespace != "sub-pkg" {
+		t.Errorf("预期子 package 的命名空间是 'sub-pkg', 得到 '%s'", ppr.Package_Data["packages/sub"].Namespace)
+	}
+}
+
+// TestProjectParserParallelMatchesSequential 测试并发解析的结果与单 worker 顺序解析的结果完全一致。
+func TestProjectParserParallelMatchesSequential(t *testing.T) {
+	rootPath, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	parseWithJobs := func(jobs int) []byte {
+		config := NewProjectParserConfig(rootPath, nil, true, []string{})
+		config.Jobs = jobs
+		ppr := NewProjectParserResult(config)
+		ppr.ProjectParser()
+		data, err := json.Marshal(ppr)
+		if err != nil {
+			t.Fatalf("序列化解析结果失败: %v", err)
+		}
+		return data
+	}
+
+	// Config.Jobs 本身也会被序列化，比较前统一抹平
+	normalize := func(data []byte) string {
+		var generic map[string]interface{}
+		if err := json.Unmarshal(data, &generic); err != nil {
+			t.Fatalf("反序列化解析结果失败: %v", err)
+		}
+		delete(generic["config"].(map[string]interface{}), "Jobs")
+		normalized, _ := json.Marshal(generic)
+		return string(normalized)
+	}
+
+	sequential := normalize(parseWithJobs(1))
+	for _, jobs := range []int{2, 8} {
+		if parallel := normalize(parseWithJobs(jobs)); parallel != sequential {
+			t.Errorf("jobs=%d 的解析结果与顺序解析不一致", jobs)
+		}
+	}
+}
+
+// TestProjectParserComponentFiles 测试 .vue 文件会被解析并存入 Js_Data，其中的导入按原文件路径解析。
+func TestProjectParserComponentFiles(t *testing.T) {
+	rootPath, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	vuePath := filepath.Join(rootPath, "src", "Home.vue")
+	vueCode := "<template>\n  <App />\n</template>\n\n<script setup lang=\"ts\">\nimport App from '@/App'\n</script>\n"
+	if err := os.WriteFile(vuePath, []byte(vueCode), 0644); err != nil {
+		t.Fatalf("写入 Home.vue 失败: %v", err)
+	}
+
+	config := NewProjectParserConfig(rootPath, nil, false, []string{})
+	ppr := NewProjectParserResult(config)
+	ppr.ProjectParser()
+
+	vueData, ok := ppr.Js_Data[vuePath]
+	if !ok {
+		t.Fatalf("预期找到 %s 的解析数据", vuePath)
+	}
+	if len(vueData.ImportDeclarations) != 1 {
+		t.Fatalf("预期 Home.vue 中有 1 个导入声明, 得到 %d", len(vueData.ImportDeclarations))
+	}
+	importDecl := vueData.ImportDeclarations[0]
+	if expected := filepath.Join(rootPath, "src", "App.ts"); importDecl.Source.FilePath != expected {
+		t.Errorf("预期的解析文件路径是 %s, 得到 %s", expected, importDecl.Source.FilePath)
+	}
+	if importDecl.SourceLocation == nil || importDecl.SourceLocation.Start.Line != 6 {
+		t.Errorf("预期导入声明位于原文件第 6 行, 得到 %+v", importDecl.SourceLocation)
+	}
+	if len(vueData.JsxElements) != 1 || vueData.JsxElements[0].ComponentChain[0] != "App" {
+		t.Errorf("预期模板中记录 1 个 App 组件标签, 得到 %+v", vueData.JsxElements)
+	}
+}
+
+// TestProjectParserMarkdownFiles 测试 .mdx 的 ESM 语句与组件标签会被解析并存入 Js_Data，
+// .md 的代码块只记录在 Md_Data 中，不会作为真实的导入存入 Js_Data。
+func TestProjectParserMarkdownFiles(t *testing.T) {
+	rootPath, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	files := map[string]string{
+		filepath.Join("docs", "app.mdx"): "import App from '@/App'\n\n# App\n\n<App title=\"demo\" />\n",
+		"README.md":                      "# Readme\n\n```tsx\nimport App from './src/App'\nrender(<App />)\n```\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(rootPath, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("创建目录失败: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("写入 %s 失败: %v", name, err)
+		}
+	}
+
+	config := NewProjectParserConfig(rootPath, nil, false, []string{})
+	ppr := NewProjectParserResult(config)
+	ppr.ProjectParser()
+
+	appPath := filepath.Join(rootPath, "src", "App.ts")
+	data, ok := ppr.Js_Data[filepath.Join(rootPath, "docs", "app.mdx")]
+	if !ok {
+		t.Fatalf("预期找到 app.mdx 的解析数据")
+	}
+	if len(data.ImportDeclarations) != 1 || data.ImportDeclarations[0].Source.FilePath != appPath {
+		t.Errorf("预期 app.mdx 导入 %s, 得到 %+v", appPath, data.ImportDeclarations)
+	}
+	if len(data.JsxElements) != 1 || data.JsxElements[0].ComponentChain[0] != "App" {
+		t.Errorf("预期 app.mdx 中记录 1 个 App 组件标签, 得到 %+v", data.JsxElements)
+	}
+	if _, ok := ppr.Js_Data[filepath.Join(rootPath, "README.md")]; ok {
+		t.Errorf("README.md 的代码块不应存入 Js_Data")
+	}
+
+	readme, ok := ppr.Md_Data[filepath.Join(rootPath, "README.md")]
+	if !ok || len(readme.CodeBlocks) != 1 {
+		t.Fatalf("预期 README.md 中有 1 个代码块, 得到 %+v", readme)
+	}
+	if block := readme.CodeBlocks[0]; block.Lang != "tsx" || block.SourceLocation.Start.Line != 4 || block.SourceLocation.End.Line != 5 {
+		t.Errorf("代码块信息不符合预期: %+v", block)
+	}
+}
+
+// TestProjectParserDynamicImportPatterns 测试 glob 模式的动态导入按扫描到的文件列表展开为具体的导入。
+func TestProjectParserDynamicImportPatterns(t *testing.T) {
+	rootPath, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	files := map[string]string{
+		filepath.Join("src", "router.ts"):                  "const pages = import.meta.glob('./pages/**/*.tsx');\nconst icons = require.context('./icons', false, /\\.svg$/);\nconst locale = import(`@/locales/${lang}`);\nconst missing = import.meta.glob('./missing/*.ts');\n",
+		filepath.Join("src", "pages", "index.tsx"):         "export default 1;",
+		filepath.Join("src", "pages", "user", "list.tsx"):  "export default 2;",
+		filepath.Join("src", "pages", "user", "helper.ts"): "export default 3;",
+		filepath.Join("src", "icons", "add.svg"):           "<svg />",
+		filepath.Join("src", "icons", "readme.md"):         "# icons",
+		filepath.Join("src", "locales", "en.ts"):           "export default {};",
+	}
+	for name, content := range files {
+		path := filepath.Join(rootPath, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("创建目录失败: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("写入 %s 失败: %v", name, err)
+		}
+	}
+
+	config := NewProjectParserConfig(rootPath, nil, false, []string{})
+	ppr := NewProjectParserResult(config)
+	ppr.ProjectParser()
+
+	routerData, ok := ppr.Js_Data[filepath.Join(rootPath, "src", "router.ts")]
+	if !ok {
+		t.Fatalf("预期找到 router.ts 的解析数据")
+	}
+
+	got := map[string][]string{}
+	for _, decl := range routerData.ImportDeclarations {
+		if !decl.IsDynamic {
+			t.Errorf("预期所有导入都被标记为动态导入, 得到 %+v", decl)
+		}
+		got[decl.Pattern] = append(got[decl.Pattern], decl.Source.Type+":"+decl.Source.FilePath)
+	}
+	expected := map[string][]string{
+		"./pages/**/*.tsx": {
+			"file:" + filepath.Join(rootPath, "src", "pages", "index.tsx"),
+			"file:" + filepath.Join(rootPath, "src", "pages", "user", "list.tsx"),
+		},
+		"./icons/*":      {"asset:" + filepath.Join(rootPath, "src", "icons", "add.svg")},
+		"@/locales/*":    {"file:" + filepath.Join(rootPath, "src", "locales", "en.ts")},
+		"./missing/*.ts": {"unknown:./missing/*.ts"},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("动态导入展开结果不符合预期:\n得到 %v\n预期 %v", got, expected)
+	}
+}
+
+func TestProjectParserStylesheets(t *testing.T) {
+	rootPath, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	files := map[string]string{
+		filepath.Join("src", "index.module.scss"):      "@use 'vars';\n@import './reset.css';\n@import '@/styles/theme';\n@import '~antd/dist/reset.css';\n.wrapper { &-inner { --gap: 8px; } }\n",
+		filepath.Join("src", "_vars.scss"):             "$gap: 8px;\n",
+		filepath.Join("src", "reset.css"):              "html { margin: 0; }\n",
+		filepath.Join("src", "styles", "theme.scss"):   ":root { --primary: red; }\n",
+		filepath.Join("src", "components", "View.tsx"): "import styles from '../index.module.scss';\nexport const View = () => <div className={styles.wrapper} />;\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(rootPath, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("创建目录失败: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("写入 %s 失败: %v", name, err)
+		}
+	}
+
+	config := NewProjectParserConfig(rootPath, nil, false, []string{})
+	ppr := NewProjectParserResult(config)
+	ppr.ProjectParser()
+
+	styleData, ok := ppr.Css_Data[filepath.Join(rootPath, "src", "index.module.scss")]
+	if !ok {
+		t.Fatalf("预期找到 index.module.scss 的解析数据")
+	}
+	var got []string
+	for _, decl := range styleData.ImportDeclarations {
+		got = append(got, decl.Source.Type+":"+decl.Source.FilePath)
+	}
+	expected := []string{
+		"style:" + filepath.Join(rootPath, "src", "_vars.scss"),
+		"style:" + filepath.Join(rootPath, "src", "reset.css"),
+		"style:" + filepath.Join(rootPath, "src", "styles", "theme.scss"),
+		"npm:antd/dist/reset.css",
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("样式导入解析结果不符合预期:\n得到 %v\n预期 %v", got, expected)
+	}
+	if len(styleData.ClassNames) != 2 || styleData.ClassNames[1].Name != "wrapper-inner" {
+		t.Errorf("类名提取结果不符合预期: %+v", styleData.ClassNames)
+	}
+	if len(styleData.CustomProperties) != 1 || styleData.CustomProperties[0].Name != "--gap" {
+		t.Errorf("自定义属性提取结果不符合预期: %+v", styleData.CustomProperties)
+	}
+
+	viewData := ppr.Js_Data[filepath.Join(rootPath, "src", "components", "View.tsx")]
+	if len(viewData.ImportDeclarations) != 1 || viewData.ImportDeclarations[0].Source.Type != "style" {
+		t.Errorf("预期 CSS Modules 导入被解析为样式文件, 得到 %+v", viewData.ImportDeclarations)
+	}
+	if len(viewData.CssModuleReferences) != 1 || viewData.CssModuleReferences[0].ClassName != "wrapper" {
+		t.Errorf("CSS Modules 引用不符合预期: %+v", viewData.CssModuleReferences)
 	}
 }
@@ -6,10 +6,13 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
+	"github.com/Flying-Bird1999/analyzer-ts/analyzer/parser"
 	"github.com/Flying-Bird1999/analyzer-ts/analyzer/scanProject"
 	"github.com/Flying-Bird1999/analyzer-ts/analyzer/utils"
+	"github.com/samber/lo"
 
 	"github.com/tidwall/jsonc"
 )
@@ -18,6 +21,11 @@ import (
 // 并为每一个文件解析其路径别名配置。
 // 它会利用 scanProject 的能力来智能地忽略被 ignore 规则匹配的目录。
 func FindAllTsConfigsAndAliases(rootPath string, ignore []string) map[string]TsConfig {
+	return findAllTsConfigsAndAliases(rootPath, ignore, nil)
+}
+
+// findAllTsConfigsAndAliases 是 FindAllTsConfigsAndAliases 的实现，读取失败的 tsconfig 会记录到 diagnostics 中（diagnostics 可以为 nil）。
+func findAllTsConfigsAndAliases(rootPath string, ignore []string, diagnostics *[]Diagnostic) map[string]TsConfig {
 	allConfigs := make(map[string]TsConfig)
 
 	// 使用 scanProject 来获取所有未被忽略的文件列表
@@ -29,8 +37,8 @@ func FindAllTsConfigsAndAliases(rootPath string, ignore []string) map[string]TsC
 	for path, fileDetail := range fileList {
 		if fileDetail.FileName == "tsconfig.json" {
 			// 解析该 tsconfig 文件及其 `extends` 链
-			config := readAliasRecursive(path, rootPath)
-			if len(config.Alias) > 0 || config.BaseUrl != "" {
+			config := readAliasRecursive(path, rootPath, diagnostics)
+			if len(config.Alias) > 0 || len(config.Paths) > 0 || config.BaseUrl != "" || config.ModuleResolution != "" {
 				// 使用 tsconfig 文件所在的目录作为键
 				dir := filepath.Dir(path)
 				allConfigs[dir] = config
@@ -46,52 +54,79 @@ func FindAllTsConfigsAndAliases(rootPath string, ignore []string) map[string]TsC
 // ReadAliasFromTsConfig 是解析路径别名的入口函数。
 // 它从项目根目录下的 tsconfig.json 开始，递归地读取和合并所有 `extends` 链上的路径别名配置。
 func ReadAliasFromTsConfig(rootPath string) TsConfig {
-	return readAliasRecursive(filepath.Join(rootPath, "tsconfig.json"), rootPath)
+	return readAliasRecursive(filepath.Join(rootPath, "tsconfig.json"), rootPath, nil)
 }
 
 // readAliasRecursive 递归地解析 tsconfig.json 文件。
 // 它首先解析父配置文件（通过 `extends` 字段指定），然后将当前文件的别名配置覆盖到父配置之上。
-func readAliasRecursive(configPath, rootPath string) TsConfig {
+// 读取或解析失败的文件会被当作空配置处理，并记录到 diagnostics 中（diagnostics 可以为 nil）。
+func readAliasRecursive(configPath, rootPath string, diagnostics *[]Diagnostic) TsConfig {
 	// 检查 tsconfig.json 文件是否存在，如果不存在则返回空映射。
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
 		return TsConfig{Alias: make(map[string]string)}
 	}
 
 	// 解析当前 tsconfig 文件，获取其 `paths` 和 `extends` 字段。
-	paths, extendsFile, baseUrl := parseSingleTsConfig(configPath)
+	current, err := parseSingleTsConfig(configPath)
+	if err != nil && diagnostics != nil {
+		*diagnostics = append(*diagnostics, newDiagnostic(configPath, DiagnosticPhaseTsConfig, DiagnosticSeverityError, err))
+	}
+	paths, extendsFile, baseUrl := current.Paths, current.Extends, current.BaseUrl
 
 	// 如果 `extends` 字段存在，则递归解析父配置文件。
-	parentConfig := TsConfig{Alias: make(map[string]string)}
+	parentConfig := TsConfig{Alias: make(map[string]string), Paths: make(map[string][]string)}
 	if extendsFile != "" {
 		extendsPath := extendsFile
 		// 将 `extends` 的相对路径转换为绝对路径。
 		if !filepath.IsAbs(extendsPath) {
 			extendsPath = filepath.Join(filepath.Dir(configPath), extendsFile)
 		}
-		parentConfig = readAliasRecursive(filepath.Clean(extendsPath), rootPath)
+		parentConfig = readAliasRecursive(filepath.Clean(extendsPath), rootPath, diagnostics)
 	}
 
 	// 将当前文件的别名合并到父别名中。子配置会覆盖父配置中的同名别名。
-	for key, path := range paths {
-		parentConfig.Alias[key] = path
+	// Alias 只保留第一个候选路径，完整的候选列表保存在 Paths 中。
+	if parentConfig.Paths == nil {
+		parentConfig.Paths = make(map[string][]string)
+	}
+	for key, targets := range paths {
+		parentConfig.Alias[key] = targets[0]
+		parentConfig.Paths[key] = targets
 	}
 
 	// 如果当前 tsconfig.json 中定义了 baseUrl，则使用它。否则，继承父配置的。
 	if baseUrl != "" {
 		parentConfig.BaseUrl = baseUrl
 	}
+	// moduleResolution 与 customConditions 同样是子配置覆盖父配置。
+	if current.ModuleResolution != "" {
+		parentConfig.ModuleResolution = strings.ToLower(current.ModuleResolution)
+	}
+	if current.CustomConditions != nil {
+		parentConfig.CustomConditions = current.CustomConditions
+	}
 
 	// 格式化最终的别名映射，移除路径中的星号。
 	parentConfig.Alias = FormatAlias(parentConfig.Alias)
 	return parentConfig
 }
 
+// singleTsConfig 是单个 tsconfig.json 文件（不含 `extends` 链）中与模块解析相关的字段。
+type singleTsConfig struct {
+	Paths            map[string][]string
+	Extends          string
+	BaseUrl          string
+	ModuleResolution string
+	CustomConditions []string
+}
+
 // parseSingleTsConfig 解析单个 tsconfig.json 文件。
-// 它不处理递归 `extends`，仅返回当前文件的 `paths` 别名、`extends` 字段值和 `baseUrl`。
-func parseSingleTsConfig(configPath string) (map[string]string, string, string) {
+// 它不处理递归 `extends`，仅返回当前文件的 `paths` 别名（保留全部候选路径）、`extends` 字段值、`baseUrl`
+// 以及 `moduleResolution`、`customConditions`。读取或解析失败时返回空配置和错误。
+func parseSingleTsConfig(configPath string) (singleTsConfig, error) {
 	data, err := utils.ReadFileContent(configPath)
 	if err != nil {
-		return nil, "", ""
+		return singleTsConfig{}, fmt.Errorf("读取 tsconfig.json 失败: %w", err)
 	}
 
 	// 将JSONC（带注释的JSON）转换为标准JSON
@@ -101,26 +136,33 @@ func parseSingleTsConfig(configPath string) (map[string]string, string, string)
 	var tsConfig struct {
 		Extends         string `json:"extends"`
 		CompilerOptions struct {
-			BaseUrl string              `json:"baseUrl"`
-			Paths   map[string][]string `json:"paths"`
+			BaseUrl          string              `json:"baseUrl"`
+			Paths            map[string][]string `json:"paths"`
+			ModuleResolution string              `json:"moduleResolution"`
+			CustomConditions []string            `json:"customConditions"`
 		}
 	}
 
 	// 解析 JSON 数据。
 	if err := json.Unmarshal(jsonData, &tsConfig); err != nil {
-		fmt.Printf("解析 tsconfig.json 失败: path:%s, err: %v\n", configPath, err)
-		return nil, "", ""
+		return singleTsConfig{}, fmt.Errorf("解析 tsconfig.json 失败: %w", err)
 	}
 
-	// `paths` 的值是一个数组，这里我们只取每个别名对应的第一个路径。
-	paths := make(map[string]string)
+	// `paths` 的值是一个按顺序尝试的候选路径数组，忽略没有候选路径的别名。
+	paths := make(map[string][]string)
 	for key, p := range tsConfig.CompilerOptions.Paths {
 		if len(p) > 0 {
-			paths[key] = p[0]
+			paths[key] = p
 		}
 	}
 
-	return paths, tsConfig.Extends, tsConfig.CompilerOptions.BaseUrl
+	return singleTsConfig{
+		Paths:            paths,
+		Extends:          tsConfig.Extends,
+		BaseUrl:          tsConfig.CompilerOptions.BaseUrl,
+		ModuleResolution: tsConfig.CompilerOptions.ModuleResolution,
+		CustomConditions: tsConfig.CompilerOptions.CustomConditions,
+	}, nil
 }
 
 // FormatAlias 格式化路径别名映射。
@@ -145,6 +187,9 @@ func FormatAlias(alias map[string]string) map[string]string {
 // 2. 相对路径 (Relative Path)
 // 3. 基于 baseUrl 的路径
 // 4. NPM 包 (NPM Package)
+//
+// alias 是经过 FormatAlias 处理的别名映射，按前缀匹配。需要完整 tsconfig `paths` 语义
+// （多个候选路径、模式中间的通配符）时请使用 MatchImportSourceWithPaths。
 func MatchImportSource(
 	importerPath string, // 包含导入语句的文件的绝对路径
 	importPath string, // 导入语句中的原始路径 (e.g., "@/components/Button", "./utils", "react")
@@ -153,20 +198,47 @@ func MatchImportSource(
 	extensions []string, // 需要尝试的文件扩展名列表 (e.g., [".ts", ".tsx"])
 	baseUrl string, // 从 tsconfig.json 解析出的 baseUrl
 ) SourceData {
+	return matchImportSource(importerPath, importPath, basePath, aliasToPathRules(alias), extensions, baseUrl)
+}
+
+// MatchImportSourceWithPaths 与 MatchImportSource 相同，但别名部分遵循 TypeScript 对 `paths` 的处理方式：
+// 选择前缀最长的匹配规则，`*` 可以出现在模式的任意位置，并按顺序尝试规则的每一个候选路径。
+func MatchImportSourceWithPaths(
+	importerPath string, // 包含导入语句的文件的绝对路径
+	importPath string, // 导入语句中的原始路径
+	basePath string, // 用于解析路径别名的基准目录 (通常是 tsconfig.json 所在的目录)
+	paths map[string][]string, // tsconfig.json 中未经处理的 `paths` 配置
+	extensions []string, // 需要尝试的文件扩展名列表
+	baseUrl string, // 从 tsconfig.json 解析出的 baseUrl
+) SourceData {
+	return matchImportSource(importerPath, importPath, basePath, pathsToPathRules(paths), extensions, baseUrl)
+}
+
+// matchImportSource 是 MatchImportSource 与 MatchImportSourceWithPaths 的共同实现。
+// 解析到的本地文件按扩展名区分来源类型，规则见 localSourceType。
+func matchImportSource(importerPath string, importPath string, basePath string, rules []pathRule, extensions []string, baseUrl string) SourceData {
+	// Vite 等构建工具的资源查询参数 (e.g., "./logo.svg?url"、"./style.css?inline") 不属于文件路径
+	if idx := strings.IndexAny(importPath, "?#"); idx > 0 && !strings.HasPrefix(importPath, "#") {
+		importPath = importPath[:idx]
+	}
+
 	// 1. 尝试解析为路径别名
-	resolvedPath, isAliasMatch := resolveAlias(importPath, alias)
-	if isAliasMatch {
-		var searchPath string
-		if baseUrl != "" {
-			// 如果有 baseUrl，基于 basePath + baseUrl 构建路径
-			searchPath = filepath.Join(basePath, baseUrl, resolvedPath)
-		} else {
-			// 如果没有 baseUrl，直接基于 basePath 构建路径
-			searchPath = filepath.Join(basePath, resolvedPath)
-		}
-		// 如果是别名匹配，则构建正确的绝对路径。
-		if finalPath, ok := resolveAsFile(searchPath, extensions); ok {
-			return SourceData{FilePath: finalPath, Type: "file"}
+	if rule, star, ok := matchPathRule(importPath, rules); ok {
+		// 按顺序尝试每一个候选路径，第一个能解析到文件的候选路径胜出。
+		for _, target := range rule.targets {
+			resolvedPath := strings.Replace(target, "*", star, 1)
+			var searchPath string
+			if baseUrl != "" {
+				// 如果有 baseUrl，基于 basePath + baseUrl 构建路径
+				searchPath = filepath.Join(basePath, baseUrl, resolvedPath)
+			} else {
+				// 如果没有 baseUrl，直接基于 basePath 构建路径
+				searchPath = filepath.Join(basePath, resolvedPath)
+			}
+			// 如果是别名匹配，则构建正确的绝对路径。
+			if finalPath, ok := resolveAsFile(searchPath, extensions); ok {
+				return SourceData{FilePath: finalPath, Type: localSourceType(finalPath), AliasRule: rule.key}
+			}
 		}
 	}
 
@@ -175,7 +247,7 @@ func MatchImportSource(
 		// 将相对路径转换为绝对路径。
 		absPath := filepath.Join(filepath.Dir(importerPath), importPath)
 		if finalPath, ok := resolveAsFile(absPath, extensions); ok {
-			return SourceData{FilePath: finalPath, Type: "file"}
+			return SourceData{FilePath: finalPath, Type: localSourceType(finalPath)}
 		}
 	}
 
@@ -184,10 +256,15 @@ func MatchImportSource(
 		absBaseUrl := filepath.Join(basePath, baseUrl)
 		absPath := filepath.Join(absBaseUrl, importPath)
 		if finalPath, ok := resolveAsFile(absPath, extensions); ok {
-			return SourceData{FilePath: finalPath, Type: "file"}
+			return SourceData{FilePath: finalPath, Type: localSourceType(finalPath)}
 		}
 	}
 
+	// 相对路径不可能是 NPM 包，找不到文件时（例如构建时生成的文件）标记为未知
+	if isRelativePath(importPath) {
+		return SourceData{FilePath: importPath, Type: "unknown"}
+	}
+
 	// 4. 如果以上都失败，则假定为 NPM 包。
 	return SourceData{
 		FilePath: importPath, // 对于NPM包，保留原始路径
@@ -196,15 +273,98 @@ func MatchImportSource(
 	}
 }
 
-// resolveAlias 检查给定路径是否与任何一个别名匹配。
-// 如果匹配，它会用别名对应的真实路径替换掉别名部分，并返回替换后的路径和 true。
-func resolveAlias(filePath string, alias map[string]string) (string, bool) {
-	for key, realPath := range alias {
-		if strings.HasPrefix(filePath, key) {
-			return strings.Replace(filePath, key, realPath, 1), true
+// scriptFileExtensions 是解析为 "file" 类型的脚本文件扩展名，".d.ts" 等声明文件同样以 ".ts" 结尾。
+var scriptFileExtensions = []string{".ts", ".tsx", ".mts", ".cts", ".js", ".jsx", ".mjs", ".cjs"}
+
+// StyleFileExtensions 是解析为 "style" 类型的样式文件扩展名。
+var StyleFileExtensions = []string{".css", ".less", ".scss", ".sass", ".styl", ".stylus", ".pcss"}
+
+// localSourceType 根据解析到的本地文件的扩展名返回来源类型：
+// 脚本文件与 Vue/Svelte 组件文件为 "file"，样式文件为 "style"，图片、字体、JSON 等其他文件为 "asset"。
+func localSourceType(filePath string) string {
+	ext := strings.ToLower(filepath.Ext(filePath))
+	switch {
+	case lo.Contains(scriptFileExtensions, ext), parser.IsComponentFile(filePath):
+		return "file"
+	case lo.Contains(StyleFileExtensions, ext):
+		return "style"
+	}
+	return "asset"
+}
+
+// pathRule 表示一条路径别名规则。
+type pathRule struct {
+	key     string   // 规则在配置中的原始写法，例如 "@/*"
+	prefix  string   // 模式中 `*` 之前的部分
+	suffix  string   // 模式中 `*` 之后的部分
+	hasStar bool     // 模式中是否包含 `*`
+	targets []string // 按顺序尝试的候选路径，其中的 `*` 会被替换为匹配到的文本
+}
+
+// pathsToPathRules 将 tsconfig 中的 `paths` 配置转换为路径规则列表。
+// 规则按 key 排序，保证在前缀长度相同时匹配结果是确定的。
+func pathsToPathRules(paths map[string][]string) []pathRule {
+	keys := make([]string, 0, len(paths))
+	for key := range paths {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	rules := make([]pathRule, 0, len(keys))
+	for _, key := range keys {
+		rule := pathRule{key: key, prefix: key, targets: paths[key]}
+		if idx := strings.Index(key, "*"); idx >= 0 {
+			rule.prefix = key[:idx]
+			rule.suffix = key[idx+1:]
+			rule.hasStar = true
 		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// aliasToPathRules 将 FormatAlias 处理后的别名映射转换为路径规则列表。
+// 这类别名已经去掉了末尾的 `*`，因此按前缀匹配，等价于模式 `key*` 映射到 `path*`。
+func aliasToPathRules(alias map[string]string) []pathRule {
+	keys := make([]string, 0, len(alias))
+	for key := range alias {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	rules := make([]pathRule, 0, len(keys))
+	for _, key := range keys {
+		rules = append(rules, pathRule{key: key, prefix: key, hasStar: true, targets: []string{alias[key] + "*"}})
+	}
+	return rules
+}
+
+// matchPathRule 为导入路径选择最匹配的路径规则，规则与 TypeScript 保持一致：
+// 不含 `*` 的规则必须完全相等，且优先于所有通配规则；通配规则中选择前缀最长的一条。
+// 返回匹配到的规则以及 `*` 所匹配的文本。
+func matchPathRule(importPath string, rules []pathRule) (*pathRule, string, bool) {
+	var best *pathRule
+	for i := range rules {
+		rule := &rules[i]
+		if !rule.hasStar {
+			if rule.key == importPath {
+				return rule, "", true
+			}
+			continue
+		}
+		if len(importPath) < len(rule.prefix)+len(rule.suffix) ||
+			!strings.HasPrefix(importPath, rule.prefix) ||
+			!strings.HasSuffix(importPath, rule.suffix) {
+			continue
+		}
+		if best == nil || len(rule.prefix) > len(best.prefix) {
+			best = rule
+		}
+	}
+	if best == nil {
+		return nil, "", false
 	}
-	return filePath, false
+	return best, importPath[len(best.prefix) : len(importPath)-len(best.suffix)], true
 }
 
 // isRelativePath 检查路径是否是相对路径（以 "./" 或 "../" 开头，或就是 ".." 或 "."）。
@@ -270,15 +430,13 @@ type PackageJsonInfo struct {
 func GetPackageJson(packageJsonPath string) (*PackageJsonInfo, error) {
 	// 检查文件是否存在
 	if _, err := os.Stat(packageJsonPath); os.IsNotExist(err) {
-		fmt.Printf("package.json 文件不存在: %s\n", packageJsonPath)
-		return nil, err
+		return nil, fmt.Errorf("package.json 文件不存在: %w", err)
 	}
 
 	// 读取文件内容
 	data, err := utils.ReadFileContent(packageJsonPath)
 	if err != nil {
-		fmt.Printf("读取 package.json 文件失败: %s\n", err)
-		return nil, err
+		return nil, fmt.Errorf("读取 package.json 文件失败: %w", err)
 	}
 
 	// 定义用于解析 JSON 的匿名结构体
@@ -292,8 +450,7 @@ func GetPackageJson(packageJsonPath string) (*PackageJsonInfo, error) {
 
 	// 解析 JSON
 	if err := json.Unmarshal([]byte(data), &packageJson); err != nil {
-		fmt.Printf("解析 package.json 文件失败: %s\n", err)
-		return nil, err
+		return nil, fmt.Errorf("解析 package.json 文件失败: %w", err)
 	}
 
 	info := &PackageJsonInfo{
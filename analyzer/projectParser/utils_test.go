@@ -99,6 +99,101 @@ func TestMatchImportSource(t *testing.T) {
 	}
 }
 
+// TestMatchImportSourceWithPaths 测试按 TypeScript `paths` 语义解析路径别名。
+// 覆盖以下场景：
+// 1. 按顺序尝试多个候选路径 (`"@/*": ["src/*", "generated/*"]`)
+// 2. 模式中间的通配符 (`"@icons/*-svg"`)
+// 3. 最长前缀匹配 (`@/*` 与 `@/components/*` 同时命中时选择后者)
+// 4. 不含通配符的精确匹配
+func TestMatchImportSourceWithPaths(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFile := func(rel string) string {
+		full := filepath.Join(tmpDir, rel)
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte{}, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return full
+	}
+	appFile := writeFile("src/App.ts")
+	generatedFile := writeFile("generated/api.ts")
+	iconFile := writeFile("assets/icons/home.svg.ts")
+	buttonFile := writeFile("ui/Button.tsx")
+	configFile := writeFile("config/index.ts")
+
+	importerPath := filepath.Join(tmpDir, "src", "main.ts")
+	extensions := []string{".ts", ".tsx"}
+	paths := map[string][]string{
+		"@/*":            {"src/*", "generated/*"},
+		"@/components/*": {"ui/*"},
+		"@icons/*-svg":   {"assets/icons/*.svg"},
+		"config":         {"config"},
+	}
+
+	tests := []struct {
+		importPath   string
+		expectedPath string
+		expectedRule string
+	}{
+		{"@/App", appFile, "@/*"},
+		{"@/api", generatedFile, "@/*"},
+		{"@icons/home-svg", iconFile, "@icons/*-svg"},
+		{"@/components/Button", buttonFile, "@/components/*"},
+		{"config", configFile, "config"},
+	}
+	for _, tt := range tests {
+		sourceData := MatchImportSourceWithPaths(importerPath, tt.importPath, tmpDir, paths, extensions, "")
+		if sourceData.Type != "file" || sourceData.FilePath != tt.expectedPath || sourceData.AliasRule != tt.expectedRule {
+			t.Errorf("%s: 预期解析为 %s (规则 %s), 得到类型 %s, 路径 %s, 规则 %s",
+				tt.importPath, tt.expectedPath, tt.expectedRule, sourceData.Type, sourceData.FilePath, sourceData.AliasRule)
+		}
+	}
+
+	// 精确规则不应按前缀匹配
+	sourceData := MatchImportSourceWithPaths(importerPath, "config/other", tmpDir, paths, extensions, "")
+	if sourceData.Type != "npm" || sourceData.AliasRule != "" {
+		t.Errorf("预期 config/other 不命中精确规则 config, 得到类型 %s, 规则 %s", sourceData.Type, sourceData.AliasRule)
+	}
+}
+
+// TestMatchImportSourceAssets 测试样式、图片与 JSON 等非脚本文件的导入被解析为本地文件，
+// 并按扩展名区分 "style" 与 "asset" 类型，而不是被当作 npm 包。
+func TestMatchImportSourceAssets(t *testing.T) {
+	tmpDir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	importerPath := filepath.Join(tmpDir, "src", "main.ts")
+	alias := map[string]string{"@": "src"}
+	extensions := []string{".ts", ".tsx", ".d.ts"}
+	for _, name := range []string{"a.module.less", "logo.svg", "data.json", "legacy.js"} {
+		if err := os.WriteFile(filepath.Join(tmpDir, "src", name), []byte{}, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		importPath   string
+		expectedType string
+		expectedPath string
+	}{
+		{"./a.module.less", "style", filepath.Join(tmpDir, "src", "a.module.less")},
+		{"@/logo.svg", "asset", filepath.Join(tmpDir, "src", "logo.svg")},
+		{"./logo.svg?url", "asset", filepath.Join(tmpDir, "src", "logo.svg")},
+		{"./data.json", "asset", filepath.Join(tmpDir, "src", "data.json")},
+		{"./legacy.js", "file", filepath.Join(tmpDir, "src", "legacy.js")},
+		{"./missing.css", "unknown", "./missing.css"},
+	}
+	for _, tt := range tests {
+		sourceData := MatchImportSource(importerPath, tt.importPath, tmpDir, alias, extensions, "")
+		if sourceData.Type != tt.expectedType || sourceData.FilePath != tt.expectedPath || sourceData.NpmPkg != "" {
+			t.Errorf("%s: 预期解析为类型 %s, 路径 %s, 得到类型 %s, 路径 %s, 包 %s",
+				tt.importPath, tt.expectedType, tt.expectedPath, sourceData.Type, sourceData.FilePath, sourceData.NpmPkg)
+		}
+	}
+}
+
 // TestExtractNpmPackageName 测试 extractNpmPackageName 函数。
 // 它验证函数是否能从不同的导入路径格式中正确地提取出 NPM 包的名称。
 func TestExtractNpmPackageName(t *testing.T) {
@@ -0,0 +1,244 @@
+package projectParser
+
+import (
+	"encoding/json"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gobwas/glob"
+	"gopkg.in/yaml.v3"
+)
+
+// --- monorepo workspace 包解析 ---
+
+// WorkspacePackage 描述 monorepo 中的一个 workspace 包。
+type WorkspacePackage struct {
+	// Name 是 package.json 中的包名。
+	Name string `json:"name"`
+	// Dir 是包所在目录的绝对路径。
+	Dir string `json:"dir"`
+}
+
+// FindWorkspacePackages 读取项目根目录的 `pnpm-workspace.yaml` 以及 package.json 的 `workspaces` 字段，
+// 返回包名到 workspace 包的映射。项目不是 workspace 时返回空映射。
+//
+// `workspaces` 字段同时支持数组形式和 yarn 的 `{ "packages": [...] }` 形式；
+// 以 `!` 开头的模式表示排除。
+func FindWorkspacePackages(rootPath string) map[string]WorkspacePackage {
+	packages := make(map[string]WorkspacePackage)
+	patterns := readWorkspacePatterns(rootPath)
+	if len(patterns) == 0 {
+		return packages
+	}
+
+	var includes, excludes []glob.Glob
+	for _, pattern := range patterns {
+		negated := strings.HasPrefix(pattern, "!")
+		pattern = strings.TrimPrefix(strings.TrimPrefix(pattern, "!"), "./")
+		pattern = strings.TrimSuffix(pattern, "/")
+		g, err := glob.Compile(pattern, '/')
+		if err != nil {
+			continue
+		}
+		if negated {
+			excludes = append(excludes, g)
+		} else {
+			includes = append(includes, g)
+		}
+	}
+
+	_ = filepath.WalkDir(rootPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return nil
+		}
+		name := d.Name()
+		if path != rootPath && (name == "node_modules" || strings.HasPrefix(name, ".")) {
+			return filepath.SkipDir
+		}
+		rel, err := filepath.Rel(rootPath, path)
+		if err != nil || rel == "." {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+		if !matchAnyGlob(includes, rel) || matchAnyGlob(excludes, rel) {
+			return nil
+		}
+		manifest, ok := readPackageManifest(filepath.Join(path, "package.json"))
+		if !ok || manifest.Name == "" {
+			return nil
+		}
+		// 同名包以先遍历到的为准，WalkDir 按字典序遍历，结果稳定
+		if _, exists := packages[manifest.Name]; !exists {
+			packages[manifest.Name] = WorkspacePackage{Name: manifest.Name, Dir: path}
+		}
+		return nil
+	})
+	return packages
+}
+
+// readWorkspacePatterns 读取 pnpm-workspace.yaml 与 package.json 中声明的 workspace 目录模式。
+func readWorkspacePatterns(rootPath string) []string {
+	var patterns []string
+
+	if data, err := os.ReadFile(filepath.Join(rootPath, "pnpm-workspace.yaml")); err == nil {
+		var pnpmWorkspace struct {
+			Packages []string `yaml:"packages"`
+		}
+		if err := yaml.Unmarshal(data, &pnpmWorkspace); err == nil {
+			patterns = append(patterns, pnpmWorkspace.Packages...)
+		}
+	}
+
+	if data, err := os.ReadFile(filepath.Join(rootPath, "package.json")); err == nil {
+		var pkg struct {
+			Workspaces json.RawMessage `json:"workspaces"`
+		}
+		if err := json.Unmarshal(data, &pkg); err == nil && len(pkg.Workspaces) > 0 {
+			var list []string
+			var object struct {
+				Packages []string `json:"packages"`
+			}
+			if err := json.Unmarshal(pkg.Workspaces, &list); err == nil {
+				patterns = append(patterns, list...)
+			} else if err := json.Unmarshal(pkg.Workspaces, &object); err == nil {
+				patterns = append(patterns, object.Packages...)
+			}
+		}
+	}
+	return patterns
+}
+
+// matchAnyGlob 判断路径是否匹配任一模式。
+func matchAnyGlob(globs []glob.Glob, path string) bool {
+	for _, g := range globs {
+		if g.Match(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// workspaceOutputDirs 是构建产物所在的目录，workspace 包的入口指向这些目录时会尝试映射回源码。
+var workspaceOutputDirs = []string{"dist", "build", "lib", "es", "esm", "cjs", "types"}
+
+// ResolveWorkspaceSource 将导入 workspace 包的 npm 来源解析为兄弟包中的源码文件。
+//
+// 入口按以下顺序查找：
+//  1. package.json 的 `source` 字段（仅包入口）；
+//  2. 按 exports、types、main 等字段解析，结果是源码文件（不是 .d.ts，也不在构建产物目录中）时直接使用；
+//  3. 将构建产物路径映射回 src 目录，例如 dist/button.js → src/button.ts；
+//  4. 依次尝试 src/<子路径>（或 src/index）以及 <包目录>/<子路径>（或 index）。
+//
+// 解析成功时 Type 为 "workspace"，FilePath 为源码文件，NpmPkg 保留包名。
+// 不是 workspace 包或无法找到源码时原样返回 source。
+func ResolveWorkspaceSource(importPath string, source SourceData, workspaces map[string]WorkspacePackage, extensions []string, options ModuleResolutionOptions) SourceData {
+	if source.Type != "npm" || len(workspaces) == 0 {
+		return source
+	}
+	pkg, ok := workspaces[source.NpmPkg]
+	if !ok {
+		return source
+	}
+	subpath := strings.TrimPrefix(strings.TrimPrefix(importPath, source.NpmPkg), "/")
+	manifest, _ := readPackageManifest(filepath.Join(pkg.Dir, "package.json"))
+
+	resolved := func(filePath string) SourceData {
+		return SourceData{FilePath: filePath, NpmPkg: source.NpmPkg, Type: "workspace", AliasRule: source.AliasRule}
+	}
+
+	// 1. source 字段
+	if subpath == "" && manifest != nil && manifest.Source != "" {
+		if filePath, ok := resolveAsFile(filepath.Join(pkg.Dir, manifest.Source), extensions); ok {
+			return resolved(filePath)
+		}
+	}
+
+	// 2. exports / types / main
+	if manifest != nil {
+		conditions := conditionSet(append(activeConditions(filepath.Join(pkg.Dir, "package.json"), options), "source"))
+		entrySubpath := "."
+		if subpath != "" {
+			entrySubpath = "./" + subpath
+		}
+		if entry, ok := resolvePackageEntry(manifest, pkg.Dir, entrySubpath, extensions, conditions); ok {
+			if isWorkspaceSourceFile(pkg.Dir, entry) {
+				return resolved(entry)
+			}
+			// 3. 构建产物映射回 src
+			if filePath, ok := mapOutputToSource(pkg.Dir, entry, extensions); ok {
+				return resolved(filePath)
+			}
+		}
+	}
+
+	// 4. 约定目录
+	candidates := []string{filepath.Join(pkg.Dir, "src", "index"), filepath.Join(pkg.Dir, "index")}
+	if subpath != "" {
+		candidates = []string{filepath.Join(pkg.Dir, "src", subpath), filepath.Join(pkg.Dir, subpath)}
+	}
+	for _, candidate := range candidates {
+		if filePath, ok := resolveAsFile(candidate, extensions); ok && isWorkspaceSourceFile(pkg.Dir, filePath) {
+			return resolved(filePath)
+		}
+	}
+	return source
+}
+
+// isWorkspaceSourceFile 判断包内文件是否为源码：不是声明文件，也不在构建产物目录中。
+func isWorkspaceSourceFile(pkgDir string, filePath string) bool {
+	if isDeclarationFile(filePath) {
+		return false
+	}
+	rel, err := filepath.Rel(pkgDir, filePath)
+	if err != nil {
+		return false
+	}
+	return !isOutputDir(strings.SplitN(filepath.ToSlash(rel), "/", 2)[0])
+}
+
+// mapOutputToSource 将构建产物路径（如 dist/esm/button.js、types/button.d.ts）映射回 src 下的源码文件。
+func mapOutputToSource(pkgDir string, filePath string, extensions []string) (string, bool) {
+	rel, err := filepath.Rel(pkgDir, filePath)
+	if err != nil {
+		return "", false
+	}
+	parts := strings.Split(filepath.ToSlash(rel), "/")
+	// 去掉开头的构建产物目录，例如 dist/esm/button.js → button.js
+	for len(parts) > 1 && isOutputDir(parts[0]) {
+		parts = parts[1:]
+	}
+	stem := stripModuleExtension(strings.Join(parts, "/"))
+	return resolveAsFile(filepath.Join(pkgDir, "src", filepath.FromSlash(stem)), extensions)
+}
+
+// isOutputDir 判断目录名是否为构建产物目录。
+func isOutputDir(name string) bool {
+	for _, dir := range workspaceOutputDirs {
+		if name == dir {
+			return true
+		}
+	}
+	return false
+}
+
+// isDeclarationFile 判断文件是否为 TypeScript 声明文件。
+func isDeclarationFile(filePath string) bool {
+	for _, ext := range []string{".d.ts", ".d.mts", ".d.cts"} {
+		if strings.HasSuffix(filePath, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// stripModuleExtension 去掉路径上的 JS/TS 文件扩展名（包括 .d.ts 等复合扩展名）。
+func stripModuleExtension(path string) string {
+	for _, ext := range []string{".d.ts", ".d.mts", ".d.cts", ".ts", ".tsx", ".mts", ".cts", ".js", ".jsx", ".mjs", ".cjs"} {
+		if strings.HasSuffix(path, ext) {
+			return strings.TrimSuffix(path, ext)
+		}
+	}
+	return path
+}
@@ -0,0 +1,174 @@
+package projectParser
+
+import (
+	"sort"
+	"strings"
+)
+
+// WorkspaceGraph 描述 monorepo 中 workspace 包之间的依赖关系。
+type WorkspaceGraph struct {
+	// Packages 是图中的所有 workspace 包，按包名排序。
+	Packages []WorkspaceGraphNode `json:"packages"`
+	// Edges 是 workspace 包之间的依赖边，按 From、To 排序。
+	Edges []WorkspaceDependency `json:"edges"`
+	// TopologicalOrder 是按依赖关系排序的包名列表，被依赖的包排在依赖它的包之前。
+	// 同一层级内按包名排序，保证结果稳定。处于循环依赖中的包不会出现在这里。
+	TopologicalOrder []string `json:"topologicalOrder"`
+	// CyclicPackages 是处于循环依赖中（或依赖了循环依赖中的包）而无法排序的包，按包名排序。
+	CyclicPackages []string `json:"cyclicPackages,omitempty"`
+}
+
+// WorkspaceGraphNode 是依赖图中的一个 workspace 包。
+type WorkspaceGraphNode struct {
+	// Name 是包名。
+	Name string `json:"name"`
+	// Version 是包的版本号。
+	Version string `json:"version"`
+	// Workspace 是该包在 Package_Data 中的 key，即 package.json 所在目录相对于项目根目录的路径。
+	Workspace string `json:"workspace"`
+	// Dependencies 是该包依赖的其他 workspace 包的包名，按包名排序。
+	Dependencies []string `json:"dependencies"`
+	// Dependents 是依赖该包的其他 workspace 包的包名，按包名排序。
+	Dependents []string `json:"dependents"`
+}
+
+// WorkspaceDependency 是两个 workspace 包之间的一条依赖边。
+type WorkspaceDependency struct {
+	// From 是声明依赖的包名。
+	From string `json:"from"`
+	// To 是被依赖的包名。
+	To string `json:"to"`
+	// Type 是依赖的类型，例如 "dependencies", "devDependencies" 或 "peerDependencies"。
+	Type string `json:"type"`
+	// Version 是 package.json 中声明的版本范围，例如 "workspace:*" 或 "^1.0.0"。
+	Version string `json:"version"`
+	// WorkspaceProtocol 表示版本是否使用了 `workspace:` 协议。
+	WorkspaceProtocol bool `json:"workspaceProtocol"`
+}
+
+// BuildWorkspaceGraph 根据解析出的 package.json 构建 workspace 包之间的依赖图。
+//
+// 如果项目声明了 workspace（workspaces 不为空），只有其中的包会成为图中的节点；
+// 否则项目中除根目录外所有带包名的 package.json 都会成为节点。
+// 依赖的包名是图中的节点时即形成一条边，无论版本是 `workspace:*` 协议还是普通的版本范围。
+// 项目中没有任何节点时返回 nil。
+func BuildWorkspaceGraph(packageData map[string]PackageJsonFileParserResult, workspaces map[string]WorkspacePackage) *WorkspaceGraph {
+	nodes := make(map[string]*WorkspaceGraphNode)
+	manifests := make(map[string]PackageJsonFileParserResult)
+
+	keys := make([]string, 0, len(packageData))
+	for key := range packageData {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		pkg := packageData[key]
+		if pkg.Namespace == "" {
+			continue
+		}
+		if len(workspaces) > 0 {
+			if _, ok := workspaces[pkg.Namespace]; !ok {
+				continue
+			}
+		} else if key == "root" {
+			continue
+		}
+		// 同名包以排序后第一个出现的为准
+		if _, exists := nodes[pkg.Namespace]; exists {
+			continue
+		}
+		nodes[pkg.Namespace] = &WorkspaceGraphNode{
+			Name:         pkg.Namespace,
+			Version:      pkg.Version,
+			Workspace:    key,
+			Dependencies: []string{},
+			Dependents:   []string{},
+		}
+		manifests[pkg.Namespace] = pkg
+	}
+	if len(nodes) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(nodes))
+	for name := range nodes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	graph := &WorkspaceGraph{
+		Packages:         []WorkspaceGraphNode{},
+		Edges:            []WorkspaceDependency{},
+		TopologicalOrder: []string{},
+	}
+	for _, name := range names {
+		npmList := manifests[name].NpmList
+		depNames := make([]string, 0, len(npmList))
+		for depName := range npmList {
+			depNames = append(depNames, depName)
+		}
+		sort.Strings(depNames)
+		for _, depName := range depNames {
+			target, ok := nodes[depName]
+			if !ok || depName == name {
+				continue
+			}
+			dep := npmList[depName]
+			graph.Edges = append(graph.Edges, WorkspaceDependency{
+				From:              name,
+				To:                depName,
+				Type:              dep.Type,
+				Version:           dep.Version,
+				WorkspaceProtocol: strings.HasPrefix(dep.Version, "workspace:"),
+			})
+			nodes[name].Dependencies = append(nodes[name].Dependencies, depName)
+			target.Dependents = append(target.Dependents, name)
+		}
+	}
+
+	// 外层按包名顺序遍历，Dependents 追加后天然有序
+	for _, name := range names {
+		graph.Packages = append(graph.Packages, *nodes[name])
+	}
+	graph.TopologicalOrder, graph.CyclicPackages = topologicalSort(names, nodes)
+	return graph
+}
+
+// topologicalSort 使用 Kahn 算法对 workspace 包排序，被依赖的包排在前面。
+// 每一轮都按包名顺序取出入度为 0 的包，无法排序的包作为第二个返回值。
+func topologicalSort(names []string, nodes map[string]*WorkspaceGraphNode) ([]string, []string) {
+	remaining := make(map[string]int, len(names))
+	for _, name := range names {
+		remaining[name] = len(nodes[name].Dependencies)
+	}
+
+	order := []string{}
+	for {
+		var ready []string
+		for _, name := range names {
+			if count, ok := remaining[name]; ok && count == 0 {
+				ready = append(ready, name)
+			}
+		}
+		if len(ready) == 0 {
+			break
+		}
+		for _, name := range ready {
+			delete(remaining, name)
+			order = append(order, name)
+			for _, dependent := range nodes[name].Dependents {
+				if _, ok := remaining[dependent]; ok {
+					remaining[dependent]--
+				}
+			}
+		}
+	}
+
+	var cyclic []string
+	for _, name := range names {
+		if _, ok := remaining[name]; ok {
+			cyclic = append(cyclic, name)
+		}
+	}
+	return order, cyclic
+}
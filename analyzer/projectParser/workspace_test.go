@@ -0,0 +1,166 @@
+package projectParser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeTestFiles 在 root 下按相对路径写入测试文件。
+func writeTestFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for rel, content := range files {
+		full := filepath.Join(root, rel)
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// TestFindWorkspacePackages 测试从 pnpm-workspace.yaml 与 package.json 的 workspaces 字段发现 workspace 包。
+func TestFindWorkspacePackages(t *testing.T) {
+	t.Run("pnpm-workspace.yaml", func(t *testing.T) {
+		root := t.TempDir()
+		writeTestFiles(t, root, map[string]string{
+			"pnpm-workspace.yaml":                "packages:\n  - 'packages/*'\n  - 'apps/**'\n  - '!**/fixtures/**'\n",
+			"package.json":                       `{"name": "root"}`,
+			"packages/ui/package.json":           `{"name": "@company/ui"}`,
+			"packages/utils/package.json":        `{"name": "@company/utils"}`,
+			"apps/web/nested/package.json":       `{"name": "web"}`,
+			"apps/web/fixtures/foo/package.json": `{"name": "fixture"}`,
+			"other/package.json":                 `{"name": "other"}`,
+		})
+		packages := FindWorkspacePackages(root)
+		expected := map[string]string{
+			"@company/ui":    filepath.Join(root, "packages", "ui"),
+			"@company/utils": filepath.Join(root, "packages", "utils"),
+			"web":            filepath.Join(root, "apps", "web", "nested"),
+		}
+		if len(packages) != len(expected) {
+			t.Fatalf("预期 %d 个 workspace 包, 得到 %+v", len(expected), packages)
+		}
+		for name, dir := range expected {
+			if packages[name].Dir != dir {
+				t.Errorf("包 %s 预期目录 %s, 得到 %s", name, dir, packages[name].Dir)
+			}
+		}
+	})
+
+	t.Run("yarn workspaces 对象形式", func(t *testing.T) {
+		root := t.TempDir()
+		writeTestFiles(t, root, map[string]string{
+			"package.json":             `{"name": "root", "workspaces": {"packages": ["libs/*"]}}`,
+			"libs/core/package.json":   `{"name": "core"}`,
+			"libs/noname/package.json": `{}`,
+		})
+		packages := FindWorkspacePackages(root)
+		if len(packages) != 1 || packages["core"].Dir != filepath.Join(root, "libs", "core") {
+			t.Errorf("预期只发现 core 包, 得到 %+v", packages)
+		}
+	})
+}
+
+// TestProjectParserResolvesWorkspaceImports 测试导入 workspace 包时解析到兄弟包的源码文件。
+func TestProjectParserResolvesWorkspaceImports(t *testing.T) {
+	root := t.TempDir()
+	writeTestFiles(t, root, map[string]string{
+		"package.json": `{"name": "root", "workspaces": ["packages/*"]}`,
+		// main 指向构建产物，需要映射回 src
+		"packages/ui/package.json":    `{"name": "@company/ui", "main": "dist/index.js", "types": "dist/index.d.ts"}`,
+		"packages/ui/src/index.ts":    `export * from "./button";`,
+		"packages/ui/src/button.ts":   `export const Button = 1;`,
+		"packages/ui/dist/index.d.ts": ``,
+		// source 字段优先
+		"packages/utils/package.json":    `{"name": "@company/utils", "source": "lib-src/main.ts", "main": "lib/main.js"}`,
+		"packages/utils/lib-src/main.ts": `export const format = 1;`,
+		"packages/app/package.json":      `{"name": "app", "dependencies": {"@company/ui": "workspace:*", "react": "^18.0.0"}}`,
+		"packages/app/src/main.ts":       `import { Button } from "@company/ui"; import { Button as B } from "@company/ui/button"; import { format } from "@company/utils"; import React from "react";`,
+	})
+
+	config := NewProjectParserConfig(root, nil, true, nil)
+	if len(config.WorkspacePackages) != 3 {
+		t.Fatalf("预期 3 个 workspace 包, 得到 %+v", config.WorkspacePackages)
+	}
+	ppr := NewProjectParserResult(config)
+	ppr.ProjectParser()
+
+	imports := ppr.Js_Data[filepath.Join(root, "packages", "app", "src", "main.ts")].ImportDeclarations
+	if len(imports) != 4 {
+		t.Fatalf("预期 4 个导入声明, 得到 %d", len(imports))
+	}
+	expected := []SourceData{
+		{Type: "workspace", NpmPkg: "@company/ui", FilePath: filepath.Join(root, "packages", "ui", "src", "index.ts")},
+		{Type: "workspace", NpmPkg: "@company/ui", FilePath: filepath.Join(root, "packages", "ui", "src", "button.ts")},
+		{Type: "workspace", NpmPkg: "@company/utils", FilePath: filepath.Join(root, "packages", "utils", "lib-src", "main.ts")},
+		{Type: "npm", NpmPkg: "react"},
+	}
+	for i, want := range expected {
+		got := imports[i].Source
+		if got.Type != want.Type || got.NpmPkg != want.NpmPkg || (want.FilePath != "" && got.FilePath != want.FilePath) {
+			t.Errorf("导入 %d 预期 %+v, 得到 %+v", i, want, got)
+		}
+	}
+}
+
+// TestWorkspaceGraph 测试同名目录的 package.json 不再互相覆盖，以及 workspace 依赖图与拓扑顺序。
+func TestWorkspaceGraph(t *testing.T) {
+	root := t.TempDir()
+	writeTestFiles(t, root, map[string]string{
+		"pnpm-workspace.yaml":           "packages:\n  - 'apps/*'\n  - 'packages/*'\n",
+		"package.json":                  `{"name": "root", "devDependencies": {"typescript": "^5.0.0"}}`,
+		"apps/web/package.json":         `{"name": "web-app", "dependencies": {"@company/ui": "workspace:*", "@company/utils": "workspace:^", "react": "^18.0.0"}}`,
+		"packages/web/package.json":     `{"name": "@company/web", "version": "1.0.0", "dependencies": {"@company/utils": "^1.0.0"}}`,
+		"packages/ui/package.json":      `{"name": "@company/ui", "dependencies": {"@company/utils": "workspace:*"}, "peerDependencies": {"react": "^18.0.0"}}`,
+		"packages/utils/package.json":   `{"name": "@company/utils", "version": "1.0.0"}`,
+		"packages/cycle-a/package.json": `{"name": "cycle-a", "dependencies": {"cycle-b": "workspace:*"}}`,
+		"packages/cycle-b/package.json": `{"name": "cycle-b", "dependencies": {"cycle-a": "workspace:*"}}`,
+	})
+
+	ppr := NewProjectParserResult(NewProjectParserConfig(root, nil, true, nil))
+	ppr.ProjectParser()
+
+	for _, key := range []string{"root", "apps/web", "packages/web", "packages/ui"} {
+		if _, ok := ppr.Package_Data[key]; !ok {
+			t.Errorf("预期 Package_Data 中存在 key %q", key)
+		}
+	}
+	if ppr.Package_Data["apps/web"].Namespace != "web-app" || ppr.Package_Data["packages/web"].Namespace != "@company/web" {
+		t.Errorf("同名目录的 package.json 解析结果错误")
+	}
+
+	graph := ppr.WorkspaceGraph
+	if graph == nil {
+		t.Fatal("预期生成 workspace 依赖图")
+	}
+	if len(graph.Packages) != 6 {
+		t.Errorf("预期 6 个 workspace 包, 得到 %d", len(graph.Packages))
+	}
+	if len(graph.Edges) != 6 {
+		t.Errorf("预期 6 条依赖边, 得到 %+v", graph.Edges)
+	}
+	for _, edge := range graph.Edges {
+		if edge.From == "@company/web" && (edge.WorkspaceProtocol || edge.Version != "^1.0.0") {
+			t.Errorf("普通版本范围不应标记为 workspace 协议: %+v", edge)
+		}
+		if edge.From == "web-app" && edge.To == "@company/utils" && (!edge.WorkspaceProtocol || edge.Version != "workspace:^") {
+			t.Errorf("workspace 协议依赖解析错误: %+v", edge)
+		}
+	}
+
+	expectedOrder := []string{"@company/utils", "@company/ui", "@company/web", "web-app"}
+	if len(graph.TopologicalOrder) != len(expectedOrder) {
+		t.Fatalf("预期拓扑顺序 %v, 得到 %v", expectedOrder, graph.TopologicalOrder)
+	}
+	for i, name := range expectedOrder {
+		if graph.TopologicalOrder[i] != name {
+			t.Errorf("预期拓扑顺序 %v, 得到 %v", expectedOrder, graph.TopologicalOrder)
+			break
+		}
+	}
+	if len(graph.CyclicPackages) != 2 || graph.CyclicPackages[0] != "cycle-a" || graph.CyclicPackages[1] != "cycle-b" {
+		t.Errorf("预期 cycle-a 与 cycle-b 处于循环依赖中, 得到 %v", graph.CyclicPackages)
+	}
+}
@@ -0,0 +1,241 @@
+package scanProject
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// AnalyzerIgnoreFileName 是工具专用的忽略文件，语法与 .gitignore 完全一致，
+// 优先级高于同目录下的 .gitignore（可以用 `!` 重新包含被 .gitignore 忽略的文件）。
+const AnalyzerIgnoreFileName = ".analyzerignore"
+
+// ignoreFileNames 是每个目录下会读取的忽略文件，按优先级从低到高排列。
+var ignoreFileNames = []string{".gitignore", AnalyzerIgnoreFileName}
+
+// ignoreRule 是忽略文件中的一条规则。
+type ignoreRule struct {
+	pattern string         // 原始规则文本
+	negate  bool           // 以 `!` 开头，重新包含被之前的规则忽略的路径
+	dirOnly bool           // 以 `/` 结尾，只匹配目录
+	regex   *regexp.Regexp // 匹配相对于规则所在目录的路径
+	source  string         // 规则所在的文件及行号，例如 /repo/.gitignore:3
+}
+
+// ignoreRuleSet 是单个忽略文件中的全部规则。
+type ignoreRuleSet struct {
+	base  string // 忽略文件中的路径相对的目录
+	rules []ignoreRule
+}
+
+// gitignoreMatcher 是在某个目录下生效的全部忽略规则，按优先级从低到高排列。
+// 进入子目录时会在父目录的基础上追加子目录中的忽略文件，已有的 matcher 不会被修改，因此可以被并发使用。
+type gitignoreMatcher struct {
+	sets []*ignoreRuleSet
+}
+
+// newGitignoreMatcher 为扫描根目录创建 matcher。
+// 如果根目录位于 git 仓库中，会先加载 `.git/info/exclude`，以及仓库根目录到扫描根目录之间各级目录中的忽略文件；
+// 扫描根目录自身的忽略文件会在遍历到该目录时通过 child 加载。
+func newGitignoreMatcher(root string) *gitignoreMatcher {
+	m := &gitignoreMatcher{}
+	gitRoot, ok := findGitRoot(root)
+	if !ok {
+		return m
+	}
+	if set := loadIgnoreFile(filepath.Join(gitRoot, ".git", "info", "exclude"), gitRoot); set != nil {
+		m.sets = append(m.sets, set)
+	}
+	rel, err := filepath.Rel(gitRoot, root)
+	if err != nil || rel == "." {
+		return m
+	}
+	dir := gitRoot
+	for _, part := range strings.Split(rel, string(filepath.Separator)) {
+		m = m.child(dir)
+		dir = filepath.Join(dir, part)
+	}
+	return m
+}
+
+// findGitRoot 从 dir 开始向上查找包含 `.git` 目录的仓库根目录。
+func findGitRoot(dir string) (string, bool) {
+	for {
+		if info, err := os.Stat(filepath.Join(dir, ".git")); err == nil && info.IsDir() {
+			return dir, true
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// child 返回在 dir 目录下生效的 matcher。dir 中没有忽略文件时直接返回 m。
+func (m *gitignoreMatcher) child(dir string) *gitignoreMatcher {
+	var added []*ignoreRuleSet
+	for _, name := range ignoreFileNames {
+		if set := loadIgnoreFile(filepath.Join(dir, name), dir); set != nil {
+			added = append(added, set)
+		}
+	}
+	if len(added) == 0 {
+		return m
+	}
+	sets := make([]*ignoreRuleSet, 0, len(m.sets)+len(added))
+	sets = append(sets, m.sets...)
+	return &gitignoreMatcher{sets: append(sets, added...)}
+}
+
+// match 判断路径是否被忽略，返回最终生效的规则。
+// 与 git 一致：优先级高的忽略文件优先，同一文件中后出现的规则优先；命中 `!` 规则时不忽略。
+func (m *gitignoreMatcher) match(path string, isDir bool) (*ignoreRule, bool) {
+	for i := len(m.sets) - 1; i >= 0; i-- {
+		set := m.sets[i]
+		rel, err := filepath.Rel(set.base, path)
+		if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+			continue
+		}
+		rel = filepath.ToSlash(rel)
+		for j := len(set.rules) - 1; j >= 0; j-- {
+			rule := &set.rules[j]
+			if rule.dirOnly && !isDir {
+				continue
+			}
+			if rule.regex.MatchString(rel) {
+				return rule, !rule.negate
+			}
+		}
+	}
+	return nil, false
+}
+
+// loadIgnoreFile 读取并解析一个忽略文件，文件不存在或没有有效规则时返回 nil。
+func loadIgnoreFile(path string, base string) *ignoreRuleSet {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	set := &ignoreRuleSet{base: base}
+	scanner := bufio.NewScanner(file)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		if rule, ok := parseIgnoreRule(scanner.Text()); ok {
+			rule.source = fmt.Sprintf("%s:%d", path, lineNo)
+			set.rules = append(set.rules, rule)
+		}
+	}
+	if len(set.rules) == 0 {
+		return nil
+	}
+	return set
+}
+
+// parseIgnoreRule 按 gitignore 语法解析一行规则：
+//   - 空行和 `#` 开头的行被忽略，`\#`、`\!` 表示字面量；
+//   - 行尾未转义的空格被去除；
+//   - `!` 开头表示取反，`/` 结尾表示只匹配目录；
+//   - 除结尾外包含 `/` 的规则相对于忽略文件所在目录锚定，否则匹配任意层级的文件名；
+//   - `**/`、`/**`、`/**/` 匹配任意层级目录。
+func parseIgnoreRule(line string) (ignoreRule, bool) {
+	line = strings.TrimSuffix(line, "\r")
+	line = trimTrailingSpaces(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return ignoreRule{}, false
+	}
+
+	rule := ignoreRule{pattern: line}
+	if strings.HasPrefix(line, "!") {
+		rule.negate = true
+		line = line[1:]
+	} else if strings.HasPrefix(line, `\!`) || strings.HasPrefix(line, `\#`) {
+		line = line[1:]
+	}
+	if strings.HasSuffix(line, "/") {
+		rule.dirOnly = true
+		line = strings.TrimSuffix(line, "/")
+	}
+	if line == "" {
+		return ignoreRule{}, false
+	}
+
+	anchored := strings.Contains(line, "/")
+	line = strings.TrimPrefix(line, "/")
+	if !anchored && line != "**" {
+		line = "**/" + line
+	}
+
+	regex, err := regexp.Compile("^" + ignorePatternToRegex(line) + "$")
+	if err != nil {
+		return ignoreRule{}, false
+	}
+	rule.regex = regex
+	return rule, true
+}
+
+// trimTrailingSpaces 去掉行尾未被反斜杠转义的空格。
+func trimTrailingSpaces(line string) string {
+	for strings.HasSuffix(line, " ") && !strings.HasSuffix(line, `\ `) {
+		line = line[:len(line)-1]
+	}
+	return line
+}
+
+// ignorePatternToRegex 将 gitignore 通配符转换为正则表达式。
+func ignorePatternToRegex(pattern string) string {
+	var sb strings.Builder
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch {
+		case c == '*' && strings.HasPrefix(pattern[i:], "**"):
+			atStart := i == 0 || pattern[i-1] == '/'
+			atEnd := i+2 == len(pattern)
+			followedBySlash := i+2 < len(pattern) && pattern[i+2] == '/'
+			switch {
+			case atStart && followedBySlash:
+				// `**/` 匹配零个或多个目录
+				sb.WriteString("(?:.*/)?")
+				i += 2
+			case atStart && atEnd:
+				// `/**` 匹配目录下的所有内容
+				sb.WriteString(".*")
+				i++
+			default:
+				// 其他位置的连续星号等同于普通星号
+				sb.WriteString("[^/]*")
+				for i+1 < len(pattern) && pattern[i+1] == '*' {
+					i++
+				}
+			}
+		case c == '*':
+			sb.WriteString("[^/]*")
+		case c == '?':
+			sb.WriteString("[^/]")
+		case c == '[':
+			end := strings.IndexByte(pattern[i+1:], ']')
+			if end < 0 {
+				sb.WriteString(`\[`)
+				continue
+			}
+			class := pattern[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			sb.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			i += end + 1
+		case c == '\\' && i+1 < len(pattern):
+			i++
+			sb.WriteString(regexp.QuoteMeta(string(pattern[i])))
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return sb.String()
+}
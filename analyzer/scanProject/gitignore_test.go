@@ -0,0 +1,125 @@
+package scanProject
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+// TestScanFileListGitignore 测试启用 gitignore 后对 .gitignore、.git/info/exclude 与 .analyzerignore 的处理。
+func TestScanFileListGitignore(t *testing.T) {
+	repo := t.TempDir()
+	files := map[string]string{
+		".git/info/exclude":          "local-notes.md\n",
+		".git/HEAD":                  "ref: refs/heads/main\n",
+		".gitignore":                 "# 构建产物\n/dist/\n*.log\n!keep.log\ngenerated/\n",
+		"app/.gitignore":             "/tmp\n**/fixtures/**\n",
+		"app/.analyzerignore":        "vendor/\n!important.log\n",
+		"app/src/index.ts":           "",
+		"app/src/debug.log":          "",
+		"app/src/keep.log":           "",
+		"app/important.log":          "",
+		"app/tmp/a.ts":               "",
+		"app/src/tmp/b.ts":           "",
+		"app/src/fixtures/x/c.ts":    "",
+		"app/src/generated/d.ts":     "",
+		"app/vendor/lib.js":          "",
+		"app/local-notes.md":         "",
+		"app/dist/bundle.js":         "",
+		"dist/bundle.js":             "",
+		"app/node_modules/pkg/a.js":  "",
+		"app/src/not-generated/e.ts": "",
+	}
+	for rel, content := range files {
+		full := filepath.Join(repo, rel)
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// 扫描 git 仓库的子目录，仓库根目录的 .gitignore 与 .git/info/exclude 同样生效
+	root := filepath.Join(repo, "app")
+	pr := NewProjectResult(root, []string{"**/*.md.bak"}, false)
+	pr.Gitignore = true
+	pr.ScanFileList()
+
+	var got []string
+	for path := range pr.GetFileList() {
+		rel, _ := filepath.Rel(root, path)
+		got = append(got, filepath.ToSlash(rel))
+	}
+	sort.Strings(got)
+	expected := []string{
+		".analyzerignore",
+		".gitignore",
+		"dist/bundle.js", // /dist/ 锚定在仓库根目录，不影响 app/dist
+		"important.log",  // .analyzerignore 中的 ! 规则重新包含
+		"src/index.ts",
+		"src/keep.log",
+		"src/not-generated/e.ts",
+		"src/tmp/b.ts", // /tmp 只锚定在 app 目录
+	}
+	if len(got) != len(expected) {
+		t.Fatalf("预期文件列表 %v, 得到 %v", expected, got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Fatalf("预期文件列表 %v, 得到 %v", expected, got)
+		}
+	}
+
+	excluded := pr.GetExcludedList()
+	checks := map[string]ExcludedItem{
+		"src/debug.log":  {Reason: ExcludeReasonGitignore, Rule: "*.log", Source: filepath.Join(repo, ".gitignore") + ":3"},
+		"tmp":            {IsDir: true, Reason: ExcludeReasonGitignore, Rule: "/tmp", Source: filepath.Join(root, ".gitignore") + ":1"},
+		"src/fixtures/x": {IsDir: true, Reason: ExcludeReasonGitignore, Rule: "**/fixtures/**", Source: filepath.Join(root, ".gitignore") + ":2"},
+		"src/generated":  {IsDir: true, Reason: ExcludeReasonGitignore, Rule: "generated/", Source: filepath.Join(repo, ".gitignore") + ":5"},
+		"vendor":         {IsDir: true, Reason: ExcludeReasonGitignore, Rule: "vendor/", Source: filepath.Join(root, ".analyzerignore") + ":1"},
+		"local-notes.md": {Reason: ExcludeReasonGitignore, Rule: "local-notes.md", Source: filepath.Join(repo, ".git", "info", "exclude") + ":1"},
+		"node_modules":   {IsDir: true, Reason: ExcludeReasonBuiltin, Rule: "node_modules"},
+	}
+	for rel, want := range checks {
+		item, ok := excluded[filepath.Join(root, filepath.FromSlash(rel))]
+		if !ok {
+			t.Errorf("预期 %s 被排除", rel)
+			continue
+		}
+		if item != want {
+			t.Errorf("%s 的排除原因预期 %+v, 得到 %+v", rel, want, item)
+		}
+	}
+}
+
+// TestScanFileListWithoutGitignore 测试未启用 gitignore 时不读取忽略文件，并记录 glob 模式的排除原因。
+func TestScanFileListWithoutGitignore(t *testing.T) {
+	root := t.TempDir()
+	for rel, content := range map[string]string{
+		".gitignore":    "*.log\n",
+		"src/debug.log": "",
+		"src/a.test.ts": "",
+		"src/index.ts":  "",
+	} {
+		full := filepath.Join(root, rel)
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	pr := NewProjectResult(root, []string{"**/*.test.ts"}, false)
+	pr.ScanFileList()
+
+	if _, ok := pr.GetFileList()[filepath.Join(root, "src", "debug.log")]; !ok {
+		t.Errorf("未启用 gitignore 时不应排除 debug.log")
+	}
+	item, ok := pr.GetExcludedList()[filepath.Join(root, "src", "a.test.ts")]
+	if !ok || item.Reason != ExcludeReasonIgnorePattern || item.Rule != "**/*.test.ts" {
+		t.Errorf("预期 a.test.ts 因 glob 模式被排除, 得到 %+v", item)
+	}
+}
@@ -5,6 +5,9 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
+	"sort"
+	"sync"
 
 	"github.com/Flying-Bird1999/analyzer-ts/analyzer/utils"
 	"github.com/gobwas/glob"
@@ -14,8 +17,13 @@ type ProjectResult struct {
 	Root       string   // 入口
 	Ignore     []string // 指定忽略的文件/文件夹
 	IsMonorepo bool     // 是否为 monorepo 项目
+	Jobs       int      // 并发遍历目录的 worker 数量，小于等于 0 时使用 runtime.NumCPU()
+	Gitignore  bool     // 是否遵循 .gitignore、.git/info/exclude 与 .analyzerignore（任意层级）中的规则
 
-	FileList map[string]FileItem // 文件列表
+	FileList map[string]FileItem     // 文件列表
+	Excluded map[string]ExcludedItem // 被排除的文件或目录及其原因
+	Errors   []ScanError             // 遍历目录时遇到的错误，按路径排序
+	mu       sync.Mutex              // 保护并发遍历时对 FileList、Excluded 与 Errors 的写入
 }
 
 func NewProjectResult(root string, ignore []string, IsMonorepo bool) *ProjectResult {
@@ -24,6 +32,7 @@ func NewProjectResult(root string, ignore []string, IsMonorepo bool) *ProjectRes
 		Ignore:     ignore,
 		IsMonorepo: IsMonorepo,
 		FileList:   make(map[string]FileItem),
+		Excluded:   make(map[string]ExcludedItem),
 	}
 }
 
@@ -31,6 +40,30 @@ func (pr *ProjectResult) GetFileList() map[string]FileItem {
 	return pr.FileList
 }
 
+// GetExcludedList 返回扫描时被排除的文件或目录，以及各自被排除的原因。
+func (pr *ProjectResult) GetExcludedList() map[string]ExcludedItem {
+	return pr.Excluded
+}
+
+// GetErrors 返回遍历目录时遇到的错误。
+func (pr *ProjectResult) GetErrors() []ScanError {
+	return pr.Errors
+}
+
+// addError 记录一个遍历错误。
+func (pr *ProjectResult) addError(path string, err error) {
+	pr.mu.Lock()
+	pr.Errors = append(pr.Errors, ScanError{Path: path, Message: err.Error()})
+	pr.mu.Unlock()
+}
+
+// exclude 记录一个被排除的文件或目录。
+func (pr *ProjectResult) exclude(path string, item ExcludedItem) {
+	pr.mu.Lock()
+	pr.Excluded[path] = item
+	pr.mu.Unlock()
+}
+
 func (pr *ProjectResult) ScanProject() {
 	pr.ScanFileList()
 }
@@ -57,39 +90,110 @@ func (pr *ProjectResult) ScanFileList() {
 	}
 
 	// 遍历项目目录，获取所有文件列表
-	err := filepath.Walk(pr.Root, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			fmt.Printf("访问路径 %s 时出错: %s\n", path, err)
-			return nil
-		}
+	rootInfo, err := os.Lstat(pr.Root)
+	if err != nil {
+		pr.addError(pr.Root, fmt.Errorf("扫描文件列表时出错: %w", err))
+		return
+	}
+
+	jobs := pr.Jobs
+	if jobs <= 0 {
+		jobs = runtime.NumCPU()
+	}
+	// sem 限制同时遍历目录的 goroutine 数量
+	sem := make(chan struct{}, jobs)
+	var wg sync.WaitGroup
+
+	// 启用 gitignore 时，根据扫描根目录所在的 git 仓库初始化忽略规则
+	var rootMatcher *gitignoreMatcher
+	if pr.Gitignore {
+		rootMatcher = newGitignoreMatcher(pr.Root)
+	}
 
+	var visit func(path string, info os.FileInfo, matcher *gitignoreMatcher)
+	visit = func(path string, info os.FileInfo, matcher *gitignoreMatcher) {
 		relPath, _ := filepath.Rel(pr.Root, path)
 		unixRelPath := filepath.ToSlash(relPath)
 
+		// 多包的case有点问题，这里先手动忽略掉 node_modules
+		if info.Name() == "node_modules" {
+			pr.exclude(path, ExcludedItem{IsDir: info.IsDir(), Reason: ExcludeReasonBuiltin, Rule: "node_modules"})
+			return
+		}
+		if matcher != nil && info.IsDir() && info.Name() == ".git" {
+			pr.exclude(path, ExcludedItem{IsDir: true, Reason: ExcludeReasonBuiltin, Rule: ".git"})
+			return
+		}
+
 		// 检查是否匹配忽略规则
-		for _, g := range ignoreGlobs {
-			// 多包的case有点问题，这里先手动忽略掉 ode_modules
-			if g.Match(unixRelPath) || info.Name() == "node_modules" {
-				if info.IsDir() {
-					return filepath.SkipDir // 跳过整个目录
-				}
-				return nil // 跳过文件
+		for i, g := range ignoreGlobs {
+			if g.Match(unixRelPath) {
+				pr.exclude(path, ExcludedItem{IsDir: info.IsDir(), Reason: ExcludeReasonIgnorePattern, Rule: Ignore[i]})
+				return // 跳过整个目录或文件
+			}
+		}
+
+		// 检查是否匹配 .gitignore 等忽略文件中的规则
+		if matcher != nil && path != pr.Root {
+			if rule, ignored := matcher.match(path, info.IsDir()); ignored {
+				pr.exclude(path, ExcludedItem{IsDir: info.IsDir(), Reason: ExcludeReasonGitignore, Rule: rule.pattern, Source: rule.source})
+				return
 			}
 		}
 
 		// 检查是否是文件
 		if !info.IsDir() {
+			pr.mu.Lock()
 			pr.FileList[path] = FileItem{
 				FileName: info.Name(),
 				Size:     utils.FormatSize(info.Size()), // 直接存储格式化后的大小
 				Ext:      filepath.Ext(path),            // 文件后缀
 			}
+			pr.mu.Unlock()
+			return
 		}
 
-		return nil
-	})
+		// 进入目录时加载该目录下的忽略文件
+		if matcher != nil {
+			matcher = matcher.child(path)
+		}
 
-	if err != nil {
-		fmt.Printf("扫描文件列表时出错: %s\n", err)
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			pr.addError(path, fmt.Errorf("访问路径时出错: %w", err))
+			return
+		}
+		for _, entry := range entries {
+			childPath := filepath.Join(path, entry.Name())
+			childInfo, err := entry.Info()
+			if err != nil {
+				pr.addError(childPath, fmt.Errorf("访问路径时出错: %w", err))
+				continue
+			}
+			if !childInfo.IsDir() {
+				visit(childPath, childInfo, matcher)
+				continue
+			}
+			// 子目录优先交给空闲的 worker，worker 已满时在当前 goroutine 中继续遍历，避免死锁
+			select {
+			case sem <- struct{}{}:
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					defer func() { <-sem }()
+					visit(childPath, childInfo, matcher)
+				}()
+			default:
+				visit(childPath, childInfo, matcher)
+			}
+		}
 	}
+
+	visit(pr.Root, rootInfo, rootMatcher)
+	wg.Wait()
+
+	// 并发遍历时错误的记录顺序不确定，按路径排序保证输出稳定
+	sort.Slice(pr.Errors, func(i, j int) bool {
+		return pr.Errors[i].Path < pr.Errors[j].Path
+	})
 }
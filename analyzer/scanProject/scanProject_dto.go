@@ -6,3 +6,24 @@ type FileItem struct {
 	Size     string `json:"size"`     // 大小
 	Ext      string `json:"ext"`      // 后缀
 }
+
+// 文件被排除的原因
+const (
+	ExcludeReasonBuiltin       = "builtin"        // 内置规则（node_modules 目录，以及启用 gitignore 时的 .git 目录）
+	ExcludeReasonIgnorePattern = "ignore-pattern" // 命中 Ignore 中的 glob 模式（默认规则或 -x 指定的模式）
+	ExcludeReasonGitignore     = "gitignore"      // 命中 .gitignore、.git/info/exclude 或 .analyzerignore 中的规则
+)
+
+// ExcludedItem 记录一个文件或目录被排除的原因。目录被排除时不会继续遍历其中的文件。
+type ExcludedItem struct {
+	IsDir  bool   `json:"isDir"`            // 是否为目录
+	Reason string `json:"reason"`           // 排除原因，取值见 ExcludeReason* 常量
+	Rule   string `json:"rule"`             // 命中的规则
+	Source string `json:"source,omitempty"` // 规则所在的忽略文件及行号（仅 gitignore 原因）
+}
+
+// ScanError 记录遍历目录时遇到的错误，出错的文件或目录会被跳过。
+type ScanError struct {
+	Path    string `json:"path"`    // 出错的文件或目录
+	Message string `json:"message"` // 错误信息
+}
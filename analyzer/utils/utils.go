@@ -44,8 +44,13 @@ func ReadFileContent(filePath string) (string, error) {
 
 // 解析TypeScript文件为AST
 func ParseTypeScriptFile(filePath string, sourceCode string) *ast.SourceFile {
+	return ParseTypeScriptFileAs(filePath, sourceCode, filepath.Ext(filePath))
+}
+
+// 按指定的扩展名（决定脚本类型）解析源码为AST，用于 .vue / .svelte 这类扩展名与脚本语言不一致的文件
+func ParseTypeScriptFileAs(filePath string, sourceCode string, ext string) *ast.SourceFile {
 	scriptKind := core.ScriptKindUnknown
-	switch strings.ToLower(filepath.Ext(filePath)) {
+	switch strings.ToLower(ext) {
 	case ".ts":
 		scriptKind = core.ScriptKindTS
 	case ".tsx":
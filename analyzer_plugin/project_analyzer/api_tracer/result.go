@@ -51,3 +51,18 @@ func (r *ApiTracerResult) ToConsole() string {
 func (r *ApiTracerResult) AnalyzerName() string {
 	return "api-tracer"
 }
+
+// 确保 ApiTracerResult 实现了 projectanalyzer.FindingsProvider 接口。
+var _ projectanalyzer.FindingsProvider = (*ApiTracerResult)(nil)
+
+// NormalizedFindings 将每个API调用点转换为一条 info 级别的发现项。
+func (r *ApiTracerResult) NormalizedFindings() []projectanalyzer.Finding {
+	findings := make([]projectanalyzer.Finding, 0, len(r.Findings))
+	for _, finding := range r.Findings {
+		findings = append(findings, projectanalyzer.FindingAt(
+			"api-tracer/api-call", projectanalyzer.SeverityInfo,
+			fmt.Sprintf("调用了接口 %s", finding.ApiPath), finding.FilePath, finding.SourceLocation,
+		))
+	}
+	return findings
+}
@@ -12,6 +12,7 @@ import (
 	"strings"
 
 	"github.com/Flying-Bird1999/analyzer-ts/analyzer/parser"
+	"github.com/Flying-Bird1999/analyzer-ts/analyzer/projectParser"
 	projectanalyzer "github.com/Flying-Bird1999/analyzer-ts/analyzer_plugin/project_analyzer"
 )
 
@@ -49,6 +50,17 @@ func (t *Tracer) Configure(params map[string]string) error {
 	return nil
 }
 
+func (t *Tracer) RequiredParseOptions() projectParser.ParseOptions {
+	return projectParser.LowMemoryParseOptions()
+}
+
+// ParamSchema 描述该分析器接受的参数。
+func (t *Tracer) ParamSchema() projectanalyzer.ParamSchema {
+	return projectanalyzer.ObjectParams(map[string]*projectanalyzer.ParamSchema{
+		"apiPaths": projectanalyzer.StringListParam("要追踪调用链路的接口路径"),
+	}, "apiPaths")
+}
+
 // normalizeString 将字符串中连续的空白字符替换为单个空格，用于健壮的字符串比较。
 func normalizeString(s string) string {
 	return strings.Join(strings.Fields(s), " ")
@@ -97,9 +109,10 @@ func (t *Tracer) Analyze(ctx *projectanalyzer.ProjectContext) (projectanalyzer.R
 						if originalPath, exists := normalizedApiPaths[normalizedPath]; exists {
 							// 找到了一个匹配项！
 							finding := ApiCallSite{
-								ApiPath:  originalPath, // 报告原始的、未标准化的路径
-								FilePath: filePath,
-								Raw:      callExpr.Raw,
+								ApiPath:        originalPath, // 报告原始的、未标准化的路径
+								FilePath:       filePath,
+								Raw:            callExpr.Raw,
+								SourceLocation: callExpr.SourceLocation,
 							}
 							result.Findings = append(result.Findings, finding)
 						}
@@ -1,5 +1,7 @@
 package api_tracer
 
+import "github.com/Flying-Bird1999/analyzer-ts/analyzer/parser"
+
 // ApiCallSite 代表一个API调用在代码中的具体位置和相关信息。
 type ApiCallSite struct {
 	// ApiPath 是匹配到的API路径字符串。
@@ -8,4 +10,6 @@ type ApiCallSite struct {
 	FilePath string `json:"filePath"`
 	// Raw 是该调用表达式在源代码中的原始文本。
 	Raw string `json:"raw"`
+	// SourceLocation 是该调用表达式在源代码中的位置。
+	SourceLocation *parser.SourceLocation `json:"sourceLocation,omitempty"`
 }
@@ -0,0 +1,138 @@
+// Package baseline 实现发现项的基线文件与行内忽略注释，让存量问题很多的项目也能把分析器作为 CI 门禁：
+//   - 基线文件记录当前所有发现项的指纹，之后只报告基线中没有的新发现项；
+//   - 行内注释 `// analyzer-ts-ignore <rule> -- reason` 忽略所在行或下一行的发现项。
+//
+// 指纹由规则、相对于项目根目录的文件路径、消息与发现项所在行的源码（去除空白差异）计算，
+// 不包含行号，因此在文件中插入或删除其他行不会使已记录的发现项变成新发现项。
+package baseline
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	projectanalyzer "github.com/Flying-Bird1999/analyzer-ts/analyzer_plugin/project_analyzer"
+)
+
+// DefaultPath 是基线文件相对于项目根目录的默认路径。
+const DefaultPath = ".analyzer/baseline.json"
+
+// Version 是基线文件的格式版本。
+const Version = 1
+
+// Baseline 是基线文件的内容。
+type Baseline struct {
+	Version int     `json:"version"`
+	Entries []Entry `json:"entries"`
+}
+
+// Entry 是基线中的一类发现项。指纹相同的发现项（例如同一文件中完全相同的多行代码）合并为一条，以 Count 计数。
+// RuleID、File 与 Message 只用于阅读与代码评审，比较时只使用 Fingerprint。
+type Entry struct {
+	Fingerprint string `json:"fingerprint"`
+	RuleID      string `json:"ruleId"`
+	File        string `json:"file,omitempty"`
+	Message     string `json:"message"`
+	Count       int    `json:"count"`
+}
+
+// New 根据当前的发现项创建基线。
+func New(findings []projectanalyzer.Finding, sources *Sources) *Baseline {
+	entries := make(map[string]*Entry)
+	for _, finding := range findings {
+		fingerprint := Fingerprint(finding, sources)
+		if entry, ok := entries[fingerprint]; ok {
+			entry.Count++
+			continue
+		}
+		entries[fingerprint] = &Entry{
+			Fingerprint: fingerprint,
+			RuleID:      finding.RuleID,
+			File:        sources.Rel(finding.File),
+			Message:     sources.normalizeMessage(finding.Message),
+			Count:       1,
+		}
+	}
+
+	baseline := &Baseline{Version: Version, Entries: make([]Entry, 0, len(entries))}
+	for _, entry := range entries {
+		baseline.Entries = append(baseline.Entries, *entry)
+	}
+	sort.Slice(baseline.Entries, func(i, j int) bool {
+		a, b := baseline.Entries[i], baseline.Entries[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.RuleID != b.RuleID {
+			return a.RuleID < b.RuleID
+		}
+		return a.Fingerprint < b.Fingerprint
+	})
+	return baseline
+}
+
+// Load 读取基线文件，文件不存在时返回的错误满足 os.IsNotExist。
+func Load(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	baseline := &Baseline{}
+	if err := json.Unmarshal(data, baseline); err != nil {
+		return nil, fmt.Errorf("解析基线文件 %s 失败: %w", path, err)
+	}
+	if baseline.Version != Version {
+		return nil, fmt.Errorf("基线文件 %s 的版本 %d 不受支持，请重新生成 (当前版本 %d)", path, baseline.Version, Version)
+	}
+	return baseline, nil
+}
+
+// Save 将基线写入文件，目录不存在时自动创建。
+func (b *Baseline) Save(path string) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return fmt.Errorf("序列化基线失败: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return fmt.Errorf("创建基线目录失败: %w", err)
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// NewFindings 返回不在基线中的发现项。
+// 指纹相同的发现项按数量比较：基线记录了 2 处、当前有 3 处时，多出的 1 处视为新发现项。
+func (b *Baseline) NewFindings(findings []projectanalyzer.Finding, sources *Sources) []projectanalyzer.Finding {
+	remaining := make(map[string]int, len(b.Entries))
+	for _, entry := range b.Entries {
+		remaining[entry.Fingerprint] += entry.Count
+	}
+	var newFindings []projectanalyzer.Finding
+	for _, finding := range findings {
+		fingerprint := Fingerprint(finding, sources)
+		if remaining[fingerprint] > 0 {
+			remaining[fingerprint]--
+			continue
+		}
+		newFindings = append(newFindings, finding)
+	}
+	return newFindings
+}
+
+// Fingerprint 计算发现项的指纹，详见包文档。
+func Fingerprint(finding projectanalyzer.Finding, sources *Sources) string {
+	snippet := ""
+	if line, ok := sources.Line(finding.File, finding.Line); ok {
+		snippet = strings.Join(strings.Fields(line), " ")
+	}
+	hash := sha256.New()
+	for _, part := range []string{finding.RuleID, sources.Rel(finding.File), sources.normalizeMessage(finding.Message), snippet} {
+		hash.Write([]byte(part))
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil)[:16])
+}
@@ -0,0 +1,101 @@
+package baseline
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	projectanalyzer "github.com/Flying-Bird1999/analyzer-ts/analyzer_plugin/project_analyzer"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func anyFinding(file string, line int) projectanalyzer.Finding {
+	return projectanalyzer.Finding{
+		RuleID:   "count-any/any-usage",
+		Severity: projectanalyzer.SeverityWarning,
+		Message:  "使用了 'any' 类型",
+		File:     file,
+		Line:     line,
+		Column:   10,
+	}
+}
+
+func TestBaselineSurvivesLineShifts(t *testing.T) {
+	root := t.TempDir()
+	file := filepath.Join(root, "src", "a.ts")
+	writeFile(t, file, "const a: any = 1;\nconst b: any = 2;\n")
+
+	baseline := New([]projectanalyzer.Finding{anyFinding(file, 1), anyFinding(file, 2)}, NewSources(root))
+	path := filepath.Join(root, DefaultPath)
+	if err := baseline.Save(path); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if len(loaded.Entries) != 2 || loaded.Entries[0].File != "src/a.ts" {
+		t.Fatalf("基线应记录相对路径的两条发现项: %+v", loaded.Entries)
+	}
+
+	// 在文件开头插入新行，已有的发现项整体下移，并新增一处 any
+	writeFile(t, file, "import x from 'x';\n\nconst a: any = 1;\nconst b: any = 2;\nconst c: any = 3;\n")
+	current := []projectanalyzer.Finding{anyFinding(file, 3), anyFinding(file, 4), anyFinding(file, 5)}
+	newFindings := loaded.NewFindings(current, NewSources(root))
+	if len(newFindings) != 1 || newFindings[0].Line != 5 {
+		t.Errorf("只有第 5 行应该是新发现项: %+v", newFindings)
+	}
+}
+
+func TestBaselineCountsDuplicates(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "a.ts"), "let x: any;\nlet x: any;\nlet x: any;\n")
+
+	// 相对路径的发现项同样基于项目根目录读取源码
+	baseline := New([]projectanalyzer.Finding{anyFinding("a.ts", 1), anyFinding("a.ts", 2)}, NewSources(root))
+	if len(baseline.Entries) != 1 || baseline.Entries[0].Count != 2 {
+		t.Fatalf("相同指纹的发现项应合并计数: %+v", baseline.Entries)
+	}
+	current := []projectanalyzer.Finding{anyFinding("a.ts", 1), anyFinding("a.ts", 2), anyFinding("a.ts", 3)}
+	if newFindings := baseline.NewFindings(current, NewSources(root)); len(newFindings) != 1 {
+		t.Errorf("超出基线数量的发现项应视为新发现项: %+v", newFindings)
+	}
+}
+
+func TestSuppress(t *testing.T) {
+	root := t.TempDir()
+	file := filepath.Join(root, "a.tsx")
+	writeFile(t, file, `// analyzer-ts-ignore count-any/any-usage -- 第三方库缺少类型
+const a: any = 1;
+const b: any = 2; // analyzer-ts-ignore count-any -- 整个分析器
+const c: any = 3;
+{/* analyzer-ts-ignore count-as,count-any/any-usage */}
+const d: any = 4;
+const e: any = 5; // analyzer-ts-ignore count-as -- 规则不匹配
+`)
+	findings := []projectanalyzer.Finding{
+		anyFinding(file, 2), anyFinding(file, 3), anyFinding(file, 4), anyFinding(file, 6), anyFinding(file, 7),
+		{RuleID: "unreferenced/unreferenced-file", Message: "没有位置的发现项不受注释影响", File: file},
+	}
+
+	kept, suppressed := Suppress(findings, NewSources(root))
+	if len(suppressed) != 3 {
+		t.Fatalf("应忽略第 2、3、6 行的发现项，实际忽略了: %+v", suppressed)
+	}
+	if suppressed[0].Reason != "第三方库缺少类型" || suppressed[1].Reason != "整个分析器" || suppressed[2].Reason != "" {
+		t.Errorf("忽略原因不正确: %+v", suppressed)
+	}
+	// 第 4 行的上一行是代码行，行尾的注释不作用于第 4 行
+	if len(kept) != 3 || kept[0].Line != 4 || kept[1].Line != 7 || kept[2].Line != 0 {
+		t.Errorf("保留的发现项不正确: %+v", kept)
+	}
+}
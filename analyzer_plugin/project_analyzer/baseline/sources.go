@@ -0,0 +1,67 @@
+package baseline
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Sources 按需读取并缓存发现项所在文件的源码行，供计算指纹与查找忽略注释使用。
+// 发现项的文件路径可以是绝对路径，也可以是相对于项目根目录的路径。
+type Sources struct {
+	root  string
+	lines map[string][]string
+}
+
+// NewSources 创建以 projectRoot 为根目录的源码读取器。
+func NewSources(projectRoot string) *Sources {
+	if root, err := filepath.Abs(projectRoot); err == nil {
+		projectRoot = root
+	}
+	return &Sources{root: projectRoot, lines: make(map[string][]string)}
+}
+
+// Rel 返回文件相对于项目根目录的路径（使用 / 分隔），文件不在项目根目录下时返回原路径。
+func (s *Sources) Rel(file string) string {
+	if file == "" || !filepath.IsAbs(file) {
+		return filepath.ToSlash(file)
+	}
+	if rel, err := filepath.Rel(s.root, file); err == nil && !strings.HasPrefix(rel, "..") {
+		return filepath.ToSlash(rel)
+	}
+	return filepath.ToSlash(file)
+}
+
+// Line 返回文件第 line 行（从 1 开始）的内容，文件无法读取或行号越界时返回 false。
+func (s *Sources) Line(file string, line int) (string, bool) {
+	if file == "" || line <= 0 {
+		return "", false
+	}
+	lines := s.fileLines(file)
+	if line > len(lines) {
+		return "", false
+	}
+	return lines[line-1], true
+}
+
+// fileLines 读取并缓存文件的所有行，读取失败时缓存空结果。
+func (s *Sources) fileLines(file string) []string {
+	path := file
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(s.root, path)
+	}
+	if lines, ok := s.lines[path]; ok {
+		return lines
+	}
+	var lines []string
+	if data, err := os.ReadFile(path); err == nil {
+		lines = strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	}
+	s.lines[path] = lines
+	return lines
+}
+
+// normalizeMessage 去掉消息中的项目根目录前缀，使消息中的绝对路径不影响指纹在不同机器上的一致性。
+func (s *Sources) normalizeMessage(message string) string {
+	return strings.ReplaceAll(message, s.root+string(filepath.Separator), "")
+}
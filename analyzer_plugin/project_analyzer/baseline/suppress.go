@@ -0,0 +1,78 @@
+package baseline
+
+import (
+	"regexp"
+	"strings"
+
+	projectanalyzer "github.com/Flying-Bird1999/analyzer-ts/analyzer_plugin/project_analyzer"
+)
+
+// IgnoreDirective 是行内忽略注释的指令名。
+const IgnoreDirective = "analyzer-ts-ignore"
+
+// ignorePattern 匹配 `// analyzer-ts-ignore <rules> -- reason`，也支持 `/* ... */` 与 JSX 中的 `{/* ... */}`。
+// <rules> 是逗号分隔的规则标识，reason 可以省略。
+var ignorePattern = regexp.MustCompile(`(?://|/\*)\s*` + IgnoreDirective + `\s+([^\s*]+)(?:\s+--\s*([^*]*))?`)
+
+// Suppression 是被行内注释忽略的发现项。
+type Suppression struct {
+	projectanalyzer.Finding
+	// Reason 是注释中 `--` 之后的说明，可能为空。
+	Reason string `json:"reason,omitempty"`
+}
+
+// Suppress 按行内忽略注释过滤发现项，返回保留的发现项与被忽略的发现项。
+// 只有带位置的发现项可以被忽略；注释可以写在发现项起始行的行尾，也可以单独写在上一行：
+//
+//	// analyzer-ts-ignore count-any/any-usage -- 第三方库缺少类型
+//	const data: any = legacy()
+//	const value = data as Foo // analyzer-ts-ignore count-as -- 已在上游校验
+//
+// 规则可以写完整的规则标识，也可以只写分析器名称以忽略该分析器的所有规则。
+func Suppress(findings []projectanalyzer.Finding, sources *Sources) ([]projectanalyzer.Finding, []Suppression) {
+	var kept []projectanalyzer.Finding
+	var suppressed []Suppression
+	for _, finding := range findings {
+		if reason, ok := ignoreReason(finding, sources); ok {
+			suppressed = append(suppressed, Suppression{Finding: finding, Reason: reason})
+			continue
+		}
+		kept = append(kept, finding)
+	}
+	return kept, suppressed
+}
+
+// ignoreReason 查找作用于发现项的忽略注释，找到时返回注释中的说明。
+func ignoreReason(finding projectanalyzer.Finding, sources *Sources) (string, bool) {
+	if line, ok := sources.Line(finding.File, finding.Line); ok {
+		if reason, ok := matchIgnore(line, finding.RuleID); ok {
+			return reason, true
+		}
+	}
+	// 上一行的注释只有在该行是单独的注释行时才生效，避免上一行行尾的注释同时作用于下一行。
+	if line, ok := sources.Line(finding.File, finding.Line-1); ok && isCommentLine(line) {
+		return matchIgnore(line, finding.RuleID)
+	}
+	return "", false
+}
+
+// matchIgnore 判断一行中的忽略注释是否包含 ruleID。
+func matchIgnore(line, ruleID string) (string, bool) {
+	match := ignorePattern.FindStringSubmatch(line)
+	if match == nil {
+		return "", false
+	}
+	analyzerName, _, _ := strings.Cut(ruleID, "/")
+	for _, rule := range strings.Split(match[1], ",") {
+		if rule == ruleID || rule == analyzerName {
+			return strings.TrimSpace(match[2]), true
+		}
+	}
+	return "", false
+}
+
+// isCommentLine 判断一行是否是单独的注释行。
+func isCommentLine(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return strings.HasPrefix(trimmed, "//") || strings.HasPrefix(trimmed, "/*") || strings.HasPrefix(trimmed, "{/*")
+}
@@ -8,8 +8,11 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/Flying-Bird1999/analyzer-ts/analyzer/projectParser"
 	projectanalyzer "github.com/Flying-Bird1999/analyzer-ts/analyzer_plugin/project_analyzer"
+	"github.com/Flying-Bird1999/analyzer-ts/analyzer_plugin/project_analyzer/external_plugin"
 
 	"github.com/spf13/cobra"
 
@@ -26,6 +29,8 @@ import (
 
 	_ "github.com/Flying-Bird1999/analyzer-ts/analyzer_plugin/project_analyzer/css_plugin"
 
+	_ "github.com/Flying-Bird1999/analyzer-ts/analyzer_plugin/project_analyzer/decorators"
+
 	_ "github.com/Flying-Bird1999/analyzer-ts/analyzer_plugin/project_analyzer/dependency"
 
 	_ "github.com/Flying-Bird1999/analyzer-ts/analyzer_plugin/project_analyzer/export_call"
@@ -72,7 +77,19 @@ func GetAnalyzeCmd() *cobra.Command {
 		excludePath    []string
 		isMonorepo     bool
 		analyzerParams []string
-		stripFields    []string // 用于存储用户指定的、需要剔除的字段
+		stripFields    []string      // 用于存储用户指定的、需要剔除的字段
+		jobs           int           // 并发解析文件的 worker 数量
+		cacheDir       string        // 持久化解析缓存目录
+		conditions     []string      // 解析 package.json exports/imports 时启用的条件
+		gitignore      bool          // 扫描文件时是否遵循 .gitignore 等忽略文件
+		strict         bool          // 严格模式，出现 error 级别的诊断信息时失败
+		configPath     string        // 分析器配置文件路径，为空时查找 <input>/.analyzer/config.{json,yaml}
+		describe       string        // 打印指定分析器接受的参数后退出
+		format         string        // 输出格式: json 或 sarif
+		baselinePath   string        // 基线文件路径，为空时不使用基线
+		updateBaseline bool          // 用当前发现项重写基线文件
+		allowPlugins   bool          // 是否允许执行进程外插件
+		pluginTimeout  time.Duration // 每个进程外插件的最长运行时间
 	)
 
 	analyzeCmd := &cobra.Command{
@@ -111,7 +128,7 @@ func GetAnalyzeCmd() *cobra.Command {
 ` +
 			`  - npm-check: 检查 NPM 依赖，识别隐式、未使用和过期依赖.
 ` +
-			`  - pkg-deps: 列出项目的所有 NPM 依赖.
+			`  - pkg-deps: 列出项目的所有 NPM 依赖, 以及 monorepo 中 workspace 包之间的依赖图与拓扑顺序.
 ` +
 			`  - trace: 追踪一个或多个NPM包的使用链路 (例如 antd).
 ` +
@@ -120,9 +137,47 @@ func GetAnalyzeCmd() *cobra.Command {
 			`  - component-deps: 分析组件之间的依赖关系. (必须使用 -p 'component-deps.manifest=path/to/component-manifest.json')
 ` +
 			`  - export-call: 分析资产目录的导出节点引用关系. (必须使用 -p 'export-call.manifest=path/to/manifest.json')
+` +
+			`  - 进程外插件: 使用 --allow-plugins 时，PATH 中名为 analyzer-ts-plugin-<name> 的可执行文件以及配置文件 plugins 中列出的可执行文件，以 <name> 作为分析器名称.
 ` +
 			`如果未指定任何分析器，命令将仅解析项目并输出完整的、未经处理的（但可能被剔除过的）原始AST结构.
 
+` +
+			`诊断信息 (--strict):
+` +
+			`解析过程中读取或解析失败的文件会记录在输出 JSON 的 'diagnostics' 字段中，而不会中断分析.
+` +
+			`使用 --strict 时，只要存在 error 级别的诊断信息，命令就会以非零状态退出.
+
+` +
+			`配置文件 (--config):
+` +
+			`分析器参数也可以写在 <input>/.analyzer/config.json 或 config.yaml 中，每个分析器一个配置段，
+` +
+			`参数按分析器声明的 schema 校验，命令行中的 -p 会覆盖配置文件中的同名参数.
+` +
+			`使用 'analyze --describe <name>' 查看某个分析器接受的参数.
+
+` +
+			`输出格式 (--format):
+` +
+			`默认输出 JSON；使用 --format sarif 时，所有分析器的发现项与诊断信息会以 SARIF 2.1.0 格式写入 <name>_analyzer_data.sarif，
+` +
+			`可直接上传到 GitHub / GitLab 的代码扫描.
+
+` +
+			`基线与行内忽略 (--baseline):
+` +
+			`使用 --baseline .analyzer/baseline.json 时，基线文件不存在则记录当前所有发现项，存在则只报告基线中没有的新发现项，
+` +
+			`出现 error 或 warning 级别的新发现项时命令以非零状态退出；使用 --update-baseline 重新记录.
+` +
+			`基线文件的相对路径基于 -i 指定的项目根目录.
+` +
+			`代码中的 '// analyzer-ts-ignore <rule> -- reason' 注释会忽略所在行或下一行的发现项，在所有输出格式中生效:
+` +
+			`JSON 输出的 findings 字段与 SARIF 输出都不包含被忽略的发现项，各分析器的原始结果保持不变.
+
 ` +
 			`特定分析器参数 (-p, --param) 使用示例:
 ` +
@@ -132,6 +187,25 @@ func GetAnalyzeCmd() *cobra.Command {
 ` +
 			`analyze trace -i . -p "trace.targetPkgs=antd" -p "trace.targetPkgs=@yy/sl-admin-components"`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			// 配置文件中可能列出了进程外插件，插件需要在选择分析器之前注册
+			configFile, err := LoadAnalyzerConfigFile(inputPath, configPath)
+			if err != nil {
+				return fmt.Errorf("错误: %w", err)
+			}
+			if err := registerPlugins(inputPath, configFile, allowPlugins, pluginTimeout); err != nil {
+				return fmt.Errorf("错误: %w", err)
+			}
+
+			// --describe 只打印参数说明，不需要解析项目
+			if describe != "" {
+				description, err := describeAnalyzer(describe)
+				if err != nil {
+					return fmt.Errorf("错误: %w", err)
+				}
+				fmt.Print(description)
+				return nil
+			}
+
 			// --- 步骤 0: 初始化和验证路径参数 ---
 			if outputPath == "" {
 				cwd, err := os.Getwd()
@@ -143,6 +217,12 @@ func GetAnalyzeCmd() *cobra.Command {
 			if inputPath == "" {
 				return fmt.Errorf("错误: 请使用 -i 或 --input 标志提供项目路径")
 			}
+			if format != "json" && format != "sarif" {
+				return fmt.Errorf("错误: 不支持的输出格式 '%s'，可选值为 json、sarif", format)
+			}
+			if updateBaseline && baselinePath == "" {
+				return fmt.Errorf("错误: --update-baseline 需要与 --baseline 一起使用")
+			}
 
 			// --- 步骤 1: 快速失败校验 ---
 			// 在执行任何耗时操作之前，首先校验用户请求的分析器名称是否都存在。
@@ -150,16 +230,37 @@ func GetAnalyzeCmd() *cobra.Command {
 			if len(args) > 0 {
 				analyzersToRun = selectAnalyzers(args)
 			}
+			// 被依赖但未指定的分析器会自动加入到列表末尾
+			requestedCount := len(analyzersToRun)
+			analyzersToRun, err = projectanalyzer.ResolveDependencies(analyzersToRun)
+			if err != nil {
+				return fmt.Errorf("错误: %w", err)
+			}
+			dependencies := make(map[string]bool)
+			for _, analyzer := range analyzersToRun[requestedCount:] {
+				dependencies[analyzer.Name()] = true
+			}
+			// 参数同样在解析项目之前校验，配置文件或 -p 中的错误能立即暴露。
+			paramsForAnalyzers, err := resolveAnalyzerParams(analyzersToRun, dependencies, parseAnalyzerParams(analyzerParams), configFile)
+			if err != nil {
+				return fmt.Errorf("错误: %w", err)
+			}
 
 			// --- 步骤 2: 执行核心解析逻辑 ---
 			// 调用公共函数，该函数会负责项目解析以及根据 --strip-fields 参数进行预处理。
-			parsingResult, err := ParseAndStripFields(inputPath, excludePath, isMonorepo, stripFields)
+			// 解析模式由待运行分析器声明的需求决定，都不需要 AST 时使用低内存模式。
+			parseOptions := projectanalyzer.ParseOptionsFor(analyzersToRun)
+			parsingResult, err := ParseAndStripFields(inputPath, excludePath, isMonorepo, stripFields, jobs, cacheDir, conditions, gitignore, strict, parseOptions)
 			if err != nil {
 				return fmt.Errorf("错误: 解析或剔除字段失败: %w", err)
 			}
 
 			// --- 步骤 3: 处理不同执行场景 ---
 			// 如果没有指定分析器，则直接输出预处理后的项目数据。
+			if len(analyzersToRun) == 0 && format == "sarif" {
+				fmt.Println("\n未指定分析器，SARIF 中仅包含解析过程的诊断信息。")
+				return writeSARIFResult(nil, parsingResult.Diagnostics, outputPath, inputPath)
+			}
 			if len(analyzersToRun) == 0 {
 				fmt.Println("\n未指定分析器，将直接输出项目解析结果。")
 				outputFileName := GenerateOutputFileName(inputPath, "analyzer_data")
@@ -167,7 +268,6 @@ func GetAnalyzeCmd() *cobra.Command {
 			}
 
 			// 如果指定了分析器，则配置并执行它们。
-			paramsForAnalyzers := parseAnalyzerParams(analyzerParams)
 			configureAnalyzers(analyzersToRun, paramsForAnalyzers)
 
 			// 为分析器创建执行上下文，传入可能已被裁剪过的解析结果。
@@ -181,7 +281,23 @@ func GetAnalyzeCmd() *cobra.Command {
 			fmt.Printf("\n将在项目 %s 中运行 %d 个分析器...\n", ctx.ProjectRoot, len(analyzersToRun))
 			allResults := executeAnalyzers(analyzersToRun, ctx)
 			// --- 步骤 4: 处理并输出最终结果 ---
-			handleResults(allResults, outputPath, inputPath)
+			// 所有输出格式都使用统一格式的发现项，行内忽略注释与基线在这里生效。
+			findings, err := filterFindings(projectanalyzer.CollectFindings(allResults), inputPath, baselinePath, updateBaseline)
+			if err != nil {
+				return fmt.Errorf("错误: %w", err)
+			}
+			if format == "sarif" {
+				if err := writeSARIFResult(findings, parsingResult.Diagnostics, outputPath, inputPath); err != nil {
+					return err
+				}
+			} else {
+				handleResults(allResults, findings, parsingResult.Diagnostics, outputPath, inputPath)
+			}
+			if baselinePath != "" {
+				if err := checkNewFindings(findings); err != nil {
+					return fmt.Errorf("错误: %w", err)
+				}
+			}
 			return nil
 		},
 	}
@@ -194,7 +310,18 @@ func GetAnalyzeCmd() *cobra.Command {
 	analyzeCmd.Flags().BoolVarP(&isMonorepo, "monorepo", "m", false, "是否为 monorepo")
 	analyzeCmd.Flags().StringSliceVarP(&analyzerParams, "param", "p", []string{}, "为特定分析器传递参数 (例如 'trace.targetPkgs=antd')")
 	analyzeCmd.Flags().StringSliceVarP(&stripFields, "strip-fields", "s", []string{}, "在分析前，从解析结果中递归删除的字段名或路径")
-	analyzeCmd.MarkFlagRequired("input")
+	analyzeCmd.Flags().IntVar(&jobs, "jobs", 0, "并发解析文件的 worker 数量 (默认为 CPU 核数)")
+	analyzeCmd.Flags().StringVar(&cacheDir, "cache-dir", "", "持久化解析缓存目录 (例如 .analyzer/cache，为空则不启用)")
+	analyzeCmd.Flags().StringSliceVar(&conditions, "conditions", []string{}, "解析 package.json exports/imports 时启用的条件 (例如 import,browser)")
+	analyzeCmd.Flags().BoolVar(&gitignore, "gitignore", false, "扫描文件时遵循 .gitignore、.git/info/exclude 与 .analyzerignore")
+	analyzeCmd.Flags().BoolVar(&strict, "strict", false, "严格模式: 解析过程中出现 error 级别的诊断信息时以非零状态退出")
+	analyzeCmd.Flags().StringVar(&configPath, "config", "", "分析器配置文件 (默认为 <input>/.analyzer/config.{json,yaml})")
+	analyzeCmd.Flags().StringVar(&describe, "describe", "", "打印指定分析器接受的参数及其 JSON Schema")
+	analyzeCmd.Flags().StringVar(&format, "format", "json", "输出格式: json 或 sarif")
+	analyzeCmd.Flags().StringVar(&baselinePath, "baseline", "", "基线文件 (例如 .analyzer/baseline.json，相对路径基于项目根目录)，不存在时记录当前发现项，存在时只报告新发现项")
+	analyzeCmd.Flags().BoolVar(&allowPlugins, "allow-plugins", false, "允许执行进程外插件 (PATH 中的 analyzer-ts-plugin-* 与配置文件 plugins 中列出的可执行文件)")
+	analyzeCmd.Flags().DurationVar(&pluginTimeout, "plugin-timeout", external_plugin.DefaultTimeout, "每个进程外插件的最长运行时间，超时的插件会被终止并报告错误")
+	analyzeCmd.Flags().BoolVar(&updateBaseline, "update-baseline", false, "用当前发现项重写 --baseline 指定的基线文件")
 	return analyzeCmd
 }
 
@@ -238,16 +365,12 @@ func selectAnalyzers(args []string) []projectanalyzer.Analyzer {
 }
 
 // configureAnalyzers 遍历所有待运行的分析器，并调用它们的 Configure 方法。
-// 它将从命令行解析出的、属于各个分析器的参数传递给它们，以完成初始化。
-func configureAnalyzers(analyzers []projectanalyzer.Analyzer, params map[string]map[string][]string) {
+// 它将合并、校验后的配置文件与命令行参数（见 resolveAnalyzerParams）传递给各个分析器，以完成初始化。
+func configureAnalyzers(analyzers []projectanalyzer.Analyzer, params map[string]map[string]string) {
 	for _, analyzer := range analyzers {
-		analyzerParams := make(map[string]string)
-		// 检查是否存在当前分析器的参数
-		if p, ok := params[analyzer.Name()]; ok {
-			// 将多个相同key的参数值用逗号连接，以兼容需要接收列表的分析器（如 `trace`）。
-			for paramName, paramValues := range p {
-				analyzerParams[paramName] = strings.Join(paramValues, ",")
-			}
+		analyzerParams := params[analyzer.Name()]
+		if analyzerParams == nil {
+			analyzerParams = make(map[string]string)
 		}
 		// 调用分析器自己的配置方法，无论有无参数，都应调用，以便分析器自行处理默认值或报错。
 		if err := analyzer.Configure(analyzerParams); err != nil {
@@ -257,27 +380,48 @@ func configureAnalyzers(analyzers []projectanalyzer.Analyzer, params map[string]
 	}
 }
 
-// executeAnalyzers 遍历并执行所有已配置好的分析器。
-// 它为每个分析器调用 Analyze 方法，并收集结果。
+// executeAnalyzers 按依赖关系的拓扑顺序执行所有已配置好的分析器。
+// 它为每个分析器调用 Analyze 方法，收集结果，并将结果写入上下文供依赖它的分析器读取。
 func executeAnalyzers(analyzers []projectanalyzer.Analyzer, ctx *projectanalyzer.ProjectContext) map[string]projectanalyzer.Result {
+	levels, err := projectanalyzer.OrderAnalyzers(analyzers)
+	if err != nil {
+		fmt.Printf("错误: %v\n", err)
+		os.Exit(1)
+	}
+
 	allResults := make(map[string]projectanalyzer.Result)
-	for _, analyzer := range analyzers {
-		fmt.Printf("===== 正在运行: %s =====\n", analyzer.Name())
-		res, err := analyzer.Analyze(ctx)
-		if err != nil {
-			fmt.Printf("分析器 '%s' 执行失败: %v\n\n", analyzer.Name(), err)
-			continue
+	for _, level := range levels {
+		for _, analyzer := range level {
+			fmt.Printf("===== 正在运行: %s =====\n", analyzer.Name())
+			res, err := analyzer.Analyze(ctx)
+			if err != nil {
+				fmt.Printf("分析器 '%s' 执行失败: %v\n\n", analyzer.Name(), err)
+				continue
+			}
+			ctx.SetResult(analyzer.Name(), res)
+			allResults[analyzer.Name()] = res
 		}
-		allResults[analyzer.Name()] = res
 	}
 	return allResults
 }
 
-// handleResults 将所有分析器的结果合并到一个map中，并写入到最终的输出文件。
-func handleResults(results map[string]projectanalyzer.Result, path string, inputPath string) {
+// handleResults 将所有分析器的结果合并到一个map中，连同解析过程的诊断信息（diagnostics 字段）一起写入到最终的输出文件。
+// findings 不为 nil 时以 findings 字段输出经过行内忽略注释（以及基线）过滤后的发现项。
+func handleResults(results map[string]projectanalyzer.Result, findings []projectanalyzer.Finding, diagnostics []projectParser.Diagnostic, path string, inputPath string) {
 	fmt.Printf("\n分析完成，正在将 %d 个分析结果写入 %s...\n", len(results), path)
+	output := make(map[string]interface{}, len(results)+2)
+	for name, res := range results {
+		output[name] = res
+	}
+	if findings != nil {
+		output["findings"] = findings
+	}
+	if diagnostics == nil {
+		diagnostics = []projectParser.Diagnostic{}
+	}
+	output["diagnostics"] = diagnostics
 	outputFileName := GenerateOutputFileName(inputPath, "analyzer_data")
-	err := WriteJSONResult(path, outputFileName, results)
+	err := WriteJSONResult(path, outputFileName, output)
 	if err != nil {
 		fmt.Printf("错误: 无法将结果写入文件 %s: %v\n", path, err)
 		os.Exit(1)
@@ -285,6 +429,19 @@ func handleResults(results map[string]projectanalyzer.Result, path string, input
 	fmt.Println("✅ 结果写入成功！")
 }
 
+// writeSARIFResult 将发现项与解析过程的诊断信息以 SARIF 格式写入输出目录。
+func writeSARIFResult(findings []projectanalyzer.Finding, diagnostics []projectParser.Diagnostic, path string, inputPath string) error {
+	findings = append(findings, projectanalyzer.DiagnosticFindings(diagnostics)...)
+	fmt.Printf("\n分析完成，正在将 %d 个发现项以 SARIF 格式写入 %s...\n", len(findings), path)
+
+	outputFileName := strings.TrimSuffix(GenerateOutputFileName(inputPath, "analyzer_data"), ".json") + ".sarif"
+	if err := WriteJSONResult(path, outputFileName, projectanalyzer.NewSARIFLog(inputPath, findings)); err != nil {
+		return fmt.Errorf("错误: 无法将结果写入文件 %s: %w", path, err)
+	}
+	fmt.Println("✅ 结果写入成功！")
+	return nil
+}
+
 // GenerateOutputFileName 是一个公共函数，用于根据输入目录和分析类型生成标准化的输出文件名。
 func GenerateOutputFileName(inputPath, suffix string) string {
 	baseName := filepath.Base(inputPath)
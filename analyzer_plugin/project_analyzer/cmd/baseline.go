@@ -0,0 +1,70 @@
+// package cmd 定义了分析器的所有命令行接口。
+// 本文件 (baseline.go) 实现了 `analyze --baseline` 的基线记录与比较，以及行内忽略注释的应用。
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	projectanalyzer "github.com/Flying-Bird1999/analyzer-ts/analyzer_plugin/project_analyzer"
+	"github.com/Flying-Bird1999/analyzer-ts/analyzer_plugin/project_analyzer/baseline"
+)
+
+// filterFindings 先按行内忽略注释过滤发现项，再与基线比较，返回需要报告的发现项。
+// baselinePath 为空时只应用忽略注释，相对路径基于 projectRoot；基线文件不存在或 update 为 true 时，用过滤后的发现项（重新）生成基线，
+// 此时所有发现项都已被接受，返回空列表。
+func filterFindings(findings []projectanalyzer.Finding, projectRoot, baselinePath string, update bool) ([]projectanalyzer.Finding, error) {
+	sources := baseline.NewSources(projectRoot)
+	findings, suppressed := baseline.Suppress(findings, sources)
+	if len(suppressed) > 0 {
+		fmt.Printf("已根据 %s 注释忽略 %d 个发现项。\n", baseline.IgnoreDirective, len(suppressed))
+	}
+	if findings == nil {
+		findings = []projectanalyzer.Finding{}
+	}
+	if baselinePath == "" {
+		return findings, nil
+	}
+	if !filepath.IsAbs(baselinePath) {
+		baselinePath = filepath.Join(projectRoot, baselinePath)
+	}
+
+	existing, err := baseline.Load(baselinePath)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if existing == nil || update {
+		if err := baseline.New(findings, sources).Save(baselinePath); err != nil {
+			return nil, fmt.Errorf("写入基线文件 %s 失败: %w", baselinePath, err)
+		}
+		fmt.Printf("已将 %d 个发现项记录到基线文件 %s。\n", len(findings), baselinePath)
+		return []projectanalyzer.Finding{}, nil
+	}
+
+	newFindings := existing.NewFindings(findings, sources)
+	fmt.Printf("基线中已有 %d 个发现项，新增 %d 个。\n", len(findings)-len(newFindings), len(newFindings))
+	if newFindings == nil {
+		newFindings = []projectanalyzer.Finding{}
+	}
+	return newFindings, nil
+}
+
+// checkNewFindings 在控制台列出不在基线中的发现项，存在 error 或 warning 级别的新发现项时返回错误，使命令以非零状态退出。
+func checkNewFindings(findings []projectanalyzer.Finding) error {
+	blocking := 0
+	for _, finding := range findings {
+		location := finding.File
+		if finding.Line > 0 {
+			location = fmt.Sprintf("%s:%d:%d", finding.File, finding.Line, finding.Column)
+		}
+		fmt.Printf("  [%s] %s %s: %s\n", finding.Severity, location, finding.RuleID, finding.Message)
+		if finding.Severity != projectanalyzer.SeverityInfo {
+			blocking++
+		}
+	}
+	if blocking > 0 {
+		return fmt.Errorf("发现 %d 个不在基线中的问题，请修复、添加 %s 注释，或使用 --update-baseline 接受它们", blocking, baseline.IgnoreDirective)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	projectanalyzer "github.com/Flying-Bird1999/analyzer-ts/analyzer_plugin/project_analyzer"
+)
+
+func TestFilterFindings(t *testing.T) {
+	root := t.TempDir()
+	file := filepath.Join(root, "src", "a.ts")
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(file, []byte("// analyzer-ts-ignore count-any -- 第三方库缺少类型\nconst a: any = 1;\nconst b: any = 2;\nconst c: any = 3;\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	finding := func(line int) projectanalyzer.Finding {
+		return projectanalyzer.Finding{
+			RuleID:   "count-any/any-usage",
+			Severity: projectanalyzer.SeverityWarning,
+			Message:  "使用了 'any' 类型",
+			File:     file,
+			Line:     line,
+			Column:   10,
+		}
+	}
+
+	// 不使用基线时也应用行内忽略注释
+	findings, err := filterFindings([]projectanalyzer.Finding{finding(2), finding(3)}, root, "", false)
+	if err != nil {
+		t.Fatalf("filterFindings() error: %v", err)
+	}
+	if len(findings) != 1 || findings[0].Line != 3 {
+		t.Errorf("第 2 行的发现项应被注释忽略, 得到 %+v", findings)
+	}
+	if findings, _ := filterFindings(nil, root, "", false); findings == nil {
+		t.Error("没有发现项时应返回空列表而不是 nil")
+	}
+
+	// 相对的基线路径基于项目根目录，而不是当前工作目录
+	findings, err = filterFindings([]projectanalyzer.Finding{finding(2), finding(3)}, root, ".analyzer/baseline.json", false)
+	if err != nil || len(findings) != 0 {
+		t.Fatalf("首次运行应记录基线并返回空列表, 得到 %+v, %v", findings, err)
+	}
+	if _, err := os.Stat(filepath.Join(root, ".analyzer", "baseline.json")); err != nil {
+		t.Fatalf("基线文件应写入项目根目录: %v", err)
+	}
+
+	findings, err = filterFindings([]projectanalyzer.Finding{finding(3), finding(4)}, root, ".analyzer/baseline.json", false)
+	if err != nil {
+		t.Fatalf("filterFindings() error: %v", err)
+	}
+	if len(findings) != 1 || findings[0].Line != 4 {
+		t.Errorf("只应报告基线中没有的第 4 行, 得到 %+v", findings)
+	}
+}
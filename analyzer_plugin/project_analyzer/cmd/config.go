@@ -0,0 +1,265 @@
+// package cmd 定义了分析器的所有命令行接口。
+// 本文件 (config.go) 实现了项目级分析器配置文件的加载、参数校验、进程外插件的注册与 `analyze --describe` 的输出。
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	projectanalyzer "github.com/Flying-Bird1999/analyzer-ts/analyzer_plugin/project_analyzer"
+	"github.com/Flying-Bird1999/analyzer-ts/analyzer_plugin/project_analyzer/external_plugin"
+	"gopkg.in/yaml.v3"
+)
+
+// analyzerConfigFileNames 是项目根目录的 .analyzer 目录下按顺序查找的配置文件名。
+var analyzerConfigFileNames = []string{"config.json", "config.yaml", "config.yml"}
+
+// AnalyzerConfigFile 是项目级分析器配置文件 `.analyzer/config.{json,yaml}` 的内容。
+// 每个分析器一个配置段，参数使用 JSON/YAML 的原生类型（列表、布尔值、嵌套对象等）：
+//
+//	analyzers:
+//	  trace:
+//	    targetPkgs: [antd, "@yy/sl-admin-components"]
+//	  find-unreferenced-files:
+//	    entrypoint: [src/index.ts]
+//	    include-entry-dirs: true
+//	plugins:
+//	  - tools/analyzer-ts-plugin-i18n
+//
+// 命令行中的 `-p analyzer.key=value` 会覆盖配置文件中的同名参数。
+type AnalyzerConfigFile struct {
+	// Analyzers 是按分析器名称分组的参数，名称可以是注册名或 Name() 的返回值。
+	Analyzers map[string]map[string]any `json:"analyzers" yaml:"analyzers"`
+
+	// Plugins 是进程外插件可执行文件的路径，相对路径基于项目根目录，见 external_plugin 包。
+	Plugins []string `json:"plugins" yaml:"plugins"`
+
+	// path 是配置文件的路径，用于错误信息。
+	path string
+}
+
+// LoadAnalyzerConfigFile 读取分析器配置文件。
+// path 为空时在 projectRoot/.analyzer 下按 analyzerConfigFileNames 查找，找不到时返回 nil。
+// 配置文件中出现未知字段时返回错误。
+func LoadAnalyzerConfigFile(projectRoot string, path string) (*AnalyzerConfigFile, error) {
+	if path == "" {
+		for _, name := range analyzerConfigFileNames {
+			candidate := filepath.Join(projectRoot, ".analyzer", name)
+			if _, err := os.Stat(candidate); err == nil {
+				path = candidate
+				break
+			}
+		}
+		if path == "" {
+			return nil, nil
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取配置文件失败: %w", err)
+	}
+
+	config := &AnalyzerConfigFile{path: path}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(config); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("解析配置文件 %s 失败: %w", path, err)
+		}
+	default:
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(config); err != nil {
+			return nil, fmt.Errorf("解析配置文件 %s 失败: %w", path, err)
+		}
+	}
+	return config, nil
+}
+
+// resolveAnalyzerParams 合并配置文件与命令行中的参数，按各分析器声明的 schema 校验，
+// 返回每个分析器（按 Name()）传给 Configure 的参数。
+// 配置文件中的所有配置段都会被校验（即使对应的分析器本次不运行），以便尽早发现拼写错误。
+// dependencies 是作为依赖被自动加入的分析器（按 Name()），它们缺少必需参数时会提示用户提供参数。
+func resolveAnalyzerParams(analyzers []projectanalyzer.Analyzer, dependencies map[string]bool, cliParams map[string]map[string][]string, configFile *AnalyzerConfigFile) (map[string]map[string]string, error) {
+	var errs []error
+
+	// 配置文件中的参数，key 为 Name()
+	fileParams := make(map[string]map[string]any)
+	// 配置段校验失败的分析器，合并后不再重复报告同样的错误
+	invalidSections := make(map[string]bool)
+	if configFile != nil {
+		for _, section := range sortedKeys(configFile.Analyzers) {
+			analyzer, ok := projectanalyzer.NewAnalyzer(section)
+			if !ok {
+				errs = append(errs, fmt.Errorf("配置文件 %s 中的分析器 '%s' 不存在", configFile.path, section))
+				continue
+			}
+			values := configFile.Analyzers[section]
+			if schema, ok := projectanalyzer.ParamSchemaOf(analyzer); ok {
+				// 必需参数可能由命令行提供，在合并之后再检查
+				schema.Required = nil
+				if err := projectanalyzer.ValidateParams(analyzer.Name(), schema, values); err != nil {
+					errs = append(errs, fmt.Errorf("配置文件 %s: %w", configFile.path, err))
+					invalidSections[analyzer.Name()] = true
+				}
+			}
+			fileParams[analyzer.Name()] = values
+		}
+	}
+
+	// 命令行中的参数，key 为 Name()
+	rawCliParams := make(map[string]map[string][]string)
+	for _, prefix := range sortedKeys(cliParams) {
+		analyzer, ok := projectanalyzer.NewAnalyzer(prefix)
+		if !ok {
+			errs = append(errs, fmt.Errorf("参数 -p '%s.*' 中的分析器 '%s' 不存在", prefix, prefix))
+			continue
+		}
+		rawCliParams[analyzer.Name()] = cliParams[prefix]
+	}
+
+	resolved := make(map[string]map[string]string, len(analyzers))
+	for _, analyzer := range analyzers {
+		name := analyzer.Name()
+		values := make(map[string]any)
+		for key, value := range fileParams[name] {
+			values[key] = value
+		}
+
+		schema, hasSchema := projectanalyzer.ParamSchemaOf(analyzer)
+		if !hasSchema {
+			// 未声明 schema 的分析器，命令行参数以逗号连接后原样传递
+			for key, value := range rawCliParams[name] {
+				values[key] = strings.Join(value, ",")
+			}
+			resolved[name] = projectanalyzer.FormatParams(values)
+			continue
+		}
+
+		cliValues, err := projectanalyzer.CoerceParams(name, schema, rawCliParams[name])
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		for key, value := range cliValues {
+			values[key] = value
+		}
+		if err := projectanalyzer.ValidateParams(name, schema, values); err != nil {
+			// 配置段本身的错误已经报告过，仅由命令行参数引入的错误（或缺少必需参数）才在这里报告
+			if dependencies[name] && !invalidSections[name] && len(rawCliParams[name]) == 0 {
+				errs = append(errs, fmt.Errorf("分析器 '%s' 被其他分析器依赖而自动加入，请在配置文件或 -p 中提供它的参数: %w", name, err))
+			} else if !invalidSections[name] || len(rawCliParams[name]) > 0 {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		resolved[name] = projectanalyzer.FormatParams(values)
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return resolved, nil
+}
+
+// registerPlugins 在 allowPlugins 为 true 时发现配置文件中列出的以及 PATH 中的进程外插件，并注册为分析器。
+// 插件会执行任意程序，未显式允许时不扫描 PATH，也不执行配置文件中列出的插件。
+// 与内置分析器同名的插件会被忽略并打印警告。
+func registerPlugins(projectRoot string, configFile *AnalyzerConfigFile, allowPlugins bool, timeout time.Duration) error {
+	var configured []string
+	if configFile != nil {
+		configured = configFile.Plugins
+	}
+	if !allowPlugins {
+		if len(configured) > 0 {
+			fmt.Printf("提示: 配置文件中列出了 %d 个进程外插件，插件会执行项目中的程序，默认不启用，使用 --allow-plugins 启用。\n", len(configured))
+		}
+		return nil
+	}
+	plugins, err := external_plugin.Discover(projectRoot, configured, os.Getenv("PATH"))
+	if err != nil {
+		return err
+	}
+	if err := external_plugin.Register(plugins, timeout); err != nil {
+		fmt.Printf("警告: %v\n", err)
+	}
+	return nil
+}
+
+// describeAnalyzer 返回分析器接受的参数说明，用于 `analyze --describe <name>`。
+func describeAnalyzer(name string) (string, error) {
+	analyzer, ok := projectanalyzer.NewAnalyzer(name)
+	if !ok {
+		return "", fmt.Errorf("未知的分析器 '%s'", name)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("分析器: %s\n", analyzer.Name()))
+	schema, ok := projectanalyzer.ParamSchemaOf(analyzer)
+	if !ok {
+		sb.WriteString("该分析器未声明参数 schema，参数以字符串形式原样传递给 Configure。\n")
+		return sb.String(), nil
+	}
+	if len(schema.Properties) == 0 {
+		sb.WriteString("该分析器不接受任何参数。\n")
+		return sb.String(), nil
+	}
+
+	sb.WriteString("参数:\n")
+	for _, key := range sortedKeys(schema.Properties) {
+		property := schema.Properties[key]
+		typeName := property.Type
+		if property.Items != nil {
+			typeName = fmt.Sprintf("array<%s>", property.Items.Type)
+		}
+		var notes []string
+		for _, required := range schema.Required {
+			if required == key {
+				notes = append(notes, "必需")
+			}
+		}
+		if property.Default != nil {
+			notes = append(notes, fmt.Sprintf("默认: %v", property.Default))
+		}
+		if len(property.Enum) > 0 {
+			notes = append(notes, "可选值: "+strings.Join(property.Enum, ", "))
+		}
+		line := fmt.Sprintf("  %s (%s)", key, typeName)
+		if len(notes) > 0 {
+			line += " [" + strings.Join(notes, "; ") + "]"
+		}
+		sb.WriteString(line + "\n")
+		if property.Description != "" {
+			sb.WriteString("      " + property.Description + "\n")
+		}
+	}
+
+	schemaJSON, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	sb.WriteString("\nJSON Schema:\n")
+	sb.Write(schemaJSON)
+	sb.WriteString("\n")
+	return sb.String(), nil
+}
+
+// sortedKeys 返回 map 的 key 并排序，保证输出与错误信息的顺序稳定。
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
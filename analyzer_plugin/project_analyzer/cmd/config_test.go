@@ -0,0 +1,249 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	projectanalyzer "github.com/Flying-Bird1999/analyzer-ts/analyzer_plugin/project_analyzer"
+)
+
+func writeConfigFile(t *testing.T, root, name, content string) string {
+	t.Helper()
+	path := filepath.Join(root, ".analyzer", name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func mustAnalyzer(t *testing.T, name string) projectanalyzer.Analyzer {
+	t.Helper()
+	analyzer, ok := projectanalyzer.NewAnalyzer(name)
+	if !ok {
+		t.Fatalf("分析器 %s 未注册", name)
+	}
+	return analyzer
+}
+
+func TestLoadAnalyzerConfigFile(t *testing.T) {
+	testCases := []struct {
+		name      string
+		fileName  string
+		content   string
+		errSubstr string
+	}{
+		{
+			name:     "JSON",
+			fileName: "config.json",
+			content:  `{"analyzers": {"trace": {"targetPkgs": ["antd", "lodash"]}}, "plugins": ["tools/plugin"]}`,
+		},
+		{
+			name:     "YAML",
+			fileName: "config.yaml",
+			content:  "analyzers:\n  trace:\n    targetPkgs: [antd, lodash]\nplugins:\n  - tools/plugin\n",
+		},
+		{
+			name:      "JSON 中的未知字段",
+			fileName:  "config.json",
+			content:   `{"analyzer": {"trace": {}}}`,
+			errSubstr: `unknown field "analyzer"`,
+		},
+		{
+			name:      "YAML 中的未知字段",
+			fileName:  "config.yml",
+			content:   "analyzer:\n  trace: {}\n",
+			errSubstr: "field analyzer not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			root := t.TempDir()
+			writeConfigFile(t, root, tc.fileName, tc.content)
+
+			config, err := LoadAnalyzerConfigFile(root, "")
+			if tc.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.errSubstr) {
+					t.Fatalf("预期错误包含 %q, 得到 %v", tc.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadAnalyzerConfigFile() error: %v", err)
+			}
+			pkgs, _ := config.Analyzers["trace"]["targetPkgs"].([]any)
+			if len(pkgs) != 2 || pkgs[0] != "antd" || len(config.Plugins) != 1 {
+				t.Errorf("配置文件内容不正确: %+v", config)
+			}
+		})
+	}
+
+	// 空的 YAML 文件与不存在的配置文件都不是错误
+	root := t.TempDir()
+	if config, err := LoadAnalyzerConfigFile(root, ""); err != nil || config != nil {
+		t.Errorf("没有配置文件时预期返回 nil, 得到 %+v, %v", config, err)
+	}
+	writeConfigFile(t, root, "config.yaml", "")
+	if _, err := LoadAnalyzerConfigFile(root, ""); err != nil {
+		t.Errorf("空的 YAML 配置文件不应报错: %v", err)
+	}
+}
+
+func TestResolveAnalyzerParams(t *testing.T) {
+	trace := mustAnalyzer(t, "trace")
+	unreferenced := mustAnalyzer(t, "find-unreferenced-files")
+	configFile := &AnalyzerConfigFile{
+		path: "config.json",
+		Analyzers: map[string]map[string]any{
+			"trace":                   {"targetPkgs": []any{"antd"}},
+			"find-unreferenced-files": {"entrypoint": []any{"src/index.ts"}, "include-entry-dirs": true},
+		},
+	}
+
+	testCases := []struct {
+		name       string
+		analyzers  []projectanalyzer.Analyzer
+		cliParams  []string
+		configFile *AnalyzerConfigFile
+		expected   map[string]map[string]string
+		errSubstr  string
+	}{
+		{
+			name:       "使用配置文件中的参数",
+			analyzers:  []projectanalyzer.Analyzer{trace, unreferenced},
+			configFile: configFile,
+			expected: map[string]map[string]string{
+				"trace":                   {"targetPkgs": "antd"},
+				"find-unreferenced-files": {"entrypoint": "src/index.ts", "include-entry-dirs": "true"},
+			},
+		},
+		{
+			name:       "命令行参数覆盖配置文件中的同名参数",
+			analyzers:  []projectanalyzer.Analyzer{trace, unreferenced},
+			cliParams:  []string{"trace.targetPkgs=react", "trace.targetPkgs=vue", "find-unreferenced-files.include-entry-dirs=false"},
+			configFile: configFile,
+			expected: map[string]map[string]string{
+				"trace":                   {"targetPkgs": "react,vue"},
+				"find-unreferenced-files": {"entrypoint": "src/index.ts", "include-entry-dirs": "false"},
+			},
+		},
+		{
+			name:      "必需参数只由命令行提供",
+			analyzers: []projectanalyzer.Analyzer{trace},
+			cliParams: []string{"trace.targetPkgs=antd"},
+			configFile: &AnalyzerConfigFile{path: "config.json", Analyzers: map[string]map[string]any{
+				"trace": {},
+			}},
+			expected: map[string]map[string]string{"trace": {"targetPkgs": "antd"}},
+		},
+		{
+			name:      "缺少必需参数",
+			analyzers: []projectanalyzer.Analyzer{trace},
+			errSubstr: "trace.targetPkgs: 缺少必需参数",
+		},
+		{
+			name:      "配置文件中的分析器配置段不存在",
+			analyzers: []projectanalyzer.Analyzer{trace},
+			cliParams: []string{"trace.targetPkgs=antd"},
+			configFile: &AnalyzerConfigFile{path: "config.json", Analyzers: map[string]map[string]any{
+				"no-such-analyzer": {},
+			}},
+			errSubstr: "配置文件 config.json 中的分析器 'no-such-analyzer' 不存在",
+		},
+		{
+			name:      "命令行中的未知参数给出拼写建议",
+			analyzers: []projectanalyzer.Analyzer{trace},
+			cliParams: []string{"trace.targetPkg=antd"},
+			errSubstr: "是否想使用 'targetPkgs'",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resolved, err := resolveAnalyzerParams(tc.analyzers, nil, parseAnalyzerParams(tc.cliParams), tc.configFile)
+			if tc.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.errSubstr) {
+					t.Fatalf("预期错误包含 %q, 得到 %v", tc.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveAnalyzerParams() error: %v", err)
+			}
+			for name, expected := range tc.expected {
+				for key, value := range expected {
+					if resolved[name][key] != value {
+						t.Errorf("%s.%s 预期为 %q, 得到 %q", name, key, value, resolved[name][key])
+					}
+				}
+				if len(resolved[name]) != len(expected) {
+					t.Errorf("%s 的参数预期为 %v, 得到 %v", name, expected, resolved[name])
+				}
+			}
+		})
+	}
+}
+
+// traceConsumer 是依赖 trace 分析器的测试分析器。
+type traceConsumer struct{}
+
+func (a *traceConsumer) Name() string                             { return "test-trace-consumer" }
+func (a *traceConsumer) Configure(params map[string]string) error { return nil }
+func (a *traceConsumer) DependsOn() []string                      { return []string{"trace"} }
+func (a *traceConsumer) Analyze(ctx *projectanalyzer.ProjectContext) (projectanalyzer.Result, error) {
+	return nil, nil
+}
+
+func TestResolveAnalyzerParamsForDependencies(t *testing.T) {
+	projectanalyzer.RegisterAnalyzer("test-trace-consumer", func() projectanalyzer.Analyzer { return &traceConsumer{} })
+
+	analyzers, err := projectanalyzer.ResolveDependencies([]projectanalyzer.Analyzer{&traceConsumer{}})
+	if err != nil {
+		t.Fatalf("ResolveDependencies() error: %v", err)
+	}
+	dependencies := map[string]bool{"trace": true}
+
+	// 自动加入的 trace 缺少必需参数时，错误信息说明它是作为依赖加入的
+	_, err = resolveAnalyzerParams(analyzers, dependencies, nil, nil)
+	if err == nil || !strings.Contains(err.Error(), "分析器 'trace' 被其他分析器依赖而自动加入") {
+		t.Fatalf("预期提示依赖缺少参数, 得到 %v", err)
+	}
+
+	// 通过命令行为依赖提供参数后可以正常运行
+	resolved, err := resolveAnalyzerParams(analyzers, dependencies, parseAnalyzerParams([]string{"trace.targetPkgs=antd"}), nil)
+	if err != nil || resolved["trace"]["targetPkgs"] != "antd" {
+		t.Errorf("为依赖提供参数后预期成功, 得到 %v, %v", resolved, err)
+	}
+}
+
+func TestDescribeAnalyzer(t *testing.T) {
+	description, err := describeAnalyzer("trace")
+	if err != nil {
+		t.Fatalf("describeAnalyzer() error: %v", err)
+	}
+	for _, expected := range []string{
+		"分析器: trace",
+		"  targetPkgs (array<string>) [必需]",
+		"      要追踪使用链路的 NPM 包名",
+		"JSON Schema:",
+		`"required": [`,
+	} {
+		if !strings.Contains(description, expected) {
+			t.Errorf("说明中缺少 %q:\n%s", expected, description)
+		}
+	}
+
+	description, err = describeAnalyzer("find-unreferenced-files")
+	if err != nil || !strings.Contains(description, "  include-entry-dirs (boolean) [默认: false]") {
+		t.Errorf("默认值应出现在说明中, 得到 %v:\n%s", err, description)
+	}
+
+	if _, err := describeAnalyzer("no-such-analyzer"); err == nil || !strings.Contains(err.Error(), "未知的分析器 'no-such-analyzer'") {
+		t.Errorf("未知的分析器应返回错误, 得到 %v", err)
+	}
+}
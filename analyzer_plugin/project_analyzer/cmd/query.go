@@ -37,6 +37,15 @@ func GetQueryCmd() *cobra.Command {
     - **按名称剔除**: '-s raw' 会删除所有名为 "raw" 的字段。
     - **按路径剔除**: '-s importDeclarations.raw' 只会删除 importDeclarations 下的 "raw" 字段。
 - **-j, --jmespath**: 提供一个 JMESPath 表达式来查询和重塑最终的 JSON 数据。
+- **--jobs**: 并发解析文件的 worker 数量，默认为 CPU 核数。
+- **--conditions**: 解析 package.json exports/imports 时启用的条件 (例如 'import,browser')，默认根据 tsconfig 的 moduleResolution 决定。
+- **--gitignore**: 扫描文件时遵循各级目录的 .gitignore、.git/info/exclude 以及工具专用的 .analyzerignore (语法与 .gitignore 相同)。
+- **--cache-dir**: 持久化解析缓存目录 (例如 '.analyzer/cache'，相对路径以项目根目录为基准)。未变化的文件会直接从缓存恢复。
+- **--strict**: 严格模式。解析过程中出现任何 error 级别的诊断信息 (读取或解析失败的文件、无效的 tsconfig.json / package.json 等) 时，命令以非零状态退出。
+
+**诊断信息:**
+
+解析过程中遇到的问题不会中断分析，而是记录在结果顶层的 "diagnostics" 字段中，每一项包含 file (文件路径)、phase (scan / tsconfig / package-json / parse / cache)、severity (error / warning) 和 message。
 
 **数据结构:**
 
@@ -52,10 +61,16 @@ func GetQueryCmd() *cobra.Command {
 			excludePaths, _ := cmd.Flags().GetStringSlice("exclude")
 			stripPaths, _ := cmd.Flags().GetStringSlice("strip-fields")
 			jmespathExpr, _ := cmd.Flags().GetString("jmespath")
+			jobs, _ := cmd.Flags().GetInt("jobs")
+			cacheDir, _ := cmd.Flags().GetString("cache-dir")
+			conditions, _ := cmd.Flags().GetStringSlice("conditions")
+			gitignore, _ := cmd.Flags().GetBool("gitignore")
+			strict, _ := cmd.Flags().GetBool("strict")
 
 			// --- 步骤 2: 调用公共函数执行项目解析和字段剔除 ---
 			// 重构后，所有数据获取和预处理都委托给了 ParseAndStripFields。
-			parsingResult, err := ParseAndStripFields(inputPath, excludePaths, isMonorepo, stripPaths)
+			// 输出的 JSON 中不包含 AST 与文件源码，因此使用低内存模式解析。
+			parsingResult, err := ParseAndStripFields(inputPath, excludePaths, isMonorepo, stripPaths, jobs, cacheDir, conditions, gitignore, strict, projectParser.LowMemoryParseOptions())
 			if err != nil {
 				return err // 直接返回错误，ParseAndStripFields 内部已经包含了足够的上下文信息
 			}
@@ -103,6 +118,11 @@ func GetQueryCmd() *cobra.Command {
 	queryCmd.Flags().StringP("output", "o", "", "输出文件目录 (如果为空，则输出到标准输出)")
 	queryCmd.Flags().StringSliceP("strip-fields", "s", []string{}, "要递归剔除的字段名或路径 (可多次使用)")
 	queryCmd.Flags().StringP("jmespath", "j", "", "(可选) 用于查询和重塑 JSON 数据的 JMESPath 表达式")
+	queryCmd.Flags().Int("jobs", 0, "并发解析文件的 worker 数量 (默认为 CPU 核数)")
+	queryCmd.Flags().String("cache-dir", "", "持久化解析缓存目录 (例如 .analyzer/cache，为空则不启用)")
+	queryCmd.Flags().StringSlice("conditions", []string{}, "解析 package.json exports/imports 时启用的条件 (例如 import,browser)")
+	queryCmd.Flags().Bool("gitignore", false, "扫描文件时遵循 .gitignore、.git/info/exclude 与 .analyzerignore")
+	queryCmd.Flags().Bool("strict", false, "严格模式: 解析过程中出现 error 级别的诊断信息时以非零状态退出")
 
 	// 将 input 标志标记为必需，如果用户没有提供 -i 或 --input，Cobra 会自动报错。
 	if err := queryCmd.MarkFlagRequired("input"); err != nil {
@@ -125,18 +145,35 @@ func GetQueryCmd() *cobra.Command {
 //   - excludePaths: 需要从分析中排除的文件或目录的 glob 模式列表。
 //   - isMonorepo:   一个布尔值，指示项目是否应被视为 monorepo。
 //   - stripPaths:   一个字符串切片，其中每个字符串都是一个要被剔除的字段名（如 "raw"）或字段路径（如 "declarations.raw"）。如果该切片为空，则不执行剔除操作。
+//   - jobs:         并发解析文件的 worker 数量，小于等于 0 时使用 CPU 核数。
+//   - cacheDir:     持久化解析缓存目录，为空时不启用缓存。
+//   - conditions:   解析 package.json exports/imports 时启用的条件，为空时根据 moduleResolution 使用默认条件。
+//   - gitignore:    扫描文件时是否遵循 .gitignore、.git/info/exclude 与 .analyzerignore。
+//   - strict:       严格模式，解析结果中存在 error 级别的诊断信息时返回错误。
+//   - parseOptions: 解析选项，决定 Js_Data 中是否保留 AST 与原始源码。
 //
 // 返回值:
 //   - *projectParser.ProjectParserResult: 指向（可能已被裁剪的）项目解析结果的指针。
 //   - error: 在解析或处理过程中发生的任何错误。
-func ParseAndStripFields(inputPath string, excludePaths []string, isMonorepo bool, stripPaths []string) (*projectParser.ProjectParserResult, error) {
+func ParseAndStripFields(inputPath string, excludePaths []string, isMonorepo bool, stripPaths []string, jobs int, cacheDir string, conditions []string, gitignore bool, strict bool, parseOptions projectParser.ParseOptions) (*projectParser.ProjectParserResult, error) {
 	// --- 步骤 1: 执行项目解析 ---
 	// 这是核心分析步骤。它会遍历项目文件，解析 AST，并构建一个包含所有信息的强类型Go结构体。
-	fmt.Println("开始解析项目，这可能需要一些时间...")
+	// 进度信息输出到标准错误，避免污染输出到标准输出的 JSON 结果。
+	fmt.Fprintln(os.Stderr, "开始解析项目，这可能需要一些时间...")
 	config := projectParser.NewProjectParserConfig(inputPath, excludePaths, isMonorepo, []string{})
+	config.Jobs = jobs
+	config.CacheDir = cacheDir
+	config.Conditions = conditions
+	config.Gitignore = gitignore
+	config.ParseOptions = parseOptions
 	parsingResult := projectParser.NewProjectParserResult(config)
 	parsingResult.ProjectParser()
-	fmt.Println("项目解析完成。")
+	fmt.Fprintf(os.Stderr, "项目解析完成，共 %d 条诊断信息。\n", len(parsingResult.Diagnostics))
+	if strict {
+		if err := parsingResult.CheckStrict(); err != nil {
+			return nil, err
+		}
+	}
 
 	// --- 步骤 2: (可选) 执行字段剔除 ---
 	// 如果用户没有提供任何需要剔除的字段，则直接返回原始的解析结果。
@@ -144,7 +181,7 @@ func ParseAndStripFields(inputPath string, excludePaths []string, isMonorepo boo
 		return parsingResult, nil
 	}
 
-	fmt.Printf("正在根据 %d 个规则剔除字段...\n", len(stripPaths))
+	fmt.Fprintf(os.Stderr, "正在根据 %d 个规则剔除字段...\n", len(stripPaths))
 
 	// --- 步骤 2a: 将 Go 结构体转换为通用的 interface{} ---
 	// 为了让递归字段剔除能够处理数据，需要将强类型的 Go 结构体转换为
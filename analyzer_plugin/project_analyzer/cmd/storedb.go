@@ -11,6 +11,7 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/Flying-Bird1999/analyzer-ts/analyzer/projectParser"
@@ -29,6 +30,9 @@ func NewStoreDbCmd() *cobra.Command {
 			outputDir, _ := cmd.Flags().GetString("output") // 现在是输出目录
 			excludePatterns, _ := cmd.Flags().GetStringSlice("exclude")
 			isMonorepo, _ := cmd.Flags().GetBool("monorepo")
+			jobs, _ := cmd.Flags().GetInt("jobs")
+			gitignore, _ := cmd.Flags().GetBool("gitignore")
+			strict, _ := cmd.Flags().GetBool("strict")
 
 			if inputPath == "" || outputDir == "" {
 				log.Fatal("需要提供输入和输出路径。")
@@ -46,9 +50,21 @@ func NewStoreDbCmd() *cobra.Command {
 
 			fmt.Println("开始分析...")
 			config := projectParser.NewProjectParserConfig(inputPath, excludePatterns, isMonorepo, []string{})
+			config.Jobs = jobs
+			config.Gitignore = gitignore
+			// 数据库只存储声明记录，不需要 AST 与文件源码
+			config.ParseOptions = projectParser.LowMemoryParseOptions()
 			projectData := projectParser.NewProjectParserResult(config)
 			projectData.ProjectParser()
 			fmt.Println(fmt.Sprintf("分析完成。发现 %d 个JS/TS文件和 %d 个package.json文件。", len(projectData.Js_Data), len(projectData.Package_Data)))
+			for _, d := range projectData.Diagnostics {
+				fmt.Printf("[%s][%s] %s: %s\n", d.Severity, d.Phase, d.File, d.Message)
+			}
+			if strict {
+				if err := projectData.CheckStrict(); err != nil {
+					log.Fatal(err)
+				}
+			}
 
 			fmt.Println("正在将结果存储到数据库:", finalDbPath)
 			if err := storeInDatabase(projectData, finalDbPath); err != nil {
@@ -63,6 +79,9 @@ func NewStoreDbCmd() *cobra.Command {
 	storeDbCmd.Flags().StringP("output", "o", "", "用于存储数据库文件的输出目录路径")
 	storeDbCmd.Flags().StringSliceP("exclude", "x", []string{}, "要从分析中排除的 Glob 模式 (可多次指定)")
 	storeDbCmd.Flags().BoolP("monorepo", "m", false, "如果要分析的是 monorepo，则设置为 true")
+	storeDbCmd.Flags().Int("jobs", 0, "并发解析文件的 worker 数量 (默认为 CPU 核数)")
+	storeDbCmd.Flags().Bool("gitignore", false, "扫描文件时遵循 .gitignore、.git/info/exclude 与 .analyzerignore")
+	storeDbCmd.Flags().Bool("strict", false, "严格模式: 解析过程中出现 error 级别的诊断信息时以非零状态退出")
 	storeDbCmd.MarkFlagRequired("input")
 	storeDbCmd.MarkFlagRequired("output")
 
@@ -106,6 +125,9 @@ func storeInDatabase(projectData *projectParser.ProjectParserResult, dbPath stri
 		"interfaces":       "INSERT INTO interfaces (file_id, identifier, line_start, line_end, references_json, raw_code) VALUES (?, ?, ?, ?, ?, ?)",
 		"function_calls":   "INSERT INTO function_calls (file_id, call_chain, line_start, line_end, arguments_json, raw_code) VALUES (?, ?, ?, ?, ?, ?)",
 		"variables":        "INSERT INTO variables (file_id, identifier, declaration_kind, exported, line_start, line_end, details_json, raw_code) VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+		"enums":            "INSERT INTO enums (file_id, identifier, exported, is_const, is_declare, line_start, line_end, raw_code) VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+		"enum_members":     "INSERT INTO enum_members (enum_id, name, initializer, value, value_type, line_start) VALUES (?, ?, ?, ?, ?, ?)",
+		"classes":          "INSERT INTO classes (file_id, identifier, exported, is_default_export, is_abstract, extends, implements_json, members_json, line_start, line_end, raw_code) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
 	}
 
 	for name, query := range queries {
@@ -202,6 +224,43 @@ func storeInDatabase(projectData *projectParser.ProjectParserResult, dbPath stri
 				}
 			}
 		}
+
+		// 存储枚举及其成员
+		for name, enum := range jsFileData.EnumDeclarations {
+			res, err := stmtCache["enums"].Exec(fileID, name, enum.Exported, enum.IsConst, enum.IsDeclare, enum.SourceLocation.Start.Line, enum.SourceLocation.End.Line, enum.Raw)
+			if err != nil {
+				tx.Rollback()
+				return fmt.Errorf("无法在文件 %s 中插入枚举 %s: %w", path, name, err)
+			}
+			enumID, _ := res.LastInsertId()
+
+			for _, member := range enum.Members {
+				// value 统一以文本形式存储，value_type 记录原始类型（number / string），无法计算时均为 NULL
+				var value, valueType interface{}
+				switch v := member.Value.(type) {
+				case float64:
+					value, valueType = strconv.FormatFloat(v, 'f', -1, 64), "number"
+				case string:
+					value, valueType = v, "string"
+				}
+				_, err := stmtCache["enum_members"].Exec(enumID, member.Name, member.Initializer, value, valueType, member.SourceLocation.Start.Line)
+				if err != nil {
+					tx.Rollback()
+					return fmt.Errorf("无法在文件 %s 中插入枚举成员 %s.%s: %w", path, name, member.Name, err)
+				}
+			}
+		}
+
+		// 存储类
+		for _, class := range jsFileData.ClassDeclarations {
+			implementsJSON, _ := json.Marshal(class.Implements)
+			membersJSON, _ := json.Marshal(class.Members)
+			_, err := stmtCache["classes"].Exec(fileID, class.Identifier, class.Exported, class.IsDefaultExport, class.IsAbstract, class.Extends, string(implementsJSON), string(membersJSON), class.SourceLocation.Start.Line, class.SourceLocation.End.Line, class.Raw)
+			if err != nil {
+				tx.Rollback()
+				return fmt.Errorf("无法在文件 %s 中插入类 %s: %w", path, class.Identifier, err)
+			}
+		}
 	}
 
 	return tx.Commit()
@@ -217,6 +276,9 @@ func createSchema(db *sql.DB) error {
     DROP TABLE IF EXISTS interfaces;
     DROP TABLE IF EXISTS function_calls;
     DROP TABLE IF EXISTS variables;
+    DROP TABLE IF EXISTS classes;
+    DROP TABLE IF EXISTS enum_members;
+    DROP TABLE IF EXISTS enums;
     DROP TABLE IF EXISTS files;
 
     CREATE TABLE packages (
@@ -298,6 +360,46 @@ func createSchema(db *sql.DB) error {
         raw_code TEXT,
         FOREIGN KEY (file_id) REFERENCES files (id)
     );
+
+    CREATE TABLE enums (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        file_id INTEGER NOT NULL,
+        identifier TEXT,
+        exported BOOLEAN,
+        is_const BOOLEAN,
+        is_declare BOOLEAN,
+        line_start INTEGER,
+        line_end INTEGER,
+        raw_code TEXT,
+        FOREIGN KEY (file_id) REFERENCES files (id)
+    );
+
+    CREATE TABLE enum_members (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        enum_id INTEGER NOT NULL,
+        name TEXT,
+        initializer TEXT,
+        value TEXT,
+        value_type TEXT,
+        line_start INTEGER,
+        FOREIGN KEY (enum_id) REFERENCES enums (id)
+    );
+
+    CREATE TABLE classes (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        file_id INTEGER NOT NULL,
+        identifier TEXT,
+        exported BOOLEAN,
+        is_default_export BOOLEAN,
+        is_abstract BOOLEAN,
+        extends TEXT,
+        implements_json TEXT,
+        members_json TEXT,
+        line_start INTEGER,
+        line_end INTEGER,
+        raw_code TEXT,
+        FOREIGN KEY (file_id) REFERENCES files (id)
+    );
     `
 	_, err := db.Exec(schema)
 	return err
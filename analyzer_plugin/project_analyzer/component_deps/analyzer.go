@@ -55,6 +55,17 @@ func (a *ComponentDepsAnalyzer) Configure(params map[string]string) error {
 	return nil
 }
 
+func (a *ComponentDepsAnalyzer) RequiredParseOptions() projectParser.ParseOptions {
+	return projectParser.LowMemoryParseOptions()
+}
+
+// ParamSchema 描述该分析器接受的参数。
+func (a *ComponentDepsAnalyzer) ParamSchema() projectanalyzer.ParamSchema {
+	return projectanalyzer.ObjectParams(map[string]*projectanalyzer.ParamSchema{
+		"manifest": projectanalyzer.StringParam("组件清单 component-manifest.json 的路径"),
+	}, "manifest")
+}
+
 // Analyze 执行组件依赖分析
 // 分析流程：
 // 1. 加载配置文件
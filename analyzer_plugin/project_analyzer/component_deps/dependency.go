@@ -91,7 +91,7 @@ func (da *DependencyAnalyzer) getDependencyKey(importDecl projectParser.ImportDe
 	switch importDecl.Source.Type {
 	case "npm":
 		return "npm:" + importDecl.Source.NpmPkg
-	case "file":
+	case "file", "workspace", "style", "asset":
 		return "file:" + importDecl.Source.FilePath
 	default:
 		return ""
@@ -192,7 +192,7 @@ func (da *DependencyAnalyzer) isExternalDependency(
 	}
 
 	// 文件类型，判断目标文件是否在当前组件目录下
-	if importDecl.Source.Type == "file" {
+	if importDecl.Source.IsLocal() {
 		targetFilePath := importDecl.Source.FilePath
 		if targetFilePath == "" {
 			return false
@@ -272,7 +272,7 @@ func (da *DependencyAnalyzer) ClassifyDependencies(
 				npmDepsSet[npmPkg] = true
 			}
 
-		case "file":
+		case "file", "workspace", "style", "asset":
 			// 判断该文件是否属于 manifest 中的某个组件
 			targetFilePath := dep.Source.FilePath
 			if targetFilePath == "" {
@@ -47,8 +47,8 @@ func NewReExportResolver(fileResults map[string]projectParser.JsFileParserResult
 func (r *ReExportResolver) ResolveDependency(
 	dep projectParser.ImportDeclarationResult,
 ) []projectParser.ImportDeclarationResult {
-	// 只处理文件类型的依赖
-	if dep.Source.Type != "file" {
+	// 只处理文件类型的依赖（包括解析到源码的 workspace 包）
+	if !dep.Source.IsLocal() {
 		return []projectParser.ImportDeclarationResult{dep}
 	}
 
@@ -132,7 +132,7 @@ func (r *ReExportResolver) buildExportMapping(
 		}
 
 		// 只处理文件类型的重导出
-		if exportDecl.Source.Type != "file" {
+		if !exportDecl.Source.IsLocal() {
 			continue
 		}
 
@@ -191,7 +191,7 @@ func (r *ReExportResolver) getDependencyKey(dep projectParser.ImportDeclarationR
 	switch dep.Source.Type {
 	case "npm":
 		return "npm:" + dep.Source.NpmPkg
-	case "file":
+	case "file", "workspace", "style", "asset":
 		return "file:" + dep.Source.FilePath
 	default:
 		return ""
@@ -220,7 +220,7 @@ func (r *ReExportResolver) IsReExportFile(filePath string) bool {
 	}
 
 	for _, exportDecl := range fileResult.ExportDeclarations {
-		if exportDecl.Source != nil && exportDecl.Source.Type == "file" {
+		if exportDecl.Source != nil && exportDecl.Source.IsLocal() {
 			return true
 		}
 	}
@@ -246,7 +246,7 @@ func (r *ReExportResolver) GetReExportChain(filePath string) []string {
 		// 获取下一个文件
 		fileResult := r.fileResults[current]
 		for _, exportDecl := range fileResult.ExportDeclarations {
-			if exportDecl.Source != nil && exportDecl.Source.Type == "file" {
+			if exportDecl.Source != nil && exportDecl.Source.IsLocal() {
 				current = exportDecl.Source.FilePath
 				break
 			}
@@ -270,7 +270,7 @@ func (r *ReExportResolver) FindExportByModule(filePath, moduleName string) (stri
 	}
 
 	for _, exportDecl := range fileResult.ExportDeclarations {
-		if exportDecl.Source == nil || exportDecl.Source.Type != "file" {
+		if exportDecl.Source == nil || !exportDecl.Source.IsLocal() {
 			continue
 		}
 
@@ -152,3 +152,23 @@ func (r *ComponentDepsResult) ToConsole() string {
 func (r *ComponentDepsResult) AnalyzerName() string {
 	return "component-deps"
 }
+
+var _ projectanalyzer.FindingsProvider = (*ComponentDepsResult)(nil)
+
+// NormalizedFindings 每个引用了其他组件的文件报告为一条 info
+func (r *ComponentDepsResult) NormalizedFindings() []projectanalyzer.Finding {
+	var findings []projectanalyzer.Finding
+	for name, comp := range r.Components {
+		for _, dep := range comp.ComponentDeps {
+			for _, file := range dep.DepFiles {
+				findings = append(findings, projectanalyzer.Finding{
+					RuleID:   "component-deps/component-dependency",
+					Severity: projectanalyzer.SeverityInfo,
+					Message:  fmt.Sprintf("组件 %s 依赖组件 %s", name, dep.Name),
+					File:     file,
+				})
+			}
+		}
+	}
+	return findings
+}
@@ -84,3 +84,68 @@ func TestCountAnyAnalyzer(t *testing.T) {
 		}
 	}
 }
+
+func TestCountAnyFindings(t *testing.T) {
+	location := func(line, column, endColumn int) parser.SourceLocation {
+		return parser.SourceLocation{
+			Start: parser.NodePosition{Line: line, Column: column},
+			End:   parser.NodePosition{Line: line, Column: endColumn},
+		}
+	}
+	mockParsingResult := &projectParser.ProjectParserResult{
+		Js_Data: map[string]projectParser.JsFileParserResult{
+			"/project/src/b.ts": {
+				ExtractedNodes: parser.ExtractedNodes{
+					AnyDeclarations: []parser.AnyInfo{{SourceLocation: location(3, 10, 13), Raw: "any"}},
+				},
+			},
+			"/project/src/a.ts": {
+				ExtractedNodes: parser.ExtractedNodes{
+					AnyDeclarations: []parser.AnyInfo{
+						{SourceLocation: location(7, 1, 4), Raw: "any"},
+						{SourceLocation: location(2, 5, 8), Raw: "any"},
+					},
+				},
+			},
+		},
+	}
+
+	result, err := (&Counter{}).Analyze(&projectanalyzer.ProjectContext{ParsingResult: mockParsingResult})
+	if err != nil {
+		t.Fatalf("Analyze() returned an unexpected error: %v", err)
+	}
+	findings, ok := projectanalyzer.FindingsOf(result)
+	if !ok {
+		t.Fatalf("CountAnyResult should implement FindingsProvider")
+	}
+	if len(findings) != 3 {
+		t.Fatalf("Expected 3 findings, but got %d", len(findings))
+	}
+	// 发现项按文件与位置排序
+	first := findings[0]
+	if first.File != "/project/src/a.ts" || first.Line != 2 || first.Column != 5 || first.EndColumn != 8 {
+		t.Errorf("Unexpected first finding: %+v", first)
+	}
+	if first.RuleID != "count-any/any-usage" || first.Severity != projectanalyzer.SeverityWarning {
+		t.Errorf("Unexpected rule or severity: %+v", first)
+	}
+
+	log := projectanalyzer.NewSARIFLog("/project", findings)
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("Unexpected SARIF log: %+v", log)
+	}
+	run := log.Runs[0]
+	if len(run.Tool.Driver.Rules) != 1 || run.Tool.Driver.Rules[0].ID != "count-any/any-usage" {
+		t.Errorf("Expected a single rule, got %+v", run.Tool.Driver.Rules)
+	}
+	if len(run.Results) != 3 || run.Results[0].Level != "warning" {
+		t.Fatalf("Unexpected SARIF results: %+v", run.Results)
+	}
+	physical := run.Results[0].Locations[0].PhysicalLocation
+	if physical.ArtifactLocation.URI != "src/a.ts" || physical.ArtifactLocation.URIBaseID != "SRCROOT" {
+		t.Errorf("Expected a path relative to SRCROOT, got %+v", physical.ArtifactLocation)
+	}
+	if physical.Region == nil || physical.Region.StartLine != 2 || physical.Region.StartColumn != 5 || physical.Region.EndColumn != 8 {
+		t.Errorf("Unexpected region: %+v", physical.Region)
+	}
+}
@@ -135,3 +135,21 @@ func (r *CountAnyResult) ToConsole() string {
 func (r *CountAnyResult) AnalyzerName() string {
 	return "count-any"
 }
+
+// 确保 CountAnyResult 实现了 projectanalyzer.FindingsProvider 接口。
+var _ projectanalyzer.FindingsProvider = (*CountAnyResult)(nil)
+
+// NormalizedFindings 将每一处 'any' 类型的使用转换为一条 warning 级别的发现项。
+func (r *CountAnyResult) NormalizedFindings() []projectanalyzer.Finding {
+	var findings []projectanalyzer.Finding
+	for _, fc := range r.FileCounts {
+		for _, detail := range fc.Details {
+			location := detail.SourceLocation
+			findings = append(findings, projectanalyzer.FindingAt(
+				"count-any/any-usage", projectanalyzer.SeverityWarning,
+				"使用了 'any' 类型", fc.FilePath, &location,
+			))
+		}
+	}
+	return findings
+}
@@ -26,6 +26,7 @@
 package countas
 
 import (
+	"github.com/Flying-Bird1999/analyzer-ts/analyzer/projectParser"
 	projectanalyzer "github.com/Flying-Bird1999/analyzer-ts/analyzer_plugin/project_analyzer"
 )
 
@@ -62,6 +63,15 @@ func (c *Counter) Configure(params map[string]string) error {
 	return nil
 }
 
+func (c *Counter) RequiredParseOptions() projectParser.ParseOptions {
+	return projectParser.LowMemoryParseOptions()
+}
+
+// ParamSchema 声明该分析器不接受任何参数。
+func (c *Counter) ParamSchema() projectanalyzer.ParamSchema {
+	return projectanalyzer.ObjectParams(nil)
+}
+
 // Analyze 执行核心的分析逻辑。
 // 这个方法会遍历项目中的所有文件，统计 'as' 类型断言的使用情况。
 //
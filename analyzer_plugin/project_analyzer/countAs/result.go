@@ -142,3 +142,21 @@ func (r *CountAsResult) ToConsole() string {
 func (r *CountAsResult) AnalyzerName() string {
 	return "count-as"
 }
+
+// 确保 CountAsResult 实现了 projectanalyzer.FindingsProvider 接口。
+var _ projectanalyzer.FindingsProvider = (*CountAsResult)(nil)
+
+// NormalizedFindings 将每一处 'as' 类型断言转换为一条 warning 级别的发现项。
+func (r *CountAsResult) NormalizedFindings() []projectanalyzer.Finding {
+	var findings []projectanalyzer.Finding
+	for _, fc := range r.FileCounts {
+		for _, detail := range fc.Details {
+			location := detail.SourceLocation
+			findings = append(findings, projectanalyzer.FindingAt(
+				"count-as/as-assertion", projectanalyzer.SeverityWarning,
+				fmt.Sprintf("使用了 'as' 类型断言: %s", detail.Raw), fc.FilePath, &location,
+			))
+		}
+	}
+	return findings
+}
@@ -1,10 +1,13 @@
-// Package css_plugin 实现列出项目中 CSS 文件的分析器
+// Package css_plugin 实现列出项目中样式文件的分析器，并交叉检查 CSS Modules 的类名使用情况
 package css_plugin
 
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 
+	"github.com/Flying-Bird1999/analyzer-ts/analyzer/parser"
 	"github.com/Flying-Bird1999/analyzer-ts/analyzer/projectParser"
 	projectanalyzer "github.com/Flying-Bird1999/analyzer-ts/analyzer_plugin/project_analyzer"
 )
@@ -21,45 +24,116 @@ func (c *CssFile) Configure(params map[string]string) error {
 	return nil
 }
 
+func (c *CssFile) RequiredParseOptions() projectParser.ParseOptions {
+	return projectParser.LowMemoryParseOptions()
+}
+
+func (c *CssFile) ParamSchema() projectanalyzer.ParamSchema {
+	return projectanalyzer.ObjectParams(nil)
+}
+
 func (c *CssFile) Analyze(ctx *projectanalyzer.ProjectContext) (projectanalyzer.Result, error) {
-	return &CssFileResult{
-		CssData: ctx.ParsingResult.Css_Data,
-	}, nil
+	result := &CssFileResult{
+		CssData:             ctx.ParsingResult.Css_Data,
+		UnusedClasses:       []UnusedClass{},
+		UndefinedReferences: []UndefinedReference{},
+	}
+	crossReferenceCssModules(ctx.ParsingResult, result)
+	return result, nil
+}
+
+// UnusedClass 是 CSS Modules 文件中声明但没有被任何文件引用的类名。
+type UnusedClass struct {
+	FilePath  string `json:"filePath"`  // 样式文件的绝对路径
+	ClassName string `json:"className"` // 类名
+	Line      int    `json:"line"`      // 类名第一次出现的行号
+}
+
+// UndefinedReference 是对 CSS Modules 文件中不存在的类名的引用。
+type UndefinedReference struct {
+	FilePath   string `json:"filePath"`   // 引用所在文件的绝对路径
+	StyleFile  string `json:"styleFile"`  // 被引用的样式文件的绝对路径
+	ClassName  string `json:"className"`  // 引用的类名
+	Identifier string `json:"identifier"` // 引用所使用的本地标识符
+	Line       int    `json:"line"`       // 引用所在行号
+	Raw        string `json:"raw"`        // 引用的源码文本
 }
 
 type CssFileResult struct {
-	CssData map[string]projectParser.CssFileInfo
+	CssData             map[string]projectParser.CssFileInfo `json:"files"`
+	UnusedClasses       []UnusedClass                        `json:"unusedClasses"`       // 未被引用的 CSS Modules 类名
+	UndefinedReferences []UndefinedReference                 `json:"undefinedReferences"` // 引用了不存在类名的 CSS Modules 引用
 }
 
 var _ projectanalyzer.Result = (*CssFileResult)(nil)
-var _ json.Marshaler = (*CssFileResult)(nil)
 
 func (r *CssFileResult) Name() string {
 	return "CSS Files"
 }
 
 func (r *CssFileResult) Summary() string {
-	return fmt.Sprintf("%d 个 CSS 文件", len(r.CssData))
-}
-
-// MarshalJSON 实现 json.Marshaler 接口，直接输出文件路径 map
-func (r *CssFileResult) MarshalJSON() ([]byte, error) {
-	return json.Marshal(r.CssData)
+	return fmt.Sprintf("%d 个样式文件，%d 个未使用的 CSS Modules 类名，%d 个未定义的类名引用",
+		len(r.CssData), len(r.UnusedClasses), len(r.UndefinedReferences))
 }
 
 func (r *CssFileResult) ToJSON(indent bool) ([]byte, error) {
 	if indent {
-		return json.MarshalIndent(r.CssData, "", "  ")
+		return json.MarshalIndent(r, "", "  ")
 	}
-	return json.Marshal(r.CssData)
+	return json.Marshal(r)
 }
 
 func (r *CssFileResult) ToConsole() string {
-	var s string
+	var b strings.Builder
+	paths := make([]string, 0, len(r.CssData))
 	for path := range r.CssData {
-		s += fmt.Sprintf("%s\n", path)
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		info := r.CssData[path]
+		fmt.Fprintf(&b, "%s (%d 个类名，%d 个自定义属性，%d 个导入)\n",
+			path, len(info.ClassNames), len(info.CustomProperties), len(info.ImportDeclarations))
 	}
-	return s
+	if len(r.UnusedClasses) > 0 {
+		b.WriteString("\n未使用的 CSS Modules 类名:\n")
+		for _, unused := range r.UnusedClasses {
+			fmt.Fprintf(&b, "  .%s  %s:%d\n", unused.ClassName, unused.FilePath, unused.Line)
+		}
+	}
+	if len(r.UndefinedReferences) > 0 {
+		b.WriteString("\n未定义的类名引用:\n")
+		for _, ref := range r.UndefinedReferences {
+			fmt.Fprintf(&b, "  %s  %s:%d (%s)\n", ref.Raw, ref.FilePath, ref.Line, ref.StyleFile)
+		}
+	}
+	return b.String()
+}
+
+var _ projectanalyzer.FindingsProvider = (*CssFileResult)(nil)
+
+// NormalizedFindings 未使用的 CSS Modules 类名为 warning，引用了不存在的类名为 error。
+func (r *CssFileResult) NormalizedFindings() []projectanalyzer.Finding {
+	findings := make([]projectanalyzer.Finding, 0, len(r.UnusedClasses)+len(r.UndefinedReferences))
+	for _, unused := range r.UnusedClasses {
+		findings = append(findings, projectanalyzer.Finding{
+			RuleID:   "css-file/unused-class",
+			Severity: projectanalyzer.SeverityWarning,
+			Message:  fmt.Sprintf("CSS Modules 类名 .%s 没有被引用", unused.ClassName),
+			File:     unused.FilePath,
+			Line:     unused.Line,
+		})
+	}
+	for _, ref := range r.UndefinedReferences {
+		findings = append(findings, projectanalyzer.Finding{
+			RuleID:   "css-file/undefined-class",
+			Severity: projectanalyzer.SeverityError,
+			Message:  fmt.Sprintf("%s 引用的类名 .%s 在 %s 中不存在", ref.Raw, ref.ClassName, ref.StyleFile),
+			File:     ref.FilePath,
+			Line:     ref.Line,
+		})
+	}
+	return findings
 }
 
 // AnalyzerName 返回对应的分析器名称
@@ -67,6 +141,176 @@ func (r *CssFileResult) AnalyzerName() string {
 	return "css-file"
 }
 
+// crossReferenceCssModules 将 JS/TS 文件中的 CSS Modules 引用与样式文件中声明的类名进行交叉比对。
+// 引用通过导入声明的本地标识符关联到解析后的样式文件。
+// 样式文件通过 @import 引入的其他样式文件中的类名同样视为已声明（LESS/SCSS 会内联这些规则），
+// 通过导入方引用的类名也同样视为被引入的样式文件中的类名被使用。
+// 存在动态引用（如 `styles[name]`）的样式文件及其引入的样式文件不报告未使用的类名。
+func crossReferenceCssModules(parsingResult *projectParser.ProjectParserResult, result *CssFileResult) {
+	used := map[string]map[string]bool{}
+	dynamic := map[string]bool{}
+	// 每个样式文件的 @import 闭包与其中声明的类名只计算一次
+	closures := map[string][]string{}
+	declared := map[string]map[string]bool{}
+
+	jsPaths := make([]string, 0, len(parsingResult.Js_Data))
+	for path := range parsingResult.Js_Data {
+		jsPaths = append(jsPaths, path)
+	}
+	sort.Strings(jsPaths)
+
+	for _, jsPath := range jsPaths {
+		jsResult := parsingResult.Js_Data[jsPath]
+		if len(jsResult.CssModuleReferences) == 0 {
+			continue
+		}
+		styleFiles := styleFilesByIdentifier(jsResult.ImportDeclarations)
+		for _, ref := range jsResult.CssModuleReferences {
+			styleFile, ok := styleFiles[ref.Identifier]
+			if !ok {
+				continue
+			}
+			closure, ok := closures[styleFile]
+			if !ok {
+				closure = styleImportClosure(parsingResult.Css_Data, styleFile)
+				closures[styleFile] = closure
+			}
+			if ref.IsDynamic {
+				for _, path := range closure {
+					dynamic[path] = true
+				}
+				continue
+			}
+			for _, path := range closure {
+				if used[path] == nil {
+					used[path] = map[string]bool{}
+				}
+				used[path][ref.ClassName] = true
+			}
+
+			if _, parsed := parsingResult.Css_Data[styleFile]; !parsed {
+				continue
+			}
+			classNames, ok := declared[styleFile]
+			if !ok {
+				classNames = declaredClassNames(parsingResult.Css_Data, closure)
+				declared[styleFile] = classNames
+			}
+			if !lookupClassName(classNames, ref.ClassName) {
+				undefined := UndefinedReference{
+					FilePath:   jsPath,
+					StyleFile:  styleFile,
+					ClassName:  ref.ClassName,
+					Identifier: ref.Identifier,
+					Raw:        ref.Raw,
+				}
+				if ref.SourceLocation != nil {
+					undefined.Line = ref.SourceLocation.Start.Line
+				}
+				result.UndefinedReferences = append(result.UndefinedReferences, undefined)
+			}
+		}
+	}
+
+	cssPaths := make([]string, 0, len(parsingResult.Css_Data))
+	for path := range parsingResult.Css_Data {
+		cssPaths = append(cssPaths, path)
+	}
+	sort.Strings(cssPaths)
+
+	for _, cssPath := range cssPaths {
+		if !parser.IsCssModuleSource(cssPath) || dynamic[cssPath] {
+			continue
+		}
+		for _, class := range parsingResult.Css_Data[cssPath].ClassNames {
+			if isClassNameUsed(used[cssPath], class.Name) {
+				continue
+			}
+			unused := UnusedClass{FilePath: cssPath, ClassName: class.Name}
+			if class.SourceLocation != nil {
+				unused.Line = class.SourceLocation.Start.Line
+			}
+			result.UnusedClasses = append(result.UnusedClasses, unused)
+		}
+	}
+}
+
+// styleFilesByIdentifier 返回 CSS Modules 导入的本地标识符到样式文件绝对路径的映射。
+func styleFilesByIdentifier(importDecls []projectParser.ImportDeclarationResult) map[string]string {
+	styleFiles := map[string]string{}
+	for _, decl := range importDecls {
+		if decl.Source.Type != "style" {
+			continue
+		}
+		for _, module := range decl.ImportModules {
+			styleFiles[module.Identifier] = decl.Source.FilePath
+		}
+	}
+	return styleFiles
+}
+
+// styleImportClosure 返回样式文件本身及其通过 @import 递归引入的所有样式文件。
+func styleImportClosure(cssData map[string]projectParser.CssFileInfo, styleFile string) []string {
+	var closure []string
+	visited := map[string]bool{}
+	var collect func(path string)
+	collect = func(path string) {
+		if visited[path] {
+			return
+		}
+		visited[path] = true
+		closure = append(closure, path)
+		for _, decl := range cssData[path].ImportDeclarations {
+			if decl.Source.Type == "style" {
+				collect(decl.Source.FilePath)
+			}
+		}
+	}
+	collect(styleFile)
+	return closure
+}
+
+// declaredClassNames 返回 @import 闭包中的样式文件声明的所有类名。
+func declaredClassNames(cssData map[string]projectParser.CssFileInfo, closure []string) map[string]bool {
+	classNames := map[string]bool{}
+	for _, path := range closure {
+		for _, class := range cssData[path].ClassNames {
+			classNames[class.Name] = true
+		}
+	}
+	return classNames
+}
+
+// lookupClassName 判断引用的类名是否已声明。引用也可以是短横线类名的驼峰形式，
+// 例如 `styles.fooBar` 对应 `.foo-bar`（css-loader 的 localsConvention: camelCase）。
+func lookupClassName(classNames map[string]bool, className string) bool {
+	if classNames[className] {
+		return true
+	}
+	for name := range classNames {
+		if camelCase(name) == className {
+			return true
+		}
+	}
+	return false
+}
+
+// isClassNameUsed 判断类名（或其驼峰形式）是否被引用。
+func isClassNameUsed(used map[string]bool, className string) bool {
+	return used[className] || used[camelCase(className)]
+}
+
+// camelCase 将短横线类名转换为驼峰形式 (e.g., "foo-bar" -> "fooBar")。
+func camelCase(name string) string {
+	parts := strings.Split(name, "-")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
 // init 在包加载时自动注册分析器
 func init() {
 	projectanalyzer.RegisterAnalyzer("css-file", func() projectanalyzer.Analyzer {
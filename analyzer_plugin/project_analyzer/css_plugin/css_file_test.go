@@ -0,0 +1,134 @@
+package css_plugin
+
+import (
+	"testing"
+
+	"github.com/Flying-Bird1999/analyzer-ts/analyzer/parser"
+	"github.com/Flying-Bird1999/analyzer-ts/analyzer/projectParser"
+	projectanalyzer "github.com/Flying-Bird1999/analyzer-ts/analyzer_plugin/project_analyzer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// styleImport 构造一条指向样式文件的导入声明
+func styleImport(filePath string, modules ...projectParser.ImportModule) projectParser.ImportDeclarationResult {
+	return projectParser.ImportDeclarationResult{
+		ImportModules: modules,
+		Source:        projectParser.SourceData{FilePath: filePath, Type: "style"},
+	}
+}
+
+func TestCssFileCrossReference(t *testing.T) {
+	cssData := map[string]projectParser.CssFileInfo{}
+	for path, code := range map[string]string{
+		"/src/View.module.less":   "@import './shared.less';\n.wrapper { &-inner {} }\n.title-text {}\n.unused {}\n",
+		"/src/shared.less":        ".shared {}\n",
+		"/src/Dynamic.module.css": ".a {}\n.b {}\n",
+	} {
+		stylesheet := parser.ParseStylesheet(path, code)
+		info := projectParser.CssFileInfo{ClassNames: stylesheet.ClassNames, CustomProperties: stylesheet.CustomProperties}
+		if path == "/src/View.module.less" {
+			info.ImportDeclarations = []projectParser.ImportDeclarationResult{styleImport("/src/shared.less")}
+		}
+		cssData[path] = info
+	}
+
+	p, err := parser.NewParserFromSource("/src/View.tsx", `import styles from './View.module.less';
+import dyn from './Dynamic.module.css';
+export const classNames = (name: string) => [styles.wrapper, styles['wrapper-inner'], styles.titleText, styles.shared, styles.missing, dyn[name]];`)
+	require.NoError(t, err)
+	p.Traverse()
+
+	parsingResult := &projectParser.ProjectParserResult{
+		Css_Data: cssData,
+		Js_Data: map[string]projectParser.JsFileParserResult{
+			"/src/View.tsx": {
+				ImportDeclarations: []projectParser.ImportDeclarationResult{
+					styleImport("/src/View.module.less", projectParser.ImportModule{ImportModule: "default", Type: "default", Identifier: "styles"}),
+					styleImport("/src/Dynamic.module.css", projectParser.ImportModule{ImportModule: "default", Type: "default", Identifier: "dyn"}),
+				},
+				CssModuleReferences: p.Result.CssModuleReferences,
+			},
+		},
+	}
+
+	analyzer := &CssFile{}
+	require.NoError(t, analyzer.Configure(map[string]string{}))
+	result, err := analyzer.Analyze(&projectanalyzer.ProjectContext{ParsingResult: parsingResult})
+	require.NoError(t, err)
+	cssResult, ok := result.(*CssFileResult)
+	require.True(t, ok)
+
+	// 存在动态引用的 Dynamic.module.css 不报告未使用的类名；shared.less 不是 CSS Modules 文件
+	assert.Equal(t, []UnusedClass{{FilePath: "/src/View.module.less", ClassName: "unused", Line: 4}}, cssResult.UnusedClasses)
+
+	// 驼峰形式与 @import 引入的类名都视为已声明
+	require.Len(t, cssResult.UndefinedReferences, 1)
+	assert.Equal(t, "missing", cssResult.UndefinedReferences[0].ClassName)
+	assert.Equal(t, "/src/View.module.less", cssResult.UndefinedReferences[0].StyleFile)
+	assert.Equal(t, "styles.missing", cssResult.UndefinedReferences[0].Raw)
+	assert.Equal(t, 3, cssResult.UndefinedReferences[0].Line)
+
+	assert.Equal(t, "3 个样式文件，1 个未使用的 CSS Modules 类名，1 个未定义的类名引用", cssResult.Summary())
+}
+
+func TestCssFileImportedModuleUsage(t *testing.T) {
+	cssData := map[string]projectParser.CssFileInfo{}
+	for path, code := range map[string]string{
+		"/src/View.module.less":  "@import './base.module.less';\n.wrapper {}\n",
+		"/src/base.module.less":  "@import './reset.module.less';\n.base {}\n.orphan {}\n",
+		"/src/reset.module.less": ".reset {}\n.cycle {}\n",
+		"/src/List.module.less":  "@import './base.module.less';\n.list {}\n",
+	} {
+		stylesheet := parser.ParseStylesheet(path, code)
+		cssData[path] = projectParser.CssFileInfo{ClassNames: stylesheet.ClassNames}
+	}
+	// reset.module.less 反过来引入 base.module.less，形成循环
+	for importer, imported := range map[string]string{
+		"/src/View.module.less":  "/src/base.module.less",
+		"/src/base.module.less":  "/src/reset.module.less",
+		"/src/reset.module.less": "/src/base.module.less",
+		"/src/List.module.less":  "/src/base.module.less",
+	} {
+		info := cssData[importer]
+		info.ImportDeclarations = []projectParser.ImportDeclarationResult{styleImport(imported)}
+		cssData[importer] = info
+	}
+
+	p, err := parser.NewParserFromSource("/src/View.tsx", `import styles from './View.module.less';
+import list from './List.module.less';
+export const classNames = [styles.wrapper, styles.base, styles.reset, styles.cycle, list.list, list[name]];`)
+	require.NoError(t, err)
+	p.Traverse()
+
+	parsingResult := &projectParser.ProjectParserResult{
+		Css_Data: cssData,
+		Js_Data: map[string]projectParser.JsFileParserResult{
+			"/src/View.tsx": {
+				ImportDeclarations: []projectParser.ImportDeclarationResult{
+					styleImport("/src/View.module.less", projectParser.ImportModule{ImportModule: "default", Type: "default", Identifier: "styles"}),
+					styleImport("/src/List.module.less", projectParser.ImportModule{ImportModule: "default", Type: "default", Identifier: "list"}),
+				},
+				CssModuleReferences: p.Result.CssModuleReferences,
+			},
+		},
+	}
+
+	// 没有动态引用时，通过 View.module.less 引用的 .base、.reset 与 .cycle 视为被引入的文件中的类名被使用，只有 .orphan 未使用
+	withoutDynamic := *parsingResult
+	withoutDynamic.Js_Data = map[string]projectParser.JsFileParserResult{"/src/View.tsx": parsingResult.Js_Data["/src/View.tsx"]}
+	jsResult := withoutDynamic.Js_Data["/src/View.tsx"]
+	jsResult.CssModuleReferences = jsResult.CssModuleReferences[:len(jsResult.CssModuleReferences)-1]
+	withoutDynamic.Js_Data["/src/View.tsx"] = jsResult
+
+	result := &CssFileResult{}
+	crossReferenceCssModules(&withoutDynamic, result)
+	assert.Equal(t, []UnusedClass{{FilePath: "/src/base.module.less", ClassName: "orphan", Line: 3}}, result.UnusedClasses)
+	assert.Empty(t, result.UndefinedReferences)
+
+	// List.module.less 的动态引用可能用到它引入的任意类名，闭包中的文件都不报告未使用的类名
+	result = &CssFileResult{}
+	crossReferenceCssModules(parsingResult, result)
+	assert.Empty(t, result.UnusedClasses)
+	assert.Empty(t, result.UndefinedReferences)
+}
@@ -0,0 +1,273 @@
+// Package decorators 实现了装饰器使用情况的统计分析器。
+//
+// 功能说明：
+// 在 Angular、NestJS、MobX 等框架中，路由、依赖注入与状态管理大多通过装饰器声明，
+// 例如 `@Controller('/users')`、`@Get(':id')`、`@Injectable()`、`@observable`。
+// 该分析器基于解析阶段提取的装饰器信息，统计项目中每个装饰器的使用位置，
+// 并将控制器装饰器与方法装饰器组合为路由定义列表。
+//
+// 支持的装饰器位置：
+// - 类：`@Injectable() class Foo {}`
+// - 方法、访问器与属性：`@Get() find() {}`、`@observable count = 0`
+// - 参数：`constructor(@Inject(TOKEN) svc: Service) {}`
+//
+// 路由识别规则（NestJS 风格）：
+// - 类上的 `@Controller(prefix)` 提供路由前缀
+// - 方法上的 `@Get/@Post/@Put/@Delete/@Patch/@Options/@Head/@All(path)` 提供 HTTP 方法与子路径
+// - 只识别字符串字面量形式的路径参数，其他形式按空路径处理
+package decorators
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/Flying-Bird1999/analyzer-ts/analyzer/parser"
+	"github.com/Flying-Bird1999/analyzer-ts/analyzer/projectParser"
+	projectanalyzer "github.com/Flying-Bird1999/analyzer-ts/analyzer_plugin/project_analyzer"
+
+	"github.com/Zzzen/typescript-go/use-at-your-own-risk/scanner"
+)
+
+// controllerDecorator 是提供路由前缀的类装饰器名称
+const controllerDecorator = "Controller"
+
+// routeMethods 是方法装饰器名称到 HTTP 方法的映射
+var routeMethods = map[string]string{
+	"Get":     "GET",
+	"Post":    "POST",
+	"Put":     "PUT",
+	"Delete":  "DELETE",
+	"Patch":   "PATCH",
+	"Options": "OPTIONS",
+	"Head":    "HEAD",
+	"All":     "ALL",
+}
+
+// Analyzer 是装饰器分析器的实现。
+type Analyzer struct {
+	// Names 是需要统计的装饰器名称集合，为空时统计所有装饰器。
+	Names map[string]struct{}
+}
+
+// 确保 Analyzer 实现了 projectanalyzer.Analyzer 接口
+var _ projectanalyzer.Analyzer = (*Analyzer)(nil)
+
+// Name 返回分析器的唯一标识符。
+func (a *Analyzer) Name() string {
+	return "decorators"
+}
+
+// Configure 配置分析器的参数。
+//
+// 可选参数：
+// - names：只统计指定名称的装饰器，多个名称以逗号分隔。路由定义不受该参数影响。
+//
+// 使用示例：
+// ```bash
+// ./analyzer-ts analyze decorators -i /path/to/project -p "decorators.names=Injectable,Inject"
+// ```
+func (a *Analyzer) Configure(params map[string]string) error {
+	namesStr, ok := params["names"]
+	if !ok {
+		return nil
+	}
+
+	a.Names = make(map[string]struct{})
+	for _, name := range strings.Split(namesStr, ",") {
+		trimmed := strings.TrimSpace(name)
+		if trimmed != "" {
+			a.Names[trimmed] = struct{}{}
+		}
+	}
+	if len(a.Names) == 0 {
+		return errors.New("decorators 分析器错误: 提供的 'names' 参数解析后为空")
+	}
+	return nil
+}
+
+func (a *Analyzer) RequiredParseOptions() projectParser.ParseOptions {
+	return projectParser.LowMemoryParseOptions()
+}
+
+// ParamSchema 描述该分析器接受的参数。
+func (a *Analyzer) ParamSchema() projectanalyzer.ParamSchema {
+	return projectanalyzer.ObjectParams(map[string]*projectanalyzer.ParamSchema{
+		"names": projectanalyzer.StringListParam("只统计这些名称的装饰器，未提供时统计所有装饰器"),
+	})
+}
+
+// Analyze 遍历项目中所有的类声明，统计装饰器使用情况并提取路由定义。
+func (a *Analyzer) Analyze(ctx *projectanalyzer.ProjectContext) (projectanalyzer.Result, error) {
+	parseResult := ctx.ParsingResult
+
+	// 按文件路径排序，保证输出稳定
+	filePaths := make([]string, 0, len(parseResult.Js_Data))
+	for filePath := range parseResult.Js_Data {
+		filePaths = append(filePaths, filePath)
+	}
+	sort.Strings(filePaths)
+
+	usagesByName := make(map[string][]DecoratorUsage)
+	routes := []RouteDefinition{}
+	for _, filePath := range filePaths {
+		for _, class := range parseResult.Js_Data[filePath].ClassDeclarations {
+			for _, usage := range collectClassUsages(filePath, class) {
+				if a.accepts(usage.Decorator) {
+					usagesByName[usage.Decorator] = append(usagesByName[usage.Decorator], usage)
+				}
+			}
+			routes = append(routes, collectRoutes(filePath, class)...)
+		}
+	}
+
+	result := &Result{
+		FilesParsed: len(parseResult.Js_Data),
+		Decorators:  []DecoratorSummary{},
+		Routes:      routes,
+	}
+	for name, usages := range usagesByName {
+		result.Decorators = append(result.Decorators, DecoratorSummary{
+			Name:   name,
+			Count:  len(usages),
+			Usages: usages,
+		})
+		result.TotalUsages += len(usages)
+	}
+	// 使用次数多的装饰器排在前面，次数相同时按名称排序
+	sort.Slice(result.Decorators, func(i, j int) bool {
+		if result.Decorators[i].Count != result.Decorators[j].Count {
+			return result.Decorators[i].Count > result.Decorators[j].Count
+		}
+		return result.Decorators[i].Name < result.Decorators[j].Name
+	})
+
+	return result, nil
+}
+
+// accepts 判断装饰器是否在统计范围内
+func (a *Analyzer) accepts(name string) bool {
+	if len(a.Names) == 0 {
+		return true
+	}
+	_, ok := a.Names[name]
+	return ok
+}
+
+// collectClassUsages 收集类本身、成员与参数上的所有装饰器使用记录。
+// 构造函数的参数属性同时作为构造函数参数出现，只按参数统计一次。
+func collectClassUsages(filePath string, class parser.ClassDeclarationResult) []DecoratorUsage {
+	var usages []DecoratorUsage
+	add := func(decorators []parser.DecoratorResult, targetKind, targetName string) {
+		for _, decorator := range decorators {
+			usages = append(usages, newUsage(filePath, class.Identifier, targetKind, targetName, decorator))
+		}
+	}
+
+	add(class.Decorators, TargetKindClass, class.Identifier)
+	for _, member := range class.Members {
+		if member.IsParameterProperty {
+			continue
+		}
+		add(member.Decorators, member.Kind, member.Name)
+		for _, param := range member.Parameters {
+			add(param.Decorators, TargetKindParameter, member.Name+"."+param.Name)
+		}
+	}
+	return usages
+}
+
+// newUsage 根据装饰器解析结果构建一条使用记录
+func newUsage(filePath, className, targetKind, targetName string, decorator parser.DecoratorResult) DecoratorUsage {
+	return DecoratorUsage{
+		Decorator:  decorator.Name,
+		FilePath:   filePath,
+		ClassName:  className,
+		TargetKind: targetKind,
+		TargetName: targetName,
+		Line:       decoratorLine(decorator),
+		Raw:        strings.TrimSpace(decorator.Raw),
+	}
+}
+
+// decoratorLine 返回装饰器所在的行号。
+// SourceLocation 的起始位置包含前导空白与注释，这里根据 Raw 中 "@" 之后的换行数，从结束行反推到 "@" 所在行；
+// 组件文件与文档中起始位置已经指向 "@"，同样适用。
+func decoratorLine(decorator parser.DecoratorResult) int {
+	if decorator.SourceLocation == nil {
+		return 0
+	}
+	text := decorator.Raw[scanner.SkipTrivia(decorator.Raw, 0):]
+	return decorator.SourceLocation.End.Line - strings.Count(text, "\n")
+}
+
+// collectRoutes 将控制器装饰器与方法装饰器组合为路由定义。
+// 没有 @Controller 装饰器的类不产生路由。
+func collectRoutes(filePath string, class parser.ClassDeclarationResult) []RouteDefinition {
+	prefix, ok := controllerPrefix(class.Decorators)
+	if !ok {
+		return nil
+	}
+
+	var routes []RouteDefinition
+	for _, member := range class.Members {
+		if member.Kind != parser.ClassMemberKindMethod {
+			continue
+		}
+		for _, decorator := range member.Decorators {
+			method, ok := routeMethods[decorator.Name]
+			if !ok {
+				continue
+			}
+			route := RouteDefinition{
+				Method:     method,
+				Path:       joinRoutePath(prefix, decoratorPath(decorator)),
+				Controller: class.Identifier,
+				Handler:    member.Name,
+				FilePath:   filePath,
+				Line:       decoratorLine(decorator),
+			}
+			routes = append(routes, route)
+		}
+	}
+	return routes
+}
+
+// controllerPrefix 返回类上 @Controller 装饰器声明的路由前缀，第二个返回值表示是否为控制器
+func controllerPrefix(decorators []parser.DecoratorResult) (string, bool) {
+	for _, decorator := range decorators {
+		if decorator.Name == controllerDecorator {
+			return decoratorPath(decorator), true
+		}
+	}
+	return "", false
+}
+
+// decoratorPath 返回装饰器第一个参数中的字符串字面量路径，没有时返回空字符串
+func decoratorPath(decorator parser.DecoratorResult) string {
+	if len(decorator.Arguments) == 0 || decorator.Arguments[0] == nil {
+		return ""
+	}
+	if path, ok := decorator.Arguments[0].Data.(string); ok && decorator.Arguments[0].Type == "stringLiteral" {
+		return path
+	}
+	return ""
+}
+
+// joinRoutePath 拼接路由前缀与子路径，结果总是以 "/" 开头且不包含重复的 "/"
+func joinRoutePath(segments ...string) string {
+	parts := []string{}
+	for _, segment := range segments {
+		if trimmed := strings.Trim(segment, "/"); trimmed != "" {
+			parts = append(parts, trimmed)
+		}
+	}
+	return "/" + strings.Join(parts, "/")
+}
+
+// init 在包加载时自动注册分析器
+func init() {
+	projectanalyzer.RegisterAnalyzer("decorators", func() projectanalyzer.Analyzer {
+		return &Analyzer{}
+	})
+}
@@ -0,0 +1,139 @@
+package decorators
+
+import (
+	"testing"
+
+	"github.com/Flying-Bird1999/analyzer-ts/analyzer/parser"
+	"github.com/Flying-Bird1999/analyzer-ts/analyzer/projectParser"
+	projectanalyzer "github.com/Flying-Bird1999/analyzer-ts/analyzer_plugin/project_analyzer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// parseFiles 解析内存中的文件，构造分析器所需的项目解析结果
+func parseFiles(t *testing.T, files map[string]string) *projectParser.ProjectParserResult {
+	t.Helper()
+	jsData := make(map[string]projectParser.JsFileParserResult)
+	for path, code := range files {
+		p, err := parser.NewParserFromSource(path, code)
+		require.NoError(t, err)
+		p.Traverse()
+		jsData[path] = projectParser.JsFileParserResult{ClassDeclarations: p.Result.ClassDeclarations}
+	}
+	return &projectParser.ProjectParserResult{Js_Data: jsData}
+}
+
+func TestDecoratorsAnalyzer(t *testing.T) {
+	parsingResult := parseFiles(t, map[string]string{
+		"/src/users.controller.ts": `@Controller('/users/')
+export class UsersController {
+	constructor(@Inject(TOKEN) private readonly service: UsersService) {}
+	@Get()
+	list() {}
+	@Get(':id')
+	find(@Param('id') id: string) {}
+	@Post()
+	create() {}
+	helper() {}
+}`,
+		"/src/users.service.ts": `@Injectable()
+export class UsersService {}`,
+		"/src/store.ts": `export class Store {
+	@observable count = 0;
+	@Get('ignored') notRoute() {}
+}`,
+	})
+
+	analyzer := &Analyzer{}
+	require.NoError(t, analyzer.Configure(map[string]string{}))
+	result, err := analyzer.Analyze(&projectanalyzer.ProjectContext{ParsingResult: parsingResult})
+	require.NoError(t, err)
+	decoratorsResult, ok := result.(*Result)
+	require.True(t, ok)
+
+	assert.Equal(t, 3, decoratorsResult.FilesParsed)
+	assert.Equal(t, 9, decoratorsResult.TotalUsages)
+
+	counts := map[string]int{}
+	for _, summary := range decoratorsResult.Decorators {
+		counts[summary.Name] = summary.Count
+	}
+	assert.Equal(t, map[string]int{"Get": 3, "Controller": 1, "Inject": 1, "Param": 1, "Post": 1, "Injectable": 1, "observable": 1}, counts)
+	assert.Equal(t, "Get", decoratorsResult.Decorators[0].Name)
+
+	// 参数装饰器只按构造函数参数统计一次
+	var inject DecoratorUsage
+	for _, summary := range decoratorsResult.Decorators {
+		if summary.Name == "Inject" {
+			inject = summary.Usages[0]
+		}
+	}
+	assert.Equal(t, TargetKindParameter, inject.TargetKind)
+	assert.Equal(t, "constructor.service", inject.TargetName)
+	assert.Equal(t, "UsersController", inject.ClassName)
+	assert.Equal(t, "@Inject(TOKEN)", inject.Raw)
+	assert.Equal(t, 3, inject.Line)
+
+	// 只有 @Controller 类中的方法装饰器才产生路由
+	assert.Equal(t, []RouteDefinition{
+		{Method: "GET", Path: "/users", Controller: "UsersController", Handler: "list", FilePath: "/src/users.controller.ts", Line: 4},
+		{Method: "GET", Path: "/users/:id", Controller: "UsersController", Handler: "find", FilePath: "/src/users.controller.ts", Line: 6},
+		{Method: "POST", Path: "/users", Controller: "UsersController", Handler: "create", FilePath: "/src/users.controller.ts", Line: 8},
+	}, decoratorsResult.Routes)
+}
+
+func TestDecoratorsAnalyzerNamesFilter(t *testing.T) {
+	parsingResult := parseFiles(t, map[string]string{
+		"/src/a.ts": `@Injectable()
+export class A {
+	@Input() value: string;
+}`,
+	})
+
+	analyzer := &Analyzer{}
+	require.NoError(t, analyzer.Configure(map[string]string{"names": "Input, "}))
+	result, err := analyzer.Analyze(&projectanalyzer.ProjectContext{ParsingResult: parsingResult})
+	require.NoError(t, err)
+
+	decoratorsResult := result.(*Result)
+	require.Len(t, decoratorsResult.Decorators, 1)
+	assert.Equal(t, "Input", decoratorsResult.Decorators[0].Name)
+	assert.Equal(t, "property", decoratorsResult.Decorators[0].Usages[0].TargetKind)
+
+	assert.Error(t, (&Analyzer{}).Configure(map[string]string{"names": " , "}))
+}
+
+// TestDecoratorUsageLine 测试装饰器的行号指向 "@" 所在行：普通文件中装饰器前的注释与空行，
+// 以及组件文件中被屏蔽的模板区域都不影响行号。
+func TestDecoratorUsageLine(t *testing.T) {
+	parsingResult := parseFiles(t, map[string]string{
+		"/src/store.ts": `export class Store {
+	count = 0;
+
+	// 注释中的 @mention 不是装饰器
+	@observable value = 1;
+}`,
+		"/src/Counter.vue": `<template>
+  <div>{{ count }}</div>
+</template>
+
+<script lang="ts">
+@Component
+export default class Counter extends Vue {}
+</script>
+`,
+	})
+
+	analyzer := &Analyzer{}
+	require.NoError(t, analyzer.Configure(map[string]string{}))
+	result, err := analyzer.Analyze(&projectanalyzer.ProjectContext{ParsingResult: parsingResult})
+	require.NoError(t, err)
+
+	lines := map[string]int{}
+	for _, summary := range result.(*Result).Decorators {
+		for _, usage := range summary.Usages {
+			lines[usage.Decorator] = usage.Line
+		}
+	}
+	assert.Equal(t, map[string]int{"observable": 5, "Component": 6}, lines)
+}
@@ -0,0 +1,130 @@
+package decorators
+
+import (
+	"fmt"
+	"strings"
+
+	projectanalyzer "github.com/Flying-Bird1999/analyzer-ts/analyzer_plugin/project_analyzer"
+)
+
+// 装饰器目标的种类。方法、属性、访问器等成员直接使用 parser.ClassMemberKind* 常量。
+const (
+	TargetKindClass     = "class"     // 类装饰器
+	TargetKindParameter = "parameter" // 参数装饰器
+)
+
+// Result 是装饰器分析的最终结果，实现了 projectanalyzer.Result 接口。
+type Result struct {
+	FilesParsed int                `json:"filesParsed"` // 成功解析的 JS/TS 文件数量
+	TotalUsages int                `json:"totalUsages"` // 统计范围内装饰器的使用总次数
+	Decorators  []DecoratorSummary `json:"decorators"`  // 按装饰器名称聚合的使用情况，按使用次数降序排列
+	Routes      []RouteDefinition  `json:"routes"`      // 由控制器与方法装饰器组合出的路由定义
+}
+
+// DecoratorSummary 是单个装饰器名称的使用统计。
+type DecoratorSummary struct {
+	Name   string           `json:"name"`   // 装饰器名称 (e.g., "Injectable")
+	Count  int              `json:"count"`  // 使用次数
+	Usages []DecoratorUsage `json:"usages"` // 每一处使用的详细信息
+}
+
+// DecoratorUsage 记录一处装饰器的使用位置。
+type DecoratorUsage struct {
+	Decorator  string `json:"decorator"`  // 装饰器名称
+	FilePath   string `json:"filePath"`   // 所在文件的绝对路径
+	ClassName  string `json:"className"`  // 所在类的名称，匿名类为空
+	TargetKind string `json:"targetKind"` // 被装饰的目标种类：class / method / property / getter / setter / parameter
+	TargetName string `json:"targetName"` // 被装饰的目标名称，参数为 "方法名.参数名" (e.g., "constructor.api")
+	Line       int    `json:"line"`       // 装饰器所在行号
+	Raw        string `json:"raw"`        // 装饰器的源码文本
+}
+
+// RouteDefinition 是一条由装饰器声明的路由。
+type RouteDefinition struct {
+	Method     string `json:"method"`     // HTTP 方法，例如 "GET"
+	Path       string `json:"path"`       // 控制器前缀与方法路径拼接后的完整路径 (e.g., "/users/:id")
+	Controller string `json:"controller"` // 控制器类名
+	Handler    string `json:"handler"`    // 处理该路由的方法名
+	FilePath   string `json:"filePath"`   // 所在文件的绝对路径
+	Line       int    `json:"line"`       // 方法装饰器所在行号
+}
+
+// 确保 Result 实现了 projectanalyzer.Result 接口
+var _ projectanalyzer.Result = (*Result)(nil)
+
+// Name 返回该结果对应的分析器的名称。
+func (r *Result) Name() string {
+	return "Decorator Usage"
+}
+
+// Summary 返回对结果的简短、人类可读的摘要。
+func (r *Result) Summary() string {
+	return fmt.Sprintf(
+		"扫描文件 %d 个，共发现 %d 种装饰器、%d 处使用，%d 条路由定义。",
+		r.FilesParsed,
+		len(r.Decorators),
+		r.TotalUsages,
+		len(r.Routes),
+	)
+}
+
+// ToJSON 将结果的完整数据序列化为 JSON 格式。
+func (r *Result) ToJSON(indent bool) ([]byte, error) {
+	return projectanalyzer.ToJSONBytes(r, indent)
+}
+
+// ToConsole 将结果格式化为适合在控制台（终端）中打印的字符串。
+func (r *Result) ToConsole() string {
+	if r.TotalUsages == 0 && len(r.Routes) == 0 {
+		return "✅ " + r.Summary()
+	}
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("📌 %s\n", r.Summary()))
+	builder.WriteString("--------------------------------------------------\n")
+	for _, summary := range r.Decorators {
+		builder.WriteString(fmt.Sprintf("  - @%s (%d 处):\n", summary.Name, summary.Count))
+		for _, usage := range summary.Usages {
+			builder.WriteString(fmt.Sprintf("    - [%s] %s:%d \t %s\n", usage.TargetKind, usage.FilePath, usage.Line, usage.TargetName))
+		}
+	}
+	if len(r.Routes) > 0 {
+		builder.WriteString("--------------------------------------------------\n")
+		builder.WriteString("路由定义:\n")
+		for _, route := range r.Routes {
+			builder.WriteString(fmt.Sprintf("  - %-7s %s \t %s.%s (%s:%d)\n", route.Method, route.Path, route.Controller, route.Handler, route.FilePath, route.Line))
+		}
+	}
+	builder.WriteString("--------------------------------------------------\n")
+
+	return builder.String()
+}
+
+// AnalyzerName 返回对应的分析器名称
+func (r *Result) AnalyzerName() string {
+	return "decorators"
+}
+
+// 确保 Result 实现了 projectanalyzer.FindingsProvider 接口
+var _ projectanalyzer.FindingsProvider = (*Result)(nil)
+
+// NormalizedFindings 将每一处装饰器的使用转换为一条 info 级别的发现项。
+func (r *Result) NormalizedFindings() []projectanalyzer.Finding {
+	findings := make([]projectanalyzer.Finding, 0, r.TotalUsages)
+	for _, summary := range r.Decorators {
+		for _, usage := range summary.Usages {
+			target := usage.TargetName
+			if usage.ClassName != "" && usage.TargetKind != TargetKindClass {
+				target = usage.ClassName + "." + target
+			}
+			findings = append(findings, projectanalyzer.Finding{
+				RuleID:   "decorators/decorator-usage",
+				Severity: projectanalyzer.SeverityInfo,
+				Message:  fmt.Sprintf("@%s 装饰了 %s %s", usage.Decorator, usage.TargetKind, target),
+				File:     usage.FilePath,
+				Line:     usage.Line,
+			})
+		}
+	}
+	return findings
+}
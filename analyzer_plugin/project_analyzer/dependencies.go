@@ -0,0 +1,204 @@
+package project_analyzer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// =============================================================================
+// 分析器之间的依赖
+// =============================================================================
+
+// resultStore 保存一次运行中已完成的分析器的结果，key 是 Analyzer.Name()。
+type resultStore struct {
+	mu      sync.RWMutex
+	results map[string]Result
+}
+
+// resultStore 返回 ctx 的结果容器，不存在时创建。
+func (ctx *ProjectContext) resultStore() *resultStore {
+	contextStateMu.Lock()
+	defer contextStateMu.Unlock()
+	if ctx.results == nil {
+		ctx.results = &resultStore{results: make(map[string]Result)}
+	}
+	return ctx.results
+}
+
+// SetResult 记录分析器的结果，供依赖它的分析器通过 DependencyResult 读取。
+// 运行器会自动调用，自行调度分析器时（例如 CLI）需要在每个分析器完成后调用。
+func (ctx *ProjectContext) SetResult(name string, result Result) {
+	store := ctx.resultStore()
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	store.results[name] = result
+}
+
+// AnalyzerResult 返回已完成的分析器的结果，name 可以是注册名或 Name() 的返回值。
+func (ctx *ProjectContext) AnalyzerResult(name string) (Result, bool) {
+	store := ctx.resultStore()
+	store.mu.RLock()
+	defer store.mu.RUnlock()
+	result, ok := store.results[canonicalAnalyzerName(name)]
+	return result, ok
+}
+
+// forRun 返回用于一次运行的上下文副本：与 ctx 共享索引，但拥有独立的结果容器，
+// 避免不同运行之间（配置可能不同）互相读取结果。
+func (ctx *ProjectContext) forRun() *ProjectContext {
+	ctx.indexStore()
+	runCtx := *ctx
+	runCtx.results = &resultStore{results: make(map[string]Result)}
+	return &runCtx
+}
+
+// DependencyResult 泛型函数获取所依赖的分析器的强类型结果。
+// 被依赖的分析器需要在 DependsOn 中声明，运行器保证它先于当前分析器完成。
+//
+// 使用示例:
+//
+//	deps, err := project_analyzer.DependencyResult[*pkg_deps.PkgDepsResult](ctx, "pkg-deps")
+func DependencyResult[T Result](ctx *ProjectContext, name string) (T, error) {
+	var zero T
+
+	result, ok := ctx.AnalyzerResult(name)
+	if !ok {
+		return zero, fmt.Errorf("result of analyzer '%s' is not available, is it declared in DependsOn?", name)
+	}
+	typed, ok := result.(T)
+	if !ok {
+		return zero, fmt.Errorf("analyzer '%s' returned unexpected type %T, expected %T", name, result, zero)
+	}
+	return typed, nil
+}
+
+// ResolveDependencies 补全分析器的依赖：被依赖但不在列表中的分析器从注册表中创建并追加到列表末尾。
+// 依赖的分析器未注册时返回错误。返回的列表中每个分析器只出现一次。
+func ResolveDependencies(analyzers []Analyzer) ([]Analyzer, error) {
+	resolved := make([]Analyzer, 0, len(analyzers))
+	present := make(map[string]bool)
+	for _, analyzer := range analyzers {
+		if !present[analyzer.Name()] {
+			present[analyzer.Name()] = true
+			resolved = append(resolved, analyzer)
+		}
+	}
+
+	// resolved 在遍历过程中增长，新加入的分析器的依赖同样会被补全
+	for i := 0; i < len(resolved); i++ {
+		for _, dependency := range dependsOn(resolved[i]) {
+			if present[dependency] {
+				continue
+			}
+			factory, ok := lookupAnalyzerFactory(dependency)
+			if !ok {
+				return nil, fmt.Errorf("analyzer '%s' depends on '%s', which is not registered", resolved[i].Name(), dependency)
+			}
+			present[dependency] = true
+			resolved = append(resolved, factory())
+		}
+	}
+	return resolved, nil
+}
+
+// OrderAnalyzers 按依赖关系对分析器做拓扑排序，返回分层的执行顺序：
+// 每一层的分析器只依赖前面各层的分析器，同一层内的分析器可以并发执行，层内按名称排序。
+// 依赖的分析器不在列表中（应先调用 ResolveDependencies）或依赖关系存在环时返回错误。
+func OrderAnalyzers(analyzers []Analyzer) ([][]Analyzer, error) {
+	byName := make(map[string]Analyzer, len(analyzers))
+	for _, analyzer := range analyzers {
+		byName[analyzer.Name()] = analyzer
+	}
+
+	// pending 记录每个分析器尚未完成的依赖数，dependents 记录依赖关系的反向边
+	pending := make(map[string]int, len(byName))
+	dependents := make(map[string][]string)
+	for name, analyzer := range byName {
+		pending[name] = 0
+		for _, dependency := range dependsOn(analyzer) {
+			if _, ok := byName[dependency]; !ok {
+				return nil, fmt.Errorf("analyzer '%s' depends on '%s', which is not scheduled", name, dependency)
+			}
+			pending[name]++
+			dependents[dependency] = append(dependents[dependency], name)
+		}
+	}
+
+	var levels [][]Analyzer
+	var ready []string
+	for name, count := range pending {
+		if count == 0 {
+			ready = append(ready, name)
+		}
+	}
+	for len(ready) > 0 {
+		sort.Strings(ready)
+		level := make([]Analyzer, 0, len(ready))
+		var next []string
+		for _, name := range ready {
+			level = append(level, byName[name])
+			delete(pending, name)
+			for _, dependent := range dependents[name] {
+				pending[dependent]--
+				if pending[dependent] == 0 {
+					next = append(next, dependent)
+				}
+			}
+		}
+		levels = append(levels, level)
+		ready = next
+	}
+
+	if len(pending) > 0 {
+		cycle := make([]string, 0, len(pending))
+		for name := range pending {
+			cycle = append(cycle, name)
+		}
+		sort.Strings(cycle)
+		return nil, fmt.Errorf("cyclic dependency between analyzers: %s", strings.Join(cycle, ", "))
+	}
+	return levels, nil
+}
+
+// dependsOn 返回分析器依赖的分析器的 Name()，未实现 DependentAnalyzer 时返回 nil。
+func dependsOn(analyzer Analyzer) []string {
+	dependent, ok := analyzer.(DependentAnalyzer)
+	if !ok {
+		return nil
+	}
+	names := make([]string, 0, len(dependent.DependsOn()))
+	for _, name := range dependent.DependsOn() {
+		names = append(names, canonicalAnalyzerName(name))
+	}
+	return names
+}
+
+// lookupAnalyzerFactory 按注册名或 Name() 的返回值查找分析器的工厂函数。
+// Name() 的返回值通过注册时记录的 names 映射回注册名，查找过程不会创建分析器。
+func lookupAnalyzerFactory(name string) (func() Analyzer, bool) {
+	analyzerRegistry.RLock()
+	defer analyzerRegistry.RUnlock()
+
+	if factory, ok := analyzerRegistry.factories[name]; ok {
+		return factory, true
+	}
+	for key, analyzerName := range analyzerRegistry.names {
+		if analyzerName == name {
+			return analyzerRegistry.factories[key], true
+		}
+	}
+	return nil, false
+}
+
+// canonicalAnalyzerName 将注册名转换为 Name() 的返回值，两者可能不同（例如 "unconsumed"）。
+// 未注册的名称原样返回。
+func canonicalAnalyzerName(name string) string {
+	analyzerRegistry.RLock()
+	defer analyzerRegistry.RUnlock()
+	if analyzerName, ok := analyzerRegistry.names[name]; ok {
+		return analyzerName
+	}
+	return name
+}
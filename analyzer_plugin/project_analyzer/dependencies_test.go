@@ -0,0 +1,320 @@
+package project_analyzer
+
+import (
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Flying-Bird1999/analyzer-ts/analyzer/projectParser"
+)
+
+// fakeAnalyzer 是测试用的分析器，deps 为空时不声明依赖。
+type fakeAnalyzer struct {
+	name    string
+	deps    []string
+	analyze func(ctx *ProjectContext) (Result, error)
+}
+
+func (a *fakeAnalyzer) Name() string                             { return a.name }
+func (a *fakeAnalyzer) Configure(params map[string]string) error { return nil }
+func (a *fakeAnalyzer) DependsOn() []string                      { return a.deps }
+func (a *fakeAnalyzer) Analyze(ctx *ProjectContext) (Result, error) {
+	if a.analyze != nil {
+		return a.analyze(ctx)
+	}
+	return &fakeResult{name: a.name}, nil
+}
+
+// fakeResult 是测试用的分析结果，value 用于在分析器之间传递数据。
+type fakeResult struct {
+	name  string
+	value int
+}
+
+func (r *fakeResult) Name() string                       { return r.name }
+func (r *fakeResult) Summary() string                    { return r.name }
+func (r *fakeResult) ToJSON(indent bool) ([]byte, error) { return []byte(`{}`), nil }
+func (r *fakeResult) ToConsole() string                  { return r.name }
+func (r *fakeResult) AnalyzerName() string               { return r.name }
+
+// levelNames 将分层的执行顺序转换为名称，便于比较。
+func levelNames(levels [][]Analyzer) [][]string {
+	names := make([][]string, 0, len(levels))
+	for _, level := range levels {
+		var levelNames []string
+		for _, analyzer := range level {
+			levelNames = append(levelNames, analyzer.Name())
+		}
+		names = append(names, levelNames)
+	}
+	return names
+}
+
+func TestOrderAnalyzers(t *testing.T) {
+	testCases := []struct {
+		name      string
+		analyzers []Analyzer
+		expected  [][]string
+		errSubstr string
+	}{
+		{
+			name:      "没有依赖时全部在同一层，层内按名称排序",
+			analyzers: []Analyzer{&fakeAnalyzer{name: "b"}, &fakeAnalyzer{name: "a"}},
+			expected:  [][]string{{"a", "b"}},
+		},
+		{
+			name: "链式依赖逐层执行",
+			analyzers: []Analyzer{
+				&fakeAnalyzer{name: "c", deps: []string{"b"}},
+				&fakeAnalyzer{name: "b", deps: []string{"a"}},
+				&fakeAnalyzer{name: "a"},
+			},
+			expected: [][]string{{"a"}, {"b"}, {"c"}},
+		},
+		{
+			name: "菱形依赖中互不依赖的分析器在同一层",
+			analyzers: []Analyzer{
+				&fakeAnalyzer{name: "d", deps: []string{"b", "c"}},
+				&fakeAnalyzer{name: "c", deps: []string{"a"}},
+				&fakeAnalyzer{name: "b", deps: []string{"a"}},
+				&fakeAnalyzer{name: "a"},
+			},
+			expected: [][]string{{"a"}, {"b", "c"}, {"d"}},
+		},
+		{
+			name: "依赖环",
+			analyzers: []Analyzer{
+				&fakeAnalyzer{name: "a", deps: []string{"b"}},
+				&fakeAnalyzer{name: "b", deps: []string{"a"}},
+				&fakeAnalyzer{name: "c"},
+			},
+			errSubstr: "cyclic dependency between analyzers: a, b",
+		},
+		{
+			name:      "依赖的分析器不在列表中",
+			analyzers: []Analyzer{&fakeAnalyzer{name: "a", deps: []string{"missing"}}},
+			errSubstr: "depends on 'missing', which is not scheduled",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			levels, err := OrderAnalyzers(tc.analyzers)
+			if tc.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.errSubstr) {
+					t.Fatalf("预期错误包含 %q, 得到 %v", tc.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("OrderAnalyzers() error: %v", err)
+			}
+			if got := levelNames(levels); !equalLevels(got, tc.expected) {
+				t.Errorf("执行顺序预期 %v, 得到 %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func equalLevels(a, b [][]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if strings.Join(a[i], ",") != strings.Join(b[i], ",") {
+			return false
+		}
+	}
+	return true
+}
+
+// registerFakeAnalyzer 以 key 注册一个 Name() 为 name 的测试分析器，返回工厂函数被调用次数的计数器。
+func registerFakeAnalyzer(key, name string, deps ...string) *atomic.Int64 {
+	var constructed atomic.Int64
+	RegisterAnalyzer(key, func() Analyzer {
+		constructed.Add(1)
+		return &fakeAnalyzer{
+			name: name,
+			deps: deps,
+			analyze: func(ctx *ProjectContext) (Result, error) {
+				return &fakeResult{name: name, value: 42}, nil
+			},
+		}
+	})
+	return &constructed
+}
+
+func TestResolveDependencies(t *testing.T) {
+	baseConstructed := registerFakeAnalyzer("test-resolve-base", "test-resolve-base-analyzer")
+	registerFakeAnalyzer("test-resolve-middle", "test-resolve-middle", "test-resolve-base")
+	constructedAtRegistration := baseConstructed.Load()
+
+	testCases := []struct {
+		name      string
+		analyzers []Analyzer
+		expected  []string
+		errSubstr string
+	}{
+		{
+			name:      "按 Name() 声明的依赖从注册表中补全",
+			analyzers: []Analyzer{&fakeAnalyzer{name: "consumer", deps: []string{"test-resolve-base-analyzer"}}},
+			expected:  []string{"consumer", "test-resolve-base-analyzer"},
+		},
+		{
+			name:      "依赖的依赖同样被补全",
+			analyzers: []Analyzer{&fakeAnalyzer{name: "consumer", deps: []string{"test-resolve-middle"}}},
+			expected:  []string{"consumer", "test-resolve-middle", "test-resolve-base-analyzer"},
+		},
+		{
+			name: "已在列表中的依赖与重复的分析器只出现一次",
+			analyzers: []Analyzer{
+				&fakeAnalyzer{name: "consumer", deps: []string{"test-resolve-base"}},
+				&fakeAnalyzer{name: "test-resolve-base-analyzer"},
+				&fakeAnalyzer{name: "consumer"},
+			},
+			expected: []string{"consumer", "test-resolve-base-analyzer"},
+		},
+		{
+			name:      "依赖未注册",
+			analyzers: []Analyzer{&fakeAnalyzer{name: "consumer", deps: []string{"test-resolve-missing"}}},
+			errSubstr: "'consumer' depends on 'test-resolve-missing', which is not registered",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resolved, err := ResolveDependencies(tc.analyzers)
+			if tc.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.errSubstr) {
+					t.Fatalf("预期错误包含 %q, 得到 %v", tc.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolveDependencies() error: %v", err)
+			}
+			var names []string
+			for _, analyzer := range resolved {
+				names = append(names, analyzer.Name())
+			}
+			if strings.Join(names, ",") != strings.Join(tc.expected, ",") {
+				t.Errorf("补全后的分析器预期 %v, 得到 %v", tc.expected, names)
+			}
+		})
+	}
+
+	// 第一、二个用例各创建了一次 base，查找依赖时不应为了匹配名称而创建分析器
+	if got := baseConstructed.Load() - constructedAtRegistration; got != 2 {
+		t.Errorf("base 分析器预期只在被补全时创建 2 次, 实际创建了 %d 次", got)
+	}
+}
+
+func TestDependencyResult(t *testing.T) {
+	ctx := (&ProjectContext{}).forRun()
+	ctx.SetResult("producer", &fakeResult{name: "producer", value: 7})
+
+	result, err := DependencyResult[*fakeResult](ctx, "producer")
+	if err != nil || result.value != 7 {
+		t.Errorf("DependencyResult() = %+v, %v", result, err)
+	}
+	if _, err := DependencyResult[*fakeResult](ctx, "absent"); err == nil || !strings.Contains(err.Error(), "is not available") {
+		t.Errorf("未完成的分析器应返回错误, 得到 %v", err)
+	}
+	if _, err := DependencyResult[*otherResult](ctx, "producer"); err == nil || !strings.Contains(err.Error(), "unexpected type") {
+		t.Errorf("类型不匹配应返回错误, 得到 %v", err)
+	}
+
+	// 每次运行使用独立的结果容器
+	if _, ok := ctx.forRun().AnalyzerResult("producer"); ok {
+		t.Errorf("新的运行不应读取到上一次运行的结果")
+	}
+}
+
+// otherResult 用于测试 DependencyResult 的类型检查。
+type otherResult struct{ fakeResult }
+
+// newTestProjectAnalyzer 创建一个不解析任何文件的 ProjectAnalyzer，用于测试运行器。
+func newTestProjectAnalyzer() *ProjectAnalyzer {
+	return &ProjectAnalyzer{
+		ParseOptions: projectParser.DefaultParseOptions(),
+		analyzers:    make(map[string]Analyzer),
+		context:      &ProjectContext{ParsingResult: &projectParser.ProjectParserResult{}},
+	}
+}
+
+func TestRunnerDependencies(t *testing.T) {
+	registerFakeAnalyzer("test-runner-base", "test-runner-base")
+
+	// consumer 依赖未被显式指定的 base，运行器应先以默认配置运行 base
+	consumer := &fakeAnalyzer{
+		name: "test-runner-consumer",
+		deps: []string{"test-runner-base"},
+		analyze: func(ctx *ProjectContext) (Result, error) {
+			base, err := DependencyResult[*fakeResult](ctx, "test-runner-base")
+			if err != nil {
+				return nil, err
+			}
+			return &fakeResult{name: "test-runner-consumer", value: base.value + 1}, nil
+		},
+	}
+	p := newTestProjectAnalyzer()
+	results, err := p.ExecuteWithConfig(&ExecutionConfig{Analyzers: []*AnalyzerWithConfig{{Analyzer: consumer}}})
+	if err != nil {
+		t.Fatalf("ExecuteWithConfig() error: %v", err)
+	}
+	if got := results["test-runner-consumer"].(*fakeResult).value; got != 43 {
+		t.Errorf("consumer 应读取到 base 的结果, 得到 %d", got)
+	}
+	if _, ok := results["test-runner-base"]; !ok {
+		t.Errorf("自动加入的依赖的结果应包含在返回值中")
+	}
+	if _, ok := p.getAnalyzer("test-runner-base"); ok {
+		t.Errorf("自动加入的依赖不应注册到 ProjectAnalyzer 中")
+	}
+
+	// 被依赖的分析器失败时，依赖它的分析器因拿不到结果而失败
+	failing := &fakeAnalyzer{name: "test-runner-failing", analyze: func(ctx *ProjectContext) (Result, error) {
+		return nil, errors.New("boom")
+	}}
+	dependent := &fakeAnalyzer{name: "test-runner-dependent", deps: []string{"test-runner-failing"}, analyze: func(ctx *ProjectContext) (Result, error) {
+		if _, err := DependencyResult[*fakeResult](ctx, "test-runner-failing"); err != nil {
+			return nil, err
+		}
+		return &fakeResult{name: "test-runner-dependent"}, nil
+	}}
+	_, err = newTestProjectAnalyzer().ExecuteWithConfig(&ExecutionConfig{Analyzers: []*AnalyzerWithConfig{{Analyzer: dependent}, {Analyzer: failing}}})
+	if err == nil || !strings.Contains(err.Error(), "2 errors") {
+		t.Errorf("预期被依赖的分析器与依赖它的分析器都报告错误, 得到 %v", err)
+	}
+}
+
+// paramAnalyzer 是声明了必需参数的测试分析器。
+type paramAnalyzer struct{ fakeAnalyzer }
+
+func (a *paramAnalyzer) ParamSchema() ParamSchema {
+	return ObjectParams(map[string]*ParamSchema{"target": StringParam("目标")}, "target")
+}
+
+func TestRunnerDependencyNeedsParams(t *testing.T) {
+	RegisterAnalyzer("test-runner-needs-params", func() Analyzer {
+		return &paramAnalyzer{fakeAnalyzer{name: "test-runner-needs-params"}}
+	})
+	consumer := &fakeAnalyzer{name: "test-runner-params-consumer", deps: []string{"test-runner-needs-params"}}
+
+	// 自动加入的依赖没有配置，错误信息提示显式指定该分析器
+	_, err := newTestProjectAnalyzer().ExecuteWithConfig(&ExecutionConfig{Analyzers: []*AnalyzerWithConfig{{Analyzer: consumer}}})
+	if err == nil || !strings.Contains(err.Error(), "analyzer 'test-runner-needs-params' was added as a dependency but needs params") {
+		t.Fatalf("预期提示依赖缺少参数, 得到 %v", err)
+	}
+
+	// 显式指定依赖及其配置后正常运行
+	dependency := &paramAnalyzer{fakeAnalyzer{name: "test-runner-needs-params"}}
+	_, err = newTestProjectAnalyzer().ExecuteWithConfig(&ExecutionConfig{Analyzers: []*AnalyzerWithConfig{
+		{Analyzer: consumer},
+		{Analyzer: dependency, Config: map[string]string{"target": "x"}},
+	}})
+	if err != nil {
+		t.Errorf("显式指定依赖的配置后预期成功, 得到 %v", err)
+	}
+}
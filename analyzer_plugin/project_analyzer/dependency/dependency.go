@@ -27,6 +27,14 @@ func (c *Checker) Configure(params map[string]string) error {
 	return nil
 }
 
+func (c *Checker) RequiredParseOptions() projectParser.ParseOptions {
+	return projectParser.LowMemoryParseOptions()
+}
+
+func (c *Checker) ParamSchema() projectanalyzer.ParamSchema {
+	return projectanalyzer.ObjectParams(nil)
+}
+
 func (c *Checker) Analyze(ctx *projectanalyzer.ProjectContext) (projectanalyzer.Result, error) {
 	parseResult := ctx.ParsingResult
 
@@ -40,6 +48,7 @@ func (c *Checker) Analyze(ctx *projectanalyzer.ProjectContext) (projectanalyzer.
 	var wg sync.WaitGroup
 	var implicitDeps []ImplicitDependency
 	var unusedDeps []UnusedDependency
+	var typeOnlyDeps []TypeOnlyDependency
 	var outdatedDeps []OutdatedDependency
 
 	wg.Add(2)
@@ -49,6 +58,7 @@ func (c *Checker) Analyze(ctx *projectanalyzer.ProjectContext) (projectanalyzer.
 		var usedDependencies map[string]bool
 		implicitDeps, usedDependencies = findImplicitAndUsedDependencies(parseResult, declaredDependencies)
 		unusedDeps = findUnusedDependencies(parseResult, usedDependencies)
+		typeOnlyDeps = findTypeOnlyDependencies(parseResult)
 	}()
 
 	go func() {
@@ -61,6 +71,7 @@ func (c *Checker) Analyze(ctx *projectanalyzer.ProjectContext) (projectanalyzer.
 	finalResult := &DependencyCheckResult{
 		ImplicitDependencies: implicitDeps,
 		UnusedDependencies:   unusedDeps,
+		TypeOnlyDependencies: typeOnlyDeps,
 		OutdatedDependencies: outdatedDeps,
 	}
 
@@ -73,13 +84,16 @@ func findImplicitAndUsedDependencies(ar *projectParser.ProjectParserResult, decl
 
 	for path, jsData := range ar.Js_Data {
 		for _, imp := range jsData.ImportDeclarations {
-			if imp.Source.Type == "npm" {
+			// workspace 包同样需要在 package.json 中声明（通常为 "workspace:*"）
+			if imp.Source.Type == "npm" || imp.Source.Type == "workspace" {
 				usedDependencies[imp.Source.NpmPkg] = true
 				if !declaredDependencies[imp.Source.NpmPkg] && !nodeBuiltInModules[imp.Source.NpmPkg] {
 					implicitDependencies = append(implicitDependencies, ImplicitDependency{
-						Name:     imp.Source.NpmPkg,
-						FilePath: path,
-						Raw:      imp.Raw,
+						Name:           imp.Source.NpmPkg,
+						FilePath:       path,
+						Raw:            imp.Raw,
+						IsTypeOnly:     imp.OnlyTypes(),
+						SourceLocation: imp.SourceLocation,
 					})
 				}
 			}
@@ -108,6 +122,40 @@ func findUnusedDependencies(ar *projectParser.ProjectParserResult, usedDependenc
 	return unusedDependencies
 }
 
+// findTypeOnlyDependencies 找出在 dependencies 中声明、但所有导入都只导入了类型的依赖。
+// 只要有一处运行时导入（包括副作用导入），该依赖就被视为运行时依赖。
+func findTypeOnlyDependencies(ar *projectParser.ProjectParserResult) []TypeOnlyDependency {
+	typeOnlyDependencies := []TypeOnlyDependency{}
+	typeUsed := make(map[string]bool)
+	runtimeUsed := make(map[string]bool)
+
+	for _, jsData := range ar.Js_Data {
+		for _, imp := range jsData.ImportDeclarations {
+			if imp.Source.Type != "npm" && imp.Source.Type != "workspace" {
+				continue
+			}
+			if imp.OnlyTypes() {
+				typeUsed[imp.Source.NpmPkg] = true
+			} else {
+				runtimeUsed[imp.Source.NpmPkg] = true
+			}
+		}
+	}
+
+	for path, pkgData := range ar.Package_Data {
+		for _, dep := range pkgData.NpmList {
+			if dep.Type == "dependencies" && typeUsed[dep.Name] && !runtimeUsed[dep.Name] {
+				typeOnlyDependencies = append(typeOnlyDependencies, TypeOnlyDependency{
+					Name:            dep.Name,
+					Version:         dep.Version,
+					PackageJsonPath: path,
+				})
+			}
+		}
+	}
+	return typeOnlyDependencies
+}
+
 func findOutdatedDependencies(ar *projectParser.ProjectParserResult) []OutdatedDependency {
 	outdatedDependencies := []OutdatedDependency{}
 	checkedPackages := make(map[string]bool)
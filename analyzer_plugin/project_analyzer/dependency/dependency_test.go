@@ -1,6 +1,7 @@
 package dependency
 
 import (
+	"os"
 	"path/filepath"
 	"reflect"
 	"testing"
@@ -79,3 +80,93 @@ func TestFindUnusedDependencies(t *testing.T) {
 		t.Errorf("Expected unused dependency to be 'unused-lib', but got %s", unusedDeps[0].Name)
 	}
 }
+
+func TestFindTypeOnlyDependencies(t *testing.T) {
+	// 准备路径
+	projectRoot, _ := filepath.Abs("/test-project")
+	indexPath := filepath.Join(projectRoot, "index.ts")
+	typesPath := filepath.Join(projectRoot, "types.ts")
+	pkgJsonPath := filepath.Join(projectRoot, "package.json")
+
+	// 1. 准备测试数据
+	mockParsingResult := &projectParser.ProjectParserResult{
+		Js_Data: map[string]projectParser.JsFileParserResult{
+			indexPath: {
+				ImportDeclarations: []projectParser.ImportDeclarationResult{
+					// 运行时导入
+					{Source: projectParser.SourceData{Type: "npm", NpmPkg: "runtime-lib"}, ImportModules: []projectParser.ImportModule{{Identifier: "run", Type: "named"}}},
+					// 整体的类型导入
+					{Source: projectParser.SourceData{Type: "npm", NpmPkg: "types-lib"}, IsTypeOnly: true, ImportModules: []projectParser.ImportModule{{Identifier: "Config", Type: "named", IsTypeOnly: true}}},
+					// 行内的类型导入
+					{Source: projectParser.SourceData{Type: "npm", NpmPkg: "inline-types-lib"}, ImportModules: []projectParser.ImportModule{{Identifier: "Options", Type: "named", IsTypeOnly: true}}},
+					// 同时存在类型导入与运行时导入
+					{Source: projectParser.SourceData{Type: "npm", NpmPkg: "mixed-lib"}, IsTypeOnly: true, ImportModules: []projectParser.ImportModule{{Identifier: "Props", Type: "named", IsTypeOnly: true}}},
+				},
+			},
+			typesPath: {
+				ImportDeclarations: []projectParser.ImportDeclarationResult{
+					{Source: projectParser.SourceData{Type: "npm", NpmPkg: "mixed-lib"}, ImportModules: []projectParser.ImportModule{{Identifier: "Props", Type: "named", IsTypeOnly: true}, {Identifier: "create", Type: "named"}}},
+					// devDependencies 中的类型导入不需要报告
+					{Source: projectParser.SourceData{Type: "npm", NpmPkg: "dev-lib"}, IsTypeOnly: true},
+				},
+			},
+		},
+		Package_Data: map[string]projectParser.PackageJsonFileParserResult{
+			pkgJsonPath: {
+				NpmList: map[string]projectParser.NpmItem{
+					"runtime-lib":      {Name: "runtime-lib", Type: "dependencies"},
+					"types-lib":        {Name: "types-lib", Type: "dependencies"},
+					"inline-types-lib": {Name: "inline-types-lib", Type: "dependencies"},
+					"mixed-lib":        {Name: "mixed-lib", Type: "dependencies"},
+					"dev-lib":          {Name: "dev-lib", Type: "devDependencies"},
+				},
+			},
+		},
+	}
+
+	// 2. 执行函数
+	typeOnlyDeps := findTypeOnlyDependencies(mockParsingResult)
+
+	// 3. 断言结果
+	names := make(map[string]bool)
+	for _, dep := range typeOnlyDeps {
+		names[dep.Name] = true
+	}
+	expected := map[string]bool{"types-lib": true, "inline-types-lib": true}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("Expected type-only dependencies to be %v, but got %v", expected, names)
+	}
+}
+
+// TestReadmeSnippetsAreNotDependencies 测试 README.md 代码块中的导入只是示例：
+// 既不会被报告为隐式依赖，也不会让只在文档中出现的依赖被视为已使用。
+func TestReadmeSnippetsAreNotDependencies(t *testing.T) {
+	projectRoot := t.TempDir()
+	for name, content := range map[string]string{
+		"package.json": `{"name": "app", "dependencies": {"react": "^18.0.0", "left-pad": "^1.0.0"}}`,
+		"src/index.ts": "import React from 'react';\nexport default React;\n",
+		"README.md":    "# App\n\n```tsx\nimport _ from 'lodash';\nimport leftPad from 'left-pad';\n```\n",
+	} {
+		path := filepath.Join(projectRoot, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	config := projectParser.NewProjectParserConfig(projectRoot, nil, false, []string{})
+	parsingResult := projectParser.NewProjectParserResult(config)
+	parsingResult.ProjectParser()
+
+	declaredDependencies := map[string]bool{"react": true, "left-pad": true}
+	implicitDeps, usedDeps := findImplicitAndUsedDependencies(parsingResult, declaredDependencies)
+	if len(implicitDeps) != 0 {
+		t.Errorf("README.md 中的导入不应被报告为隐式依赖, 得到 %+v", implicitDeps)
+	}
+	unusedDeps := findUnusedDependencies(parsingResult, usedDeps)
+	if len(unusedDeps) != 1 || unusedDeps[0].Name != "left-pad" {
+		t.Errorf("只在 README.md 中出现的 left-pad 应被报告为未使用, 得到 %+v", unusedDeps)
+	}
+}
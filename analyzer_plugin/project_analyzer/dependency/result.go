@@ -8,10 +8,11 @@ import (
 )
 
 // DependencyCheckResult 是依赖检查功能最终输出的完整结果结构体。
-// 它整合了隐式依赖、未使用依赖和过期依赖三项检查的结果，并实现了 projectanalyzer.Result 接口。
+// 它整合了隐式依赖、未使用依赖、仅类型依赖和过期依赖四项检查的结果，并实现了 projectanalyzer.Result 接口。
 type DependencyCheckResult struct {
 	ImplicitDependencies []ImplicitDependency `json:"implicitDependencies"`
 	UnusedDependencies   []UnusedDependency   `json:"unusedDependencies"`
+	TypeOnlyDependencies []TypeOnlyDependency `json:"typeOnlyDependencies"`
 	OutdatedDependencies []OutdatedDependency `json:"outdatedDependencies"`
 }
 
@@ -26,9 +27,10 @@ func (r *DependencyCheckResult) Name() string {
 // Summary 返回对结果的简短、人类可读的摘要。
 func (r *DependencyCheckResult) Summary() string {
 	return fmt.Sprintf(
-		"发现 %d 个隐式依赖, %d 个未使用依赖, %d 个过期依赖。",
+		"发现 %d 个隐式依赖, %d 个未使用依赖, %d 个仅类型依赖, %d 个过期依赖。",
 		len(r.ImplicitDependencies),
 		len(r.UnusedDependencies),
+		len(r.TypeOnlyDependencies),
 		len(r.OutdatedDependencies),
 	)
 }
@@ -40,7 +42,7 @@ func (r *DependencyCheckResult) ToJSON(indent bool) ([]byte, error) {
 
 // ToConsole 将结果格式化为适合在控制台（终端）中打印的字符串。
 func (r *DependencyCheckResult) ToConsole() string {
-	totalIssues := len(r.ImplicitDependencies) + len(r.UnusedDependencies) + len(r.OutdatedDependencies)
+	totalIssues := len(r.ImplicitDependencies) + len(r.UnusedDependencies) + len(r.TypeOnlyDependencies) + len(r.OutdatedDependencies)
 	if totalIssues == 0 {
 		return "✅ NPM 依赖健康检查通过，没有发现任何问题。"
 	}
@@ -51,6 +53,10 @@ func (r *DependencyCheckResult) ToConsole() string {
 	if len(r.ImplicitDependencies) > 0 {
 		builder.WriteString("\n--- 👻 隐式依赖 (幽灵依赖) ---\n")
 		for _, dep := range r.ImplicitDependencies {
+			if dep.IsTypeOnly {
+				builder.WriteString(fmt.Sprintf("  - %s (在 %s 中作为类型使用)\n", dep.Name, dep.FilePath))
+				continue
+			}
 			builder.WriteString(fmt.Sprintf("  - %s (在 %s 中使用)\n", dep.Name, dep.FilePath))
 		}
 	}
@@ -62,6 +68,13 @@ func (r *DependencyCheckResult) ToConsole() string {
 		}
 	}
 
+	if len(r.TypeOnlyDependencies) > 0 {
+		builder.WriteString("\n--- 🏷️ 仅类型依赖 (可移至 devDependencies) ---\n")
+		for _, dep := range r.TypeOnlyDependencies {
+			builder.WriteString(fmt.Sprintf("  - %s@%s (在 %s 中声明)\n", dep.Name, dep.Version, dep.PackageJsonPath))
+		}
+	}
+
 	if len(r.OutdatedDependencies) > 0 {
 		builder.WriteString("\n--- ⬆️ 过期依赖 ---\n")
 		for _, dep := range r.OutdatedDependencies {
@@ -76,3 +89,48 @@ func (r *DependencyCheckResult) ToConsole() string {
 func (r *DependencyCheckResult) AnalyzerName() string {
 	return "npm-check"
 }
+
+// 确保 Result 结构体实现了 projectanalyzer.FindingsProvider 接口。
+var _ projectanalyzer.FindingsProvider = (*DependencyCheckResult)(nil)
+
+// NormalizedFindings 将四项检查的结果转换为统一格式的发现项。
+// 隐式依赖位于导入语句处，为 error 级别；其余三类位于声明依赖的 package.json，
+// 未使用依赖为 warning 级别，仅类型依赖与过期依赖为 info 级别。
+func (r *DependencyCheckResult) NormalizedFindings() []projectanalyzer.Finding {
+	var findings []projectanalyzer.Finding
+	for _, dep := range r.ImplicitDependencies {
+		message := fmt.Sprintf("使用了未在 package.json 中声明的依赖 '%s'", dep.Name)
+		if dep.IsTypeOnly {
+			message = fmt.Sprintf("以类型导入使用了未在 package.json 中声明的依赖 '%s'", dep.Name)
+		}
+		findings = append(findings, projectanalyzer.FindingAt(
+			"npm-check/implicit-dependency", projectanalyzer.SeverityError, message, dep.FilePath, dep.SourceLocation,
+		))
+	}
+	for _, dep := range r.UnusedDependencies {
+		findings = append(findings, projectanalyzer.Finding{
+			RuleID:   "npm-check/unused-dependency",
+			Severity: projectanalyzer.SeverityWarning,
+			Message:  fmt.Sprintf("依赖 '%s@%s' 已声明但没有被使用", dep.Name, dep.Version),
+			File:     dep.PackageJsonPath,
+		})
+	}
+	for _, dep := range r.TypeOnlyDependencies {
+		findings = append(findings, projectanalyzer.Finding{
+			RuleID:   "npm-check/type-only-dependency",
+			Severity: projectanalyzer.SeverityInfo,
+			Message:  fmt.Sprintf("依赖 '%s@%s' 只以类型导入使用，可以移到 devDependencies", dep.Name, dep.Version),
+			File:     dep.PackageJsonPath,
+		})
+	}
+	// 消息参与基线指纹，因此不包含最新版本号，避免上游每次发布都让基线中已记录的发现项变成新发现项
+	for _, dep := range r.OutdatedDependencies {
+		findings = append(findings, projectanalyzer.Finding{
+			RuleID:   "npm-check/outdated-dependency",
+			Severity: projectanalyzer.SeverityInfo,
+			Message:  fmt.Sprintf("依赖 '%s' 的版本 %s 已过期", dep.Name, dep.CurrentVersion),
+			File:     dep.PackageJsonPath,
+		})
+	}
+	return findings
+}
@@ -3,17 +3,22 @@
 // 这个包定义了依赖检查分析器的完整类型系统，用于：
 // - 隐式依赖（幽灵依赖）的检测和报告
 // - 未使用依赖的识别和分类
+// - 仅作为类型使用的运行时依赖的识别
 // - 过时依赖的版本信息记录
 // - NPM Registry API 响应数据的解析
 //
 // 所有类型都支持 JSON 序列化，便于数据导出和集成到其他系统。
 package dependency
 
+import "github.com/Flying-Bird1999/analyzer-ts/analyzer/parser"
+
 // ImplicitDependency 代表一个隐式依赖（或称“幽灵依赖”）。
 type ImplicitDependency struct {
-	Name     string `json:"name"`
-	FilePath string `json:"filePath"`
-	Raw      string `json:"raw"`
+	Name           string                 `json:"name"`
+	FilePath       string                 `json:"filePath"`
+	Raw            string                 `json:"raw"`
+	IsTypeOnly     bool                   `json:"isTypeOnly"`               // 该导入是否只导入了类型（例如 `import type`），类型导入只需要在开发时可用。
+	SourceLocation *parser.SourceLocation `json:"sourceLocation,omitempty"` // 导入语句在源码中的位置
 }
 
 // UnusedDependency 代表一个未使用的依赖。
@@ -23,6 +28,14 @@ type UnusedDependency struct {
 	PackageJsonPath string `json:"packageJsonPath"`
 }
 
+// TypeOnlyDependency 代表一个在 dependencies 中声明、但代码中只以类型导入使用的依赖。
+// 类型导入在编译后会被移除，这类依赖通常可以移到 devDependencies 中。
+type TypeOnlyDependency struct {
+	Name            string `json:"name"`
+	Version         string `json:"version"`
+	PackageJsonPath string `json:"packageJsonPath"`
+}
+
 // OutdatedDependency 代表一个已过期的依赖。
 type OutdatedDependency struct {
 	Name            string `json:"name"`
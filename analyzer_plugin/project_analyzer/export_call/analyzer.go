@@ -16,6 +16,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/Flying-Bird1999/analyzer-ts/analyzer/projectParser"
 	projectanalyzer "github.com/Flying-Bird1999/analyzer-ts/analyzer_plugin/project_analyzer"
 )
 
@@ -54,6 +55,17 @@ func (a *ExportCallAnalyzer) Configure(params map[string]string) error {
 	return nil
 }
 
+func (a *ExportCallAnalyzer) RequiredParseOptions() projectParser.ParseOptions {
+	return projectParser.LowMemoryParseOptions()
+}
+
+// ParamSchema 描述该分析器接受的参数。
+func (a *ExportCallAnalyzer) ParamSchema() projectanalyzer.ParamSchema {
+	return projectanalyzer.ObjectParams(map[string]*projectanalyzer.ParamSchema{
+		"manifest": projectanalyzer.StringParam("资产清单 manifest.json 的路径，只处理其中的 functions 配置项"),
+	}, "manifest")
+}
+
 // Analyze 执行导出节点引用分析
 // 分析流程：
 // 1. 加载配置文件
@@ -77,7 +89,11 @@ func (a *ExportCallAnalyzer) Analyze(ctx *projectanalyzer.ProjectContext) (proje
 	exportNodes := scanner.ScanAll(ctx.ParsingResult.Js_Data)
 
 	// 步骤 4: 建立引用关系
-	builder := NewReferenceBuilder(assets, ctx.ParsingResult.Js_Data)
+	exportTable, err := projectanalyzer.Index(ctx, projectanalyzer.ExportTableIndex)
+	if err != nil {
+		return nil, err
+	}
+	builder := NewReferenceBuilder(assets, ctx.ParsingResult.Js_Data, exportTable)
 	refMap := builder.BuildReferences(exportNodes)
 
 	// 步骤 5: 按模块分组构建结果
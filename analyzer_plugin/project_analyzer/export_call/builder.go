@@ -10,8 +10,9 @@ import (
 
 // ReferenceBuilder 引用关系构建器
 type ReferenceBuilder struct {
-	assets []AssetItem
-	jsData map[string]projectParser.JsFileParserResult
+	assets  []AssetItem
+	jsData  map[string]projectParser.JsFileParserResult
+	exports projectParser.ExportTable
 
 	// assetPathMap: filePath -> AssetItem
 	assetPathMap map[string]*AssetItem
@@ -21,10 +22,12 @@ type ReferenceBuilder struct {
 func NewReferenceBuilder(
 	assets []AssetItem,
 	jsData map[string]projectParser.JsFileParserResult,
+	exports projectParser.ExportTable,
 ) *ReferenceBuilder {
 	rb := &ReferenceBuilder{
-		assets:      assets,
-		jsData:      jsData,
+		assets:       assets,
+		jsData:       jsData,
+		exports:      exports,
 		assetPathMap: make(map[string]*AssetItem),
 	}
 
@@ -50,8 +53,8 @@ func (b *ReferenceBuilder) BuildReferences(exportNodes []*ExportNode) map[string
 	for filePath, fileData := range b.jsData {
 		// 遍历该文件的所有 import
 		for _, importDecl := range fileData.ImportDeclarations {
-			// 只处理文件类型的 import（包括经过路径别名解析后的npm包）
-			if importDecl.Source.Type != "file" {
+			// 只处理文件类型的 import（包括经过路径别名解析后的npm包以及 workspace 包）
+			if !importDecl.Source.IsLocal() {
 				continue
 			}
 
@@ -151,8 +154,9 @@ func (b *ReferenceBuilder) resolveReExportFile(sourceFile, moduleName string) st
 	normalizedSource := b.normalizeFilePath(sourceFile)
 
 	// 获取 sourceFile 的解析数据
-	fileData, exists := b.jsData[normalizedSource]
-	if !exists {
+	if _, exists := b.jsData[normalizedSource]; exists {
+		sourceFile = normalizedSource
+	} else {
 		// 尝试通过后缀匹配查找文件（处理相对路径问题）
 		for key := range b.jsData {
 			if strings.HasSuffix(key, sourceFile) || strings.HasSuffix(key, normalizedSource) {
@@ -160,38 +164,27 @@ func (b *ReferenceBuilder) resolveReExportFile(sourceFile, moduleName string) st
 				break
 			}
 		}
-		fileData, exists = b.jsData[sourceFile]
-		if !exists {
+		if _, exists := b.jsData[sourceFile]; !exists {
 			return ""
 		}
 	}
 
 	// 检查该文件是否有重导出声明
-	for _, exportDecl := range fileData.ExportDeclarations {
-		if exportDecl.Source == nil || exportDecl.Source.Type != "file" {
+	for _, entry := range b.exports[sourceFile] {
+		if entry.Kind != projectParser.ExportKindReExport || entry.From == "" {
 			continue
 		}
 
-		// 检查是否导出了目标模块
-		for _, module := range exportDecl.ExportModules {
-			// 使用外部名称（Identifier）进行匹配
-			exportName := module.Identifier
-			if exportName == "" {
-				exportName = module.ModuleName
-			}
-
-			// 处理 export * 的情况
-			if module.ModuleName == "*" {
-				// 递归查找目标模块
-				targetFile := b.normalizeFilePath(exportDecl.Source.FilePath)
-				return b.resolveReExportFile(targetFile, moduleName)
-			}
+		// 处理 export * 的情况
+		if entry.LocalName == "*" {
+			// 递归查找目标模块
+			return b.resolveReExportFile(b.normalizeFilePath(entry.From), moduleName)
+		}
 
-			// 匹配模块名称
-			if exportName == moduleName {
-				// 找到了，返回实际定义文件（确保有扩展名）
-				return b.normalizeFilePath(exportDecl.Source.FilePath)
-			}
+		// 使用外部名称匹配目标模块
+		if entry.Name == moduleName {
+			// 找到了，返回实际定义文件（确保有扩展名）
+			return b.normalizeFilePath(entry.From)
 		}
 	}
 
@@ -62,25 +62,35 @@ func (r *SymbolResolver) ResolveExportAssignment(
 		return NodeTypeFunction
 	}
 	if strings.Contains(expr, "class") {
-		return NodeTypeFunction
+		return NodeTypeClass
 	}
 	return NodeTypeVariable
 }
 
-// findSymbolDefinition 在文件中查找符号定义
+// findSymbolDefinition 在文件中查找符号定义，只查找顶层声明，命名空间内的同名声明不参与匹配
 func (r *SymbolResolver) findSymbolDefinition(
 	fileData *projectParser.JsFileParserResult,
 	symbolName string,
 ) NodeType {
 	// 1. 查找函数声明
 	for _, fn := range fileData.FunctionDeclarations {
-		if fn.Identifier == symbolName {
+		if fn.Identifier == symbolName && fn.Namespace == "" {
 			return NodeTypeFunction
 		}
 	}
 
-	// 2. 查找变量声明
+	// 2. 查找类声明
+	for _, class := range fileData.ClassDeclarations {
+		if class.Identifier == symbolName && class.Namespace == "" {
+			return NodeTypeClass
+		}
+	}
+
+	// 3. 查找变量声明
 	for _, v := range fileData.VariableDeclarations {
+		if v.Namespace != "" {
+			continue
+		}
 		for _, decl := range v.Declarators {
 			if decl.Identifier == symbolName {
 				return NodeTypeVariable
@@ -88,17 +98,17 @@ func (r *SymbolResolver) findSymbolDefinition(
 		}
 	}
 
-	// 3. 查找类型声明
+	// 4. 查找类型声明
 	if _, ok := fileData.TypeDeclarations[symbolName]; ok {
 		return NodeTypeType
 	}
 
-	// 4. 查找接口声明
+	// 5. 查找接口声明
 	if _, ok := fileData.InterfaceDeclarations[symbolName]; ok {
 		return NodeTypeInterface
 	}
 
-	// 5. 查找枚举声明
+	// 6. 查找枚举声明
 	if _, ok := fileData.EnumDeclarations[symbolName]; ok {
 		return NodeTypeEnum
 	}
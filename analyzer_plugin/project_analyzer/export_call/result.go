@@ -18,6 +18,7 @@ const (
 	NodeTypeType      NodeType = "type"
 	NodeTypeInterface NodeType = "interface"
 	NodeTypeEnum      NodeType = "enum"
+	NodeTypeClass     NodeType = "class"
 )
 
 // ExportType 导出方式（按导出语法分类）
@@ -184,3 +185,26 @@ func indexOf(s, substr string) int {
 func (r *ExportCallResult) AnalyzerName() string {
 	return "export-call"
 }
+
+var _ projectanalyzer.FindingsProvider = (*ExportCallResult)(nil)
+
+// NormalizedFindings 未被任何文件引用的导出节点报告为 info
+func (r *ExportCallResult) NormalizedFindings() []projectanalyzer.Finding {
+	var findings []projectanalyzer.Finding
+	for _, module := range r.ModuleExports {
+		for _, record := range module.Files {
+			for _, node := range record.Nodes {
+				if len(node.RefFiles) > 0 {
+					continue
+				}
+				findings = append(findings, projectanalyzer.Finding{
+					RuleID:   "export-call/unreferenced-export",
+					Severity: projectanalyzer.SeverityInfo,
+					Message:  fmt.Sprintf("模块 %s 的导出 %s [%s] 未被引用", module.ModuleName, node.Name, node.NodeType),
+					File:     record.File,
+				})
+			}
+		}
+	}
+	return findings
+}
@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/Flying-Bird1999/analyzer-ts/analyzer/parser"
 	"github.com/Flying-Bird1999/analyzer-ts/analyzer/projectParser"
 )
 
@@ -80,7 +81,7 @@ func (s *ExportScanner) extractNamedExports(
 
 	// VariableDeclarations - 先处理，因为 const foo = () => {} 应该是 variable 类型
 	for _, v := range fileData.VariableDeclarations {
-		if v.Exported {
+		if v.Exported && v.Namespace == "" {
 			for _, decl := range v.Declarators {
 				if decl.Identifier != "" {
 					nodes = append(nodes, &ExportNode{
@@ -98,7 +99,7 @@ func (s *ExportScanner) extractNamedExports(
 
 	// FunctionDeclarations - 只处理非 default export 的函数
 	for _, fn := range fileData.FunctionDeclarations {
-		if fn.Exported && !fn.IsDefaultExport {
+		if fn.Exported && !fn.IsDefaultExport && fn.Namespace == "" {
 			nodes = append(nodes, &ExportNode{
 				ID:         fmt.Sprintf("%s:%s:named", asset.Name, fn.Identifier),
 				Name:       fn.Identifier,
@@ -110,9 +111,12 @@ func (s *ExportScanner) extractNamedExports(
 		}
 	}
 
+	// ClassDeclarations - 只处理非 default export 的类
+	nodes = append(nodes, classExportNodes(fileData.ClassDeclarations, asset, filePath, ExportTypeNamed)...)
+
 	// TypeDeclarations
 	for name, t := range fileData.TypeDeclarations {
-		if t.Exported {
+		if t.Exported && t.Namespace == "" {
 			nodes = append(nodes, &ExportNode{
 				ID:         fmt.Sprintf("%s:%s:named", asset.Name, name),
 				Name:       name,
@@ -126,7 +130,7 @@ func (s *ExportScanner) extractNamedExports(
 
 	// InterfaceDeclarations
 	for name, iface := range fileData.InterfaceDeclarations {
-		if iface.Exported {
+		if iface.Exported && iface.Namespace == "" {
 			nodes = append(nodes, &ExportNode{
 				ID:         fmt.Sprintf("%s:%s:named", asset.Name, name),
 				Name:       name,
@@ -140,7 +144,7 @@ func (s *ExportScanner) extractNamedExports(
 
 	// EnumDeclarations
 	for name, enum := range fileData.EnumDeclarations {
-		if enum.Exported {
+		if enum.Exported && enum.Namespace == "" {
 			nodes = append(nodes, &ExportNode{
 				ID:         fmt.Sprintf("%s:%s:named", asset.Name, name),
 				Name:       name,
@@ -207,7 +211,7 @@ func (s *ExportScanner) resolveReExport(
 	var nodes []*ExportNode
 
 	// 只处理文件类型的重导出
-	if exportDecl.Source.Type != "file" {
+	if !exportDecl.Source.IsLocal() {
 		return nodes
 	}
 
@@ -301,7 +305,7 @@ func (s *ExportScanner) extractAllExportsFromFile(
 
 	// 3. 递归处理该文件中的重导出
 	for _, exportDecl := range fileData.ExportDeclarations {
-		if exportDecl.Source != nil && exportDecl.Source.Type == "file" {
+		if exportDecl.Source != nil && exportDecl.Source.IsLocal() {
 			reexportedPath := exportDecl.Source.FilePath
 			if reexportedPath != "" && !visited[reexportedPath] {
 				reexportedData, exists := s.resolver.jsData[reexportedPath]
@@ -355,6 +359,9 @@ func (s *ExportScanner) extractDefaultExportsFromFile(
 		}
 	}
 
+	// 3. 处理 IsDefaultExport == true 的类声明
+	nodes = append(nodes, classExportNodes(fileData.ClassDeclarations, asset, filePath, ExportTypeDefault)...)
+
 	return nodes
 }
 
@@ -368,7 +375,7 @@ func (s *ExportScanner) extractNamedExportsFromFile(
 
 	// 1. VariableDeclarations
 	for _, v := range fileData.VariableDeclarations {
-		if v.Exported {
+		if v.Exported && v.Namespace == "" {
 			for _, decl := range v.Declarators {
 				if decl.Identifier != "" {
 					nodes = append(nodes, &ExportNode{
@@ -386,7 +393,7 @@ func (s *ExportScanner) extractNamedExportsFromFile(
 
 	// 2. FunctionDeclarations（非 default）
 	for _, fn := range fileData.FunctionDeclarations {
-		if fn.Exported && !fn.IsDefaultExport {
+		if fn.Exported && !fn.IsDefaultExport && fn.Namespace == "" {
 			nodes = append(nodes, &ExportNode{
 				ID:         fmt.Sprintf("%s:%s:named", asset.Name, fn.Identifier),
 				Name:       fn.Identifier,
@@ -398,9 +405,12 @@ func (s *ExportScanner) extractNamedExportsFromFile(
 		}
 	}
 
-	// 3. TypeDeclarations
+	// 3. ClassDeclarations（非 default）
+	nodes = append(nodes, classExportNodes(fileData.ClassDeclarations, asset, filePath, ExportTypeNamed)...)
+
+	// 4. TypeDeclarations
 	for name, t := range fileData.TypeDeclarations {
-		if t.Exported {
+		if t.Exported && t.Namespace == "" {
 			nodes = append(nodes, &ExportNode{
 				ID:         fmt.Sprintf("%s:%s:named", asset.Name, name),
 				Name:       name,
@@ -412,9 +422,9 @@ func (s *ExportScanner) extractNamedExportsFromFile(
 		}
 	}
 
-	// 4. InterfaceDeclarations
+	// 5. InterfaceDeclarations
 	for name, iface := range fileData.InterfaceDeclarations {
-		if iface.Exported {
+		if iface.Exported && iface.Namespace == "" {
 			nodes = append(nodes, &ExportNode{
 				ID:         fmt.Sprintf("%s:%s:named", asset.Name, name),
 				Name:       name,
@@ -426,9 +436,9 @@ func (s *ExportScanner) extractNamedExportsFromFile(
 		}
 	}
 
-	// 5. EnumDeclarations
+	// 6. EnumDeclarations
 	for name, enum := range fileData.EnumDeclarations {
-		if enum.Exported {
+		if enum.Exported && enum.Namespace == "" {
 			nodes = append(nodes, &ExportNode{
 				ID:         fmt.Sprintf("%s:%s:named", asset.Name, name),
 				Name:       name,
@@ -494,6 +504,47 @@ func (s *ExportScanner) extractDefaultExports(
 		}
 	}
 
+	// 3. 处理 IsDefaultExport == true 的类声明（export default class Foo {} 形式）
+	nodes = append(nodes, classExportNodes(fileData.ClassDeclarations, asset, filePath, ExportTypeDefault)...)
+
+	return nodes
+}
+
+// classExportNodes 将类声明转换为指定导出类型的导出节点。
+// named 只处理顶层非 default 导出的类；default 处理 export default class，匿名类命名为 "default"。
+func classExportNodes(
+	classes []parser.ClassDeclarationResult,
+	asset AssetItem,
+	filePath string,
+	exportType ExportType,
+) []*ExportNode {
+	var nodes []*ExportNode
+	for _, class := range classes {
+		name := class.Identifier
+		switch exportType {
+		case ExportTypeNamed:
+			if !class.Exported || class.IsDefaultExport || class.Namespace != "" {
+				continue
+			}
+		case ExportTypeDefault:
+			if !class.IsDefaultExport {
+				continue
+			}
+			if name == "" {
+				name = "default"
+			}
+		default:
+			continue
+		}
+		nodes = append(nodes, &ExportNode{
+			ID:         fmt.Sprintf("%s:%s:%s", asset.Name, name, exportType),
+			Name:       name,
+			AssetName:  asset.Name,
+			NodeType:   NodeTypeClass,
+			ExportType: exportType,
+			SourceFile: filePath,
+		})
+	}
 	return nodes
 }
 
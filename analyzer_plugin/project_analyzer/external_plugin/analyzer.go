@@ -0,0 +1,227 @@
+package external_plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Flying-Bird1999/analyzer-ts/analyzer/projectParser"
+	projectanalyzer "github.com/Flying-Bird1999/analyzer-ts/analyzer_plugin/project_analyzer"
+)
+
+// maxStderrInError 是错误信息中保留的插件 stderr 的最大字节数。
+const maxStderrInError = 4096
+
+// DefaultTimeout 是插件进程默认的最长运行时间。
+const DefaultTimeout = 5 * time.Minute
+
+// ExternalAnalyzer 是运行外部插件进程的分析器。
+type ExternalAnalyzer struct {
+	plugin  Plugin
+	timeout time.Duration
+	params  map[string]string
+}
+
+var _ projectanalyzer.Analyzer = (*ExternalAnalyzer)(nil)
+
+// NewExternalAnalyzer 为插件创建分析器，插件进程运行超过 timeout 时被终止并返回错误，timeout <= 0 时使用 DefaultTimeout。
+func NewExternalAnalyzer(plugin Plugin, timeout time.Duration) *ExternalAnalyzer {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &ExternalAnalyzer{plugin: plugin, timeout: timeout}
+}
+
+func (a *ExternalAnalyzer) Name() string {
+	return a.plugin.Name
+}
+
+// Configure 保存参数，参数在运行时随请求发送给插件，由插件自行校验。
+func (a *ExternalAnalyzer) Configure(params map[string]string) error {
+	a.params = params
+	return nil
+}
+
+// RequiredParseOptions 插件收到的是 JSON 序列化后的解析结果，AST 与文件的完整源码都不会被序列化，
+// 因此使用低内存模式；各声明的 raw 字段不受解析模式影响，仍会发送给插件。
+func (a *ExternalAnalyzer) RequiredParseOptions() projectParser.ParseOptions {
+	return projectParser.LowMemoryParseOptions()
+}
+
+// Analyze 启动插件进程，通过 stdin 发送项目上下文，从 stdout 读取发现项。
+func (a *ExternalAnalyzer) Analyze(ctx *projectanalyzer.ProjectContext) (projectanalyzer.Result, error) {
+	params := a.params
+	if params == nil {
+		params = map[string]string{}
+	}
+	request := Request{
+		ProtocolVersion: ProtocolVersion,
+		Analyzer:        a.plugin.Name,
+		Params:          params,
+		Project: Project{
+			ProjectRoot:   ctx.ProjectRoot,
+			Exclude:       ctx.Exclude,
+			IsMonorepo:    ctx.IsMonorepo,
+			ParsingResult: ctx.ParsingResult,
+		},
+	}
+
+	// 插件挂起或不关闭 stdout 时，超时后终止进程；WaitDelay 保证插件的子进程占用输出管道时 Wait 也能返回。
+	runCtx, cancel := context.WithTimeout(context.Background(), a.timeout)
+	defer cancel()
+	cmd := exec.CommandContext(runCtx, a.plugin.Path)
+	cmd.WaitDelay = time.Second
+	cmd.Dir = ctx.ProjectRoot
+	cmd.Env = append(os.Environ(), fmt.Sprintf("%s=%d", ProtocolVersionEnv, ProtocolVersion))
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("插件 '%s' 创建 stdin 失败: %w", a.plugin.Name, err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("启动插件 '%s' (%s) 失败: %w", a.plugin.Name, a.plugin.Path, err)
+	}
+
+	// 解析结果可能很大，边序列化边写入 stdin，避免在内存中再保留一份完整的 JSON。
+	// 插件不读取 stdin 就退出时写入会失败，此时以插件的退出状态为准。
+	encodeErr := json.NewEncoder(stdin).Encode(request)
+	closeErr := stdin.Close()
+	waitErr := cmd.Wait()
+	if errors.Is(runCtx.Err(), context.DeadlineExceeded) {
+		return nil, a.processError(fmt.Sprintf("执行超时 (超过 %s)，已终止", a.timeout), stderr.Bytes())
+	}
+	if waitErr != nil {
+		return nil, a.processError(fmt.Sprintf("执行失败: %v", waitErr), stderr.Bytes())
+	}
+	if err := errors.Join(encodeErr, closeErr); err != nil {
+		return nil, a.processError(fmt.Sprintf("发送请求失败: %v", err), stderr.Bytes())
+	}
+
+	var response Response
+	if err := json.Unmarshal(stdout.Bytes(), &response); err != nil {
+		return nil, a.processError(fmt.Sprintf("响应不是合法的 JSON: %v", err), stderr.Bytes())
+	}
+	if response.ProtocolVersion != ProtocolVersion {
+		return nil, fmt.Errorf("插件 '%s' 的协议版本为 %d，运行器支持的版本为 %d", a.plugin.Name, response.ProtocolVersion, ProtocolVersion)
+	}
+	if response.Error != "" {
+		return nil, fmt.Errorf("插件 '%s' 分析失败: %s", a.plugin.Name, response.Error)
+	}
+	return newResult(a.plugin.Name, response)
+}
+
+// processError 构造插件进程出错时的错误信息，附带插件 stderr 的末尾部分。
+func (a *ExternalAnalyzer) processError(message string, stderr []byte) error {
+	if len(stderr) > maxStderrInError {
+		stderr = stderr[len(stderr)-maxStderrInError:]
+	}
+	if text := strings.TrimSpace(string(stderr)); text != "" {
+		return fmt.Errorf("插件 '%s' %s\nstderr:\n%s", a.plugin.Name, message, text)
+	}
+	return fmt.Errorf("插件 '%s' %s", a.plugin.Name, message)
+}
+
+// Result 是外部插件的分析结果。
+type Result struct {
+	Analyzer    string    `json:"analyzer"`
+	SummaryText string    `json:"summary"`
+	Findings    []Finding `json:"findings"`
+}
+
+var _ projectanalyzer.Result = (*Result)(nil)
+
+// newResult 将插件的响应包装为 Result，发现项按文件与位置排序。
+// 未指定级别的发现项视为 warning，级别不是 error、warning、info 之一时返回错误；
+// ruleId 不以 "<插件名>/" 开头时加上该前缀，使发现项可以按插件名被忽略注释与基线匹配。
+func newResult(name string, response Response) (*Result, error) {
+	findings := make([]Finding, len(response.Findings))
+	copy(findings, response.Findings)
+	for i := range findings {
+		switch findings[i].Severity {
+		case "":
+			findings[i].Severity = projectanalyzer.SeverityWarning
+		case projectanalyzer.SeverityError, projectanalyzer.SeverityWarning, projectanalyzer.SeverityInfo:
+		default:
+			return nil, fmt.Errorf("插件 '%s' 的发现项 '%s' 的级别 '%s' 无效，应为 error、warning 或 info", name, findings[i].RuleID, findings[i].Severity)
+		}
+		if findings[i].RuleID == "" {
+			return nil, fmt.Errorf("插件 '%s' 的发现项缺少 ruleId: %s", name, findings[i].Message)
+		}
+		if !strings.HasPrefix(findings[i].RuleID, name+"/") {
+			findings[i].RuleID = name + "/" + findings[i].RuleID
+		}
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].File != findings[j].File {
+			return findings[i].File < findings[j].File
+		}
+		if findings[i].Line != findings[j].Line {
+			return findings[i].Line < findings[j].Line
+		}
+		return findings[i].Column < findings[j].Column
+	})
+
+	summary := response.Summary
+	if summary == "" {
+		summary = fmt.Sprintf("插件 %s 报告了 %d 个问题", name, len(findings))
+	}
+	return &Result{Analyzer: name, SummaryText: summary, Findings: findings}, nil
+}
+
+func (r *Result) Name() string {
+	return r.Analyzer
+}
+
+func (r *Result) Summary() string {
+	return r.SummaryText
+}
+
+func (r *Result) ToJSON(indent bool) ([]byte, error) {
+	return projectanalyzer.ToJSONBytes(r, indent)
+}
+
+func (r *Result) ToConsole() string {
+	var sb strings.Builder
+	sb.WriteString(r.SummaryText + "\n")
+	for _, finding := range r.Findings {
+		location := finding.File
+		if finding.Line > 0 {
+			location = fmt.Sprintf("%s:%d:%d", finding.File, finding.Line, finding.Column)
+		}
+		sb.WriteString(fmt.Sprintf("  [%s] %s %s: %s\n", finding.Severity, location, finding.RuleID, finding.Message))
+	}
+	return sb.String()
+}
+
+func (r *Result) AnalyzerName() string {
+	return r.Analyzer
+}
+
+var _ projectanalyzer.FindingsProvider = (*Result)(nil)
+
+// NormalizedFindings 将插件的发现项转换为统一格式，Data 不参与转换。
+func (r *Result) NormalizedFindings() []projectanalyzer.Finding {
+	findings := make([]projectanalyzer.Finding, len(r.Findings))
+	for i, finding := range r.Findings {
+		findings[i] = projectanalyzer.Finding{
+			RuleID:    finding.RuleID,
+			Severity:  finding.Severity,
+			Message:   finding.Message,
+			File:      finding.File,
+			Line:      finding.Line,
+			Column:    finding.Column,
+			EndLine:   finding.EndLine,
+			EndColumn: finding.EndColumn,
+		}
+	}
+	return findings
+}
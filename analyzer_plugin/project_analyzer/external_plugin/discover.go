@@ -0,0 +1,147 @@
+package external_plugin
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	projectanalyzer "github.com/Flying-Bird1999/analyzer-ts/analyzer_plugin/project_analyzer"
+)
+
+// PluginPrefix 是 PATH 中插件可执行文件名的前缀，前缀之后的部分即分析器名称，
+// 例如 analyzer-ts-plugin-i18n 注册为分析器 i18n。
+const PluginPrefix = "analyzer-ts-plugin-"
+
+// Plugin 是一个已发现的外部插件。
+type Plugin struct {
+	// Name 是插件注册的分析器名称。
+	Name string `json:"name"`
+	// Path 是插件可执行文件的绝对路径。
+	Path string `json:"path"`
+}
+
+// Discover 发现所有可用的插件。
+// configured 是配置文件中显式列出的插件路径，相对路径基于 projectRoot 解析；
+// pathList（PATH 环境变量格式）非空时还会在其中的目录查找以 PluginPrefix 开头的可执行文件，为空时不扫描。
+// 同名插件以配置文件中的为准，pathList 中靠前目录的优先于靠后目录的。
+//
+// 插件是任意可执行文件，调用方应只在用户明确允许时（例如 analyze --allow-plugins）调用 Discover，
+// 避免分析不受信任的仓库时执行其中附带的程序。
+func Discover(projectRoot string, configured []string, pathList string) ([]Plugin, error) {
+	byName := make(map[string]Plugin)
+	for _, path := range configured {
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(projectRoot, path)
+		}
+		plugin, err := PluginFromPath(path)
+		if err != nil {
+			return nil, err
+		}
+		if existing, ok := byName[plugin.Name]; ok {
+			return nil, fmt.Errorf("插件 %s 与 %s 的名称 '%s' 重复", plugin.Path, existing.Path, plugin.Name)
+		}
+		byName[plugin.Name] = plugin
+	}
+	for _, plugin := range DiscoverInPath(pathList) {
+		if _, ok := byName[plugin.Name]; !ok {
+			byName[plugin.Name] = plugin
+		}
+	}
+
+	plugins := make([]Plugin, 0, len(byName))
+	for _, plugin := range byName {
+		plugins = append(plugins, plugin)
+	}
+	sort.Slice(plugins, func(i, j int) bool { return plugins[i].Name < plugins[j].Name })
+	return plugins, nil
+}
+
+// DiscoverInPath 在 pathList（PATH 环境变量格式）的目录中查找以 PluginPrefix 开头的可执行文件。
+// 不存在或无法读取的目录会被忽略。
+func DiscoverInPath(pathList string) []Plugin {
+	var plugins []Plugin
+	seen := make(map[string]bool)
+	for _, dir := range filepath.SplitList(pathList) {
+		if dir == "" {
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if !strings.HasPrefix(entry.Name(), PluginPrefix) || entry.IsDir() {
+				continue
+			}
+			path, err := filepath.Abs(filepath.Join(dir, entry.Name()))
+			if err != nil || !isExecutable(path) {
+				continue
+			}
+			name := pluginName(entry.Name())
+			if name == "" || seen[name] {
+				continue
+			}
+			seen[name] = true
+			plugins = append(plugins, Plugin{Name: name, Path: path})
+		}
+	}
+	return plugins
+}
+
+// PluginFromPath 根据可执行文件路径创建插件，分析器名称为去掉 PluginPrefix 与扩展名后的文件名。
+func PluginFromPath(path string) (Plugin, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return Plugin{}, fmt.Errorf("无法解析插件路径 %s: %w", path, err)
+	}
+	if !isExecutable(absPath) {
+		return Plugin{}, fmt.Errorf("插件 %s 不存在或不可执行", absPath)
+	}
+	name := pluginName(filepath.Base(absPath))
+	if name == "" {
+		return Plugin{}, fmt.Errorf("无法从插件文件名 %s 得到分析器名称", absPath)
+	}
+	return Plugin{Name: name, Path: absPath}, nil
+}
+
+// Register 把插件注册到分析器注册表，之后可以像内置分析器一样通过名称运行。
+// timeout 是每次运行插件进程的最长时间，见 NewExternalAnalyzer。
+// 与已注册的分析器同名的插件不会被注册，并在返回的错误中列出。
+func Register(plugins []Plugin, timeout time.Duration) error {
+	var conflicts []string
+	for _, plugin := range plugins {
+		if _, exists := projectanalyzer.NewAnalyzer(plugin.Name); exists {
+			conflicts = append(conflicts, fmt.Sprintf("%s (%s)", plugin.Name, plugin.Path))
+			continue
+		}
+		projectanalyzer.RegisterAnalyzer(plugin.Name, func() projectanalyzer.Analyzer {
+			return NewExternalAnalyzer(plugin, timeout)
+		})
+	}
+	if len(conflicts) > 0 {
+		return fmt.Errorf("以下插件与已注册的分析器同名，已忽略: %s", strings.Join(conflicts, ", "))
+	}
+	return nil
+}
+
+// pluginName 从可执行文件名得到分析器名称，扩展名（.exe、.sh 等）会被去掉。
+func pluginName(fileName string) string {
+	fileName = strings.TrimSuffix(fileName, filepath.Ext(fileName))
+	return strings.TrimPrefix(fileName, PluginPrefix)
+}
+
+// isExecutable 判断 path 是否为可执行的普通文件。Windows 上只检查文件是否存在。
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		return true
+	}
+	return info.Mode().Perm()&0111 != 0
+}
@@ -0,0 +1,179 @@
+package external_plugin
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Flying-Bird1999/analyzer-ts/analyzer/projectParser"
+	projectanalyzer "github.com/Flying-Bird1999/analyzer-ts/analyzer_plugin/project_analyzer"
+)
+
+// writePlugin 在 dir 中写入一个 shell 脚本插件。
+func writePlugin(t *testing.T, dir, name, script string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0755); err != nil {
+		t.Fatalf("写入插件失败: %v", err)
+	}
+	return path
+}
+
+func TestDiscover(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("测试插件是 shell 脚本")
+	}
+	binDir := t.TempDir()
+	projectRoot := t.TempDir()
+	writePlugin(t, binDir, PluginPrefix+"i18n", "")
+	writePlugin(t, binDir, PluginPrefix+"local", "")
+	writePlugin(t, binDir, "other-tool", "")
+	if err := os.WriteFile(filepath.Join(binDir, PluginPrefix+"not-executable"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(projectRoot, "tools"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	localPath := writePlugin(t, filepath.Join(projectRoot, "tools"), "local.sh", "")
+	plugins, err := Discover(projectRoot, []string{"tools/local.sh"}, binDir)
+	if err != nil {
+		t.Fatalf("Discover() error = %v", err)
+	}
+	if len(plugins) != 2 {
+		t.Fatalf("应发现 2 个插件，实际为 %+v", plugins)
+	}
+	if plugins[0].Name != "i18n" || plugins[0].Path != filepath.Join(binDir, PluginPrefix+"i18n") {
+		t.Errorf("plugins[0] = %+v", plugins[0])
+	}
+	// 配置文件中列出的插件优先于 PATH 中的同名插件
+	if plugins[1].Name != "local" || plugins[1].Path != localPath {
+		t.Errorf("plugins[1] = %+v, want path %s", plugins[1], localPath)
+	}
+
+	if _, err := Discover(projectRoot, []string{"tools/missing"}, ""); err == nil {
+		t.Error("配置中的插件不存在时应返回错误")
+	}
+
+	// pathList 为空时不扫描 PATH
+	plugins, err = Discover(projectRoot, nil, "")
+	if err != nil || len(plugins) != 0 {
+		t.Errorf("不应发现任何插件: %+v, %v", plugins, err)
+	}
+}
+
+func TestExternalAnalyzer(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("测试插件是 shell 脚本")
+	}
+	dir := t.TempDir()
+	ctx := &projectanalyzer.ProjectContext{
+		ProjectRoot: dir,
+		ParsingResult: &projectParser.ProjectParserResult{
+			Js_Data: map[string]projectParser.JsFileParserResult{
+				filepath.Join(dir, "src/index.ts"): {},
+			},
+		},
+	}
+
+	t.Run("返回发现项", func(t *testing.T) {
+		// 插件把收到的请求保存下来，并返回两个发现项
+		path := writePlugin(t, dir, "ok", `cat > request.json
+echo '{"protocolVersion":1,"findings":[
+  {"ruleId":"i18n/raw-text","severity":"error","message":"b","file":"src/b.ts","line":3,"column":1},
+  {"ruleId":"raw-text","message":"a","file":"src/a.ts","line":7,"column":2}
+]}'
+`)
+		analyzer := NewExternalAnalyzer(Plugin{Name: "i18n", Path: path}, 0)
+		if err := analyzer.Configure(map[string]string{"locale": "zh"}); err != nil {
+			t.Fatal(err)
+		}
+		res, err := analyzer.Analyze(ctx)
+		if err != nil {
+			t.Fatalf("Analyze() error = %v", err)
+		}
+		result := res.(*Result)
+		if len(result.Findings) != 2 || result.Findings[0].File != "src/a.ts" || result.Findings[0].Severity != "warning" {
+			t.Errorf("发现项应按文件排序且默认级别为 warning: %+v", result.Findings)
+		}
+		for _, finding := range result.NormalizedFindings() {
+			if finding.RuleID != "i18n/raw-text" {
+				t.Errorf("ruleId 应以插件名为前缀且不重复添加, 得到 %s", finding.RuleID)
+			}
+		}
+		if result.Summary() != "插件 i18n 报告了 2 个问题" {
+			t.Errorf("Summary() = %q", result.Summary())
+		}
+
+		request, err := os.ReadFile(filepath.Join(dir, "request.json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, want := range []string{`"protocolVersion":1`, `"analyzer":"i18n"`, `"locale":"zh"`, `"js_data"`} {
+			if !strings.Contains(string(request), want) {
+				t.Errorf("请求中应包含 %s: %s", want, request)
+			}
+		}
+	})
+
+	testCases := []struct {
+		name        string
+		script      string
+		errContains string
+	}{
+		{
+			name:        "进程以非零状态退出",
+			script:      "echo 'boom' >&2\nexit 3\n",
+			errContains: "boom",
+		},
+		{
+			name:        "响应不是 JSON",
+			script:      "cat > /dev/null\necho 'not json'\n",
+			errContains: "不是合法的 JSON",
+		},
+		{
+			name:        "协议版本不一致",
+			script:      "cat > /dev/null\necho '{\"protocolVersion\":2,\"findings\":[]}'\n",
+			errContains: "协议版本为 2",
+		},
+		{
+			name:        "插件报告错误",
+			script:      "cat > /dev/null\necho '{\"protocolVersion\":1,\"error\":\"缺少 locale 参数\"}'\n",
+			errContains: "缺少 locale 参数",
+		},
+		{
+			name:        "发现项的级别无效",
+			script:      "cat > /dev/null\necho '{\"protocolVersion\":1,\"findings\":[{\"ruleId\":\"broken/rule\",\"severity\":\"critical\",\"message\":\"a\"}]}'\n",
+			errContains: "级别 'critical' 无效",
+		},
+		{
+			name:        "发现项缺少 ruleId",
+			script:      "cat > /dev/null\necho '{\"protocolVersion\":1,\"findings\":[{\"message\":\"a\"}]}'\n",
+			errContains: "缺少 ruleId",
+		},
+	}
+	for i, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := writePlugin(t, dir, "case"+string(rune('a'+i)), tc.script)
+			_, err := NewExternalAnalyzer(Plugin{Name: "broken", Path: path}, 0).Analyze(ctx)
+			if err == nil || !strings.Contains(err.Error(), tc.errContains) {
+				t.Errorf("错误信息应包含 %q，实际为 %v", tc.errContains, err)
+			}
+		})
+	}
+
+	t.Run("插件超时", func(t *testing.T) {
+		// 插件不读取 stdin 也不退出
+		path := writePlugin(t, dir, "hang", "sleep 30\n")
+		start := time.Now()
+		_, err := NewExternalAnalyzer(Plugin{Name: "hang", Path: path}, 200*time.Millisecond).Analyze(ctx)
+		if err == nil || !strings.Contains(err.Error(), "执行超时") {
+			t.Errorf("应返回超时错误，实际为 %v", err)
+		}
+		if elapsed := time.Since(start); elapsed > 10*time.Second {
+			t.Errorf("超时后应立即返回，实际耗时 %s", elapsed)
+		}
+	})
+}
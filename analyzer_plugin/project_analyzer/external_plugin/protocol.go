@@ -0,0 +1,67 @@
+// Package external_plugin 实现进程外分析器插件：运行器发现外部可执行文件，
+// 通过 stdin/stdout 上的 JSON 协议与其通信，并把插件返回的发现项包装为 Result。
+//
+// 协议（版本 1）：
+//  1. 运行器启动插件进程，工作目录为项目根目录，环境变量 ANALYZER_TS_PROTOCOL_VERSION 为协议版本；
+//  2. 运行器向插件的 stdin 写入一个 Request JSON 对象后关闭 stdin；
+//  3. 插件向 stdout 写入一个 Response JSON 对象并以 0 退出，stderr 中的内容仅用于错误信息。
+package external_plugin
+
+import (
+	"github.com/Flying-Bird1999/analyzer-ts/analyzer/projectParser"
+)
+
+// ProtocolVersion 是当前的插件协议版本。
+// 请求与响应的字段只做向后兼容的增加，不兼容的修改必须提升版本号。
+const ProtocolVersion = 1
+
+// ProtocolVersionEnv 是向插件进程传递协议版本的环境变量名。
+const ProtocolVersionEnv = "ANALYZER_TS_PROTOCOL_VERSION"
+
+// Request 是运行器发送给插件的请求，即 ProjectContext 的 JSON 序列化。
+type Request struct {
+	// ProtocolVersion 是请求使用的协议版本。
+	ProtocolVersion int `json:"protocolVersion"`
+	// Analyzer 是插件在本次运行中的分析器名称。
+	Analyzer string `json:"analyzer"`
+	// Params 是传给插件的参数，与内置分析器 Configure 收到的参数相同。
+	Params map[string]string `json:"params"`
+	// Project 是项目上下文。
+	Project Project `json:"project"`
+}
+
+// Project 是 ProjectContext 中可以序列化的部分。
+// 解析结果不包含 AST，声明记录中保留原始源码（raw 字段）。
+type Project struct {
+	ProjectRoot   string                             `json:"projectRoot"`
+	Exclude       []string                           `json:"exclude"`
+	IsMonorepo    bool                               `json:"isMonorepo"`
+	ParsingResult *projectParser.ProjectParserResult `json:"parsingResult"`
+}
+
+// Response 是插件返回给运行器的响应。
+type Response struct {
+	// ProtocolVersion 是插件实现的协议版本，必须与请求的版本一致。
+	ProtocolVersion int `json:"protocolVersion"`
+	// Summary 是结果的文本摘要，为空时运行器根据发现项数量生成。
+	Summary string `json:"summary,omitempty"`
+	// Findings 是插件的发现项。
+	Findings []Finding `json:"findings"`
+	// Error 非空时表示插件分析失败，运行器将其作为分析器的错误返回。
+	Error string `json:"error,omitempty"`
+}
+
+// Finding 是插件报告的一条发现项。
+// File 是相对于项目根目录或绝对的文件路径，行号与列号从 1 开始，0 表示未知。
+type Finding struct {
+	RuleID    string `json:"ruleId"`             // 不以 "<插件名>/" 开头时由运行器加上该前缀
+	Severity  string `json:"severity,omitempty"` // error、warning 或 info，为空时视为 warning
+	Message   string `json:"message"`
+	File      string `json:"file,omitempty"`
+	Line      int    `json:"line,omitempty"`
+	Column    int    `json:"column,omitempty"`
+	EndLine   int    `json:"endLine,omitempty"`
+	EndColumn int    `json:"endColumn,omitempty"`
+	// Data 是插件附带的任意结构化数据，原样输出。
+	Data any `json:"data,omitempty"`
+}
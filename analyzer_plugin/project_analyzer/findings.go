@@ -0,0 +1,109 @@
+package project_analyzer
+
+import (
+	"sort"
+
+	"github.com/Flying-Bird1999/analyzer-ts/analyzer/parser"
+	"github.com/Flying-Bird1999/analyzer-ts/analyzer/projectParser"
+)
+
+// 发现项的严重程度。
+const (
+	SeverityError   = "error"   // 需要修复的问题，例如隐式依赖
+	SeverityWarning = "warning" // 建议处理的问题，例如未使用的导出
+	SeverityInfo    = "info"    // 仅供参考的信息，例如某个 API 的调用位置
+)
+
+// Finding 是与具体分析器无关的、统一格式的发现项。
+// File 是文件的绝对路径（或相对于项目根目录的路径），位置从 1 开始，0 表示未知；
+// End 位置是区间的结束位置（不包含），与 parser.SourceLocation 的约定一致。
+type Finding struct {
+	// RuleID 是规则标识，格式为 "<分析器名称>/<规则>"，例如 "count-any/any-usage"。
+	RuleID    string `json:"ruleId"`
+	Severity  string `json:"severity,omitempty"`
+	Message   string `json:"message"`
+	File      string `json:"file,omitempty"`
+	Line      int    `json:"line,omitempty"`
+	Column    int    `json:"column,omitempty"`
+	EndLine   int    `json:"endLine,omitempty"`
+	EndColumn int    `json:"endColumn,omitempty"`
+}
+
+// FindingsProvider 是 Result 可以选择实现的接口，用于把各分析器各自的结果结构
+// 转换为统一格式的发现项，供 SARIF 等通用输出使用。
+// 所有内置分析器的结果都实现了该接口；纯数据导出类的分析器（例如 md-file）返回空列表。
+type FindingsProvider interface {
+	// NormalizedFindings 返回结果中的所有发现项。
+	NormalizedFindings() []Finding
+}
+
+// FindingsOf 返回结果的发现项，结果未实现 FindingsProvider 时返回 false。
+// 发现项按文件、位置与规则排序，保证输出稳定。
+func FindingsOf(result Result) ([]Finding, bool) {
+	provider, ok := result.(FindingsProvider)
+	if !ok {
+		return nil, false
+	}
+	findings := provider.NormalizedFindings()
+	SortFindings(findings)
+	return findings, true
+}
+
+// CollectFindings 汇总所有实现了 FindingsProvider 的结果的发现项，并按文件与位置排序。
+func CollectFindings(results map[string]Result) []Finding {
+	var findings []Finding
+	for _, result := range results {
+		if resultFindings, ok := FindingsOf(result); ok {
+			findings = append(findings, resultFindings...)
+		}
+	}
+	SortFindings(findings)
+	return findings
+}
+
+// DiagnosticFindings 将解析过程的诊断信息转换为发现项，规则标识为 "diagnostics/<阶段>"。
+func DiagnosticFindings(diagnostics []projectParser.Diagnostic) []Finding {
+	findings := make([]Finding, 0, len(diagnostics))
+	for _, diagnostic := range diagnostics {
+		severity := SeverityWarning
+		if diagnostic.Severity == projectParser.DiagnosticSeverityError {
+			severity = SeverityError
+		}
+		findings = append(findings, Finding{
+			RuleID:   "diagnostics/" + diagnostic.Phase,
+			Severity: severity,
+			Message:  diagnostic.Message,
+			File:     diagnostic.File,
+		})
+	}
+	return findings
+}
+
+// SortFindings 按文件、位置与规则对发现项排序。
+func SortFindings(findings []Finding) {
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		if a.Column != b.Column {
+			return a.Column < b.Column
+		}
+		return a.RuleID < b.RuleID
+	})
+}
+
+// FindingAt 创建位于 location 处的发现项，location 为 nil 时只记录文件。
+func FindingAt(ruleID, severity, message, file string, location *parser.SourceLocation) Finding {
+	finding := Finding{RuleID: ruleID, Severity: severity, Message: message, File: file}
+	if location != nil {
+		finding.Line = location.Start.Line
+		finding.Column = location.Start.Column
+		finding.EndLine = location.End.Line
+		finding.EndColumn = location.End.Column
+	}
+	return finding
+}
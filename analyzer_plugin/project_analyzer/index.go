@@ -0,0 +1,121 @@
+package project_analyzer
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/Flying-Bird1999/analyzer-ts/analyzer/projectParser"
+)
+
+// =============================================================================
+// 共享索引
+// =============================================================================
+
+// IndexKey 标识 ProjectContext 上的一个派生索引，T 是索引的类型。
+// 同一个 ProjectContext 上，每个 IndexKey 的 build 函数最多执行一次，结果被所有分析器共享。
+//
+// 分析器可以用 NewIndexKey 定义自己的索引，通常作为包级变量：
+//
+//	var callerIndex = projectanalyzer.NewIndexKey("my-callers", func(ctx *projectanalyzer.ProjectContext) (map[string][]string, error) {
+//	    ...
+//	})
+//	callers, err := projectanalyzer.Index(ctx, callerIndex)
+type IndexKey[T any] struct {
+	name  string
+	build func(ctx *ProjectContext) (T, error)
+}
+
+// NewIndexKey 创建一个索引标识。name 用于错误信息，索引按 IndexKey 指针区分。
+func NewIndexKey[T any](name string, build func(ctx *ProjectContext) (T, error)) *IndexKey[T] {
+	return &IndexKey[T]{name: name, build: build}
+}
+
+// Name 返回索引的名称。
+func (k *IndexKey[T]) Name() string {
+	return k.name
+}
+
+// 预定义的共享索引，均在首次访问时根据 ctx.ParsingResult 构建。
+var (
+	// ImportGraphIndex 是项目内文件的正向导入关系图。
+	ImportGraphIndex = NewIndexKey("import-graph", func(ctx *ProjectContext) (projectParser.ImportGraph, error) {
+		return projectParser.BuildImportGraph(ctx.ParsingResult), nil
+	})
+	// ReverseImportGraphIndex 是反向导入关系图，key 是被导入的文件。
+	ReverseImportGraphIndex = NewIndexKey("reverse-import-graph", func(ctx *ProjectContext) (projectParser.ImportGraph, error) {
+		graph, err := Index(ctx, ImportGraphIndex)
+		if err != nil {
+			return nil, err
+		}
+		return graph.Reverse(), nil
+	})
+	// ExportTableIndex 是每个文件对外导出的符号表。
+	ExportTableIndex = NewIndexKey("export-table", func(ctx *ProjectContext) (projectParser.ExportTable, error) {
+		return projectParser.BuildExportTable(ctx.ParsingResult), nil
+	})
+)
+
+// Index 返回 ctx 上 key 对应的索引，首次访问时构建并缓存。可以被多个分析器并发调用。
+// 索引假定 ctx.ParsingResult 在首次访问后不再改变，返回的索引应当只读。
+// build 函数返回错误或发生 panic 时返回错误，之后对该索引的访问返回同一个错误，不会重新构建。
+func Index[T any](ctx *ProjectContext, key *IndexKey[T]) (T, error) {
+	entry := ctx.indexStore().entry(key)
+	entry.once.Do(func() {
+		defer func() {
+			if r := recover(); r != nil {
+				entry.err = fmt.Errorf("build index '%s' panicked: %v", key.name, r)
+			}
+		}()
+		value, err := key.build(ctx)
+		if err != nil {
+			entry.err = fmt.Errorf("build index '%s' failed: %w", key.name, err)
+			return
+		}
+		entry.value = value
+	})
+	if entry.err != nil {
+		var zero T
+		return zero, entry.err
+	}
+	return entry.value.(T), nil
+}
+
+// indexStore 缓存一个 ProjectContext 上已构建的索引。
+type indexStore struct {
+	mu      sync.Mutex
+	entries map[any]*indexEntry
+}
+
+// indexEntry 是一个索引的缓存项，once 保证索引只构建一次。构建失败时 err 不为 nil。
+type indexEntry struct {
+	once  sync.Once
+	value any
+	err   error
+}
+
+// entry 返回 key 对应的缓存项，不存在时创建。
+// 构建索引在 entry 的 once 中进行而不持有 store 的锁，因此索引的 build 函数可以访问其他索引。
+func (s *indexStore) entry(key any) *indexEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.entries[key]
+	if !ok {
+		entry = &indexEntry{}
+		s.entries[key] = entry
+	}
+	return entry
+}
+
+// contextStateMu 保护 ProjectContext 上索引与结果容器的延迟创建。
+// ProjectContext 通常以结构体字面量创建，因此容器无法在构造时初始化。
+var contextStateMu sync.Mutex
+
+// indexStore 返回 ctx 的索引缓存，不存在时创建。
+func (ctx *ProjectContext) indexStore() *indexStore {
+	contextStateMu.Lock()
+	defer contextStateMu.Unlock()
+	if ctx.indexes == nil {
+		ctx.indexes = &indexStore{entries: make(map[any]*indexEntry)}
+	}
+	return ctx.indexes
+}
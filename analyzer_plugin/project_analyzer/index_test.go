@@ -0,0 +1,113 @@
+package project_analyzer
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestIndexBuildsOnceUnderConcurrency(t *testing.T) {
+	var builds atomic.Int64
+	key := NewIndexKey("test-concurrent", func(ctx *ProjectContext) (map[string]int, error) {
+		builds.Add(1)
+		return map[string]int{"a": 1}, nil
+	})
+
+	ctx := &ProjectContext{}
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if index, err := Index(ctx, key); err != nil || index["a"] != 1 {
+				t.Errorf("Index() = %v, %v", index, err)
+			}
+		}()
+	}
+	wg.Wait()
+	if builds.Load() != 1 {
+		t.Errorf("并发访问时索引预期只构建 1 次, 实际构建了 %d 次", builds.Load())
+	}
+
+	// 不同的上下文各自构建索引
+	if _, err := Index(&ProjectContext{}, key); err != nil || builds.Load() != 2 {
+		t.Errorf("新的上下文应重新构建索引, 构建次数 %d, 错误 %v", builds.Load(), err)
+	}
+}
+
+func TestIndexBuildFailure(t *testing.T) {
+	testCases := []struct {
+		name      string
+		build     func(ctx *ProjectContext) (int, error)
+		errSubstr string
+	}{
+		{
+			name:      "构建函数返回错误",
+			build:     func(ctx *ProjectContext) (int, error) { return 0, errors.New("broken") },
+			errSubstr: "build index 'test-failure' failed: broken",
+		},
+		{
+			name:      "构建函数 panic",
+			build:     func(ctx *ProjectContext) (int, error) { panic("boom") },
+			errSubstr: "build index 'test-failure' panicked: boom",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var builds atomic.Int64
+			key := NewIndexKey("test-failure", func(ctx *ProjectContext) (int, error) {
+				builds.Add(1)
+				return tc.build(ctx)
+			})
+			ctx := &ProjectContext{}
+			// 再次访问返回同一个错误，不会重新构建，也不会因为缓存值为空而 panic
+			for i := 0; i < 2; i++ {
+				if _, err := Index(ctx, key); err == nil || !strings.Contains(err.Error(), tc.errSubstr) {
+					t.Fatalf("第 %d 次访问预期错误包含 %q, 得到 %v", i+1, tc.errSubstr, err)
+				}
+			}
+			if builds.Load() != 1 {
+				t.Errorf("构建失败的索引不应重新构建, 实际构建了 %d 次", builds.Load())
+			}
+		})
+	}
+}
+
+func TestIndexSharedAcrossAnalyzers(t *testing.T) {
+	var builds atomic.Int64
+	key := NewIndexKey("test-shared", func(ctx *ProjectContext) (int, error) {
+		builds.Add(1)
+		return 5, nil
+	})
+	useIndex := func(name string) *fakeAnalyzer {
+		return &fakeAnalyzer{name: name, analyze: func(ctx *ProjectContext) (Result, error) {
+			value, err := Index(ctx, key)
+			if err != nil {
+				return nil, err
+			}
+			return &fakeResult{name: name, value: value}, nil
+		}}
+	}
+
+	p := newTestProjectAnalyzer()
+	config := &ExecutionConfig{Analyzers: []*AnalyzerWithConfig{
+		{Analyzer: useIndex("test-index-a")},
+		{Analyzer: useIndex("test-index-b")},
+	}}
+	// 同一个 ProjectAnalyzer 的多次运行共享索引
+	for i := 0; i < 2; i++ {
+		results, err := p.ExecuteWithConfig(config)
+		if err != nil {
+			t.Fatalf("ExecuteWithConfig() error: %v", err)
+		}
+		if len(results) != 2 {
+			t.Fatalf("预期 2 个结果, 得到 %d 个", len(results))
+		}
+	}
+	if builds.Load() != 1 {
+		t.Errorf("索引预期在多个分析器与多次运行之间只构建 1 次, 实际构建了 %d 次", builds.Load())
+	}
+}
@@ -1,9 +1,10 @@
-// Package md_plugin 实现列出项目中 Markdown 文件的分析器
+// Package md_plugin 实现列出项目中 Markdown/MDX 文件及其代码块的分析器
 package md_plugin
 
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 
 	"github.com/Flying-Bird1999/analyzer-ts/analyzer/projectParser"
 	projectanalyzer "github.com/Flying-Bird1999/analyzer-ts/analyzer_plugin/project_analyzer"
@@ -21,6 +22,14 @@ func (m *MdFile) Configure(params map[string]string) error {
 	return nil
 }
 
+func (m *MdFile) RequiredParseOptions() projectParser.ParseOptions {
+	return projectParser.LowMemoryParseOptions()
+}
+
+func (m *MdFile) ParamSchema() projectanalyzer.ParamSchema {
+	return projectanalyzer.ObjectParams(nil)
+}
+
 func (m *MdFile) Analyze(ctx *projectanalyzer.ProjectContext) (projectanalyzer.Result, error) {
 	return &MdFileResult{
 		MdData: ctx.ParsingResult.Md_Data,
@@ -39,7 +48,11 @@ func (r *MdFileResult) Name() string {
 }
 
 func (r *MdFileResult) Summary() string {
-	return fmt.Sprintf("%d 个 Markdown 文件", len(r.MdData))
+	blocks := 0
+	for _, info := range r.MdData {
+		blocks += len(info.CodeBlocks)
+	}
+	return fmt.Sprintf("%d 个 Markdown 文件，%d 个代码块", len(r.MdData), blocks)
 }
 
 // MarshalJSON 实现 json.Marshaler 接口，直接输出文件路径 map
@@ -55,9 +68,18 @@ func (r *MdFileResult) ToJSON(indent bool) ([]byte, error) {
 }
 
 func (r *MdFileResult) ToConsole() string {
-	var s string
+	paths := make([]string, 0, len(r.MdData))
 	for path := range r.MdData {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var s string
+	for _, path := range paths {
 		s += fmt.Sprintf("%s\n", path)
+		for _, block := range r.MdData[path].CodeBlocks {
+			s += fmt.Sprintf("  %s 代码块 (第 %d-%d 行)\n", block.Lang, block.SourceLocation.Start.Line, block.SourceLocation.End.Line)
+		}
 	}
 	return s
 }
@@ -67,6 +89,13 @@ func (r *MdFileResult) AnalyzerName() string {
 	return "md-file"
 }
 
+var _ projectanalyzer.FindingsProvider = (*MdFileResult)(nil)
+
+// NormalizedFindings md-file 只导出代码块数据，没有发现项
+func (r *MdFileResult) NormalizedFindings() []projectanalyzer.Finding {
+	return nil
+}
+
 // init 在包加载时自动注册分析器
 func init() {
 	projectanalyzer.RegisterAnalyzer("md-file", func() projectanalyzer.Analyzer {
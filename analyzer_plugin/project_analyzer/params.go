@@ -0,0 +1,415 @@
+package project_analyzer
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// =============================================================================
+// 分析器参数描述与校验
+// =============================================================================
+
+// 参数的类型，取值与 JSON Schema 的 type 关键字一致。
+const (
+	ParamTypeString  = "string"
+	ParamTypeBoolean = "boolean"
+	ParamTypeInteger = "integer"
+	ParamTypeNumber  = "number"
+	ParamTypeArray   = "array"
+	ParamTypeObject  = "object"
+)
+
+// ParamSchema 以 JSON Schema 的子集描述分析器接受的参数。
+// 分析器的顶层 schema 是 object 类型，Properties 中的每一项对应 Configure 收到的一个参数。
+type ParamSchema struct {
+	// Type 是参数的类型，取值见 ParamType* 常量。
+	Type string `json:"type"`
+	// Description 是参数的说明，用于 `analyze --describe` 的输出。
+	Description string `json:"description,omitempty"`
+	// Default 是未提供参数时分析器使用的默认值，仅用于说明，不会自动填充。
+	Default any `json:"default,omitempty"`
+	// Enum 限定 string 类型参数的取值范围。
+	Enum []string `json:"enum,omitempty"`
+	// Items 是 array 类型参数中元素的 schema。
+	Items *ParamSchema `json:"items,omitempty"`
+	// Properties 是 object 类型参数的子参数。
+	Properties map[string]*ParamSchema `json:"properties,omitempty"`
+	// Required 是 object 类型参数中必须提供的子参数。
+	Required []string `json:"required,omitempty"`
+}
+
+// ParamDescriber 是分析器可以选择实现的接口，用于描述其接受的参数。
+// 实现了该接口的分析器，来自配置文件与命令行的参数会先按 schema 校验：
+// 未知的参数、类型不符与缺少必需参数都会报错，而不是被静默忽略。
+// 不接受任何参数的分析器应当返回没有 Properties 的 ObjectParams()。
+type ParamDescriber interface {
+	// ParamSchema 返回分析器参数的 schema，类型必须是 object。
+	ParamSchema() ParamSchema
+}
+
+// ObjectParams 创建 object 类型的 schema，required 是必须提供的参数。
+func ObjectParams(properties map[string]*ParamSchema, required ...string) ParamSchema {
+	return ParamSchema{Type: ParamTypeObject, Properties: properties, Required: required}
+}
+
+// StringParam 创建 string 类型的参数。
+func StringParam(description string) *ParamSchema {
+	return &ParamSchema{Type: ParamTypeString, Description: description}
+}
+
+// BoolParam 创建 boolean 类型的参数。
+func BoolParam(description string, defaultValue bool) *ParamSchema {
+	return &ParamSchema{Type: ParamTypeBoolean, Description: description, Default: defaultValue}
+}
+
+// StringListParam 创建元素为 string 的 array 类型参数。
+// 命令行中可以多次传入同一参数，或使用逗号分隔多个值。
+func StringListParam(description string) *ParamSchema {
+	return &ParamSchema{Type: ParamTypeArray, Description: description, Items: &ParamSchema{Type: ParamTypeString}}
+}
+
+// ParamSchemaOf 返回分析器的参数 schema，未实现 ParamDescriber 时返回 false。
+func ParamSchemaOf(analyzer Analyzer) (ParamSchema, bool) {
+	if describer, ok := analyzer.(ParamDescriber); ok {
+		return describer.ParamSchema(), true
+	}
+	return ParamSchema{}, false
+}
+
+// ParamError 描述一个参数校验错误。
+type ParamError struct {
+	// Path 是参数的路径，例如 "trace.targetPkgs" 或 "my-analyzer.options.depth"。
+	Path string
+	// Message 是错误说明。
+	Message string
+}
+
+func (e ParamError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// ParamErrors 是一组参数校验错误。
+type ParamErrors []ParamError
+
+func (errs ParamErrors) Error() string {
+	messages := make([]string, 0, len(errs))
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+	return fmt.Sprintf("参数校验失败:\n  - %s", strings.Join(messages, "\n  - "))
+}
+
+// ValidateParams 按 schema 校验分析器的参数，name 作为错误路径的前缀。
+// values 可以来自 JSON/YAML 配置文件，也可以是 CoerceParams 转换后的命令行参数。
+// 所有错误会一次性返回，类型为 ParamErrors。
+func ValidateParams(name string, schema ParamSchema, values map[string]any) error {
+	var errs ParamErrors
+	validateObject(name, &schema, values, &errs)
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// validateObject 校验 object 类型的值。
+func validateObject(path string, schema *ParamSchema, values map[string]any, errs *ParamErrors) {
+	for _, key := range sortedParamKeys(values) {
+		propertySchema, ok := schema.Properties[key]
+		if !ok {
+			*errs = append(*errs, ParamError{Path: path + "." + key, Message: unknownParamMessage(key, schema)})
+			continue
+		}
+		validateValue(path+"."+key, propertySchema, values[key], errs)
+	}
+	for _, key := range schema.Required {
+		if _, ok := values[key]; !ok {
+			*errs = append(*errs, ParamError{Path: path + "." + key, Message: "缺少必需参数"})
+		}
+	}
+}
+
+// validateValue 校验单个值是否符合 schema。
+func validateValue(path string, schema *ParamSchema, value any, errs *ParamErrors) {
+	mismatch := func() {
+		*errs = append(*errs, ParamError{Path: path, Message: fmt.Sprintf("类型应为 %s，实际为 %s", describeType(schema), jsonTypeOf(value))})
+	}
+
+	switch schema.Type {
+	case ParamTypeString:
+		s, ok := value.(string)
+		if !ok {
+			mismatch()
+			return
+		}
+		if len(schema.Enum) > 0 && !lo.Contains(schema.Enum, s) {
+			*errs = append(*errs, ParamError{Path: path, Message: fmt.Sprintf("取值 %q 无效，可选值为 %s", s, strings.Join(schema.Enum, ", "))})
+		}
+	case ParamTypeBoolean:
+		if _, ok := value.(bool); !ok {
+			mismatch()
+		}
+	case ParamTypeInteger:
+		if n, ok := toFloat(value); !ok || n != math.Trunc(n) {
+			mismatch()
+		}
+	case ParamTypeNumber:
+		if _, ok := toFloat(value); !ok {
+			mismatch()
+		}
+	case ParamTypeArray:
+		items, ok := toSlice(value)
+		if !ok {
+			mismatch()
+			return
+		}
+		if schema.Items != nil {
+			for i, item := range items {
+				validateValue(fmt.Sprintf("%s[%d]", path, i), schema.Items, item, errs)
+			}
+		}
+	case ParamTypeObject:
+		object, ok := value.(map[string]any)
+		if !ok {
+			mismatch()
+			return
+		}
+		validateObject(path, schema, object, errs)
+	}
+}
+
+// ValidateConfigParams 按分析器的 schema 校验以 map[string]string 形式传给 Configure 的参数，
+// 用于 ExecuteWithConfig 与 RunOneT 等 Go API。未实现 ParamDescriber 的分析器不做校验。
+func ValidateConfigParams(analyzer Analyzer, params map[string]string) error {
+	schema, ok := ParamSchemaOf(analyzer)
+	if !ok {
+		return nil
+	}
+	raw := make(map[string][]string, len(params))
+	for key, value := range params {
+		raw[key] = []string{value}
+	}
+	values, err := CoerceParams(analyzer.Name(), schema, raw)
+	if err != nil {
+		return err
+	}
+	return ValidateParams(analyzer.Name(), schema, values)
+}
+
+// CoerceParams 按 schema 将命令行中 `-p analyzer.key=value` 形式的字符串参数转换为带类型的值，
+// 转换结果再交给 ValidateParams 校验。同一参数出现多次时，array 参数合并所有值（每个值还可以用逗号分隔），
+// 其他类型以逗号连接后转换。schema 中不存在的参数保留为字符串，由 ValidateParams 报告为未知参数。
+func CoerceParams(name string, schema ParamSchema, raw map[string][]string) (map[string]any, error) {
+	values := make(map[string]any, len(raw))
+	var errs ParamErrors
+	for _, key := range sortedParamKeys(raw) {
+		joined := strings.Join(raw[key], ",")
+		propertySchema, ok := schema.Properties[key]
+		if !ok {
+			values[key] = joined
+			continue
+		}
+		value, err := coerceString(propertySchema, raw[key])
+		if err != nil {
+			errs = append(errs, ParamError{Path: name + "." + key, Message: fmt.Sprintf("无法将 %q 转换为 %s: %v", joined, describeType(propertySchema), err)})
+			continue
+		}
+		values[key] = value
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return values, nil
+}
+
+// coerceString 将命令行中的字符串值转换为 schema 对应的类型。
+func coerceString(schema *ParamSchema, raw []string) (any, error) {
+	joined := strings.Join(raw, ",")
+	switch schema.Type {
+	case ParamTypeBoolean:
+		return strconv.ParseBool(strings.TrimSpace(joined))
+	case ParamTypeInteger:
+		return strconv.Atoi(strings.TrimSpace(joined))
+	case ParamTypeNumber:
+		return strconv.ParseFloat(strings.TrimSpace(joined), 64)
+	case ParamTypeArray:
+		items := make([]any, 0, len(raw))
+		for _, value := range raw {
+			for _, part := range strings.Split(value, ",") {
+				part = strings.TrimSpace(part)
+				if part == "" {
+					continue
+				}
+				if schema.Items == nil {
+					items = append(items, part)
+					continue
+				}
+				item, err := coerceString(schema.Items, []string{part})
+				if err != nil {
+					return nil, err
+				}
+				items = append(items, item)
+			}
+		}
+		return items, nil
+	case ParamTypeObject:
+		var object map[string]any
+		if err := json.Unmarshal([]byte(joined), &object); err != nil {
+			return nil, fmt.Errorf("object 参数需要是 JSON 对象: %w", err)
+		}
+		return object, nil
+	default:
+		return joined, nil
+	}
+}
+
+// FormatParams 将带类型的参数转换为 Configure 接受的 map[string]string：
+// array 以逗号连接，object 序列化为 JSON，其余类型转换为字面量文本。
+func FormatParams(values map[string]any) map[string]string {
+	params := make(map[string]string, len(values))
+	for key, value := range values {
+		params[key] = formatParam(value)
+	}
+	return params
+}
+
+// formatParam 将单个参数值转换为字符串。
+func formatParam(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case nil:
+		return ""
+	}
+	if n, ok := toFloat(value); ok {
+		return strconv.FormatFloat(n, 'f', -1, 64)
+	}
+	if items, ok := toSlice(value); ok {
+		parts := make([]string, 0, len(items))
+		for _, item := range items {
+			parts = append(parts, formatParam(item))
+		}
+		return strings.Join(parts, ",")
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+// unknownParamMessage 生成未知参数的错误说明，参数名与已知参数相近时给出建议。
+func unknownParamMessage(key string, schema *ParamSchema) string {
+	if len(schema.Properties) == 0 {
+		return "未知参数，该分析器不接受任何参数"
+	}
+	known := sortedParamKeys(schema.Properties)
+	best, bestDistance := "", len(key)/2+1
+	for _, candidate := range known {
+		if distance := editDistance(strings.ToLower(key), strings.ToLower(candidate)); distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+	if best != "" {
+		return fmt.Sprintf("未知参数，是否想使用 '%s'？", best)
+	}
+	return fmt.Sprintf("未知参数，可用参数为 %s", strings.Join(known, ", "))
+}
+
+// editDistance 计算两个字符串的编辑距离，用于为拼写错误的参数名给出建议。
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current := make([]int, len(rb)+1)
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+	return previous[len(rb)]
+}
+
+// describeType 返回 schema 类型的可读描述，例如 "array<string>"。
+func describeType(schema *ParamSchema) string {
+	if schema.Type == ParamTypeArray && schema.Items != nil {
+		return fmt.Sprintf("array<%s>", schema.Items.Type)
+	}
+	return schema.Type
+}
+
+// jsonTypeOf 返回值在 JSON Schema 中对应的类型名，用于错误信息。
+func jsonTypeOf(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return ParamTypeString
+	case bool:
+		return ParamTypeBoolean
+	case map[string]any:
+		return ParamTypeObject
+	}
+	if n, ok := toFloat(value); ok {
+		if n == math.Trunc(n) {
+			return ParamTypeInteger
+		}
+		return ParamTypeNumber
+	}
+	if _, ok := toSlice(value); ok {
+		return ParamTypeArray
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// toFloat 将 JSON/YAML 解码出的各种数字类型统一转换为 float64。
+func toFloat(value any) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
+
+// toSlice 将 []any 与 []string 统一转换为 []any。
+func toSlice(value any) ([]any, bool) {
+	switch v := value.(type) {
+	case []any:
+		return v, true
+	case []string:
+		items := make([]any, len(v))
+		for i, s := range v {
+			items[i] = s
+		}
+		return items, true
+	}
+	return nil, false
+}
+
+// sortedParamKeys 返回 map 的 key 并排序，保证错误信息的顺序稳定。
+func sortedParamKeys[V any](m map[string]V) []string {
+	keys := lo.Keys(m)
+	sort.Strings(keys)
+	return keys
+}
@@ -3,6 +3,8 @@ package pkg_deps
 
 import (
 	"fmt"
+	"path"
+	"strings"
 
 	"github.com/Flying-Bird1999/analyzer-ts/analyzer/projectParser"
 	projectanalyzer "github.com/Flying-Bird1999/analyzer-ts/analyzer_plugin/project_analyzer"
@@ -28,15 +30,26 @@ func (l *PkgDepsAnalyzer) Configure(params map[string]string) error {
 	return nil
 }
 
+func (l *PkgDepsAnalyzer) RequiredParseOptions() projectParser.ParseOptions {
+	return projectParser.LowMemoryParseOptions()
+}
+
+func (l *PkgDepsAnalyzer) ParamSchema() projectanalyzer.ParamSchema {
+	return projectanalyzer.ObjectParams(nil)
+}
+
 func (l *PkgDepsAnalyzer) Analyze(ctx *projectanalyzer.ProjectContext) (projectanalyzer.Result, error) {
 	return &PkgDepsResult{
-		PackageData: ctx.ParsingResult.Package_Data,
+		PackageData:    ctx.ParsingResult.Package_Data,
+		WorkspaceGraph: ctx.ParsingResult.WorkspaceGraph,
 	}, nil
 }
 
 // PkgDepsResult NPM 依赖列表分析结果
 type PkgDepsResult struct {
 	PackageData map[string]projectParser.PackageJsonFileParserResult `json:"packageData"`
+	// WorkspaceGraph 是 workspace 包之间的依赖关系图，非 monorepo 项目为 nil
+	WorkspaceGraph *projectParser.WorkspaceGraph `json:"workspaceGraph,omitempty"`
 }
 
 var _ projectanalyzer.Result = (*PkgDepsResult)(nil)
@@ -46,6 +59,9 @@ func (r *PkgDepsResult) Name() string {
 }
 
 func (r *PkgDepsResult) Summary() string {
+	if r.WorkspaceGraph != nil {
+		return fmt.Sprintf("%d 个 package.json，%d 个 workspace 包", len(r.PackageData), len(r.WorkspaceGraph.Packages))
+	}
 	return fmt.Sprintf("%d 个 package.json", len(r.PackageData))
 }
 
@@ -61,9 +77,45 @@ func (r *PkgDepsResult) ToConsole() string {
 			s += fmt.Sprintf("  %s@%s\n", name, dep.Version)
 		}
 	}
+	if r.WorkspaceGraph != nil {
+		s += "\nworkspace 依赖图:\n"
+		for _, pkg := range r.WorkspaceGraph.Packages {
+			s += fmt.Sprintf("  %s (%s)\n", pkg.Name, pkg.Workspace)
+			for _, dep := range pkg.Dependencies {
+				s += fmt.Sprintf("    → %s\n", dep)
+			}
+		}
+		s += fmt.Sprintf("拓扑顺序: %s\n", strings.Join(r.WorkspaceGraph.TopologicalOrder, " → "))
+		if len(r.WorkspaceGraph.CyclicPackages) > 0 {
+			s += fmt.Sprintf("循环依赖: %s\n", strings.Join(r.WorkspaceGraph.CyclicPackages, ", "))
+		}
+	}
 	return s
 }
 
 func (r *PkgDepsResult) AnalyzerName() string {
 	return "pkg-deps"
 }
+
+var _ projectanalyzer.FindingsProvider = (*PkgDepsResult)(nil)
+
+// NormalizedFindings 处于循环依赖中的 workspace 包在其 package.json 上报告为 warning
+func (r *PkgDepsResult) NormalizedFindings() []projectanalyzer.Finding {
+	if r.WorkspaceGraph == nil {
+		return nil
+	}
+	workspaces := make(map[string]string, len(r.WorkspaceGraph.Packages))
+	for _, pkg := range r.WorkspaceGraph.Packages {
+		workspaces[pkg.Name] = pkg.Workspace
+	}
+	findings := make([]projectanalyzer.Finding, 0, len(r.WorkspaceGraph.CyclicPackages))
+	for _, name := range r.WorkspaceGraph.CyclicPackages {
+		findings = append(findings, projectanalyzer.Finding{
+			RuleID:   "pkg-deps/workspace-cycle",
+			Severity: projectanalyzer.SeverityWarning,
+			Message:  fmt.Sprintf("workspace 包 %s 处于循环依赖中（或依赖了循环依赖中的包），无法确定构建顺序", name),
+			File:     path.Join(workspaces[name], "package.json"),
+		})
+	}
+	return findings
+}
@@ -55,6 +55,26 @@ type Analyzer interface {
 	Analyze(ctx *ProjectContext) (Result, error)
 }
 
+// ParseRequirer 是分析器可以选择实现的接口，用于声明其对解析结果中 AST 与原始源码的需求。
+// 运行器会合并所有待运行分析器的需求，选择满足它们的、最省内存的解析模式。
+//
+// 未实现该接口的分析器被视为需要完整的解析结果（DefaultParseOptions），
+// 只使用 Js_Data 中声明记录的分析器应当返回 projectParser.LowMemoryParseOptions()。
+type ParseRequirer interface {
+	// RequiredParseOptions 返回分析器所需的解析选项。
+	RequiredParseOptions() projectParser.ParseOptions
+}
+
+// DependentAnalyzer 是分析器可以选择实现的接口，用于声明它依赖其他分析器的结果。
+// 运行器会按依赖关系对分析器做拓扑排序，先运行被依赖的分析器，
+// 并把结果放入 ProjectContext，分析器通过 DependencyResult 读取，而不必重新计算。
+//
+// 未被显式要求运行的依赖会从注册表中创建并以默认配置运行，依赖关系存在环时运行失败。
+type DependentAnalyzer interface {
+	// DependsOn 返回所依赖的分析器名称，可以是注册名或 Name() 的返回值。
+	DependsOn() []string
+}
+
 // Result 是所有分析结果都必须实现的接口。
 // 这个接口定义了分析结果的标准格式和输出方式。
 //
@@ -117,12 +137,36 @@ type ProjectContext struct {
 	// 这个数据由 projectParser 模块生成，包含了所有 TypeScript/TSX 文件的解析结果。
 	// 这是所有分析器的核心数据源。
 	ParsingResult *projectParser.ProjectParserResult
+
+	// indexes 缓存基于 ParsingResult 派生的共享索引，通过 Index 访问。
+	indexes *indexStore
+
+	// results 保存本次运行中已完成的分析器的结果，通过 DependencyResult 访问。
+	results *resultStore
 }
 
 // =============================================================================
 // 辅助函数
 // =============================================================================
 
+// RequiredParseOptions 返回分析器所需的解析选项，未实现 ParseRequirer 的分析器需要完整的解析结果。
+func RequiredParseOptions(analyzer Analyzer) projectParser.ParseOptions {
+	if requirer, ok := analyzer.(ParseRequirer); ok {
+		return requirer.RequiredParseOptions()
+	}
+	return projectParser.DefaultParseOptions()
+}
+
+// ParseOptionsFor 合并一组分析器的需求，返回能满足所有分析器的、最省内存的解析选项。
+// 没有任何分析器时返回 LowMemoryParseOptions。
+func ParseOptionsFor(analyzers []Analyzer) projectParser.ParseOptions {
+	options := projectParser.LowMemoryParseOptions()
+	for _, analyzer := range analyzers {
+		options = options.Merge(RequiredParseOptions(analyzer))
+	}
+	return options
+}
+
 // ToJSONBytes 是一个辅助函数，用于简化各种 Result 类型对 ToJSON 方法的实现。
 // 提供了标准的JSON序列化功能，支持格式化输出。
 //
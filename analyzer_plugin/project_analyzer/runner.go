@@ -37,19 +37,25 @@ const (
 )
 
 // analyzerRegistry 分析器注册表（名称 -> 工厂函数）
+// names 记录每个注册名对应的 Name() 返回值，两者可能不同（例如 "unconsumed"），注册时计算一次
 var analyzerRegistry = struct {
 	sync.RWMutex
 	factories map[string]func() Analyzer
+	names     map[string]string
 }{
 	factories: make(map[string]func() Analyzer),
+	names:     make(map[string]string),
 }
 
 // RegisterAnalyzer 注册分析器工厂函数
 // 各个 analyzer 包在 init() 时调用此方法注册自己
 func RegisterAnalyzer(name string, factory func() Analyzer) {
+	analyzerName := factory().Name()
+
 	analyzerRegistry.Lock()
 	defer analyzerRegistry.Unlock()
 	analyzerRegistry.factories[name] = factory
+	analyzerRegistry.names[name] = analyzerName
 }
 
 // String 实现 Stringer 接口
@@ -231,6 +237,18 @@ type Config struct {
 	Exclude []string
 	// 是否为 monorepo（可选，默认 false）
 	IsMonorepo bool
+	// 并发解析文件的 worker 数量（可选，默认 CPU 核数）
+	Jobs int
+	// 持久化解析缓存目录（可选，为空则不启用）
+	CacheDir string
+	// 是否遵循 .gitignore、.git/info/exclude 与 .analyzerignore（可选，默认 false）
+	Gitignore bool
+	// 严格模式：解析过程中出现 error 级别的诊断信息时 NewProjectAnalyzer 返回错误（可选，默认 false）
+	Strict bool
+	// 解析选项，决定是否保留 AST 与原始源码（可选，为 nil 时根据 Analyzers 选择，两者都未设置则全部保留）
+	ParseOptions *projectParser.ParseOptions
+	// 预计要运行的分析器（可选）。未设置 ParseOptions 时，按这些分析器声明的需求选择最省内存的解析模式
+	Analyzers []AnalyzerType
 }
 
 // AnalyzerWithConfig 带配置的分析器包装（内部使用）
@@ -258,6 +276,14 @@ type ProjectAnalyzer struct {
 	Exclude []string
 	// 是否为 monorepo
 	IsMonorepo bool
+	// 并发解析文件的 worker 数量
+	Jobs int
+	// 持久化解析缓存目录
+	CacheDir string
+	// 是否遵循 .gitignore 等忽略文件
+	Gitignore bool
+	// 解析选项
+	ParseOptions projectParser.ParseOptions
 	// 已注册的分析器
 	analyzers map[string]Analyzer
 	mu        sync.RWMutex
@@ -287,15 +313,29 @@ func NewProjectAnalyzer(config Config) (*ProjectAnalyzer, error) {
 		return nil, fmt.Errorf("project root does not exist: %s", absPath)
 	}
 
+	parseOptions, err := resolveParseOptions(config)
+	if err != nil {
+		return nil, err
+	}
+
 	analyzer := &ProjectAnalyzer{
-		ProjectRoot: absPath,
-		Exclude:     config.Exclude,
-		IsMonorepo:  config.IsMonorepo,
-		analyzers:   make(map[string]Analyzer),
+		ProjectRoot:  absPath,
+		Exclude:      config.Exclude,
+		IsMonorepo:   config.IsMonorepo,
+		Jobs:         config.Jobs,
+		CacheDir:     config.CacheDir,
+		Gitignore:    config.Gitignore,
+		ParseOptions: parseOptions,
+		analyzers:    make(map[string]Analyzer),
 	}
 
 	// 立即解析项目（耗时操作）
 	pr := analyzer.parseInternal()
+	if config.Strict {
+		if err := pr.Result.CheckStrict(); err != nil {
+			return nil, err
+		}
+	}
 
 	// 创建并缓存分析上下文
 	analyzer.context = &ProjectContext{
@@ -308,6 +348,43 @@ func NewProjectAnalyzer(config Config) (*ProjectAnalyzer, error) {
 	return analyzer, nil
 }
 
+// resolveParseOptions 根据配置决定项目的解析选项：
+// 显式指定的 ParseOptions 优先，其次按 Analyzers 中各分析器声明的需求合并，都未设置时全部保留。
+func resolveParseOptions(config Config) (projectParser.ParseOptions, error) {
+	if config.ParseOptions != nil {
+		return *config.ParseOptions, nil
+	}
+	if len(config.Analyzers) == 0 {
+		return projectParser.DefaultParseOptions(), nil
+	}
+
+	analyzers := make([]Analyzer, 0, len(config.Analyzers))
+	for _, analyzerType := range config.Analyzers {
+		analyzerRegistry.RLock()
+		factory, ok := analyzerRegistry.factories[string(analyzerType)]
+		analyzerRegistry.RUnlock()
+		if !ok {
+			return projectParser.ParseOptions{}, fmt.Errorf("analyzer '%s' not registered", analyzerType)
+		}
+		analyzers = append(analyzers, factory())
+	}
+	// 自动加入的依赖同样参与解析模式的选择
+	analyzers, err := ResolveDependencies(analyzers)
+	if err != nil {
+		return projectParser.ParseOptions{}, err
+	}
+	return ParseOptionsFor(analyzers), nil
+}
+
+// checkParseOptions 检查项目的解析选项是否满足分析器的需求。
+func (p *ProjectAnalyzer) checkParseOptions(analyzer Analyzer) error {
+	required := RequiredParseOptions(analyzer)
+	if !p.ParseOptions.Satisfies(required) {
+		return fmt.Errorf("analyzer '%s' requires parse options %+v, but the project was parsed with %+v", analyzer.Name(), required, p.ParseOptions)
+	}
+	return nil
+}
+
 // =============================================================================
 // 执行方法
 // =============================================================================
@@ -341,6 +418,11 @@ func (p *ProjectAnalyzer) ExecuteWithConfig(config *ExecutionConfig) (map[string
 		}
 		seenNames[name] = true
 
+		// 检查解析模式是否满足分析器的需求
+		if err := p.checkParseOptions(awc.Analyzer); err != nil {
+			return nil, err
+		}
+
 		// 注册
 		if err := p.registerAnalyzer(awc.Analyzer); err != nil {
 			return nil, err
@@ -390,59 +472,106 @@ func (p *ProjectAnalyzer) getAnalyzer(name string) (Analyzer, bool) {
 }
 
 // runBatch 批量运行多个分析器（内部方法）
+// 分析器按依赖关系分层执行：同一层内并发执行，被依赖但未指定的分析器会以默认配置自动加入
 func (p *ProjectAnalyzer) runBatch(configs map[string]map[string]string) (map[string]Result, error) {
 	if len(configs) == 0 {
 		return nil, fmt.Errorf("no analyzers specified")
 	}
 
-	// 使用已缓存的上下文（在 NewProjectAnalyzer 中已解析）
-	ctx := p.context
-
-	// 并发执行所有分析器
-	results := make(map[string]Result)
-	var mu sync.Mutex
-	var wg sync.WaitGroup
-	errChan := make(chan error, len(configs))
+	// 使用已缓存的上下文（在 NewProjectAnalyzer 中已解析），每次运行使用独立的结果容器
+	ctx := p.context.forRun()
 
-	for analyzerName, config := range configs {
+	analyzers := make([]Analyzer, 0, len(configs))
+	for analyzerName := range configs {
 		// 获取分析器
 		analyzer, ok := p.getAnalyzer(analyzerName)
 		if !ok {
 			return nil, fmt.Errorf("analyzer '%s' not found", analyzerName)
 		}
+		analyzers = append(analyzers, analyzer)
+	}
 
-		wg.Add(1)
-		go func(name string, a Analyzer, cfg map[string]string) {
-			defer wg.Done()
+	levels, err := p.scheduleAnalyzers(analyzers)
+	if err != nil {
+		return nil, err
+	}
 
-			// 配置分析器
-			if cfg != nil && len(cfg) > 0 {
-				if err := a.Configure(cfg); err != nil {
+	return runLevels(ctx, levels, configs)
+}
+
+// scheduleAnalyzers 补全分析器的依赖并按依赖关系分层（内部方法）
+func (p *ProjectAnalyzer) scheduleAnalyzers(analyzers []Analyzer) ([][]Analyzer, error) {
+	resolved, err := ResolveDependencies(analyzers)
+	if err != nil {
+		return nil, err
+	}
+
+	// 自动加入的依赖同样需要满足解析模式。它们只参与本次运行，不注册到 p.analyzers 中
+	for _, analyzer := range resolved[len(analyzers):] {
+		if err := p.checkParseOptions(analyzer); err != nil {
+			return nil, err
+		}
+	}
+
+	return OrderAnalyzers(resolved)
+}
+
+// runLevels 按层依次执行分析器，同一层内并发执行
+// 每个分析器的结果都会写入 ctx，供后续层中依赖它的分析器读取
+func runLevels(ctx *ProjectContext, levels [][]Analyzer, configs map[string]map[string]string) (map[string]Result, error) {
+	results := make(map[string]Result)
+	var mu sync.Mutex
+	var errs []error
+
+	for _, level := range levels {
+		var wg sync.WaitGroup
+		errChan := make(chan error, len(level))
+
+		for _, analyzer := range level {
+			wg.Add(1)
+			go func(name string, a Analyzer, cfg map[string]string) {
+				defer wg.Done()
+
+				// 按分析器声明的 schema 校验参数
+				if err := ValidateConfigParams(a, cfg); err != nil {
+					// 自动加入的依赖没有配置，缺少必需参数时提示调用方显式指定该分析器及其配置
+					if _, requested := configs[name]; !requested {
+						errChan <- fmt.Errorf("analyzer '%s' was added as a dependency but needs params, add it with its config explicitly: %w", name, err)
+						return
+					}
 					errChan <- fmt.Errorf("configure analyzer '%s' failed: %w", name, err)
 					return
 				}
-			}
 
-			// 执行分析
-			result, err := a.Analyze(ctx)
-			if err != nil {
-				errChan <- fmt.Errorf("analyze '%s' failed: %w", name, err)
-				return
-			}
+				// 配置分析器
+				if cfg != nil && len(cfg) > 0 {
+					if err := a.Configure(cfg); err != nil {
+						errChan <- fmt.Errorf("configure analyzer '%s' failed: %w", name, err)
+						return
+					}
+				}
 
-			mu.Lock()
-			results[name] = result
-			mu.Unlock()
-		}(analyzerName, analyzer, config)
-	}
+				// 执行分析
+				result, err := a.Analyze(ctx)
+				if err != nil {
+					errChan <- fmt.Errorf("analyze '%s' failed: %w", name, err)
+					return
+				}
 
-	wg.Wait()
-	close(errChan)
+				ctx.SetResult(name, result)
+				mu.Lock()
+				results[name] = result
+				mu.Unlock()
+			}(analyzer.Name(), analyzer, configs[analyzer.Name()])
+		}
 
-	// 检查是否有错误
-	var errs []error
-	for err := range errChan {
-		errs = append(errs, err)
+		wg.Wait()
+		close(errChan)
+
+		// 收集错误，后续层中依赖失败分析器的分析器会因拿不到结果而报错
+		for err := range errChan {
+			errs = append(errs, err)
+		}
 	}
 
 	if len(errs) > 0 {
@@ -466,6 +595,10 @@ type ParseResult struct {
 // parseInternal 内部解析方法
 func (p *ProjectAnalyzer) parseInternal() *ParseResult {
 	config := projectParser.NewProjectParserConfig(p.ProjectRoot, p.Exclude, p.IsMonorepo, nil)
+	config.Jobs = p.Jobs
+	config.CacheDir = p.CacheDir
+	config.Gitignore = p.Gitignore
+	config.ParseOptions = p.ParseOptions
 	result := projectParser.NewProjectParserResult(config)
 	result.ProjectParser()
 
@@ -475,6 +608,14 @@ func (p *ProjectAnalyzer) parseInternal() *ParseResult {
 	}
 }
 
+// Diagnostics 返回项目解析过程中遇到的问题
+func (p *ProjectAnalyzer) Diagnostics() []projectParser.Diagnostic {
+	if p.context == nil || p.context.ParsingResult == nil {
+		return nil
+	}
+	return p.context.ParsingResult.Diagnostics
+}
+
 // Context 返回分析上下文
 // 用于在业务代码中传递和复用解析结果
 // NewProjectAnalyzer 构造时会自动解析并创建 context
@@ -507,6 +648,9 @@ func (p *ProjectAnalyzer) runOne(analyzerType AnalyzerType, config any) (Result,
 	}
 
 	analyzer := factory()
+	if err := p.checkParseOptions(analyzer); err != nil {
+		return nil, err
+	}
 
 	// 转换配置
 	var configMap map[string]string
@@ -514,15 +658,17 @@ func (p *ProjectAnalyzer) runOne(analyzerType AnalyzerType, config any) (Result,
 		configMap = toConfigMap(config)
 	}
 
-	// 配置 analyzer
-	if configMap != nil && len(configMap) > 0 {
-		if err := analyzer.Configure(configMap); err != nil {
-			return nil, fmt.Errorf("configure analyzer '%s' failed: %w", name, err)
-		}
+	// 先运行所依赖的分析器（默认配置），再运行目标分析器
+	levels, err := p.scheduleAnalyzers([]Analyzer{analyzer})
+	if err != nil {
+		return nil, err
+	}
+	results, err := runLevels(ctx.forRun(), levels, map[string]map[string]string{analyzer.Name(): configMap})
+	if err != nil {
+		return nil, err
 	}
 
-	// 执行分析
-	return analyzer.Analyze(ctx)
+	return results[analyzer.Name()], nil
 }
 
 // =============================================================================
@@ -639,6 +785,15 @@ func AvailableAnalyzers() []AnalyzerType {
 	return names
 }
 
+// NewAnalyzer 按注册名或 Name() 的返回值创建分析器，未注册时返回 false
+func NewAnalyzer(name string) (Analyzer, bool) {
+	factory, ok := lookupAnalyzerFactory(name)
+	if !ok {
+		return nil, false
+	}
+	return factory(), true
+}
+
 // GetAvailableAnalyzersMap 返回所有已注册的分析器映射
 // key 是从 Analyzer.Name() 方法动态获取的，确保单一数据源
 // 这样 CLI 的 availableAnalyzers 不需要硬编码 key，而是直接使用 analyzer 自己报告的名称
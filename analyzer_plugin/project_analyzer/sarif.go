@@ -0,0 +1,194 @@
+package project_analyzer
+
+import (
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/Flying-Bird1999/analyzer-ts/analyzer/projectParser"
+)
+
+// SARIF 2.1.0 (Static Analysis Results Interchange Format) 输出，供 GitHub / GitLab 的代码扫描在代码行上直接标注发现项。
+// 这里只定义了用到的字段，完整规范见 https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	// sarifSourceRoot 是项目根目录在 SARIF 中的 uriBaseId，文件路径以相对于项目根目录的形式输出。
+	sarifSourceRoot = "SRCROOT"
+	// sarifToolName 是 SARIF 中的工具名称。
+	sarifToolName = "analyzer-ts"
+)
+
+// SARIFLog 是 SARIF 文件的顶层结构。
+type SARIFLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []SARIFRun `json:"runs"`
+}
+
+// SARIFRun 是一次工具运行的结果。
+type SARIFRun struct {
+	Tool               SARIFTool                   `json:"tool"`
+	OriginalURIBaseIDs map[string]SARIFArtifactURI `json:"originalUriBaseIds,omitempty"`
+	Results            []SARIFResult               `json:"results"`
+}
+
+// SARIFTool 描述产生结果的工具。
+type SARIFTool struct {
+	Driver SARIFDriver `json:"driver"`
+}
+
+// SARIFDriver 是工具的主组件，Rules 列出了结果中出现的所有规则。
+type SARIFDriver struct {
+	Name    string      `json:"name"`
+	Version string      `json:"version,omitempty"`
+	Rules   []SARIFRule `json:"rules"`
+}
+
+// SARIFRule 是一条规则的元数据。
+type SARIFRule struct {
+	ID                   string             `json:"id"`
+	DefaultConfiguration SARIFConfiguration `json:"defaultConfiguration"`
+}
+
+// SARIFConfiguration 是规则的默认配置。
+type SARIFConfiguration struct {
+	Level string `json:"level"`
+}
+
+// SARIFResult 是一条发现项。
+type SARIFResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   SARIFMessage    `json:"message"`
+	Locations []SARIFLocation `json:"locations,omitempty"`
+}
+
+// SARIFMessage 是发现项的描述文本。
+type SARIFMessage struct {
+	Text string `json:"text"`
+}
+
+// SARIFLocation 是发现项所在的位置。
+type SARIFLocation struct {
+	PhysicalLocation SARIFPhysicalLocation `json:"physicalLocation"`
+}
+
+// SARIFPhysicalLocation 是文件与文件中的区域。
+type SARIFPhysicalLocation struct {
+	ArtifactLocation SARIFArtifactURI `json:"artifactLocation"`
+	Region           *SARIFRegion     `json:"region,omitempty"`
+}
+
+// SARIFArtifactURI 是文件的 URI，URIBaseID 非空时 URI 相对于对应的基准目录。
+type SARIFArtifactURI struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+// SARIFRegion 是文件中的区域，行号与列号从 1 开始，EndColumn 不包含在区域内。
+type SARIFRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+	EndLine     int `json:"endLine,omitempty"`
+	EndColumn   int `json:"endColumn,omitempty"`
+}
+
+// NewSARIFLog 将发现项转换为 SARIF 日志。位于 projectRoot 之下的文件以相对路径输出，
+// 规则按首次出现的顺序列出，其默认级别取第一条发现项的级别。
+func NewSARIFLog(projectRoot string, findings []Finding) *SARIFLog {
+	run := SARIFRun{
+		Tool: SARIFTool{Driver: SARIFDriver{
+			Name:    sarifToolName,
+			Version: projectParser.ToolVersion,
+			Rules:   []SARIFRule{},
+		}},
+		Results: []SARIFResult{},
+	}
+	if root, err := filepath.Abs(projectRoot); err == nil && projectRoot != "" {
+		run.OriginalURIBaseIDs = map[string]SARIFArtifactURI{
+			sarifSourceRoot: {URI: fileURI(root) + "/"},
+		}
+		projectRoot = root
+	}
+
+	ruleIndex := make(map[string]int)
+	for _, finding := range findings {
+		level := sarifLevel(finding.Severity)
+		index, ok := ruleIndex[finding.RuleID]
+		if !ok {
+			index = len(run.Tool.Driver.Rules)
+			ruleIndex[finding.RuleID] = index
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, SARIFRule{
+				ID:                   finding.RuleID,
+				DefaultConfiguration: SARIFConfiguration{Level: level},
+			})
+		}
+
+		result := SARIFResult{
+			RuleID:    finding.RuleID,
+			RuleIndex: index,
+			Level:     level,
+			Message:   SARIFMessage{Text: finding.Message},
+		}
+		if finding.File != "" {
+			location := SARIFLocation{PhysicalLocation: SARIFPhysicalLocation{
+				ArtifactLocation: sarifArtifact(projectRoot, finding.File),
+			}}
+			if finding.Line > 0 {
+				region := &SARIFRegion{StartLine: finding.Line, StartColumn: finding.Column}
+				if finding.EndLine >= finding.Line {
+					region.EndLine = finding.EndLine
+					region.EndColumn = finding.EndColumn
+				}
+				location.PhysicalLocation.Region = region
+			}
+			result.Locations = []SARIFLocation{location}
+		}
+		run.Results = append(run.Results, result)
+	}
+
+	return &SARIFLog{Schema: sarifSchema, Version: sarifVersion, Runs: []SARIFRun{run}}
+}
+
+// sarifLevel 将发现项的严重程度转换为 SARIF 的 level。
+func sarifLevel(severity string) string {
+	switch severity {
+	case SeverityError:
+		return "error"
+	case SeverityInfo:
+		return "note"
+	default:
+		return "warning"
+	}
+}
+
+// sarifArtifact 返回文件在 SARIF 中的位置：项目根目录下的文件使用相对路径，其他文件使用 file:// URI。
+func sarifArtifact(projectRoot, file string) SARIFArtifactURI {
+	if !filepath.IsAbs(file) {
+		return SARIFArtifactURI{URI: relativeURI(filepath.ToSlash(file)), URIBaseID: sarifSourceRoot}
+	}
+	if projectRoot != "" {
+		if rel, err := filepath.Rel(projectRoot, file); err == nil && !strings.HasPrefix(rel, "..") {
+			return SARIFArtifactURI{URI: relativeURI(filepath.ToSlash(rel)), URIBaseID: sarifSourceRoot}
+		}
+	}
+	return SARIFArtifactURI{URI: fileURI(file)}
+}
+
+// relativeURI 对相对路径做 URI 转义。
+func relativeURI(path string) string {
+	return (&url.URL{Path: path}).String()
+}
+
+// fileURI 返回绝对路径对应的 file:// URI。
+func fileURI(path string) string {
+	path = filepath.ToSlash(path)
+	if !strings.HasPrefix(path, "/") {
+		// Windows 路径，例如 C:/project
+		path = "/" + path
+	}
+	return (&url.URL{Scheme: "file", Path: path}).String()
+}
@@ -0,0 +1,129 @@
+package project_analyzer
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+)
+
+func TestNewSARIFLog(t *testing.T) {
+	root := t.TempDir()
+	findings := []Finding{
+		{RuleID: "count-any/any-usage", Severity: SeverityWarning, Message: "使用了 'any' 类型", File: filepath.Join(root, "src", "my file.ts"), Line: 3, Column: 10, EndLine: 3, EndColumn: 13},
+		{RuleID: "npm-check/implicit-dependency", Severity: SeverityError, Message: "隐式依赖", File: "src/b.ts", Line: 1, Column: 1},
+		{RuleID: "count-any/any-usage", Severity: SeverityInfo, Message: "使用了 'any' 类型", File: filepath.Join(root, "src", "c.ts"), Line: 7, Column: 2},
+		{RuleID: "unreferenced/unreferenced-file", Severity: SeverityInfo, Message: "项目外的文件", File: "/elsewhere/d.ts"},
+		{RuleID: "impact/impacted-component", Severity: SeverityInfo, Message: "没有文件的发现项"},
+	}
+
+	data, err := json.Marshal(NewSARIFLog(root, findings))
+	if err != nil {
+		t.Fatalf("json.Marshal() error: %v", err)
+	}
+	var log map[string]any
+	if err := json.Unmarshal(data, &log); err != nil {
+		t.Fatalf("json.Unmarshal() error: %v", err)
+	}
+
+	// SARIF 2.1.0 schema 要求的字段
+	if log["version"] != "2.1.0" || log["$schema"] == nil {
+		t.Errorf("缺少 version 或 $schema: %s", data)
+	}
+	runs, _ := log["runs"].([]any)
+	if len(runs) != 1 {
+		t.Fatalf("预期 1 个 run, 得到 %s", data)
+	}
+	run := runs[0].(map[string]any)
+	driver := run["tool"].(map[string]any)["driver"].(map[string]any)
+	if driver["name"] != "analyzer-ts" {
+		t.Errorf("tool.driver.name 预期为 analyzer-ts, 得到 %v", driver["name"])
+	}
+	results := run["results"].([]any)
+	if len(results) != len(findings) {
+		t.Fatalf("预期 %d 个 result, 得到 %d 个", len(findings), len(results))
+	}
+	for i, result := range results {
+		message, _ := result.(map[string]any)["message"].(map[string]any)
+		if message["text"] != findings[i].Message {
+			t.Errorf("第 %d 个 result 的 message.text 不正确: %v", i, result)
+		}
+	}
+
+	// 规则按首次出现的顺序去重，默认级别取第一条发现项的级别，ruleIndex 指向对应的规则
+	rules := driver["rules"].([]any)
+	expectedRules := []struct{ id, level string }{
+		{"count-any/any-usage", "warning"},
+		{"npm-check/implicit-dependency", "error"},
+		{"unreferenced/unreferenced-file", "note"},
+		{"impact/impacted-component", "note"},
+	}
+	if len(rules) != len(expectedRules) {
+		t.Fatalf("预期 %d 条规则, 得到 %v", len(expectedRules), rules)
+	}
+	for i, expected := range expectedRules {
+		rule := rules[i].(map[string]any)
+		level := rule["defaultConfiguration"].(map[string]any)["level"]
+		if rule["id"] != expected.id || level != expected.level {
+			t.Errorf("第 %d 条规则预期为 %s (%s), 得到 %v", i, expected.id, expected.level, rule)
+		}
+	}
+	for i, expectedIndex := range []float64{0, 1, 0, 2, 3} {
+		result := results[i].(map[string]any)
+		if result["ruleIndex"] != expectedIndex || result["ruleId"] != expectedRules[int(expectedIndex)].id {
+			t.Errorf("第 %d 个 result 的规则不正确: %v", i, result)
+		}
+	}
+	if results[2].(map[string]any)["level"] != "note" {
+		t.Errorf("result 的 level 应取发现项自身的级别: %v", results[2])
+	}
+
+	// 项目根目录下的文件使用相对于 SRCROOT 的 uri，项目外的文件使用 file:// uri，没有文件的发现项不输出位置
+	artifact := func(i int) map[string]any {
+		locations, _ := results[i].(map[string]any)["locations"].([]any)
+		if len(locations) == 0 {
+			return nil
+		}
+		physical := locations[0].(map[string]any)["physicalLocation"].(map[string]any)
+		return physical["artifactLocation"].(map[string]any)
+	}
+	for i, expected := range []struct{ uri, baseID string }{
+		{"src/my%20file.ts", "SRCROOT"},
+		{"src/b.ts", "SRCROOT"},
+		{"src/c.ts", "SRCROOT"},
+		{"file:///elsewhere/d.ts", ""},
+	} {
+		location := artifact(i)
+		baseID, _ := location["uriBaseId"].(string)
+		if location["uri"] != expected.uri || baseID != expected.baseID {
+			t.Errorf("第 %d 个 result 的位置预期为 %s (%s), 得到 %v", i, expected.uri, expected.baseID, location)
+		}
+	}
+	if artifact(4) != nil {
+		t.Errorf("没有文件的发现项不应输出位置: %v", results[4])
+	}
+	baseIDs := run["originalUriBaseIds"].(map[string]any)
+	if baseIDs["SRCROOT"].(map[string]any)["uri"] != "file://"+filepath.ToSlash(root)+"/" {
+		t.Errorf("SRCROOT 应指向项目根目录, 得到 %v", baseIDs)
+	}
+
+	// 区域使用发现项的起止位置
+	region := results[0].(map[string]any)["locations"].([]any)[0].(map[string]any)["physicalLocation"].(map[string]any)["region"].(map[string]any)
+	if region["startLine"] != 3.0 || region["startColumn"] != 10.0 || region["endLine"] != 3.0 || region["endColumn"] != 13.0 {
+		t.Errorf("区域不正确: %v", region)
+	}
+}
+
+func TestNewSARIFLogWithoutFindings(t *testing.T) {
+	data, err := json.Marshal(NewSARIFLog("", nil))
+	if err != nil {
+		t.Fatalf("json.Marshal() error: %v", err)
+	}
+	// 没有发现项时 results 与 rules 也必须输出为空数组
+	var log SARIFLog
+	if err := json.Unmarshal(data, &log); err != nil {
+		t.Fatalf("json.Unmarshal() error: %v", err)
+	}
+	if log.Runs[0].Results == nil || log.Runs[0].Tool.Driver.Rules == nil {
+		t.Errorf("results 与 rules 应为空数组: %s", data)
+	}
+}
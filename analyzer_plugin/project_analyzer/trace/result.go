@@ -2,7 +2,10 @@ package trace
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/Flying-Bird1999/analyzer-ts/analyzer/parser"
+	"github.com/Flying-Bird1999/analyzer-ts/analyzer/projectParser"
 	projectanalyzer "github.com/Flying-Bird1999/analyzer-ts/analyzer_plugin/project_analyzer"
 )
 
@@ -113,3 +116,35 @@ func (r *TraceResult) ToConsole() string {
 func (r *TraceResult) AnalyzerName() string {
 	return "trace"
 }
+
+// 确保 TraceResult 实现了 projectanalyzer.FindingsProvider 接口。
+var _ projectanalyzer.FindingsProvider = (*TraceResult)(nil)
+
+// NormalizedFindings 将链路中带有位置信息的节点转换为 info 级别的发现项：
+// 对目标包的导入语句，以及与目标包相关的函数调用。
+func (r *TraceResult) NormalizedFindings() []projectanalyzer.Finding {
+	var findings []projectanalyzer.Finding
+	for filePath, data := range r.Data {
+		fileData, ok := data.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if imports, ok := fileData["importDeclarations"].([]projectParser.ImportDeclarationResult); ok {
+			for _, imp := range imports {
+				findings = append(findings, projectanalyzer.FindingAt(
+					"trace/package-import", projectanalyzer.SeverityInfo,
+					fmt.Sprintf("导入了追踪的 NPM 包 %s", imp.Source.NpmPkg), filePath, imp.SourceLocation,
+				))
+			}
+		}
+		if calls, ok := fileData["callExpressions"].([]parser.CallExpression); ok {
+			for _, call := range calls {
+				findings = append(findings, projectanalyzer.FindingAt(
+					"trace/package-call", projectanalyzer.SeverityInfo,
+					fmt.Sprintf("调用了来自追踪的 NPM 包的 %s", strings.Join(call.CallChain, ".")), filePath, call.SourceLocation,
+				))
+			}
+		}
+	}
+	return findings
+}
@@ -114,6 +114,17 @@ func (t *Tracer) Configure(params map[string]string) error {
 	return nil
 }
 
+func (t *Tracer) RequiredParseOptions() projectParser.ParseOptions {
+	return projectParser.LowMemoryParseOptions()
+}
+
+// ParamSchema 描述该分析器接受的参数。
+func (t *Tracer) ParamSchema() projectanalyzer.ParamSchema {
+	return projectanalyzer.ObjectParams(map[string]*projectanalyzer.ParamSchema{
+		"targetPkgs": projectanalyzer.StringListParam("要追踪使用链路的 NPM 包名，例如 antd"),
+	}, "targetPkgs")
+}
+
 // Analyze 执行NPM包链路追踪的核心分析逻辑。
 //
 // 分析流程：
@@ -314,6 +325,21 @@ func (t *Tracer) buildFilteredResult(pr *projectParser.ProjectParserResult, tain
 			filteredFileData["importDeclarations"] = relevantImports
 		}
 
+		// --- 过滤 Module Declarations ---
+		// 只保留那些扩展目标NPM包的模块声明（例如 `declare module 'antd' {}`）。
+		var relevantModules []projectParser.ModuleDeclarationResult
+		for _, mod := range fileData.ModuleDeclarations {
+			if mod.Source == nil {
+				continue
+			}
+			if _, isTarget := t.TargetPkgs[mod.Source.NpmPkg]; isTarget {
+				relevantModules = append(relevantModules, mod)
+			}
+		}
+		if len(relevantModules) > 0 {
+			filteredFileData["moduleDeclarations"] = relevantModules
+		}
+
 		// --- 过滤 Variable Declarations ---
 		// 只保留那些赋值来源被污染的变量声明。
 		var relevantVars []parser.VariableDeclaration
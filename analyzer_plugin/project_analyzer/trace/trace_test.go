@@ -3,6 +3,7 @@ package trace
 import (
 	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/Flying-Bird1999/analyzer-ts/analyzer/parser"
@@ -83,6 +84,61 @@ func TestTracerConfigure(t *testing.T) {
 	}
 }
 
+func TestTracerParamSchema(t *testing.T) {
+	schema := (&Tracer{}).ParamSchema()
+
+	testCases := []struct {
+		name        string
+		values      map[string]any
+		expectErr   bool
+		errContains string
+	}{
+		{
+			name:   "正常情况 - 包列表",
+			values: map[string]any{"targetPkgs": []any{"antd", "lodash"}},
+		},
+		{
+			name:        "异常情况 - 参数名拼写错误",
+			values:      map[string]any{"targetPkg": []any{"antd"}},
+			expectErr:   true,
+			errContains: "是否想使用 'targetPkgs'",
+		},
+		{
+			name:        "异常情况 - 类型错误",
+			values:      map[string]any{"targetPkgs": true},
+			expectErr:   true,
+			errContains: "类型应为",
+		},
+		{
+			name:        "异常情况 - 缺少必需参数",
+			values:      map[string]any{},
+			expectErr:   true,
+			errContains: "缺少必需参数",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := projectanalyzer.ValidateParams("trace", schema, tc.values)
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("ValidateParams() error = %v, expectErr %v", err, tc.expectErr)
+			}
+			if err != nil && !strings.Contains(err.Error(), tc.errContains) {
+				t.Errorf("错误信息 %q 中应包含 %q", err.Error(), tc.errContains)
+			}
+		})
+	}
+
+	// 命令行中多次出现的参数合并为列表
+	values, err := projectanalyzer.CoerceParams("trace", schema, map[string][]string{"targetPkgs": {"antd", "lodash,moment"}})
+	if err != nil {
+		t.Fatalf("CoerceParams() error = %v", err)
+	}
+	if got := projectanalyzer.FormatParams(values)["targetPkgs"]; got != "antd,lodash,moment" {
+		t.Errorf("FormatParams() = %q, want %q", got, "antd,lodash,moment")
+	}
+}
+
 func TestTracerAnalyze(t *testing.T) {
 	// 准备路径
 	projectRoot, _ := filepath.Abs("/test-project")
@@ -24,7 +24,7 @@ type Finding struct {
 	ExportName string `json:"exportName"`
 	// Line 是导出语句所在的行号，便于快速定位。
 	Line int `json:"line"`
-	// Kind 描述了导出项的类型（如 var, const, function, interface, type, enum）。
+	// Kind 描述了导出项的类型（如 var, const, function, class, interface, type, enum, namespace）。
 	Kind string `json:"kind"`
 }
 
@@ -93,3 +93,21 @@ func (r *Result) ToConsole() string {
 func (r *Result) AnalyzerName() string {
 	return "unconsumed"
 }
+
+// 确保 Result 结构体实现了 projectanalyzer.FindingsProvider 接口。
+var _ projectanalyzer.FindingsProvider = (*Result)(nil)
+
+// NormalizedFindings 将每个未被消费的导出项转换为一条 warning 级别的发现项。
+func (r *Result) NormalizedFindings() []projectanalyzer.Finding {
+	findings := make([]projectanalyzer.Finding, 0, len(r.Findings))
+	for _, f := range r.Findings {
+		findings = append(findings, projectanalyzer.Finding{
+			RuleID:   "unconsumed/unused-export",
+			Severity: projectanalyzer.SeverityWarning,
+			Message:  fmt.Sprintf("导出的 %s '%s' 没有被任何文件导入", f.Kind, f.ExportName),
+			File:     f.FilePath,
+			Line:     f.Line,
+		})
+	}
+	return findings
+}
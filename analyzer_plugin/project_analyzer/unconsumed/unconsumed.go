@@ -33,6 +33,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/Flying-Bird1999/analyzer-ts/analyzer/parser"
 	"github.com/Flying-Bird1999/analyzer-ts/analyzer/projectParser"
 	projectanalyzer "github.com/Flying-Bird1999/analyzer-ts/analyzer_plugin/project_analyzer"
 )
@@ -63,6 +64,15 @@ func (f *Finder) Configure(params map[string]string) error {
 	return nil
 }
 
+func (f *Finder) RequiredParseOptions() projectParser.ParseOptions {
+	return projectParser.LowMemoryParseOptions()
+}
+
+// ParamSchema 声明该分析器不接受任何参数。
+func (f *Finder) ParamSchema() projectanalyzer.ParamSchema {
+	return projectanalyzer.ObjectParams(nil)
+}
+
 // alias 结构体用于追踪导出别名信息。
 // 当一个文件使用 `export { OriginalName as NewName } from './module'` 语法时，
 // 我们需要记录这个映射关系，以便正确追踪原始导出的使用情况。
@@ -112,8 +122,8 @@ func (f *Finder) Analyze(ctx *projectanalyzer.ProjectContext) (projectanalyzer.R
 	// 记录 export { name as newName } from 'module' 的映射关系
 	exportAliases := make(map[string]alias)
 
-	// 遍历所有已解析的文件，收集导入和重导出信息
-	for filePath, fileData := range deps.Js_Data {
+	// 遍历所有已解析的文件，收集导入信息
+	for _, fileData := range deps.Js_Data {
 		// 处理所有导入声明
 		for _, imp := range fileData.ImportDeclarations {
 			// 跳过无效的导入声明
@@ -144,26 +154,23 @@ func (f *Finder) Analyze(ctx *projectanalyzer.ProjectContext) (projectanalyzer.R
 				consumedExports[key] = true
 			}
 		}
+	}
 
-		// 处理重导出声明：export { name } from 'module'
-		for _, exp := range fileData.ExportDeclarations {
-			// 只处理有来源的导出声明（即重导出）
-			if exp.Source == nil || exp.Source.FilePath == "" {
+	// 处理重导出声明：export { name } from 'module'
+	// 映射关系来自共享的导出表，只处理有来源的导出声明（即重导出）
+	exportTable, err := projectanalyzer.Index(ctx, projectanalyzer.ExportTableIndex)
+	if err != nil {
+		return nil, err
+	}
+	for filePath, entries := range exportTable {
+		for _, entry := range entries {
+			if entry.Kind != projectParser.ExportKindReExport || entry.From == "" {
 				continue
 			}
-
-			// 建立重导出映射关系
-			for _, module := range exp.ExportModules {
-				aliasKey := fmt.Sprintf("%s#%s", filePath, module.Identifier)
-				// 确定原始导出名称
-				originalName := module.ModuleName
-				if originalName == "" {
-					originalName = module.Identifier
-				}
-				exportAliases[aliasKey] = alias{
-					OrigPath: exp.Source.FilePath,
-					OrigName: originalName,
-				}
+			aliasKey := fmt.Sprintf("%s#%s", filePath, entry.Name)
+			exportAliases[aliasKey] = alias{
+				OrigPath: entry.From,
+				OrigName: entry.LocalName,
 			}
 		}
 	}
@@ -246,12 +253,14 @@ func (f *Finder) Analyze(ctx *projectanalyzer.ProjectContext) (projectanalyzer.R
 // 1. 是测试文件，通常不会被生产代码引用
 // 2. 是类型定义文件，通常用于提供类型而非实际功能
 // 3. 是测试工具相关文件，用于测试环境
+// 4. 是 Markdown/MDX 文档，其中的导出（如 MDX 的 `export const meta`）由文档站点消费
 //
 // 忽略的文件类型：
 // - *.test.* 和 *.spec.*：测试文件
 // - *.d.ts：TypeScript 类型定义文件
 // - __tests__ 目录：Jest 测试目录
 // - __mocks__ 目录：模拟文件目录
+// - *.md 和 *.mdx：文档文件
 //
 // 参数说明：
 // - filePath: 需要判断的文件路径
@@ -263,7 +272,8 @@ func isIgnoredFile(filePath string) bool {
 		strings.Contains(filePath, ".spec.") ||
 		strings.HasSuffix(filePath, ".d.ts") ||
 		strings.Contains(filePath, "__tests__") ||
-		strings.Contains(filePath, "__mocks__")
+		strings.Contains(filePath, "__mocks__") ||
+		parser.IsMarkdownFile(filePath)
 }
 
 // addUnconsumedFromDeclarations 从各种声明语句中查找未使用的导出项。
@@ -275,6 +285,7 @@ func isIgnoredFile(filePath string) bool {
 // 3. 枚举声明（enum）
 // 4. 类型别名声明（type）
 // 5. 函数声明（function）- 通过 VariableDeclarations 处理
+// 6. 类声明（class），`export default class` 按默认导出处理
 //
 // 参数说明：
 // - findings: 用于存储找到的未使用导出项的切片引用
@@ -286,8 +297,8 @@ func addUnconsumedFromDeclarations(findings *[]Finding, totalExportsFound *int,
 	// === 处理变量声明 ===
 	// 包括 const, let, var 声明，以及函数声明（function declarations）
 	for _, v := range fileData.VariableDeclarations {
-		// 只处理被导出的变量声明
-		if !v.Exported {
+		// 只处理被导出的顶层变量声明，命名空间内的导出属于命名空间本身
+		if !v.Exported || v.Namespace != "" {
 			continue
 		}
 
@@ -308,8 +319,8 @@ func addUnconsumedFromDeclarations(findings *[]Finding, totalExportsFound *int,
 
 	// === 处理接口声明 ===
 	for identifier, decl := range fileData.InterfaceDeclarations {
-		// 只处理被导出的接口
-		if !decl.Exported {
+		// 只处理被导出的顶层接口，命名空间内的导出属于命名空间本身
+		if !decl.Exported || decl.Namespace != "" {
 			continue
 		}
 		*totalExportsFound++
@@ -326,8 +337,8 @@ func addUnconsumedFromDeclarations(findings *[]Finding, totalExportsFound *int,
 
 	// === 处理枚举声明 ===
 	for identifier, decl := range fileData.EnumDeclarations {
-		// 只处理被导出的枚举
-		if !decl.Exported {
+		// 只处理被导出的顶层枚举，命名空间内的导出属于命名空间本身
+		if !decl.Exported || decl.Namespace != "" {
 			continue
 		}
 		*totalExportsFound++
@@ -344,8 +355,8 @@ func addUnconsumedFromDeclarations(findings *[]Finding, totalExportsFound *int,
 
 	// === 处理类型别名声明 ===
 	for identifier, decl := range fileData.TypeDeclarations {
-		// 只处理被导出的类型别名
-		if !decl.Exported {
+		// 只处理被导出的顶层类型别名，命名空间内的导出属于命名空间本身
+		if !decl.Exported || decl.Namespace != "" {
 			continue
 		}
 		*totalExportsFound++
@@ -359,6 +370,48 @@ func addUnconsumedFromDeclarations(findings *[]Finding, totalExportsFound *int,
 			})
 		}
 	}
+
+	// === 处理类声明 ===
+	for _, decl := range fileData.ClassDeclarations {
+		// 只处理被导出的顶层类，命名空间内的导出属于命名空间本身
+		if !decl.Exported || decl.Namespace != "" {
+			continue
+		}
+		*totalExportsFound++
+		exportName := decl.Identifier
+		key := fmt.Sprintf("%s#%s", filePath, decl.Identifier)
+		if decl.IsDefaultExport {
+			// 默认导出的类与 export default 一样使用 "*" 作为导出名
+			exportName = "default"
+			key = fmt.Sprintf("%s#*", filePath)
+		}
+		if !consumedExports[key] {
+			*findings = append(*findings, Finding{
+				FilePath:   filePath,
+				ExportName: exportName,
+				Line:       decl.SourceLocation.Start.Line,
+				Kind:       "class",
+			})
+		}
+	}
+
+	// === 处理命名空间声明 ===
+	for _, decl := range fileData.ModuleDeclarations {
+		// 只处理被导出的顶层命名空间
+		if !decl.Exported || decl.Kind != parser.ModuleKindNamespace || decl.Namespace != "" {
+			continue
+		}
+		*totalExportsFound++
+		key := fmt.Sprintf("%s#%s", filePath, decl.Name)
+		if !consumedExports[key] {
+			*findings = append(*findings, Finding{
+				FilePath:   filePath,
+				ExportName: decl.Name,
+				Line:       decl.SourceLocation.Start.Line,
+				Kind:       "namespace",
+			})
+		}
+	}
 }
 
 // init 在包加载时自动注册分析器
@@ -108,3 +108,99 @@ func TestUnconsumedFinder(t *testing.T) {
 		t.Errorf("Expected Findings to be %v, but got %v", expectedFindings, findResult.Findings)
 	}
 }
+
+func TestUnconsumedFinderClasses(t *testing.T) {
+	projectRoot, _ := filepath.Abs("/test-project")
+	servicePath := filepath.Join(projectRoot, "src/service.ts")
+	appPath := filepath.Join(projectRoot, "src/app.ts")
+
+	// service.ts: 导出一个被使用的类、一个未使用的类和一个未导出的类
+	mockParsingResult := &projectParser.ProjectParserResult{
+		Js_Data: map[string]projectParser.JsFileParserResult{
+			servicePath: {
+				ClassDeclarations: []parser.ClassDeclarationResult{
+					{Identifier: "UserService", Exported: true, SourceLocation: &parser.SourceLocation{Start: parser.NodePosition{Line: 1}}},
+					{Identifier: "LegacyService", Exported: true, SourceLocation: &parser.SourceLocation{Start: parser.NodePosition{Line: 5}}},
+					{Identifier: "Helper", SourceLocation: &parser.SourceLocation{Start: parser.NodePosition{Line: 9}}},
+				},
+			},
+			// app.ts: 只导入了 UserService
+			appPath: {
+				ImportDeclarations: []projectParser.ImportDeclarationResult{
+					{
+						Source: projectParser.SourceData{FilePath: servicePath},
+						ImportModules: []projectParser.ImportModule{
+							{Identifier: "UserService", Type: "named"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	result, err := (&Finder{}).Analyze(&projectanalyzer.ProjectContext{ParsingResult: mockParsingResult})
+	if err != nil {
+		t.Fatalf("Analyze() returned an unexpected error: %v", err)
+	}
+	findResult := result.(*Result)
+
+	if findResult.Stats.TotalExportsFound != 2 {
+		t.Errorf("Expected TotalExportsFound to be 2, but got %d", findResult.Stats.TotalExportsFound)
+	}
+	expectedFindings := []Finding{
+		{FilePath: servicePath, ExportName: "LegacyService", Line: 5, Kind: "class"},
+	}
+	if !reflect.DeepEqual(findResult.Findings, expectedFindings) {
+		t.Errorf("Expected Findings to be %v, but got %v", expectedFindings, findResult.Findings)
+	}
+}
+
+// TestUnconsumedFinderNamespaces 测试命名空间作为一个整体参与未消费导出的检测，其内部的导出不单独报告。
+func TestUnconsumedFinderNamespaces(t *testing.T) {
+	projectRoot, _ := filepath.Abs("/test-project")
+	apiPath := filepath.Join(projectRoot, "src/api.ts")
+	appPath := filepath.Join(projectRoot, "src/app.ts")
+
+	// api.ts: 导出两个命名空间，其中一个包含导出的接口；另有一个 antd 的模块扩展
+	mockParsingResult := &projectParser.ProjectParserResult{
+		Js_Data: map[string]projectParser.JsFileParserResult{
+			apiPath: {
+				InterfaceDeclarations: map[string]parser.InterfaceDeclarationResult{
+					"API.User": {Identifier: "User", Exported: true, Namespace: "API", SourceLocation: &parser.SourceLocation{Start: parser.NodePosition{Line: 2}}},
+				},
+				ModuleDeclarations: []projectParser.ModuleDeclarationResult{
+					{ModuleDeclarationResult: parser.ModuleDeclarationResult{Name: "API", Kind: parser.ModuleKindNamespace, Exported: true, SourceLocation: &parser.SourceLocation{Start: parser.NodePosition{Line: 1}}}},
+					{ModuleDeclarationResult: parser.ModuleDeclarationResult{Name: "Legacy", Kind: parser.ModuleKindNamespace, Exported: true, SourceLocation: &parser.SourceLocation{Start: parser.NodePosition{Line: 5}}}},
+					{ModuleDeclarationResult: parser.ModuleDeclarationResult{Name: "antd", Kind: parser.ModuleKindModule, IsDeclare: true, IsAugmentation: true, SourceLocation: &parser.SourceLocation{Start: parser.NodePosition{Line: 8}}}},
+				},
+			},
+			// app.ts: 只导入了 API
+			appPath: {
+				ImportDeclarations: []projectParser.ImportDeclarationResult{
+					{
+						Source: projectParser.SourceData{FilePath: apiPath},
+						ImportModules: []projectParser.ImportModule{
+							{Identifier: "API", Type: "named"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	result, err := (&Finder{}).Analyze(&projectanalyzer.ProjectContext{ParsingResult: mockParsingResult})
+	if err != nil {
+		t.Fatalf("Analyze() returned an unexpected error: %v", err)
+	}
+	findResult := result.(*Result)
+
+	if findResult.Stats.TotalExportsFound != 2 {
+		t.Errorf("Expected TotalExportsFound to be 2, but got %d", findResult.Stats.TotalExportsFound)
+	}
+	expectedFindings := []Finding{
+		{FilePath: apiPath, ExportName: "Legacy", Line: 5, Kind: "namespace"},
+	}
+	if !reflect.DeepEqual(findResult.Findings, expectedFindings) {
+		t.Errorf("Expected Findings to be %v, but got %v", expectedFindings, findResult.Findings)
+	}
+}
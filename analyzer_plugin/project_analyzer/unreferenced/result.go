@@ -160,3 +160,29 @@ func (r *FindUnreferencedFilesResult) ToConsole() string {
 func (r *FindUnreferencedFilesResult) AnalyzerName() string {
 	return "find-unreferenced-files"
 }
+
+// 确保 FindUnreferencedFilesResult 实现了 projectanalyzer.FindingsProvider 接口。
+var _ projectanalyzer.FindingsProvider = (*FindUnreferencedFilesResult)(nil)
+
+// NormalizedFindings 将未引用文件转换为文件级的发现项。
+// 真正未引用的文件为 warning 级别，可疑文件需要人工确认，为 info 级别。
+func (r *FindUnreferencedFilesResult) NormalizedFindings() []projectanalyzer.Finding {
+	findings := make([]projectanalyzer.Finding, 0, len(r.TrulyUnreferencedFiles)+len(r.SuspiciousFiles))
+	for _, file := range r.TrulyUnreferencedFiles {
+		findings = append(findings, projectanalyzer.Finding{
+			RuleID:   "find-unreferenced-files/unreferenced-file",
+			Severity: projectanalyzer.SeverityWarning,
+			Message:  "文件没有被任何入口文件直接或间接引用",
+			File:     file,
+		})
+	}
+	for _, file := range r.SuspiciousFiles {
+		findings = append(findings, projectanalyzer.Finding{
+			RuleID:   "find-unreferenced-files/suspicious-file",
+			Severity: projectanalyzer.SeverityInfo,
+			Message:  "文件没有被引用，但根据命名或位置可能被间接使用，请人工确认",
+			File:     file,
+		})
+	}
+	return findings
+}
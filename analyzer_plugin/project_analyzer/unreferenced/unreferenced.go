@@ -30,14 +30,24 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/Flying-Bird1999/analyzer-ts/analyzer/parser"
 	"github.com/Flying-Bird1999/analyzer-ts/analyzer/projectParser"
 	projectanalyzer "github.com/Flying-Bird1999/analyzer-ts/analyzer_plugin/project_analyzer"
+	"github.com/samber/lo"
 )
 
 // =============================================================================
 // 分析器主体定义
 // =============================================================================
 
+// referenceEdgeKinds 是构成文件引用的导入边类型。样式文件之间的 @import 不计入，
+// 与只分析 JS 文件的引用关系保持一致。
+var referenceEdgeKinds = []string{
+	projectParser.ImportEdgeKindImport,
+	projectParser.ImportEdgeKindReExport,
+	projectParser.ImportEdgeKindJsx,
+}
+
 // Finder 是"未引用文件"分析器的实现。
 //
 // 设计理念：
@@ -112,6 +122,18 @@ func (f *Finder) Configure(params map[string]string) error {
 	return nil
 }
 
+func (f *Finder) RequiredParseOptions() projectParser.ParseOptions {
+	return projectParser.LowMemoryParseOptions()
+}
+
+// ParamSchema 描述该分析器接受的参数。
+func (f *Finder) ParamSchema() projectanalyzer.ParamSchema {
+	return projectanalyzer.ObjectParams(map[string]*projectanalyzer.ParamSchema{
+		"entrypoint":         projectanalyzer.StringListParam("入口文件路径，从入口出发不可达的文件视为未引用"),
+		"include-entry-dirs": projectanalyzer.BoolParam("未指定入口文件时，自动识别 index.ts、main.ts 等常见入口文件", false),
+	})
+}
+
 // Analyze 执行未引用文件分析的核心逻辑。
 //
 // 分析流程：
@@ -148,25 +170,21 @@ func (f *Finder) Analyze(ctx *projectanalyzer.ProjectContext) (projectanalyzer.R
 	deps := ctx.ParsingResult
 
 	// 步骤 1: 构建引用关系图
-	// 收集所有被其他文件引用的文件
+	// 收集所有被其他文件引用的文件（导入、再导出与 JSX 组件引用）
+	graph, err := projectanalyzer.Index(ctx, projectanalyzer.ImportGraphIndex)
+	if err != nil {
+		return nil, err
+	}
+	reverseGraph, err := projectanalyzer.Index(ctx, projectanalyzer.ReverseImportGraphIndex)
+	if err != nil {
+		return nil, err
+	}
 	referencedFiles := make(map[string]bool)
-	for _, fileDeps := range deps.Js_Data {
-		// 分析导入语句
-		for _, dep := range fileDeps.ImportDeclarations {
-			if dep.Source.FilePath != "" {
-				referencedFiles[dep.Source.FilePath] = true
-			}
-		}
-		// 分析导出语句
-		for _, dep := range fileDeps.ExportDeclarations {
-			if dep.Source != nil && dep.Source.FilePath != "" {
-				referencedFiles[dep.Source.FilePath] = true
-			}
-		}
-		// 分析 JSX 组件引用
-		for _, jsx := range fileDeps.JsxElements {
-			if jsx.Source.FilePath != "" {
-				referencedFiles[jsx.Source.FilePath] = true
+	for target, edges := range reverseGraph {
+		for _, edge := range edges {
+			if lo.Contains(referenceEdgeKinds, edge.Kind) {
+				referencedFiles[target] = true
+				break
 			}
 		}
 	}
@@ -201,12 +219,16 @@ func (f *Finder) Analyze(ctx *projectanalyzer.ProjectContext) (projectanalyzer.R
 	var unreferencedFiles []string
 	allFiles := make(map[string]bool)
 	for filePath := range deps.Js_Data {
+		// Markdown/MDX 文档是文档站点的页面，不会被其他文件导入
+		if parser.IsMarkdownFile(filePath) {
+			continue
+		}
 		allFiles[filePath] = true
 	}
 
 	if len(entrypointFiles) > 0 {
 		// 使用深度优先搜索分析可达性
-		visited := performDFS(entrypointFiles, deps)
+		visited := performDFS(entrypointFiles, graph)
 		for filePath := range allFiles {
 			if !referencedFiles[filePath] && !visited[filePath] {
 				unreferencedFiles = append(unreferencedFiles, filePath)
@@ -271,12 +293,12 @@ func (f *Finder) Analyze(ctx *projectanalyzer.ProjectContext) (projectanalyzer.R
 //
 // 参数说明：
 // - entrypointFiles: 入口文件的集合，作为遍历的起点
-// - deps: 项目解析结果，包含所有文件的依赖关系
+// - graph: 项目的正向导入关系图
 //
 // 返回值说明：
 //   - map[string]bool: 从入口文件可达的文件集合
 //     key 为文件路径，value 为可达性标记（true 表示可达）
-func performDFS(entrypointFiles map[string]bool, deps *projectParser.ProjectParserResult) map[string]bool {
+func performDFS(entrypointFiles map[string]bool, graph projectParser.ImportGraph) map[string]bool {
 	visited := make(map[string]bool)
 
 	// 递归遍历函数
@@ -289,29 +311,9 @@ func performDFS(entrypointFiles map[string]bool, deps *projectParser.ProjectPars
 		// 标记当前文件为已访问
 		visited[filePath] = true
 
-		// 获取当前文件的依赖关系
-		fileDeps, exists := deps.Js_Data[filePath]
-		if !exists {
-			return
-		}
-
-		// 遍历导入引用
-		for _, dep := range fileDeps.ImportDeclarations {
-			if dep.Source.FilePath != "" {
-				dfs(dep.Source.FilePath)
-			}
-		}
-		// 遍历导出引用
-		for _, dep := range fileDeps.ExportDeclarations {
-			if dep.Source != nil && dep.Source.FilePath != "" {
-				dfs(dep.Source.FilePath)
-			}
-		}
-		// 遍历 JSX 组件引用
-		for _, jsx := range fileDeps.JsxElements {
-			if jsx.Source.FilePath != "" {
-				dfs(jsx.Source.FilePath)
-			}
+		// 遍历导入、再导出与 JSX 组件引用
+		for _, dep := range graph.Neighbors(filePath, referenceEdgeKinds...) {
+			dfs(dep)
 		}
 	}
 
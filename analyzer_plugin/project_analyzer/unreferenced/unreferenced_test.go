@@ -17,6 +17,8 @@ func TestUnreferencedFinder(t *testing.T) {
 	referencedPath := filepath.Join(projectRoot, "src/referenced.ts")
 	unreferencedPath := filepath.Join(projectRoot, "src/unreferenced.ts")
 	suspiciousPath := filepath.Join(projectRoot, "src/config.ts") // 可疑文件
+	docsPath := filepath.Join(projectRoot, "docs/usage.mdx")      // 文档页面，只会引用其他文件
+	docsOnlyPath := filepath.Join(projectRoot, "src/demo.tsx")    // 只在文档中使用的文件
 
 	// 1. 准备测试数据
 	mockParsingResult := &projectParser.ProjectParserResult{
@@ -35,6 +37,15 @@ func TestUnreferencedFinder(t *testing.T) {
 			unreferencedPath: {},
 			// 可疑的未引用文件
 			suspiciousPath: {},
+			// 文档页面本身不会被报告，它引用的文件视为已引用
+			docsPath: {
+				ImportDeclarations: []projectParser.ImportDeclarationResult{
+					{
+						Source: projectParser.SourceData{FilePath: docsOnlyPath},
+					},
+				},
+			},
+			docsOnlyPath: {},
 		},
 	}
 
@@ -80,8 +91,8 @@ func TestUnreferencedFinder(t *testing.T) {
 	}
 
 	// 检查统计数据
-	if findResult.Stats.TotalFiles != 4 {
-		t.Errorf("Expected TotalFiles to be 4, but got %d", findResult.Stats.TotalFiles)
+	if findResult.Stats.TotalFiles != 5 {
+		t.Errorf("Expected TotalFiles to be 5, but got %d", findResult.Stats.TotalFiles)
 	}
 	if findResult.Stats.TrulyUnreferencedFiles != 1 {
 		t.Errorf("Expected TrulyUnreferencedFiles count to be 1, but got %d", findResult.Stats.TrulyUnreferencedFiles)
@@ -91,7 +102,7 @@ func TestUnreferencedFinder(t *testing.T) {
 	}
 
 	// 检查摘要信息
-	expectedSummary := "扫描文件 4 个，发现 1 个真正未引用文件和 1 个可疑文件。"
+	expectedSummary := "扫描文件 5 个，发现 1 个真正未引用文件和 1 个可疑文件。"
 	if summary := result.Summary(); summary != expectedSummary {
 		t.Errorf("Expected Summary() to be '%s', but got '%s'", expectedSummary, summary)
 	}
@@ -357,19 +357,31 @@ func (bcr *BatchCollectResult) preCacheGlobalDeclarations() {
 
 	for filePath, fileData := range ppr.Js_Data {
 		for typeName, decl := range fileData.TypeDeclarations {
-			bcr.globalDeclarationsCache[typeName] = GlobalDeclaration{
+			key, ok := globalDeclarationKey(typeName, decl.Namespace)
+			if !ok {
+				continue
+			}
+			bcr.globalDeclarationsCache[key] = GlobalDeclaration{
 				RawSource: decl.Raw,
 				FilePath:  filePath,
 			}
 		}
 		for typeName, decl := range fileData.InterfaceDeclarations {
-			bcr.globalDeclarationsCache[typeName] = GlobalDeclaration{
+			key, ok := globalDeclarationKey(typeName, decl.Namespace)
+			if !ok {
+				continue
+			}
+			bcr.globalDeclarationsCache[key] = GlobalDeclaration{
 				RawSource: decl.Raw,
 				FilePath:  filePath,
 			}
 		}
 		for typeName, decl := range fileData.EnumDeclarations {
-			bcr.globalDeclarationsCache[typeName] = GlobalDeclaration{
+			key, ok := globalDeclarationKey(typeName, decl.Namespace)
+			if !ok {
+				continue
+			}
+			bcr.globalDeclarationsCache[key] = GlobalDeclaration{
 				RawSource: decl.Raw,
 				FilePath:  filePath,
 			}
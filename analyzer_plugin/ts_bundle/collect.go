@@ -111,19 +111,31 @@ func (br *CollectResult) preCacheGlobalDeclarations() {
 	// 遍历解析结果，将找到的声明存入缓存
 	for filePath, fileData := range ppr.Js_Data {
 		for typeName, decl := range fileData.TypeDeclarations {
-			br.globalDeclarationsCache[typeName] = GlobalDeclaration{
+			key, ok := globalDeclarationKey(typeName, decl.Namespace)
+			if !ok {
+				continue
+			}
+			br.globalDeclarationsCache[key] = GlobalDeclaration{
 				RawSource: decl.Raw,
 				FilePath:  filePath,
 			}
 		}
 		for typeName, decl := range fileData.InterfaceDeclarations {
-			br.globalDeclarationsCache[typeName] = GlobalDeclaration{
+			key, ok := globalDeclarationKey(typeName, decl.Namespace)
+			if !ok {
+				continue
+			}
+			br.globalDeclarationsCache[key] = GlobalDeclaration{
 				RawSource: decl.Raw,
 				FilePath:  filePath,
 			}
 		}
 		for typeName, decl := range fileData.EnumDeclarations {
-			br.globalDeclarationsCache[typeName] = GlobalDeclaration{
+			key, ok := globalDeclarationKey(typeName, decl.Namespace)
+			if !ok {
+				continue
+			}
+			br.globalDeclarationsCache[key] = GlobalDeclaration{
 				RawSource: decl.Raw,
 				FilePath:  filePath,
 			}
@@ -131,6 +143,22 @@ func (br *CollectResult) preCacheGlobalDeclarations() {
 	}
 }
 
+// globalDeclarationKey 返回声明在全局声明缓存中的 key。
+// `declare global {}` 中的声明以其名称作为 key，命名空间中的声明以限定名称 (e.g., "API.User") 作为 key，
+// 环境模块声明（`declare module 'x' {}`）中的声明只能通过导入访问，不属于全局声明，返回 false。
+func globalDeclarationKey(key string, namespace string) (string, bool) {
+	if namespace == "" {
+		return key, true
+	}
+	if namespace == "global" || strings.HasPrefix(namespace, "global.") {
+		return strings.TrimPrefix(key, "global."), true
+	}
+	if strings.HasPrefix(namespace, `"`) {
+		return "", false
+	}
+	return key, true
+}
+
 // scanGlobalDeclarationsIfNeeded 确保全局声明只在需要时被扫描一次。
 // 它采用懒加载策略，避免在初始化时进行不必要的昂贵操作。
 func (br *CollectResult) scanGlobalDeclarationsIfNeeded() {
@@ -39,16 +39,20 @@ package cmd
 //   可选：
 //     --git-root <path>         Git 仓库根（默认=project-root）
 //     --manifest <path>         组件清单路径
-//     --format json|pretty|summary  输出格式（默认 json）
+//     --format json|pretty|summary|sarif  输出格式（默认 json）
 //     --output <path>           输出文件
 //     --exclude <pattern>       排除 glob 模式
 //     --max-depth <n>           最大深度（默认 10）
+//     --jobs <n>                并发解析文件的 worker 数量（默认 CPU 核数）
+//     --cache-dir <path>        持久化解析缓存目录（如 .analyzer/cache，默认不启用）
+//     --strict                  严格模式，解析过程中出现 error 级别的诊断信息时失败
 //     --quiet                   静默模式
 //
 // 输出格式：
 //   json    - 紧凑 JSON，程序解析（默认）
 //   pretty  - 美化 JSON，人工阅读
 //   summary - 简要摘要，快速查看
+//   sarif   - SARIF 2.1.0，变更文件与受影响文件作为发现项，供代码扫描标注
 
 import (
 	"context"
@@ -56,8 +60,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
+	"github.com/Flying-Bird1999/analyzer-ts/analyzer/projectParser"
+	projectanalyzer "github.com/Flying-Bird1999/analyzer-ts/analyzer_plugin/project_analyzer"
 	"github.com/Flying-Bird1999/analyzer-ts/pkg/pipeline"
 	"github.com/spf13/cobra"
 )
@@ -82,11 +89,13 @@ var (
 	gitRoot      string // Git 仓库根目录（可选，默认等于 projectRoot）
 	manifestPath string // 组件清单路径（可选）
 	maxDepth     int    // 影响分析最大深度
-	// excludePaths 已在 scan.go 中声明（包级别共享变量）
+	cacheDir     string // 持久化解析缓存目录（可选）
+	strictMode   bool   // 严格模式，解析出现 error 级别的诊断信息时失败
+	// excludePaths、jobs 已在 scan.go 中声明（包级别共享变量）
 
 	// 输出配置
 	outputFile   string // 输出文件路径（可选，默认 stdout）
-	outputFormat string // 输出格式：json | pretty | summary
+	outputFormat string // 输出格式：json | pretty | summary | sarif
 	verbose      bool   // 详细输出
 	showSymbols  bool   // 显示符号级分析结果
 	quiet        bool   // 静默模式，只输出结果
@@ -136,10 +145,13 @@ func init() {
 
 	// 分析配置
 	ImpactCmd.Flags().IntVar(&maxDepth, "max-depth", 10, "影响分析最大深度")
+	ImpactCmd.Flags().IntVar(&jobs, "jobs", 0, "并发解析文件的 worker 数量（默认 CPU 核数）")
+	ImpactCmd.Flags().BoolVar(&strictMode, "strict", false, "严格模式：解析过程中出现 error 级别的诊断信息时失败")
+	ImpactCmd.Flags().StringVar(&cacheDir, "cache-dir", "", "持久化解析缓存目录（如 .analyzer/cache，相对路径以项目根目录为基准）")
 
 	// 输出配置
 	ImpactCmd.Flags().StringVarP(&outputFile, "output", "o", "", "输出文件路径（可选，默认 stdout）")
-	ImpactCmd.Flags().StringVar(&outputFormat, "format", "json", "输出格式：json | pretty | summary")
+	ImpactCmd.Flags().StringVar(&outputFormat, "format", "json", "输出格式：json | pretty | summary | sarif")
 	ImpactCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "详细输出")
 	ImpactCmd.Flags().BoolVar(&showSymbols, "show-symbols", false, "显示符号级分析结果")
 	ImpactCmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "静默模式，只输出结果")
@@ -188,6 +200,21 @@ func runImpact(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// 设置并发解析的 worker 数量
+	if jobs > 0 {
+		analysisCtx.SetOption("jobs", jobs)
+	}
+
+	// 设置持久化解析缓存目录
+	if cacheDir != "" {
+		analysisCtx.SetOption("cacheDir", cacheDir)
+	}
+
+	// 严格模式
+	if strictMode {
+		analysisCtx.SetOption("strict", true)
+	}
+
 	// 如果是 diff 字符串输入，通过 context 传递
 	if source == pipeline.DiffSourceString && diffString != "" {
 		analysisCtx.SetOption("diffString", diffString)
@@ -276,8 +303,8 @@ func validateFlags() error {
 	}
 
 	// 检查输出格式
-	if outputFormat != "json" && outputFormat != "pretty" && outputFormat != "summary" {
-		return fmt.Errorf("无效的输出格式: %s，必须是 json、pretty 或 summary", outputFormat)
+	if outputFormat != "json" && outputFormat != "pretty" && outputFormat != "summary" && outputFormat != "sarif" {
+		return fmt.Errorf("无效的输出格式: %s，必须是 json、pretty、summary 或 sarif", outputFormat)
 	}
 
 	return nil
@@ -384,6 +411,8 @@ type AnalysisOutput struct {
 	} `json:"fileAnalysis"`
 
 	ComponentAnalysis *ComponentAnalysisOutput `json:"componentAnalysis,omitempty"` // 组件分析结果（可选）
+
+	Diagnostics []projectParser.Diagnostic `json:"diagnostics"` // 项目解析过程中遇到的问题
 }
 
 // SymbolAnalysisOutput 符号分析输出
@@ -466,6 +495,14 @@ func buildOutput(result *pipeline.PipelineResult) (*AnalysisOutput, error) {
 	output.Meta.AnalyzedAt = time.Now().Format(time.RFC3339)
 	output.Meta.InputSource = sourceDesc(determineSourceType())
 
+	// 填充项目解析的诊断信息
+	output.Diagnostics = []projectParser.Diagnostic{}
+	if parseResult, ok := result.GetResult("项目解析"); ok {
+		if parsingResult, ok := parseResult.(*projectParser.ProjectParserResult); ok {
+			output.Diagnostics = append(output.Diagnostics, parsingResult.Diagnostics...)
+		}
+	}
+
 	// 获取影响分析结果
 	impactResult, ok := result.GetResult("影响分析（文件级）")
 	if !ok {
@@ -592,6 +629,8 @@ func writeOutput(output *AnalysisOutput) error {
 		data, err = json.MarshalIndent(output, "", "  ")
 	case "summary":
 		data = []byte(buildSummary(output))
+	case "sarif":
+		data, err = json.MarshalIndent(projectanalyzer.NewSARIFLog(projectRoot, buildFindings(output)), "", "  ")
 	default:
 		data, err = json.Marshal(output)
 	}
@@ -617,6 +656,39 @@ func writeOutput(output *AnalysisOutput) error {
 	return nil
 }
 
+// buildFindings 将影响分析结果转换为统一格式的发现项：
+// 变更文件与受影响文件各报告一条 info，受影响组件不对应具体文件，只记录消息。
+func buildFindings(output *AnalysisOutput) []projectanalyzer.Finding {
+	var findings []projectanalyzer.Finding
+	for _, change := range output.FileAnalysis.Changes {
+		findings = append(findings, projectanalyzer.Finding{
+			RuleID:   "impact/changed-file",
+			Severity: projectanalyzer.SeverityInfo,
+			Message:  fmt.Sprintf("文件发生变更 (%s)", change.Type),
+			File:     change.Path,
+		})
+	}
+	for _, impact := range output.FileAnalysis.Impact {
+		findings = append(findings, projectanalyzer.Finding{
+			RuleID:   "impact/impacted-file",
+			Severity: projectanalyzer.SeverityInfo,
+			Message:  fmt.Sprintf("受变更影响 (层级 %d)，影响来源: %s", impact.ImpactLevel, strings.Join(impact.ChangePaths, ", ")),
+			File:     impact.Path,
+		})
+	}
+	if output.ComponentAnalysis != nil {
+		for _, impact := range output.ComponentAnalysis.Impact {
+			findings = append(findings, projectanalyzer.Finding{
+				RuleID:   "impact/impacted-component",
+				Severity: projectanalyzer.SeverityInfo,
+				Message:  fmt.Sprintf("组件 %s 受变更影响 (层级 %d)，影响来源: %s", impact.Name, impact.ImpactLevel, strings.Join(impact.ChangePaths, ", ")),
+			})
+		}
+	}
+	projectanalyzer.SortFindings(findings)
+	return append(findings, projectanalyzer.DiagnosticFindings(output.Diagnostics)...)
+}
+
 // buildSummary 构建简要摘要
 func buildSummary(output *AnalysisOutput) string {
 	var summary string
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/Flying-Bird1999/analyzer-ts/analyzer/projectParser"
+	projectanalyzer "github.com/Flying-Bird1999/analyzer-ts/analyzer_plugin/project_analyzer"
+)
+
+func TestBuildFindings(t *testing.T) {
+	output := &AnalysisOutput{}
+	output.FileAnalysis.Changes = []FileChangeOutput{{Path: "/project/src/b.ts", Type: "modified"}}
+	output.FileAnalysis.Impact = []FileImpactOutput{
+		{Path: "/project/src/a.ts", ImpactLevel: 1, ChangePaths: []string{"/project/src/b.ts"}},
+	}
+	output.ComponentAnalysis = &ComponentAnalysisOutput{
+		Impact: []ComponentImpactOutput{{Name: "Button", ImpactLevel: 2, ChangePaths: []string{"Input", "Form"}}},
+	}
+	output.Diagnostics = []projectParser.Diagnostic{{
+		File:     "/project/src/broken.ts",
+		Phase:    projectParser.DiagnosticPhaseParse,
+		Severity: projectParser.DiagnosticSeverityError,
+		Message:  "解析失败",
+	}}
+
+	// 分析结果的发现项按文件排序，诊断信息排在最后
+	expected := []projectanalyzer.Finding{
+		{RuleID: "impact/impacted-component", Severity: projectanalyzer.SeverityInfo, Message: "组件 Button 受变更影响 (层级 2)，影响来源: Input, Form"},
+		{RuleID: "impact/impacted-file", Severity: projectanalyzer.SeverityInfo, Message: "受变更影响 (层级 1)，影响来源: /project/src/b.ts", File: "/project/src/a.ts"},
+		{RuleID: "impact/changed-file", Severity: projectanalyzer.SeverityInfo, Message: "文件发生变更 (modified)", File: "/project/src/b.ts"},
+		{RuleID: "diagnostics/parse", Severity: projectanalyzer.SeverityError, Message: "解析失败", File: "/project/src/broken.ts"},
+	}
+	findings := buildFindings(output)
+	if len(findings) != len(expected) {
+		t.Fatalf("预期 %d 个发现项, 得到 %d 个: %+v", len(expected), len(findings), findings)
+	}
+	for i := range expected {
+		if findings[i] != expected[i] {
+			t.Errorf("第 %d 个发现项预期为 %+v, 得到 %+v", i, expected[i], findings[i])
+		}
+	}
+
+	// 没有组件分析结果时只报告文件
+	output.ComponentAnalysis = nil
+	output.Diagnostics = nil
+	if findings := buildFindings(output); len(findings) != 2 {
+		t.Errorf("没有组件分析结果时预期 2 个发现项, 得到 %+v", findings)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/Flying-Bird1999/analyzer-ts/analyzer/projectParser"
 	"github.com/Flying-Bird1999/analyzer-ts/analyzer/scanProject"
 	"github.com/spf13/cobra"
 )
@@ -21,6 +22,14 @@ var (
 	isMonorepo bool
 	// outputDir 存储用户通过 --output 或 -o 标志指定的输出目录。如果为空，则结果将打印到标准输出。
 	outputDir string
+	// jobs 存储用户通过 --jobs 标志指定的并发 worker 数量，在 scan 和 impact 命令之间共享。
+	jobs int
+	// gitignore 标记扫描时是否遵循 .gitignore、.git/info/exclude 与 .analyzerignore，通过 --gitignore 标志设置。
+	gitignore bool
+	// showExcluded 标记是否输出被排除的文件及其原因，通过 --show-excluded 标志设置。
+	showExcluded bool
+	// strict 标记是否启用严格模式，遍历目录出现错误时以非零状态退出，通过 --strict 标志设置。
+	strict bool
 )
 
 // ScanCmd 定义了 `scan` 命令的所有行为和属性。
@@ -30,13 +39,20 @@ var ScanCmd = &cobra.Command{
 	// Short 是命令的简短描述，会显示在帮助列表（-h）中。
 	Short: "扫描项目仓库以列出所有文件",
 	// Long 是命令的详细描述，当用户运行 `go run main.go help scan` 时显示。
-	Long: `扫描给定的项目仓库，列出所有文件，并根据排除模式进行过滤。支持 JSON 格式输出。`,
+	Long: `扫描给定的项目仓库，列出所有文件，并根据排除模式进行过滤。支持 JSON 格式输出。
+使用 --gitignore 时会额外遵循各级目录的 .gitignore、.git/info/exclude 以及 .analyzerignore；
+使用 --show-excluded 可以查看每个被排除的文件或目录命中的规则。
+遍历目录时遇到的错误（例如无权限读取的目录）记录在 scan_diagnostics.json 中（未指定 -o 时打印到标准错误）；
+使用 --strict 时，只要存在这类错误，命令就会以非零状态退出。`,
 	// Run 是 `scan` 命令的核心逻辑。当命令被调用时，这个函数会被执行。
 	Run: func(cmd *cobra.Command, args []string) {
 		// 1. 基于用户输入的参数，初始化项目扫描器。
 		pr := scanProject.NewProjectResult(inputDir, excludePaths, isMonorepo)
+		pr.Jobs = jobs
+		pr.Gitignore = gitignore
 		// 执行文件列表的扫描。
 		pr.ScanFileList()
+		diagnostics := projectParser.ScanDiagnostics(pr.GetErrors())
 
 		// 2. 检查用户是否指定了输出目录。
 		if outputDir != "" {
@@ -66,11 +82,58 @@ var ScanCmd = &cobra.Command{
 			}
 			// 提示用户操作成功。
 			fmt.Printf("扫描结果已成功写入: %s\n", outputPath)
+
+			// 如果需要，将被排除的文件及原因写入 scan_excluded.json。
+			if showExcluded {
+				excludedData, err := json.MarshalIndent(pr.GetExcludedList(), "", "  ")
+				if err != nil {
+					fmt.Printf("错误：序列化JSON失败: %s\n", err)
+					os.Exit(1)
+				}
+				excludedPath := filepath.Join(outputDir, "scan_excluded.json")
+				if err := os.WriteFile(excludedPath, excludedData, 0644); err != nil {
+					fmt.Printf("错误：写入文件失败: %s\n", err)
+					os.Exit(1)
+				}
+				fmt.Printf("排除列表已成功写入: %s\n", excludedPath)
+			}
+
+			// 将遍历目录时遇到的问题写入 scan_diagnostics.json。
+			diagnosticsData, err := json.MarshalIndent(diagnostics, "", "  ")
+			if err != nil {
+				fmt.Printf("错误：序列化JSON失败: %s\n", err)
+				os.Exit(1)
+			}
+			diagnosticsPath := filepath.Join(outputDir, "scan_diagnostics.json")
+			if err := os.WriteFile(diagnosticsPath, diagnosticsData, 0644); err != nil {
+				fmt.Printf("错误：写入文件失败: %s\n", err)
+				os.Exit(1)
+			}
 		} else {
 			// 2.2. 如果未指定输出目录，则将结果逐行打印到标准输出（控制台）。
 			for path, item := range pr.GetFileList() {
 				fmt.Printf("file: %s, fileName: %s, size: %s, ext: %s\n", path, item.FileName, item.Size, item.Ext)
 			}
+			if showExcluded {
+				for path, item := range pr.GetExcludedList() {
+					fmt.Printf("excluded: %s, reason: %s, rule: %s", path, item.Reason, item.Rule)
+					if item.Source != "" {
+						fmt.Printf(", source: %s", item.Source)
+					}
+					fmt.Println()
+				}
+			}
+			for _, d := range diagnostics {
+				fmt.Fprintf(os.Stderr, "[%s][%s] %s: %s\n", d.Severity, d.Phase, d.File, d.Message)
+			}
+		}
+
+		// 3. 严格模式下，遍历目录出现错误时以非零状态退出。
+		if strict {
+			if err := projectParser.CheckStrictDiagnostics(diagnostics); err != nil {
+				fmt.Printf("错误：%s\n", err)
+				os.Exit(1)
+			}
 		}
 	},
 }
@@ -83,6 +146,10 @@ func init() {
 	ScanCmd.Flags().StringSliceVarP(&excludePaths, "exclude", "x", []string{}, "要排除的 glob 模式")
 	ScanCmd.Flags().BoolVarP(&isMonorepo, "monorepo", "m", false, "是否为 monorepo 项目？")
 	ScanCmd.Flags().StringVarP(&outputDir, "output", "o", "", "用于存放 scan_result.json 的输出目录（可选，默认为标准输出）")
+	ScanCmd.Flags().IntVar(&jobs, "jobs", 0, "并发遍历目录的 worker 数量（默认为 CPU 核数）")
+	ScanCmd.Flags().BoolVar(&gitignore, "gitignore", false, "遵循 .gitignore、.git/info/exclude 与 .analyzerignore 中的规则")
+	ScanCmd.Flags().BoolVar(&strict, "strict", false, "严格模式：遍历目录出现错误时以非零状态退出")
+	ScanCmd.Flags().BoolVar(&showExcluded, "show-excluded", false, "输出被排除的文件或目录及其原因（指定 -o 时写入 scan_excluded.json）")
 
 	// 将 --input 标志设置为必需项，如果用户未提供此标志，Cobra 将会报错。
 	ScanCmd.MarkFlagRequired("input")
@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 
+	"github.com/Flying-Bird1999/analyzer-ts/analyzer/projectParser"
 	"github.com/spf13/cobra"
 )
 
@@ -19,5 +20,7 @@ var versionCmd = &cobra.Command{
 }
 
 func init() {
+	// 工具版本参与解析缓存指纹的计算，升级后旧缓存自动失效
+	projectParser.ToolVersion = version
 	RootCmd.AddCommand(versionCmd)
 }
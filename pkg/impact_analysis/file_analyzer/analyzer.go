@@ -138,6 +138,7 @@ func buildFileImpactInfos(impactedFiles *ImpactedFiles) []FileImpactInfo {
 			ImpactLevel: impact.ImpactLevel,
 			ChangePaths: impact.ChangePaths,
 			SymbolCount: impact.SymbolCount,
+			TypeOnly:    impact.TypeOnly,
 		})
 	}
 
@@ -161,6 +162,7 @@ type FileImpactInfo struct {
 	ImpactLevel int                         `json:"impactLevel"`
 	ChangePaths []string                    `json:"changePaths"`
 	SymbolCount int                         `json:"symbolCount"` // 影响的符号数量
+	TypeOnly    bool                        `json:"typeOnly,omitempty"` // 是否只通过类型导入受到影响，不影响运行时行为
 }
 
 // =============================================================================
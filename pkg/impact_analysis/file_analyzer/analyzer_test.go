@@ -358,6 +358,137 @@ func TestSymbolPropagator_TransitiveImpact(t *testing.T) {
 	}
 }
 
+// TestSymbolPropagator_TypeOnlyImpact 测试类型导入的影响不传播为运行时影响
+// types.ts <- (import type) Button.tsx <- App.tsx；types.ts <- Form.tsx
+func TestSymbolPropagator_TypeOnlyImpact(t *testing.T) {
+	parsingResult := &projectParser.ProjectParserResult{
+		Js_Data: make(map[string]projectParser.JsFileParserResult),
+	}
+
+	// types.ts exports: Props
+	parsingResult.Js_Data["/project/types.ts"] = projectParser.JsFileParserResult{
+		ExportDeclarations: []projectParser.ExportDeclarationResult{
+			{
+				ExportModules: []projectParser.ExportModule{
+					{Identifier: "Props", Type: "named"},
+				},
+			},
+		},
+	}
+
+	// Button.tsx: import type { Props } from './types'
+	parsingResult.Js_Data["/project/Button.tsx"] = projectParser.JsFileParserResult{
+		ImportDeclarations: []projectParser.ImportDeclarationResult{
+			{
+				Source:     projectParser.SourceData{FilePath: "/project/types.ts"},
+				IsTypeOnly: true,
+				ImportModules: []projectParser.ImportModule{
+					{Identifier: "Props", Type: "named", IsTypeOnly: true},
+				},
+			},
+		},
+	}
+
+	// App.tsx: import { Button } from './Button'
+	parsingResult.Js_Data["/project/App.tsx"] = projectParser.JsFileParserResult{
+		ImportDeclarations: []projectParser.ImportDeclarationResult{
+			{
+				Source: projectParser.SourceData{FilePath: "/project/Button.tsx"},
+				ImportModules: []projectParser.ImportModule{
+					{Identifier: "Button", Type: "named"},
+				},
+			},
+		},
+	}
+
+	// Form.tsx: import { Props, type Other } from './types'
+	parsingResult.Js_Data["/project/Form.tsx"] = projectParser.JsFileParserResult{
+		ImportDeclarations: []projectParser.ImportDeclarationResult{
+			{
+				Source: projectParser.SourceData{FilePath: "/project/types.ts"},
+				ImportModules: []projectParser.ImportModule{
+					{Identifier: "Props", Type: "named"},
+					{Identifier: "Other", Type: "named", IsTypeOnly: true},
+				},
+			},
+		},
+	}
+
+	changedSymbols := []ChangedSymbol{
+		{
+			Name:       "Props",
+			FilePath:   "/project/types.ts",
+			ExportType: symbol_analysis.ExportTypeNamed,
+		},
+	}
+
+	propagator := NewSymbolPropagator(parsingResult)
+	result := propagator.Propagate(changedSymbols, nil)
+
+	expected := map[string]bool{
+		"/project/Button.tsx": true,  // 只以类型导入 Props
+		"/project/App.tsx":    true,  // 上游 Button.tsx 只受类型影响
+		"/project/Form.tsx":   false, // 以运行时导入使用 Props
+	}
+	for filePath, typeOnly := range expected {
+		impact, exists := result.Indirect[filePath]
+		if !exists {
+			t.Fatalf("%s should be impacted", filePath)
+		}
+		if impact.TypeOnly != typeOnly {
+			t.Errorf("%s TypeOnly = %v, want %v", filePath, impact.TypeOnly, typeOnly)
+		}
+	}
+}
+
+// TestSymbolPropagator_DynamicImportImpact 测试 import.meta.glob 展开的动态导入同样传播影响
+// pages/User.tsx <- (import.meta.glob('./pages/*.tsx')) router.ts
+func TestSymbolPropagator_DynamicImportImpact(t *testing.T) {
+	parsingResult := &projectParser.ProjectParserResult{
+		Js_Data: make(map[string]projectParser.JsFileParserResult),
+	}
+
+	// pages/User.tsx exports: UserPage
+	parsingResult.Js_Data["/project/pages/User.tsx"] = projectParser.JsFileParserResult{
+		ExportDeclarations: []projectParser.ExportDeclarationResult{
+			{
+				ExportModules: []projectParser.ExportModule{
+					{Identifier: "UserPage", Type: "named"},
+				},
+			},
+		},
+	}
+
+	// router.ts: const pages = import.meta.glob('./pages/*.tsx')
+	parsingResult.Js_Data["/project/router.ts"] = projectParser.JsFileParserResult{
+		ImportDeclarations: []projectParser.ImportDeclarationResult{
+			{
+				Source:    projectParser.SourceData{FilePath: "/project/pages/User.tsx", Type: "file"},
+				IsDynamic: true,
+				Pattern:   "./pages/*.tsx",
+				ImportModules: []projectParser.ImportModule{
+					{ImportModule: "default", Identifier: "default", Type: "dynamic"},
+				},
+			},
+		},
+	}
+
+	changedSymbols := []ChangedSymbol{
+		{
+			Name:       "UserPage",
+			FilePath:   "/project/pages/User.tsx",
+			ExportType: symbol_analysis.ExportTypeNamed,
+		},
+	}
+
+	propagator := NewSymbolPropagator(parsingResult)
+	result := propagator.Propagate(changedSymbols, nil)
+
+	if _, exists := result.Indirect["/project/router.ts"]; !exists {
+		t.Error("router.ts should be impacted (pages are loaded by import.meta.glob)")
+	}
+}
+
 // =============================================================================
 // 新增场景测试
 // =============================================================================
@@ -658,6 +789,55 @@ func TestSymbolPropagator_NonSymbolFileChange(t *testing.T) {
 	}
 }
 
+// TestSymbolPropagator_StyleImportChain 测试样式文件之间的 @import 依赖传播影响
+func TestSymbolPropagator_StyleImportChain(t *testing.T) {
+	parsingResult := &projectParser.ProjectParserResult{
+		Js_Data:  make(map[string]projectParser.JsFileParserResult),
+		Css_Data: make(map[string]projectParser.CssFileInfo),
+	}
+
+	// index.module.less: @import './vars.less';
+	parsingResult.Css_Data["/project/index.module.less"] = projectParser.CssFileInfo{
+		ImportDeclarations: []projectParser.ImportDeclarationResult{
+			{
+				Source:        projectParser.SourceData{FilePath: "/project/vars.less", Type: "style"},
+				ImportModules: []projectParser.ImportModule{},
+			},
+		},
+	}
+	parsingResult.Css_Data["/project/vars.less"] = projectParser.CssFileInfo{}
+
+	// View.tsx: import styles from './index.module.less'
+	parsingResult.Js_Data["/project/View.tsx"] = projectParser.JsFileParserResult{
+		ImportDeclarations: []projectParser.ImportDeclarationResult{
+			{
+				Source: projectParser.SourceData{FilePath: "/project/index.module.less", Type: "style"},
+				ImportModules: []projectParser.ImportModule{
+					{ImportModule: "default", Identifier: "styles", Type: "default"},
+				},
+			},
+		},
+	}
+
+	propagator := NewSymbolPropagator(parsingResult)
+	result := propagator.Propagate(nil, []string{"/project/vars.less"})
+
+	if _, exists := result.Direct["/project/vars.less"]; !exists {
+		t.Error("vars.less should be in Direct changes")
+	}
+	if _, exists := result.Indirect["/project/index.module.less"]; !exists {
+		t.Error("index.module.less should be impacted (it @imports vars.less)")
+	}
+	if _, exists := result.Indirect["/project/View.tsx"]; !exists {
+		t.Error("View.tsx should be impacted through index.module.less")
+	}
+
+	graph := NewGraphBuilder(parsingResult).BuildFileDependencyGraph()
+	if deps := graph.DepGraph["/project/index.module.less"]; len(deps) != 1 || deps[0] != "/project/vars.less" {
+		t.Errorf("index.module.less should depend on vars.less, got %v", deps)
+	}
+}
+
 // TestSymbolPropagator_DirectImpactSameFile 测试同一文件内既有变更又有影响
 func TestSymbolPropagator_DirectImpactSameFile(t *testing.T) {
 	parsingResult := &projectParser.ProjectParserResult{
@@ -60,6 +60,13 @@ func (b *GraphBuilder) BuildFileDependencyGraph() *FileDependencyGraph {
 		}
 	}
 
+	// 遍历所有已解析的样式文件的 @import / @use 依赖
+	for sourceFile, cssResult := range b.parsingResult.Css_Data {
+		for _, importDecl := range cssResult.ImportDeclarations {
+			b.processImport(sourceFile, importDecl, graph)
+		}
+	}
+
 	// 构建反向依赖图
 	b.buildReverseGraph(graph)
 
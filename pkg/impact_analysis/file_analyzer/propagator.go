@@ -21,6 +21,9 @@ type SymbolPropagator struct {
 	// reverseImportIndex 反向导入索引：filePath -> importers[]
 	// 预构建后可将 getFilesImporting 从 O(N) 优化到 O(1)
 	reverseImportIndex map[string][]string
+	// runtimeImportEdges 运行时导入边：importer -> filePath 之间存在非类型导入时为 true
+	// 只有类型导入（import type）的边在编译后会被移除，不传播运行时影响
+	runtimeImportEdges map[importEdge]bool
 	// indexBuilt 标记索引是否已构建
 	indexBuilt bool
 	// maxDepth 最大传播深度（0=不限制，推荐值：3-5）
@@ -40,6 +43,7 @@ func NewSymbolPropagatorWithMaxDepth(parsingResult *projectParser.ProjectParserR
 	p := &SymbolPropagator{
 		parsingResult:      parsingResult,
 		reverseImportIndex: make(map[string][]string),
+		runtimeImportEdges: make(map[importEdge]bool),
 		indexBuilt:         false,
 		maxDepth:           maxDepth,
 	}
@@ -57,25 +61,33 @@ func (p *SymbolPropagator) buildReverseIndex() {
 	}
 
 	p.reverseImportIndex = make(map[string][]string)
+	p.runtimeImportEdges = make(map[importEdge]bool)
 
-	for sourceFile, fileResult := range p.parsingResult.Js_Data {
-		for _, importDecl := range fileResult.ImportDeclarations {
-			targetFile := importDecl.Source.FilePath
-			if targetFile == "" {
-				continue // 跳过外部依赖
+	// 只考虑 import 语句与样式文件的 @import / @use，再导出与 JSX 引用由符号级传播单独处理
+	reverse := projectParser.BuildImportGraph(p.parsingResult).Reverse()
+	for targetFile, edges := range reverse {
+		for _, edge := range edges {
+			if edge.Kind != projectParser.ImportEdgeKindImport && edge.Kind != projectParser.ImportEdgeKindStyle {
+				continue
+			}
+			// 记录：targetFile 被 edge.From 导入
+			p.reverseImportIndex[targetFile] = appendUnique(p.reverseImportIndex[targetFile], edge.From)
+			// 样式文件之间的依赖同样是运行时依赖
+			if !edge.IsTypeOnly {
+				p.runtimeImportEdges[importEdge{importer: edge.From, target: targetFile}] = true
 			}
-
-			// 记录：targetFile 被 sourceFile 导入
-			p.reverseImportIndex[targetFile] = appendUnique(
-				p.reverseImportIndex[targetFile],
-				sourceFile,
-			)
 		}
 	}
 
 	p.indexBuilt = true
 }
 
+// importEdge 导入边：importer 导入了 target
+type importEdge struct {
+	importer string
+	target   string
+}
+
 // ImpactedFiles 受影响的文件集合
 type ImpactedFiles struct {
 	Direct   map[string]*FileImpact // 直接变更的文件
@@ -88,6 +100,7 @@ type FileImpact struct {
 	ImpactLevel int      // 影响层级（0=直接，1=间接，2+=二级）
 	ChangePaths []string // 从变更源头到该文件的路径
 	SymbolCount int      // 影响的符号数量
+	TypeOnly    bool     // 是否只通过类型导入受到影响（不影响运行时行为）
 }
 
 // Propagate 传播符号影响
@@ -331,7 +344,9 @@ func (p *SymbolPropagator) matchImportsWithChangedSymbols(
 			isDefaultExport := export.Name == "default" && export.ExportType == symbol_analysis.ExportTypeDefault
 			isDefaultImport := module.Type == "default"
 
-			if isDefaultExport && isDefaultImport {
+			if importDecl.IsDynamic {
+				// 动态导入（import()、import.meta.glob() 等）拿到的是整个模块，所有导出都可能被使用
+			} else if isDefaultExport && isDefaultImport {
 				// 对于 export default，不管导入名是什么都匹配
 				// 因为 import Button from ... 和 import MyButton from ... 都引用同一个默认导出
 			} else if export.Name != importedName {
@@ -358,6 +373,7 @@ func (p *SymbolPropagator) matchImportsWithChangedSymbols(
 				SourceFile: sourceFile, // 符号所属的文件
 				ImportType: module.Type,
 				ExportType: export.ExportType,
+				TypeOnly:   importDecl.IsTypeOnly || module.IsTypeOnly,
 			})
 		}
 	}
@@ -371,11 +387,17 @@ func (p *SymbolPropagator) matchImportsWithChangedSymbols(
 // - export default X 匹配 import X (default import)
 // - export { X } 匹配 import { X } (named import)
 // - export * as X 匹配 import * as X (namespace import)
+// - 动态导入匹配所有导出
 func (p *SymbolPropagator) isExportImportMatch(
 	exportType symbol_analysis.ExportType,
 	importType string,
 	importDecl projectParser.ImportDeclarationResult,
 ) bool {
+	// 动态导入拿到的是整个模块，与任何导出都匹配
+	if importDecl.IsDynamic {
+		return true
+	}
+
 	// 检查匹配规则
 	switch exportType {
 	case symbol_analysis.ExportTypeDefault:
@@ -418,27 +440,31 @@ func (p *SymbolPropagator) findFilesImportingNonSymbols(
 		nonSymbolSet[file] = true
 	}
 
-	// 遍历所有文件，查找导入非符号文件的文件
-	for filePath, fileResult := range p.parsingResult.Js_Data {
-		for _, importDecl := range fileResult.ImportDeclarations {
+	// 检查是否导入的是非符号文件
+	collect := func(filePath string, importDecls []projectParser.ImportDeclarationResult) {
+		for _, importDecl := range importDecls {
 			sourceFile := importDecl.Source.FilePath
-			if sourceFile == "" {
+			if sourceFile == "" || !nonSymbolSet[sourceFile] {
 				continue
 			}
-
-			// 检查是否导入的是非符号文件
-			if nonSymbolSet[sourceFile] {
-				// 创建一个特殊的符号影响，标记为非符号文件导入
-				result[filePath] = append(result[filePath], &SymbolImpact{
-					SymbolName: "",        // 非符号文件没有符号名
-					SourceFile: sourceFile, // 导入的非符号文件路径
-					ImportType: "non-symbol",
-					ExportType: symbol_analysis.ExportTypeNone,
-				})
-			}
+			// 创建一个特殊的符号影响，标记为非符号文件导入
+			result[filePath] = append(result[filePath], &SymbolImpact{
+				SymbolName: "",         // 非符号文件没有符号名
+				SourceFile: sourceFile, // 导入的非符号文件路径
+				ImportType: "non-symbol",
+				ExportType: symbol_analysis.ExportTypeNone,
+			})
 		}
 	}
 
+	// 遍历所有文件，查找导入非符号文件的文件；样式文件通过 @import / @use 导入其他样式文件
+	for filePath, fileResult := range p.parsingResult.Js_Data {
+		collect(filePath, fileResult.ImportDeclarations)
+	}
+	for filePath, cssResult := range p.parsingResult.Css_Data {
+		collect(filePath, cssResult.ImportDeclarations)
+	}
+
 	return result
 }
 
@@ -462,11 +488,13 @@ func (p *SymbolPropagator) bfsPropagation(
 	for filePath, impacts := range directImpactedFiles {
 		if _, alreadyDirect := result.Direct[filePath]; !alreadyDirect {
 			// 将直接受影响的文件加入 Indirect 结果
+			typeOnly := allTypeOnly(impacts)
 			result.Indirect[filePath] = &FileImpact{
 				FilePath:    filePath,
 				ImpactLevel: 1,
 				ChangePaths: []string{filePath},
 				SymbolCount: len(impacts),
+				TypeOnly:    typeOnly,
 			}
 
 			// 加入队列用于 BFS 传播到下游文件
@@ -476,6 +504,7 @@ func (p *SymbolPropagator) bfsPropagation(
 				path:        []string{filePath},
 				depth:       1,
 				sourceFiles: p.getSourceFilesFromImpacts(impacts),
+				typeOnly:    typeOnly,
 			})
 			visited[filePath] = true
 		}
@@ -499,6 +528,9 @@ func (p *SymbolPropagator) bfsPropagation(
 			// 计算新的影响层级：总是递增1级
 			newDepth := current.depth + 1
 
+			// 只要当前文件只受类型影响，或下游文件只以类型导入当前文件，下游文件就只受类型影响
+			typeOnly := current.typeOnly || !p.isRuntimeImport(downstream, current.filePath)
+
 			// 添加到结果
 			if existing, exists := result.Indirect[downstream]; exists {
 				// 更新影响层级（取最小值）
@@ -509,12 +541,25 @@ func (p *SymbolPropagator) bfsPropagation(
 				existing.ChangePaths = append(existing.ChangePaths, formatPath(newPath))
 				// 添加新的影响符号
 				existing.SymbolCount += len(current.symbols)
+				// 之前只受类型影响的文件又通过运行时导入受到影响时，需要重新向下游传播运行时影响
+				if existing.TypeOnly && !typeOnly {
+					existing.TypeOnly = false
+					queue.PushBack(&propagationNode{
+						filePath:    downstream,
+						symbols:     current.symbols,
+						path:        newPath,
+						depth:       newDepth,
+						sourceFiles: current.sourceFiles,
+						typeOnly:    false,
+					})
+				}
 			} else {
 				result.Indirect[downstream] = &FileImpact{
 					FilePath:    downstream,
 					ImpactLevel: newDepth,
 					ChangePaths: []string{formatPath(newPath)},
 					SymbolCount: len(current.symbols),
+					TypeOnly:    typeOnly,
 				}
 
 				// 将下游文件加入队列（如果未访问过）
@@ -525,6 +570,7 @@ func (p *SymbolPropagator) bfsPropagation(
 						path:        newPath,
 						depth:       newDepth,
 						sourceFiles: current.sourceFiles,
+						typeOnly:    typeOnly,
 					})
 					visited[downstream] = true
 				}
@@ -540,6 +586,7 @@ type propagationNode struct {
 	path        []string        // 影响路径
 	depth       int             // 当前深度
 	sourceFiles map[string]bool // 符号来源文件（用于计算影响层级）
+	typeOnly    bool            // 当前文件是否只受类型影响
 }
 
 // SymbolImpact 符号影响信息
@@ -548,6 +595,7 @@ type SymbolImpact struct {
 	SourceFile string                     // 符号所属的文件
 	ImportType string                     // 导入类型
 	ExportType symbol_analysis.ExportType // 导出类型
+	TypeOnly   bool                       // 是否通过类型导入/导出引用该符号
 }
 
 // getFilesImporting 获取导入指定文件的所有文件
@@ -571,6 +619,38 @@ func (p *SymbolPropagator) getFilesImporting(filePath string) []string {
 	return importers
 }
 
+// isRuntimeImport 检查 importer 是否以非类型导入的方式导入了 target
+func (p *SymbolPropagator) isRuntimeImport(importer, target string) bool {
+	if p.indexBuilt {
+		return p.runtimeImportEdges[importEdge{importer: importer, target: target}]
+	}
+
+	// 降级处理：如果索引未构建，直接遍历导入声明
+	fileResult, ok := p.parsingResult.Js_Data[importer]
+	if !ok {
+		return false
+	}
+	for _, importDecl := range fileResult.ImportDeclarations {
+		if importDecl.Source.FilePath == target && !importDecl.OnlyTypes() {
+			return true
+		}
+	}
+	return false
+}
+
+// allTypeOnly 检查符号影响是否全部来自类型导入/导出
+func allTypeOnly(impacts []*SymbolImpact) bool {
+	if len(impacts) == 0 {
+		return false
+	}
+	for _, impact := range impacts {
+		if !impact.TypeOnly {
+			return false
+		}
+	}
+	return true
+}
+
 // getSourceFilesFromImpacts 从符号影响中提取来源文件
 func (p *SymbolPropagator) getSourceFilesFromImpacts(impacts []*SymbolImpact) map[string]bool {
 	sourceFiles := make(map[string]bool)
@@ -1088,6 +1168,7 @@ func (p *SymbolPropagator) findFilesReexportingChangedSymbols(
 					SymbolName: symbolName,
 					SourceFile: sourceFile,
 					ImportType: module.Type,
+					TypeOnly:   exportDecl.IsTypeOnly || module.IsTypeOnly,
 				})
 			}
 		}
@@ -1116,7 +1197,9 @@ func (p *SymbolPropagator) matchImportsWithChangedSymbolsWithReexport(
 			isDefaultExport := export.Name == "default" && export.ExportType == symbol_analysis.ExportTypeDefault
 			isDefaultImport := module.Type == "default"
 
-			if isDefaultExport && isDefaultImport {
+			if importDecl.IsDynamic {
+				// 动态导入（import()、import.meta.glob() 等）拿到的是整个模块，所有导出都可能被使用
+			} else if isDefaultExport && isDefaultImport {
 				// 对于 export default，不管导入名是什么都匹配
 			} else if export.Name != importedName {
 				// 对于非 default 导出，检查名称是否匹配
@@ -1155,6 +1238,7 @@ func (p *SymbolPropagator) matchImportsWithChangedSymbolsWithReexport(
 				SourceFile: actualSourceFile, // 使用实际来源文件
 				ImportType: module.Type,
 				ExportType: export.ExportType,
+				TypeOnly:   importDecl.IsTypeOnly || module.IsTypeOnly,
 			})
 		}
 	}
@@ -63,8 +63,12 @@ func (r *Result) ToConsole() string {
 		// 按影响层级排序
 		sortedByLevel := r.sortByImpactLevel()
 		for _, impact := range sortedByLevel {
-			buffer.WriteString(fmt.Sprintf("▶ %s (层级: %d, 符号数: %d)\n",
-				impact.Path, impact.ImpactLevel, impact.SymbolCount))
+			typeOnlyMark := ""
+			if impact.TypeOnly {
+				typeOnlyMark = ", 仅类型"
+			}
+			buffer.WriteString(fmt.Sprintf("▶ %s (层级: %d, 符号数: %d%s)\n",
+				impact.Path, impact.ImpactLevel, impact.SymbolCount, typeOnlyMark))
 			if len(impact.ChangePaths) > 0 {
 				buffer.WriteString("  影响路径:\n")
 				for _, path := range impact.ChangePaths {
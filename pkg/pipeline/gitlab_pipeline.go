@@ -113,10 +113,15 @@ func (s *ProjectParserStage) Execute(ctx *AnalysisContext) (interface{}, error)
 	fmt.Println("  - 解析项目 AST...")
 
 	// 创建 tsmorphgo.Project（内部会自动解析项目）
-	// 使用 ExcludePaths 来忽略指定的文件/目录
+	// 使用 ExcludePaths 来忽略指定的文件/目录，jobs 选项控制并发解析的 worker 数量，
+	// cacheDir 选项启用持久化解析缓存
+	jobs, _ := ctx.GetOption("jobs", 0).(int)
+	cacheDir, _ := ctx.GetOption("cacheDir", "").(string)
 	projectConfig := tsmorphgo.ProjectConfig{
 		RootPath:       ctx.ProjectRoot,
 		IgnorePatterns: ctx.ExcludePaths,
+		Jobs:           jobs,
+		CacheDir:       cacheDir,
 	}
 
 	project := tsmorphgo.NewProject(projectConfig)
@@ -129,6 +134,16 @@ func (s *ProjectParserStage) Execute(ctx *AnalysisContext) (interface{}, error)
 
 	fileCount := len(parsingResult.Js_Data)
 	fmt.Printf("  - 发现 %d 个 JS/TS 文件\n", fileCount)
+	if len(parsingResult.Diagnostics) > 0 {
+		fmt.Printf("  - 解析过程中出现 %d 条诊断信息\n", len(parsingResult.Diagnostics))
+	}
+
+	// 严格模式下，解析过程中出现 error 级别的诊断信息时终止流水线
+	if strict, _ := ctx.GetOption("strict", false).(bool); strict {
+		if err := parsingResult.CheckStrict(); err != nil {
+			return nil, err
+		}
+	}
 
 	if fileCount == 0 {
 		return nil, fmt.Errorf("no JS/TS files found in project")
@@ -254,7 +254,7 @@ func (a *Analyzer) extractExportsFromFileResult(
 
 	// 从带有内联导出的 VariableDeclarations 提取（例如，export const A = 1）
 	for _, varDecl := range fileResult.VariableDeclarations {
-		if varDecl.Exported {
+		if varDecl.Exported && varDecl.Namespace == "" {
 			// 从声明器中提取符号名称
 			for _, declarator := range varDecl.Declarators {
 				if declarator.Identifier != "" {
@@ -276,7 +276,7 @@ func (a *Analyzer) extractExportsFromFileResult(
 
 	// 从带有内联导出的 FunctionDeclarations 提取（例如，export function A() {}）
 	for _, fnDecl := range fileResult.FunctionDeclarations {
-		if fnDecl.Exported {
+		if fnDecl.Exported && fnDecl.Namespace == "" {
 			lineNum := 0
 			if fnDecl.Node != nil {
 				lineNum = a.calculateLineNumber(sourceFile, fnDecl.Node)
@@ -293,7 +293,7 @@ func (a *Analyzer) extractExportsFromFileResult(
 
 	// 从带有内联导出的 InterfaceDeclarations 提取（例如，export interface A {}）
 	for _, ifaceDecl := range fileResult.InterfaceDeclarations {
-		if ifaceDecl.Exported {
+		if ifaceDecl.Exported && ifaceDecl.Namespace == "" {
 			lineNum := 0
 			if ifaceDecl.Node != nil {
 				lineNum = a.calculateLineNumber(sourceFile, ifaceDecl.Node)
@@ -310,7 +310,7 @@ func (a *Analyzer) extractExportsFromFileResult(
 
 	// 从带有内联导出的 TypeDeclarations 提取（例如，export type A = ...）
 	for _, typeDecl := range fileResult.TypeDeclarations {
-		if typeDecl.Exported {
+		if typeDecl.Exported && typeDecl.Namespace == "" {
 			lineNum := 0
 			if typeDecl.Node != nil {
 				lineNum = a.calculateLineNumber(sourceFile, typeDecl.Node)
@@ -327,7 +327,7 @@ func (a *Analyzer) extractExportsFromFileResult(
 
 	// 从带有内联导出的 EnumDeclarations 提取（例如，export enum A {...}）
 	for _, enumDecl := range fileResult.EnumDeclarations {
-		if enumDecl.Exported {
+		if enumDecl.Exported && enumDecl.Namespace == "" {
 			lineNum := 0
 			if enumDecl.Node != nil {
 				lineNum = a.calculateLineNumber(sourceFile, enumDecl.Node)
@@ -342,103 +342,48 @@ func (a *Analyzer) extractExportsFromFileResult(
 		}
 	}
 
-	// 通过遍历 AST 提取导出的类（parser 没有 ClassDeclarationResult）
-	a.extractExportedClasses(sourceFile, result)
-}
-
-// extractExportedClasses 通过遍历 AST 提取导出的类声明。
-// 这是必要的，因为 parser 没有专门的 ClassDeclarationResult。
-// 使用 AST 节点关系和修饰符而不是字符串匹配来进行准确分析。
-func (a *Analyzer) extractExportedClasses(sourceFile *tsmorphgo.SourceFile, result *FileAnalysisResult) {
-	sourceFile.ForEachDescendant(func(node tsmorphgo.Node) {
-		if !node.IsClassDeclaration() {
-			return
-		}
-
-		// 检查类是否有 export 修饰符（核心 AST-based 检查）
-		hasExportKeyword := false
-		isDefaultExport := false
-
-		// 方法1: 检查子节点中的 ExportKeyword（最准确）
-		node.ForEachChild(func(child tsmorphgo.Node) bool {
-			if child.Kind == ast.KindExportKeyword {
-				hasExportKeyword = true
-				// 继续检查是否有 DefaultKeyword
-				return false // 继续检查 default 关键字
-			}
-			if child.Kind == ast.KindDefaultKeyword {
-				isDefaultExport = true
-				hasExportKeyword = true
+	// 从带有内联导出的 ClassDeclarations 提取（例如，export class A {}、export default class B {}）
+	for _, classDecl := range fileResult.ClassDeclarations {
+		if classDecl.Exported && classDecl.Namespace == "" {
+			lineNum := 0
+			if classDecl.Node != nil {
+				lineNum = a.calculateLineNumber(sourceFile, classDecl.Node)
 			}
-			return false
-		})
 
-		// 方法2: 检查父节点关系（处理 export { Class } 的情况）
-		if !hasExportKeyword {
-			parent := node.GetParent()
-			if parent.IsValid() && parent.IsExportDeclaration() {
-				hasExportKeyword = true
-			}
-		}
-
-		// 方法3: 检查 FileResult 中的 ExportAssignments
-		// 处理 "class A {} export default A" 这种独立导出的情况
-		if !hasExportKeyword {
-			fileResult := sourceFile.GetFileResult()
-			className := a.extractClassNameFromNode(node)
-			for _, exportAssign := range fileResult.ExportAssignments {
-				if className != "" && strings.Contains(exportAssign.Raw, className) {
-					hasExportKeyword = true
-					if strings.Contains(exportAssign.Raw, "export default") {
-						isDefaultExport = true
-					}
-					break
+			name := classDecl.Identifier
+			exportType := ExportTypeNamed
+			if classDecl.IsDefaultExport {
+				exportType = ExportTypeDefault
+				if name == "" {
+					name = "default"
 				}
 			}
-		}
-
-		if !hasExportKeyword {
-			return
-		}
-
-		// 确定导出类型
-		exportType := ExportTypeNamed
-		if isDefaultExport {
-			exportType = ExportTypeDefault
-		}
 
-		// 提取类名：使用 AST 节点遍历而非字符串解析
-		className := a.extractClassNameFromNode(node)
-		if className == "" {
-			className = "default"
+			result.FileExports = append(result.FileExports, ExportInfo{
+				Name:       name,
+				ExportType: exportType,
+				DeclLine:   lineNum,
+				DeclNode:   "ClassDeclaration",
+			})
 		}
+	}
 
-		lineNum := node.GetStartLineNumber()
-
-		result.FileExports = append(result.FileExports, ExportInfo{
-			Name:       className,
-			ExportType: exportType,
-			DeclLine:   lineNum,
-			DeclNode:   "ClassDeclaration",
-		})
-	})
-}
-
-// extractClassNameFromNode 从 ClassDeclaration AST 节点提取类名。
-// 使用 AST 遍历查找 Identifier 节点，而不是字符串解析。
-func (a *Analyzer) extractClassNameFromNode(node tsmorphgo.Node) string {
-	var className string
+	// 从带有内联导出的顶层命名空间提取（例如，export namespace A {}）
+	for _, moduleDecl := range fileResult.ModuleDeclarations {
+		if moduleDecl.Exported && moduleDecl.Namespace == "" && moduleDecl.Kind == parser.ModuleKindNamespace {
+			lineNum := 0
+			if moduleDecl.Node != nil {
+				lineNum = a.calculateLineNumber(sourceFile, moduleDecl.Node)
+			}
 
-	// 遍历子节点查找 Identifier 节点
-	node.ForEachChild(func(child tsmorphgo.Node) bool {
-		if child.IsIdentifier() {
-			className = child.GetText()
-			return true // 找到后停止
+			result.FileExports = append(result.FileExports, ExportInfo{
+				Name:       moduleDecl.Name,
+				ExportType: ExportTypeNamed,
+				DeclLine:   lineNum,
+				DeclNode:   "ModuleDeclaration",
+			})
 		}
-		return false
-	})
-
-	return className
+	}
 }
 
 // extractSymbolNameFromExport 从导出声明中提取符号名称。
@@ -35,6 +35,13 @@ type ProjectConfig struct {
 	IgnorePatterns   []string
 	IsMonorepo       bool
 	TargetExtensions []string
+	// Jobs 并发解析文件的 worker 数量，小于等于 0 时使用 CPU 核数
+	Jobs int
+	// CacheDir 持久化解析缓存目录，为空时不启用缓存
+	CacheDir string
+	// ParseOptions 决定解析结果中是否保留 AST 与原始源码，为 nil 时全部保留。
+	// 使用低内存模式时，文件的 AST 会在首次访问时按需重新解析。
+	ParseOptions *projectParser.ParseOptions
 	// TypeScript 配置文件路径，如果为空则自动查找
 	TsConfigPath string
 	// 是否使用 tsconfig.json 中的配置覆盖其他设置
@@ -92,6 +99,11 @@ func NewProject(config ProjectConfig) *Project {
 	}
 
 	ppConfig := projectParser.NewProjectParserConfig(enhancedConfig.RootPath, ignorePatterns, enhancedConfig.IsMonorepo, enhancedConfig.TargetExtensions)
+	ppConfig.Jobs = enhancedConfig.Jobs
+	ppConfig.CacheDir = enhancedConfig.CacheDir
+	if enhancedConfig.ParseOptions != nil {
+		ppConfig.ParseOptions = *enhancedConfig.ParseOptions
+	}
 	ppResult := projectParser.NewProjectParserResult(ppConfig)
 	ppResult.ProjectParser()
 
@@ -160,7 +172,7 @@ func (p *Project) getLspService() (*lsp.Service, error) {
 			// 从: /Users/xxx/demo-react-app/src/hooks/useUserData.ts
 			// 转换为: /src/hooks/useUserData.ts
 			lspPath := p.convertToLspPath(k)
-			sources[lspPath] = v.Raw
+			sources[lspPath] = p.sourceText(k, v)
 		}
 
 		// 显式传递 tsconfig.json 到 LSP 服务
@@ -177,6 +189,21 @@ func (p *Project) getLspService() (*lsp.Service, error) {
 	return p.lspService, err
 }
 
+// sourceText 返回文件的源码。低内存模式下 Js_Data 不保留原始源码，此时从已按需解析的源文件或磁盘读取。
+func (p *Project) sourceText(path string, result projectParser.JsFileParserResult) string {
+	if result.Raw != "" {
+		return result.Raw
+	}
+	if sf, ok := p.sourceFiles[path]; ok && sf.fileResult != nil && sf.fileResult.Raw != "" {
+		return sf.fileResult.Raw
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return string(content)
+}
+
 // hasTsConfigInSources 检查 sources 中是否已包含 tsconfig.json
 func (p *Project) hasTsConfigInSources(sources map[string]any) bool {
 	for path := range sources {
@@ -346,6 +373,7 @@ func (p *Project) findNodeAt(filePath string, line, char int) *ast.Node {
 	if !ok {
 		return nil
 	}
+	sf.ensureAst()
 
 	lines := strings.Split(sf.fileResult.Raw, "\n")
 	if line-1 >= len(lines) {
@@ -432,6 +460,12 @@ func (p *Project) CreateSourceFile(filePath string, sourceCode string, options .
 	// 获取解析结果
 	parserResult := fileParser.Result.GetResult()
 
+	// 模块声明暂不解析扩展目标的来源
+	moduleDeclarations := make([]projectParser.ModuleDeclarationResult, 0, len(parserResult.ModuleDeclarations))
+	for _, decl := range parserResult.ModuleDeclarations {
+		moduleDeclarations = append(moduleDeclarations, projectParser.ModuleDeclarationResult{ModuleDeclarationResult: decl})
+	}
+
 	// 转换为 projectParser 的 JsFileParserResult 格式
 	jsFileResult := projectParser.JsFileParserResult{
 		Ast:                   fileParser.Ast,
@@ -445,6 +479,8 @@ func (p *Project) CreateSourceFile(filePath string, sourceCode string, options .
 		VariableDeclarations:  parserResult.VariableDeclarations,
 		CallExpressions:       parserResult.CallExpressions,
 		FunctionDeclarations:  parserResult.FunctionDeclarations,
+		ClassDeclarations:     parserResult.ClassDeclarations,
+		ModuleDeclarations:    moduleDeclarations,
 		ExtractedNodes:        parserResult.ExtractedNodes,
 		Errors:                fileParser.Result.Errors,
 	}
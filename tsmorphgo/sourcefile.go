@@ -1,6 +1,8 @@
 package tsmorphgo
 
 import (
+	"sync"
+
 	"github.com/Flying-Bird1999/analyzer-ts/analyzer/projectParser"
 	"github.com/Zzzen/typescript-go/use-at-your-own-risk/ast"
 )
@@ -16,6 +18,8 @@ type SourceFile struct {
 
 	// nodeResultMap 从 ast.Node 指针快速定位到其对应的、被 parser 解析出的具体结果结构体
 	nodeResultMap map[*ast.Node]interface{}
+	// astOnce 确保从解析缓存恢复的文件只会被重新解析一次
+	astOnce sync.Once
 }
 
 // GetFilePath 返回此源文件的绝对路径。
@@ -26,12 +30,14 @@ func (sf *SourceFile) GetFilePath() string {
 // GetFileResult 返回此源文件的解析结果。
 // 这个方法提供了对文件解析结果的访问，包括导入、导出、声明等信息。
 func (sf *SourceFile) GetFileResult() *projectParser.JsFileParserResult {
+	sf.ensureAst()
 	return sf.fileResult
 }
 
 // GetAstNode 返回此源文件的 AST 根节点。
 // 这个方法提供了对文件抽象语法树的直接访问。
 func (sf *SourceFile) GetAstNode() *ast.Node {
+	sf.ensureAst()
 	return sf.astNode
 }
 
@@ -58,9 +64,27 @@ func (sf *SourceFile) ForEachDescendant(callback func(node Node)) {
 			return false
 		})
 	}
+	sf.ensureAst()
 	walk(sf.astNode)
 }
 
+// ensureAst 确保源文件持有 AST 及带 Node 指针的解析结果。
+// 从解析缓存恢复或按低内存模式解析的文件没有 AST，会在首次访问时重新解析。
+func (sf *SourceFile) ensureAst() {
+	sf.astOnce.Do(func() {
+		if sf.astNode != nil || sf.fileResult == nil || sf.project == nil || sf.project.parserResult == nil {
+			return
+		}
+		result, err := sf.project.parserResult.ReparseJsFile(sf.filePath)
+		if err != nil {
+			return
+		}
+		*sf.fileResult = *result
+		sf.astNode = result.Ast
+		sf.buildNodeResultMap()
+	})
+}
+
 // buildNodeResultMap 遍历文件解析结果，构建 ast.Node 到其具体结果结构体的映射。
 func (sf *SourceFile) buildNodeResultMap() {
 	if sf.fileResult == nil {
@@ -130,6 +154,18 @@ func (sf *SourceFile) buildNodeResultMap() {
 		}
 	}
 
+	for _, decl := range sf.fileResult.ClassDeclarations {
+		if decl.Node != nil {
+			sf.nodeResultMap[decl.Node] = decl
+		}
+	}
+
+	for _, decl := range sf.fileResult.ModuleDeclarations {
+		if decl.Node != nil {
+			sf.nodeResultMap[decl.Node] = decl
+		}
+	}
+
 	for _, decl := range sf.fileResult.ExtractedNodes.AnyDeclarations {
 		if decl.Node != nil {
 			sf.nodeResultMap[decl.Node] = decl
@@ -1,8 +1,11 @@
 package tsmorphgo_test
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/Flying-Bird1999/analyzer-ts/analyzer/projectParser"
 	. "github.com/Flying-Bird1999/analyzer-ts/tsmorphgo"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -501,3 +504,45 @@ func TestProjectConfig_BackwardCompatibility(t *testing.T) {
 		assert.GreaterOrEqual(t, len(sourceFiles), 0, "应该能够获取源文件列表")
 	})
 }
+
+// TestProject_ParseCacheRestoresAst 测试从解析缓存恢复的文件在访问时会按需重建 AST
+// 测试 API: NewProject() + ProjectConfig.CacheDir, GetAstNode(), ForEachDescendant()
+func TestProject_ParseCacheRestoresAst(t *testing.T) {
+	rootPath := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(rootPath, "index.ts"), []byte(`export const cached = 1;`), 0644))
+	config := ProjectConfig{RootPath: rootPath, CacheDir: filepath.Join(t.TempDir(), "cache")}
+
+	// 第一次创建项目写入缓存，第二次从缓存恢复
+	NewProject(config).Close()
+	project := NewProject(config)
+	defer project.Close()
+
+	sf := project.GetSourceFile(filepath.Join(rootPath, "index.ts"))
+	require.NotNil(t, sf)
+	assert.NotNil(t, sf.GetAstNode(), "从缓存恢复的文件应能按需重建 AST")
+
+	var found bool
+	sf.ForEachDescendant(func(node Node) {
+		if node.IsIdentifier() && node.GetText() == "cached" {
+			found = true
+		}
+	})
+	assert.True(t, found, "应该能在重建的 AST 中找到标识符 cached")
+}
+
+// TestProject_LowMemoryReparsesOnDemand 测试低内存模式下文件的 AST 与源码会在访问时按需重建
+// 测试 API: NewProject() + ProjectConfig.ParseOptions, GetAstNode(), GetFileResult()
+func TestProject_LowMemoryReparsesOnDemand(t *testing.T) {
+	rootPath := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(rootPath, "index.ts"), []byte(`export const lowMemory = 1;`), 0644))
+	options := projectParser.LowMemoryParseOptions()
+	project := NewProject(ProjectConfig{RootPath: rootPath, ParseOptions: &options})
+	defer project.Close()
+
+	sf := project.GetSourceFile(filepath.Join(rootPath, "index.ts"))
+	require.NotNil(t, sf)
+	assert.NotNil(t, sf.GetAstNode(), "低内存模式下的文件应能按需重建 AST")
+	assert.Equal(t, `export const lowMemory = 1;`, sf.GetFileResult().Raw, "按需重建的结果应包含原始源码")
+	require.Len(t, sf.GetFileResult().VariableDeclarations, 1)
+	assert.NotNil(t, sf.GetFileResult().VariableDeclarations[0].Node, "按需重建的结果应包含 Node 指针")
+}